@@ -0,0 +1,54 @@
+FAKE_MAIL_IMAGE ?= quay.io/$(QUAY_NAMESPACE)/fake-mail:latest
+FAKE_MAIL_CERTS_DIR := $(OUT_DIR)/fake-mail
+# the host of the Mailgun API, which is hardcoded in the Mailgun client of the host operator
+MAILGUN_API_HOST := api.mailgun.net
+
+.PHONY: push-fake-mail
+## Build and push the image of the fake mail delivery service
+push-fake-mail:
+	@mkdir -p $(OUT_DIR)/bin
+	CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build -o $(OUT_DIR)/bin/fake-mail ./testsupport/mail/cmd/fake-mail
+	podman build --platform linux/amd64 -t ${FAKE_MAIL_IMAGE} --build-arg BINARY=fake-mail -f build/recorder/Dockerfile .
+	podman push ${FAKE_MAIL_IMAGE}
+
+# Deploys the fake mail delivery service next to the host operator and configures the host operator to send the
+# notifications via the `mailgun` delivery service. Since the Mailgun API URL cannot be configured, the host operator
+# resolves the Mailgun API host to the fake delivery service (via a host alias), and trusts its self-signed certificate
+# (via the `SSL_CERT_FILE` environment variable).
+# The tests can then retrieve the messages with `testsupport.FakeMailDelivery`.
+.PHONY: deploy-fake-mail
+## Deploy the fake mail delivery service and configure the host operator to use it
+deploy-fake-mail: generate-fake-mail-certs
+	@echo "deploying the fake mail delivery service in '${HOST_NS}' namespace with image '${FAKE_MAIL_IMAGE}'"
+	oc create secret tls fake-mail-tls -n ${HOST_NS} --cert=$(FAKE_MAIL_CERTS_DIR)/tls.crt --key=$(FAKE_MAIL_CERTS_DIR)/tls.key --dry-run=client -o yaml | oc apply -f -
+	oc create secret generic fake-mail-ca -n ${HOST_NS} --from-file=ca.crt=$(FAKE_MAIL_CERTS_DIR)/ca.crt --dry-run=client -o yaml | oc apply -f -
+	HOST_NS=${HOST_NS} FAKE_MAIL_IMAGE=${FAKE_MAIL_IMAGE} envsubst < deploy/fake-mail/fake-mail.yaml | oc apply -f -
+	oc rollout status deployment/fake-mail -n ${HOST_NS} --timeout=2m
+	oc patch secret host-operator-secret -n ${HOST_NS} --type=merge \
+		-p '{"stringData":{"mailgun.domain":"sandbox.e2e.example.com","mailgun.api.key":"fake-api-key","mailgun.sender.email":"noreply@sandbox.e2e.example.com","mailgun.replyto.email":"devsandbox@e2e.example.com"}}'
+	oc patch toolchainconfig config -n ${HOST_NS} --type=merge \
+		-p '{"spec":{"host":{"notifications":{"notificationDeliveryService":"mailgun","secret":{"ref":"host-operator-secret","mailgunDomain":"mailgun.domain","mailgunAPIKey":"mailgun.api.key","mailgunSenderEmail":"mailgun.sender.email","mailgunReplyToEmail":"mailgun.replyto.email"}}}}}'
+	oc patch deployment host-operator-controller-manager -n ${HOST_NS} --type=strategic \
+		-p "{\"spec\":{\"template\":{\"spec\":{\"hostAliases\":[{\"ip\":\"$$(oc get service fake-mail -n ${HOST_NS} -o jsonpath='{.spec.clusterIP}')\",\"hostnames\":[\"$(MAILGUN_API_HOST)\"]}],\"volumes\":[{\"name\":\"fake-mail-ca\",\"secret\":{\"secretName\":\"fake-mail-ca\"}}],\"containers\":[{\"name\":\"manager\",\"env\":[{\"name\":\"SSL_CERT_FILE\",\"value\":\"/etc/fake-mail/ca.crt\"}],\"volumeMounts\":[{\"name\":\"fake-mail-ca\",\"mountPath\":\"/etc/fake-mail\",\"readOnly\":true}]}]}}}}"
+	oc rollout status deployment/host-operator-controller-manager -n ${HOST_NS} --timeout=5m
+
+.PHONY: generate-fake-mail-certs
+generate-fake-mail-certs:
+	@mkdir -p $(FAKE_MAIL_CERTS_DIR)
+	openssl req -x509 -newkey rsa:2048 -nodes -days 30 -subj "/CN=fake-mail-ca" \
+		-keyout $(FAKE_MAIL_CERTS_DIR)/ca.key -out $(FAKE_MAIL_CERTS_DIR)/ca.crt
+	openssl req -newkey rsa:2048 -nodes -subj "/CN=$(MAILGUN_API_HOST)" \
+		-keyout $(FAKE_MAIL_CERTS_DIR)/tls.key -out $(FAKE_MAIL_CERTS_DIR)/tls.csr
+	printf "subjectAltName=DNS:$(MAILGUN_API_HOST)" > $(FAKE_MAIL_CERTS_DIR)/san.ext
+	openssl x509 -req -days 30 -in $(FAKE_MAIL_CERTS_DIR)/tls.csr -CA $(FAKE_MAIL_CERTS_DIR)/ca.crt -CAkey $(FAKE_MAIL_CERTS_DIR)/ca.key \
+		-CAcreateserial -extfile $(FAKE_MAIL_CERTS_DIR)/san.ext -out $(FAKE_MAIL_CERTS_DIR)/tls.crt
+
+.PHONY: undeploy-fake-mail
+## Remove the fake mail delivery service and restore the host operator deployment
+undeploy-fake-mail:
+	oc patch deployment host-operator-controller-manager -n ${HOST_NS} --type=json \
+		-p '[{"op":"remove","path":"/spec/template/spec/hostAliases"}]' || true
+	oc set volume deployment/host-operator-controller-manager -n ${HOST_NS} --remove --name=fake-mail-ca || true
+	oc set env deployment/host-operator-controller-manager -n ${HOST_NS} -c manager SSL_CERT_FILE-
+	HOST_NS=${HOST_NS} FAKE_MAIL_IMAGE=${FAKE_MAIL_IMAGE} envsubst < deploy/fake-mail/fake-mail.yaml | oc delete --ignore-not-found -f -
+	oc delete secret fake-mail-tls fake-mail-ca -n ${HOST_NS} --ignore-not-found
@@ -0,0 +1,36 @@
+FAKE_SMS_IMAGE ?= quay.io/$(QUAY_NAMESPACE)/fake-sms:latest
+
+.PHONY: push-fake-sms
+## Build and push the image of the fake SMS provider
+push-fake-sms:
+	@mkdir -p $(OUT_DIR)/bin
+	CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build -o $(OUT_DIR)/bin/fake-sms ./testsupport/sms/cmd/fake-sms
+	podman build --platform linux/amd64 -t ${FAKE_SMS_IMAGE} --build-arg BINARY=fake-sms -f build/recorder/Dockerfile .
+	podman push ${FAKE_SMS_IMAGE}
+
+# Deploys the fake SMS provider next to the registration service and configures the registration service to send the
+# verification messages via the `aws` notification sender, with the SNS endpoint pointing to the fake provider.
+# The tests can then retrieve the messages with `testsupport.FakeSMSProvider`.
+.PHONY: deploy-fake-sms
+## Deploy the fake SMS provider and configure the registration service to use it
+deploy-fake-sms:
+	@echo "deploying the fake SMS provider in '${REGISTRATION_SERVICE_NS}' namespace with image '${FAKE_SMS_IMAGE}'"
+	REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} FAKE_SMS_IMAGE=${FAKE_SMS_IMAGE} envsubst < deploy/fake-sms/fake-sms.yaml | oc apply -f -
+	oc rollout status deployment/fake-sms -n ${REGISTRATION_SERVICE_NS} --timeout=2m
+	oc patch secret host-operator-secret -n ${HOST_NS} --type=merge \
+		-p '{"stringData":{"aws.access.key.id":"fake-access-key-id","aws.secret.access.key":"fake-secret-access-key"}}'
+	oc patch toolchainconfig config -n ${HOST_NS} --type=merge \
+		-p '{"spec":{"host":{"registrationService":{"verification":{"notificationSender":"aws","awsRegion":"us-east-1","awsSenderID":"DevSandbox","awsSMSType":"Transactional","secret":{"awsAccessKeyID":"aws.access.key.id","awsSecretAccessKey":"aws.secret.access.key"}}}}}}'
+	oc set env deployment/registration-service -n ${REGISTRATION_SERVICE_NS} AWS_ENDPOINT_URL_SNS=http://fake-sms.${REGISTRATION_SERVICE_NS}.svc
+	oc rollout status deployment/registration-service -n ${REGISTRATION_SERVICE_NS} --timeout=5m
+
+.PHONY: undeploy-fake-sms
+## Remove the fake SMS provider and restore the Twilio notification sender of the registration service
+undeploy-fake-sms:
+	oc set env deployment/registration-service -n ${REGISTRATION_SERVICE_NS} AWS_ENDPOINT_URL_SNS-
+	oc patch toolchainconfig config -n ${HOST_NS} --type=json \
+		-p '[{"op":"replace","path":"/spec/host/registrationService/verification/notificationSender","value":"twilio"}]'
+	@# the fake AWS credentials may already have been removed, hence the errors are ignored
+	-oc patch secret host-operator-secret -n ${HOST_NS} --type=json \
+		-p '[{"op":"remove","path":"/data/aws.access.key.id"},{"op":"remove","path":"/data/aws.secret.access.key"}]'
+	REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} FAKE_SMS_IMAGE=${FAKE_SMS_IMAGE} envsubst < deploy/fake-sms/fake-sms.yaml | oc delete --ignore-not-found -f -
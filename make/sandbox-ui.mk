@@ -8,6 +8,11 @@ OPENID_SECRET_NAME=openid-sandbox-public-client-secret
 PUSH_SANDBOX_IMAGE ?= false
 UI_ENVIRONMENT := ui-e2e-tests
 SSO_USERNAME_READ := $(shell if [ -n "$(CI)" ]; then cat /usr/local/sandbox-secrets/SSO_USERNAME 2>/dev/null || echo ""; else echo "${SSO_USERNAME}"; fi)
+OIDC_ADDRESS ?= :9090
+OIDC_ISSUER_URL ?= http://localhost:9090/auth/realms/sandbox-dev
+OIDC_PUBLIC_URL ?= ${OIDC_ISSUER_URL}
+OIDC_USERNAME ?= ui-e2e-user
+MOCK_OIDC_PID_FILE := /tmp/mock-oidc.pid
 SSO_PASSWORD_READ := $(shell if [ -n "$(CI)" ]; then cat /usr/local/sandbox-secrets/SSO_PASSWORD 2>/dev/null || echo ""; else echo "${SSO_PASSWORD}"; fi)
 
 QUAY_NAMESPACE ?= codeready-toolchain-test
@@ -177,14 +182,7 @@ clean-sandbox-ui:
 .PHONY: e2e-run-sandbox-ui
 e2e-run-sandbox-ui: HOST_NS=$(shell oc get projects -l app=host-operator --output=name -o jsonpath='{range .items[*]}{.metadata.name}{"\n"}{end}' | sort | tail -n 1)
 e2e-run-sandbox-ui: RHDH=https://rhdh-${SANDBOX_UI_NS}.$(shell oc get ingress.config.openshift.io/cluster -o jsonpath='{.spec.domain}')
-e2e-run-sandbox-ui:
-	@echo "Installing Playwright..."
-	$(eval PWGO_VER := $(shell grep -oE "playwright-go v\S+" go.mod | sed 's/playwright-go //g'))
-	@echo "Installing Playwright CLI version: $(PWGO_VER)"
-	go install github.com/playwright-community/playwright-go/cmd/playwright@$(PWGO_VER)
-	@echo "Installing Firefox browser for Playwright..."
-	$(GOPATH)/bin/playwright install firefox
-
+e2e-run-sandbox-ui: install-playwright
 	@echo "Running Developer Sandbox UI setup e2e tests..."
 	SANDBOX_UI_NS=${SANDBOX_UI_NS} go test "./test/e2e/sandbox-ui/setup" -v -timeout=10m -failfast
 	
@@ -196,6 +194,70 @@ e2e-run-sandbox-ui:
 	@echo "The Developer Sandbox UI e2e tests successfully finished"
 
 
+.PHONY: install-playwright
+install-playwright:
+	@echo "Installing Playwright..."
+	$(eval PWGO_VER := $(shell grep -oE "playwright-go v\S+" go.mod | sed 's/playwright-go //g'))
+	@echo "Installing Playwright CLI version: $(PWGO_VER)"
+	go install github.com/playwright-community/playwright-go/cmd/playwright@$(PWGO_VER)
+	@echo "Installing Firefox browser for Playwright..."
+	$(GOPATH)/bin/playwright install firefox
+
+
+# Runs the mock OIDC provider in the background. It signs in the OIDC_USERNAME user without any login page,
+# with tokens which are accepted by a registration service deployed in e2e mode.
+# OIDC_ISSUER_URL must be reachable from the Developer Sandbox UI pod, and OIDC_PUBLIC_URL from the browser.
+.PHONY: start-mock-oidc
+start-mock-oidc: stop-mock-oidc
+	@echo "starting the mock OIDC provider on '${OIDC_ADDRESS}' with issuer '${OIDC_ISSUER_URL}'"
+	@mkdir -p $(OUT_DIR)/bin
+	go build -o $(OUT_DIR)/bin/mock-oidc ./testsupport/sandbox-ui/cmd/mock-oidc
+	@nohup $(OUT_DIR)/bin/mock-oidc --address=${OIDC_ADDRESS} --issuer=${OIDC_ISSUER_URL} --public-url=${OIDC_PUBLIC_URL} \
+		--username=${OIDC_USERNAME} > $(OUT_DIR)/mock-oidc.log 2>&1 & echo $$! > ${MOCK_OIDC_PID_FILE}
+
+.PHONY: stop-mock-oidc
+stop-mock-oidc:
+	@if [ -f ${MOCK_OIDC_PID_FILE} ]; then \
+		kill $$(cat ${MOCK_OIDC_PID_FILE}) 2>/dev/null || true; \
+		rm -f ${MOCK_OIDC_PID_FILE}; \
+	fi
+
+# Deploys the Developer Sandbox UI on any cluster (eg, kind) with the mock OIDC provider instead of the Developer Sandbox SSO.
+# The toolchain resources must be deployed in e2e mode, so that the registration service accepts the tokens of the mock OIDC provider.
+.PHONY: deploy-sandbox-ui-without-sso
+deploy-sandbox-ui-without-sso:
+	@if [ -z "${REGISTRATION_SERVICE_API}" ] || [ -z "${HOST_OPERATOR_API}" ] || [ -z "${RHDH}" ]; then \
+		echo "REGISTRATION_SERVICE_API, HOST_OPERATOR_API and RHDH must be set"; \
+		exit 1; \
+	fi
+	@kubectl create namespace ${SANDBOX_UI_NS} --dry-run=client -o yaml | kubectl apply -f -
+	kubectl kustomize deploy/sandbox-ui/local | REGISTRATION_SERVICE_API=${REGISTRATION_SERVICE_API} \
+		HOST_OPERATOR_API=${HOST_OPERATOR_API} \
+		SANDBOX_UI_NS=${SANDBOX_UI_NS} \
+		SANDBOX_PLUGIN_IMAGE=${IMAGE_TO_PUSH_IN_QUAY} \
+		OIDC_ISSUER_URL=${OIDC_ISSUER_URL} \
+		RHDH=${RHDH} envsubst | kubectl apply -f -
+	@kubectl -n ${SANDBOX_UI_NS} rollout status deploy/rhdh
+	@echo "Developer Sandbox UI deployed, it should be reachable at ${RHDH} (eg, via 'kubectl -n ${SANDBOX_UI_NS} port-forward svc/rhdh 7007:http-backend')"
+
+# Runs the UI e2e tests which do not depend on the Developer Sandbox SSO, in a headless browser
+# (the external pages linked from the header and from the activities are not opened in this mode)
+.PHONY: e2e-run-sandbox-ui-without-sso
+e2e-run-sandbox-ui-without-sso: install-playwright
+	@BASE_URL=${RHDH} BROWSER=firefox envsubst < deploy/sandbox-ui/local/.env > testsupport/sandbox-ui/.env
+	go test "./test/e2e/sandbox-ui" -v -timeout=10m -failfast -run '^(TestHomepage|TestHeader|TestActivitiesPage)$$'
+	@echo "The Developer Sandbox UI e2e tests successfully finished"
+
+# Runs the mock OIDC provider, deploys the Developer Sandbox UI and runs the UI e2e tests against it via a port-forward
+.PHONY: test-ui-e2e-without-sso
+test-ui-e2e-without-sso: RHDH=http://localhost:7007
+test-ui-e2e-without-sso:
+	$(MAKE) start-mock-oidc deploy-sandbox-ui-without-sso RHDH=${RHDH}
+	@kubectl -n ${SANDBOX_UI_NS} port-forward svc/rhdh 7007:http-backend > /dev/null 2>&1 & pf=$$!; \
+		$(MAKE) e2e-run-sandbox-ui-without-sso RHDH=${RHDH}; status=$$?; \
+		kill $$pf; $(MAKE) stop-mock-oidc; exit $$status
+
+
 .PHONY: test-ui-e2e
 test-ui-e2e:
 	$(MAKE) deploy-sandbox-ui e2e-run-sandbox-ui ENVIRONMENT=${UI_ENVIRONMENT}
@@ -0,0 +1,29 @@
+SLOW_PROXY_IMAGE ?= quay.io/$(QUAY_NAMESPACE)/slow-proxy:latest
+# the initial address of the API server to forward the connections to. The in-cluster address of the API server serves the
+# same certificates as its public endpoint (selected via SNI), so the kubeconfigs of the ToolchainClusters remain valid.
+# NOTE: this default only reaches the member cluster when the host and the member operators share the same cluster. This
+# is why `testsupport.SlowResponses` always sets the upstream address to the API endpoint of the ToolchainCluster (via
+# the control API of the proxy) before delaying the responses.
+SLOW_PROXY_UPSTREAM ?= kubernetes.default.svc:443
+
+.PHONY: push-slow-proxy
+## Build and push the image of the slow proxy
+push-slow-proxy:
+	@mkdir -p $(OUT_DIR)/bin
+	CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build -o $(OUT_DIR)/bin/slow-proxy ./testsupport/slowproxy/cmd/slow-proxy
+	podman build --platform linux/amd64 -t ${SLOW_PROXY_IMAGE} -f build/slow-proxy/Dockerfile .
+	podman push ${SLOW_PROXY_IMAGE}
+
+# Deploys the slow proxy next to the host operator. The tests can then point the kubeconfig of a ToolchainCluster to the
+# proxy and change the delay of the responses with `testsupport.SlowResponses`.
+.PHONY: deploy-slow-proxy
+## Deploy the slow proxy which is used to simulate slow member clusters
+deploy-slow-proxy:
+	@echo "deploying the slow proxy in '${HOST_NS}' namespace with image '${SLOW_PROXY_IMAGE}'"
+	HOST_NS=${HOST_NS} SLOW_PROXY_IMAGE=${SLOW_PROXY_IMAGE} SLOW_PROXY_UPSTREAM=${SLOW_PROXY_UPSTREAM} envsubst < deploy/slow-proxy/slow-proxy.yaml | oc apply -f -
+	oc rollout status deployment/slow-proxy -n ${HOST_NS} --timeout=2m
+
+.PHONY: undeploy-slow-proxy
+## Remove the slow proxy
+undeploy-slow-proxy:
+	HOST_NS=${HOST_NS} SLOW_PROXY_IMAGE=${SLOW_PROXY_IMAGE} SLOW_PROXY_UPSTREAM=${SLOW_PROXY_UPSTREAM} envsubst < deploy/slow-proxy/slow-proxy.yaml | oc delete --ignore-not-found -f -
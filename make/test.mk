@@ -85,6 +85,41 @@ e2e-migration-verify:
 	$(MAKE) execute-tests MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} HOST_NS=${HOST_NS} REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} TESTS_TO_EXECUTE="./test/migration/verify"
 	@echo "Migration tests successfully finished"
 
+# the space-separated list of the published versions (index image tags, or `latest`) to go through before upgrading
+# to the versions built from the current repositories, eg: `MIGRATION_VERSIONS="v0.1.0 latest"`
+MIGRATION_VERSIONS ?= latest
+
+.PHONY: verify-migration-matrix
+## Run the migration setup and verification for each hop between the MIGRATION_VERSIONS and the current versions
+verify-migration-matrix: prepare-projects
+	@previous=""; \
+	for version in ${MIGRATION_VERSIONS} current; do \
+		if [[ -z "$${previous}" ]]; then \
+			echo "Deploying the operators at version '$${version}'..."; \
+			if [[ "$${version}" == "latest" ]]; then \
+				$(MAKE) e2e-deploy-latest || exit 1; \
+			else \
+				$(MAKE) get-publish-install-and-register-operators MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} HOST_NS=${HOST_NS} REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} ENVIRONMENT=${ENVIRONMENT} INSTALL_OPERATOR=${INSTALL_OPERATOR} PUBLISH_OPERATOR=false FORCED_TAG=$${version} KSCTL_TLS_VERIFY_PARAM=${KSCTL_TLS_VERIFY_PARAM} || exit 1; \
+			fi; \
+			$(MAKE) e2e-migration-setup MIGRATION_HOP="$${version}" || exit 1; \
+		else \
+			echo "Upgrading the operators from version '$${previous}' to version '$${version}'..."; \
+			if [[ "$${version}" == "current" ]]; then \
+				$(MAKE) get-publish-and-install-operators || exit 1; \
+			elif [[ "$${version}" == "latest" ]]; then \
+				$(MAKE) get-publish-and-install-operators DEPLOY_LATEST=true || exit 1; \
+			else \
+				$(MAKE) get-publish-and-install-operators PUBLISH_OPERATOR=false FORCED_TAG=$${version} || exit 1; \
+			fi; \
+			$(MAKE) e2e-migration-verify MIGRATION_HOP="$${previous}->$${version}" || exit 1; \
+			if [[ "$${version}" != "current" ]]; then \
+				$(MAKE) e2e-migration-setup MIGRATION_HOP="$${version}" || exit 1; \
+			fi; \
+		fi; \
+		previous=$${version}; \
+	done
+	@echo "Migration matrix successfully verified"
+
 .PHONY: e2e-deploy-latest
 e2e-deploy-latest:
 	$(MAKE) get-publish-install-and-register-operators MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} HOST_NS=${HOST_NS} REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} ENVIRONMENT=${ENVIRONMENT} INSTALL_OPERATOR=${INSTALL_OPERATOR} DEPLOY_LATEST=true KSCTL_TLS_VERIFY_PARAM=${KSCTL_TLS_VERIFY_PARAM}
@@ -180,6 +215,11 @@ execute-tests:
 	MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} HOST_NS=${HOST_NS} REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} SECOND_MEMBER_MODE=${SECOND_MEMBER_MODE} go test ${TESTS_TO_EXECUTE} -run ${TESTS_RUN_FILTER_REGEXP} -p 1 -v -timeout=90m -failfast -count=1 || \
 	($(MAKE) print-logs HOST_NS=${HOST_NS} MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} REGISTRATION_SERVICE_NS=${REGISTRATION_SERVICE_NS} && exit 1)
 
+.PHONY: doctor
+## Check the prerequisites of the e2e tests in the cluster and print the remediation hints of the ones which are not met
+doctor:
+	MEMBER_NS=${MEMBER_NS} MEMBER_NS_2=${MEMBER_NS_2} SECOND_MEMBER_MODE=${SECOND_MEMBER_MODE} go run setup/main.go doctor --host-ns=${HOST_NS} --registration-service-ns=${REGISTRATION_SERVICE_NS}
+
 .PHONY: print-logs
 print-logs:
 	@echo "Time: $(shell date)"
@@ -486,3 +526,8 @@ test-support:
 .PHONY: test-setup
 test-setup:
 	@go test github.com/codeready-toolchain/toolchain-e2e/setup/... -failfast
+
+.PHONY: validate-tiers
+## Validate the NSTemplateTiers and TierTemplates in the 'deploy' directory, without deploying them
+validate-tiers:
+	@go test github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers/validation -run TestValidateDeployedTiers -count=1
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/auth"
+	cfg "github.com/codeready-toolchain/toolchain-e2e/setup/configuration"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/operators"
+	"github.com/codeready-toolchain/toolchain-e2e/setup/terminal"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/doctor"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/util"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	doctorHostNs                string
+	doctorRegistrationServiceNs string
+	doctorMemberNs              string
+	doctorCRDsDir               string
+	doctorSetup                 bool
+)
+
+// newDoctorCmd returns the command which checks all the prerequisites of the e2e tests (or of the setup)
+// and prints a checklist with the remediation hints of the prerequisites which are not met
+func newDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "doctor",
+		Short:         "check the prerequisites of the e2e tests and of the setup",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Args:          cobra.NoArgs,
+		RunE:          runDoctor,
+	}
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "absolute path to the kubeconfig file")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "if 'debug' traces should be displayed in the console")
+	cmd.Flags().StringVar(&doctorHostNs, "host-ns", envOrDefault(wait.HostNsVar, cfg.DefaultHostNS), "the namespace of Host operator")
+	cmd.Flags().StringVar(&doctorRegistrationServiceNs, "registration-service-ns", os.Getenv(wait.RegistrationServiceVar), "the namespace of the registration service (defaults to the namespace of the Host operator)")
+	cmd.Flags().StringVar(&doctorMemberNs, "member-ns", "", "the namespace of the Member operator (by default, the Member operators are looked up as in the e2e tests)")
+	cmd.Flags().StringVar(&doctorCRDsDir, "crds", "deploy/crds", "the directory of the third-party CRDs which must be installed in the cluster")
+	cmd.Flags().BoolVar(&doctorSetup, "setup", false, "if the prerequisites of the setup should be checked instead of the ones of the e2e tests")
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, _ []string) error {
+	term := terminal.New(cmd.InOrStdin, cmd.OutOrStdout, verbose)
+	if doctorRegistrationServiceNs == "" {
+		doctorRegistrationServiceNs = doctorHostNs
+	}
+	term.Infof("Host Operator namespace:          '%s'", doctorHostNs)
+	term.Infof("Registration Service namespace:   '%s'\n", doctorRegistrationServiceNs)
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	apiConfig, err := loadingRules.Load()
+	if err != nil {
+		return fmt.Errorf("error while loading KUBECONFIG: %w", err)
+	}
+	cl, bearerToken, err := newDoctorClient(*apiConfig)
+	if err != nil {
+		return fmt.Errorf("cannot create client: %w", err)
+	}
+
+	env := doctor.Environment{
+		Client:                       cl,
+		BearerToken:                  bearerToken,
+		HostNamespace:                doctorHostNs,
+		RegistrationServiceNamespace: doctorRegistrationServiceNs,
+		ClientForContext: func(kubeContext string) (client.Client, error) {
+			contextConfig := apiConfig.DeepCopy()
+			if _, found := contextConfig.Contexts[kubeContext]; !found {
+				return nil, fmt.Errorf("kubeconfig context '%s' not found", kubeContext)
+			}
+			contextConfig.CurrentContext = kubeContext
+			cl, _, err := newDoctorClient(*contextConfig)
+			return cl, err
+		},
+		CRDsDir: doctorCRDsDir,
+	}
+	if doctorMemberNs != "" {
+		env.Members = []testsupport.MemberLocation{{Namespace: doctorMemberNs}}
+	}
+
+	ctx := context.TODO()
+	var checks []doctor.Check
+	if doctorSetup {
+		checks = append(doctor.OperatorChecks(ctx, env), setupChecks(cl, doctorHostNs)...)
+	} else {
+		checks = doctor.E2EChecks(ctx, env)
+	}
+	report := doctor.Run(ctx, checks...)
+	report.Print(term.OutOrStdout())
+	if !report.Passed() {
+		return fmt.Errorf("%d prerequisite(s) not met", report.Failures())
+	}
+	return nil
+}
+
+// newDoctorClient returns a client to the cluster of the current context of the given kubeconfig, and the token of the user
+func newDoctorClient(apiConfig api.Config) (client.Client, string, error) {
+	restConfig, err := util.BuildKubernetesRESTConfig(apiConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := testsupport.NewSchemeWithAllAPIs()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := operatorsv1.AddToScheme(s); err != nil {
+		return nil, "", err
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: s})
+	return cl, restConfig.BearerToken, err
+}
+
+// setupChecks returns the checks of the prerequisites of the setup, in addition to the ones of the operators
+func setupChecks(cl client.Client, hostNs string) []doctor.Check {
+	return []doctor.Check{
+		{
+			Name: "sandbox host and member operators are installed",
+			Hint: "install the sandbox operators with OLM (eg, with `make dev-deploy-e2e`) before running the setup",
+			Verify: func(_ context.Context) error {
+				return operators.VerifySandboxOperatorsInstalled(cl)
+			},
+		},
+		{
+			Name: fmt.Sprintf("NSTemplateTier '%s' exists in namespace '%s'", cfg.UserSpaceTier, hostNs),
+			Hint: "check that the host operator is running and has created the NSTemplateTiers",
+			Verify: func(ctx context.Context) error {
+				return cl.Get(ctx, types.NamespacedName{Namespace: hostNs, Name: cfg.UserSpaceTier}, &toolchainv1alpha1.NSTemplateTier{})
+			},
+		},
+		{
+			Name: fmt.Sprintf("ToolchainConfig 'config' exists in namespace '%s'", hostNs),
+			Hint: "check that the host operator is running and has been configured",
+			Verify: func(ctx context.Context) error {
+				return cl.Get(ctx, types.NamespacedName{Namespace: hostNs, Name: "config"}, &toolchainv1alpha1.ToolchainConfig{})
+			},
+		},
+		{
+			Name: "OLMConfig 'cluster' exists",
+			Hint: "the setup requires a cluster with OLM installed (eg, OpenShift)",
+			Verify: func(ctx context.Context) error {
+				return cl.Get(ctx, types.NamespacedName{Name: "cluster"}, &operatorsv1.OLMConfig{})
+			},
+		},
+		{
+			Name: "a token can be obtained from `oc`",
+			Hint: "log into the cluster with a token, eg. `oc login --token=<token> --server=<server>`, or use the `--token` flag of the setup",
+			Verify: func(_ context.Context) error {
+				token, err := auth.GetTokenFromOC()
+				if err != nil {
+					return err
+				}
+				if token == "" {
+					return errors.New("empty token")
+				}
+				return nil
+			},
+		},
+	}
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
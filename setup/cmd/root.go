@@ -87,6 +87,8 @@ func Execute() {
 	cmd.Flags().StringVarP(&token, "token", "t", "", "Openshift API token")
 	cmd.Flags().StringSliceVar(&workloads, "workloads", []string{}, "workload namespace:name pairs that should have metrics collected during the setup. all values are comma-separated eg. \"--workloads service-binding-operator:service-binding-operator,rhoas-operator:rhoas-operator\"")
 
+	cmd.AddCommand(newDoctorCmd())
+
 	if err := cmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
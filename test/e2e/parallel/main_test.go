@@ -0,0 +1,18 @@
+package parallel
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	// write the report of the slowest waits and of the total wait time per test
+	if err := wait.WriteWaitReport("e2e-parallel"); err != nil {
+		fmt.Printf("failed to write the wait report: %s\n", err)
+	}
+	os.Exit(code)
+}
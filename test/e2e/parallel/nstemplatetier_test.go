@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -177,6 +179,69 @@ func TestGoTemplate(t *testing.T) {
 		NamespaceResourcesTier: base1Ns,
 		SpaceRolesTier:         base1Ns,
 	})
+
+	t.Run("resources match the golden files", func(t *testing.T) {
+		VerifySpaceRelatedResources(t, awaitilities, user.UserSignup, base1nsGoTemplateTier.Name)
+	})
+}
+
+// TestGoTemplateCustomTier verifies that a custom NSTemplateTier built with Go-template TierTemplates (new ones, as well as
+// duplicated and parameterized ones) provisions the resources which are rendered locally from its TierTemplates
+func TestGoTemplateCustomTier(t *testing.T) {
+	t.Parallel()
+
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	base1nsGoTemplateTier, err := hostAwait.WaitForNSTemplateTier(t, "base1ns-gotemplate")
+	require.NoError(t, err)
+	devNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "{{.SPACE_NAME}}-dev",
+		},
+	}
+	greetingConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "greeting",
+			Namespace: "{{.SPACE_NAME}}-dev",
+		},
+		Data: map[string]string{
+			"greeting": "{{.GREETING}}",
+		},
+	}
+
+	// when
+	customTier := tiers.CreateCustomNSTemplateTier(t, hostAwait, "gotmpl", base1nsGoTemplateTier,
+		tiers.WithParametersOf(base1nsGoTemplateTier),
+		tiers.WithClusterResources(t, base1nsGoTemplateTier),
+		tiers.WithGoTemplateNamespace(t, "dev", devNamespace, greetingConfigMap),
+		tiers.WithSpaceRoles(t, base1nsGoTemplateTier, tiers.ParameterizeTemplateObjects("rbac-edit", "EDIT_ROLE")),
+		tiers.WithParameter("GREETING", "hello"),
+		tiers.WithParameter("EDIT_ROLE", "rbac-edit-custom"))
+	spaces := setupSpaces(t, awaitilities, customTier, "gotmplspace%02d", awaitilities.Member1(), 1)
+
+	// then
+	verifyResourceUpdatesForSpaces(t, hostAwait, awaitilities.Member1(), spaces, customTier)
+	for _, spaceName := range spaces {
+		// the space role was rendered with the `EDIT_ROLE` parameter
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: spaceName + "-dev"}}
+		_, err := awaitilities.Member1().WaitForRole(t, ns, "rbac-edit-custom")
+		require.NoError(t, err)
+		bindings, err := hostAwait.ListSpaceBindings(spaceName)
+		require.NoError(t, err)
+		require.Len(t, bindings, 1)
+		rb, err := awaitilities.Member1().WaitForRoleBinding(t, ns, bindings[0].Spec.MasterUserRecord+"-rbac-edit-custom")
+		require.NoError(t, err)
+		assert.Equal(t, "rbac-edit-custom", rb.RoleRef.Name)
+	}
+
+	t.Run("update parameter", func(t *testing.T) {
+		// when
+		customTier = tiers.UpdateCustomNSTemplateTier(t, hostAwait, customTier, tiers.WithParameter("GREETING", "hi"))
+
+		// then
+		verifyResourceUpdatesForSpaces(t, hostAwait, awaitilities.Member1(), spaces, customTier)
+	})
 }
 
 func TestResetDeactivatingStateWhenPromotingUser(t *testing.T) {
@@ -2,16 +2,9 @@ package parallel
 
 import (
 	"context"
-	"crypto/tls"
-	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
-	"os"
-	"os/signal"
 	"strings"
 	"sync"
 	"testing"
@@ -25,6 +18,8 @@ import (
 	spacebindingrequesttestcommon "github.com/codeready-toolchain/toolchain-common/pkg/test/spacebindingrequest"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
 	appstudiov1 "github.com/codeready-toolchain/toolchain-e2e/testsupport/appstudio/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/metrics"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/proxy"
 	testsupportspace "github.com/codeready-toolchain/toolchain-e2e/testsupport/space"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport/spacebinding"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
@@ -33,14 +28,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/google/uuid"
-	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	kubewait "k8s.io/apimachinery/pkg/util/wait"
 )
 
 type proxyUser struct {
@@ -94,33 +87,15 @@ func (u *proxyUser) invalidShareSpaceWith(t *testing.T, awaitilities wait.Awaiti
 }
 
 func (u *proxyUser) listWorkspaces(t *testing.T, hostAwait *wait.HostAwaitility) []toolchainv1alpha1.Workspace {
-	proxyCl := u.createProxyClient(t, hostAwait)
-
-	workspaces := &toolchainv1alpha1.WorkspaceList{}
-	err := proxyCl.List(context.TODO(), workspaces)
-	require.NoError(t, err)
-	return workspaces.Items
+	return u.createProxyClient(t, hostAwait).ListWorkspaces()
 }
 
-func (u *proxyUser) createProxyClient(t *testing.T, hostAwait *wait.HostAwaitility) client.Client {
-	proxyCl, err := hostAwait.CreateAPIProxyClient(t, u.token, hostAwait.APIProxyURL)
-	require.NoError(t, err)
-	return proxyCl
+func (u *proxyUser) createProxyClient(t *testing.T, hostAwait *wait.HostAwaitility) *proxy.Client {
+	return proxy.NewClient(t, hostAwait, u.token)
 }
 
 func (u *proxyUser) getWorkspace(t *testing.T, hostAwait *wait.HostAwaitility, workspaceName string) (*toolchainv1alpha1.Workspace, error) {
-	proxyCl := u.createProxyClient(t, hostAwait)
-
-	workspace := &toolchainv1alpha1.Workspace{}
-	var cause error
-	// only wait up to 5 seconds because in some test cases the workspace is not expected to be found
-	_ = kubewait.PollUntilContextTimeout(context.TODO(), wait.DefaultRetryInterval, 5*time.Second, true, func(ctx context.Context) (bool, error) {
-		cause = proxyCl.Get(context.TODO(), types.NamespacedName{Name: workspaceName}, workspace)
-		return cause == nil, nil
-	})
-
-	// do not assert error before returning because in some test cases the workspace is not expected to be found
-	return workspace, cause
+	return u.createProxyClient(t, hostAwait).GetWorkspace(workspaceName)
 }
 
 func (u *proxyUser) getApplication(t *testing.T, proxyClient client.Client, applicationName string) *appstudiov1.Application {
@@ -193,13 +168,13 @@ func TestProxyFlow(t *testing.T) {
 	for _, user := range users {
 		createAppStudioUser(t, awaitilities, user)
 	}
+	proxyMetrics := hostAwait.ProxyMetrics(t)
+	proxyMetricsBefore := proxyMetrics.TakeMetricsSnapshot(t)
 
 	for index, user := range users {
 		t.Run(user.username, func(t *testing.T) {
 			// Start a new websocket watcher
-			w := newWsWatcher(t, *user, user.compliantUsername, hostAwait.APIProxyURL)
-			closeConnection := w.Start()
-			defer closeConnection()
+			w := watchApplications(t, user.token, hostAwait.APIProxyURL, user.compliantUsername)
 			proxyCl := user.createProxyClient(t, hostAwait)
 			applicationList := &appstudiov1.ApplicationList{}
 
@@ -216,7 +191,7 @@ func TestProxyFlow(t *testing.T) {
 
 					// then
 					// wait for the websocket watcher which uses the proxy to receive the Application CR
-					found, err := w.WaitForApplication(
+					found, err := w.WaitForObject(
 						expectedApp.Name,
 					)
 					require.NoError(t, err)
@@ -302,7 +277,7 @@ func TestProxyFlow(t *testing.T) {
 						// Delete
 						err := proxyCl.Delete(context.TODO(), proxyApp)
 						require.NoError(t, err)
-						err = w.WaitForApplicationDeletion(
+						err = w.WaitForDeletion(
 							proxyApp.Name,
 						)
 						require.NoError(t, err)
@@ -448,9 +423,7 @@ func TestProxyFlow(t *testing.T) {
 			t.Run("successful workspace context request", func(t *testing.T) {
 				proxyWorkspaceURL := hostAwait.ProxyURLWithWorkspaceContext(user.compliantUsername)
 				// Start a new websocket watcher which watches for Application CRs in the user's namespace
-				w := newWsWatcher(t, *user, user.compliantUsername, proxyWorkspaceURL)
-				closeConnection := w.Start()
-				defer closeConnection()
+				w := watchApplications(t, user.token, proxyWorkspaceURL, user.compliantUsername)
 				workspaceCl, err := hostAwait.CreateAPIProxyClient(t, user.token, proxyWorkspaceURL) // proxy client with workspace context
 				require.NoError(t, err)
 
@@ -465,7 +438,7 @@ func TestProxyFlow(t *testing.T) {
 
 				// then
 				// wait for the websocket watcher which uses the proxy to receive the Application CR
-				found, err := w.WaitForApplication(
+				found, err := w.WaitForObject(
 					expectedApp.Name,
 				)
 				require.NoError(t, err)
@@ -483,30 +456,14 @@ func TestProxyFlow(t *testing.T) {
 				// we are going to repurpose a well known, always running route as a proxy plugin to contact through the registration service
 				CreateProxyPluginWithCleanup(t, hostAwait, "openshift-console", "openshift-console", "console")
 				VerifyProxyPlugin(t, hostAwait, "openshift-console")
-				proxyPluginWorkspaceURL := hostAwait.PluginProxyURLWithWorkspaceContext("openshift-console", user.compliantUsername)
-				client := http.Client{
-					Timeout: 30 * time.Second,
-					Transport: &http.Transport{
-						TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
-					},
-				}
-				request, err := http.NewRequest("GET", proxyPluginWorkspaceURL, nil)
-				require.NoError(t, err)
+				pluginCl := proxy.NewPluginClient(t, hostAwait, user.token, "openshift-console", user.compliantUsername)
 
-				request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", user.token))
-				var resp *http.Response
-				resp, err = client.Do(request)
-				require.NoError(t, err)
-				defer resp.Body.Close()
-				var body []byte
-				body, err = io.ReadAll(resp.Body)
-				require.NoError(t, err)
-				bodyStr := string(body)
+				resp := pluginCl.Do(http.MethodGet, "", nil)
 				if resp.StatusCode != http.StatusOK {
-					t.Errorf("unexpected http return code of %d with body text %s", resp.StatusCode, bodyStr)
+					t.Errorf("unexpected http return code of %d with body text %s", resp.StatusCode, resp.Body)
 				}
-				if !strings.Contains(bodyStr, "Red") || !strings.Contains(bodyStr, "Open") {
-					t.Errorf("unexpected http response body %s", bodyStr)
+				if !strings.Contains(resp.Body, "Red") || !strings.Contains(resp.Body, "Open") {
+					t.Errorf("unexpected http response body %s", resp.Body)
 				}
 			}) // end of successful workspace context request with proxy plugin
 
@@ -525,47 +482,34 @@ func TestProxyFlow(t *testing.T) {
 
 			t.Run("invalid request headers", func(t *testing.T) {
 				// given
-				proxyWorkspaceURL := hostAwait.ProxyURLWithWorkspaceContext(user.compliantUsername)
-				rejectedHeaders := []headerKeyValue{
-					{"Impersonate-Group", "system:cluster-admins"},
-					{"Impersonate-Group", "system:node-admins"},
-				}
-				client := http.Client{
-					Timeout: time.Duration(5 * time.Second), // because sometimes the network connection may be a bit slow
-				}
-				client.Transport = &http.Transport{
-					TLSClientConfig: &tls.Config{
-						InsecureSkipVerify: true, // nolint:gosec
-					},
+				workspaceCl := proxy.NewHTTPClient(t, user.token, hostAwait.ProxyURLWithWorkspaceContext(user.compliantUsername))
+				rejectedHeaders := []proxy.Header{
+					{Key: "Impersonate-Group", Value: "system:cluster-admins"},
+					{Key: "Impersonate-Group", Value: "system:node-admins"},
 				}
-				t.Logf("proxyWorkspaceURL: %s", proxyWorkspaceURL)
-				nodesURL := fmt.Sprintf("%s/api/v1/nodes", proxyWorkspaceURL)
-				t.Logf("nodesURL: %s", nodesURL)
+				t.Logf("proxyWorkspaceURL: %s", workspaceCl.URL)
 
 				for _, header := range rejectedHeaders {
-					t.Run(fmt.Sprintf("k=%s,v=%s", header.key, header.value), func(t *testing.T) {
-						// given
-						request, err := http.NewRequest("GET", nodesURL, nil)
-						request.Header.Add(header.key, header.value)
-						require.NoError(t, err)
-						request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", user.token)) // uses the user's token with the impersonation headers
-
+					t.Run(fmt.Sprintf("k=%s,v=%s", header.Key, header.Value), func(t *testing.T) {
 						// when
-						resp, err := client.Do(request)
+						// uses the user's token with the impersonation headers
+						resp := workspaceCl.Do(http.MethodGet, "/api/v1/nodes", nil, header)
 
 						// then
-						require.NoError(t, err)
-						require.NotNil(t, resp)
-						defer resp.Body.Close()
 						require.Equal(t, 403, resp.StatusCode) // should be forbidden
-						r, _ := io.ReadAll(resp.Body)
-						assert.Contains(t, string(r), fmt.Sprintf(`nodes is forbidden: User \"%s\" cannot list resource \"nodes\" in API group \"\" at the cluster scope`, user.compliantUsername))
+						assert.Contains(t, resp.Body, fmt.Sprintf(`nodes is forbidden: User \"%s\" cannot list resource \"nodes\" in API group \"\" at the cluster scope`, user.compliantUsername))
 					})
 				}
 			}) // end of invalid request headers
 		})
 	} // end users loop
 
+	t.Run("proxy metrics recorded the requests", func(t *testing.T) {
+		// the proxy is also used by other tests running in parallel, so only a lower bound can be verified
+		deltas := proxyMetrics.TakeMetricsSnapshot(t).DeltasSince(proxyMetricsBefore)
+		assert.GreaterOrEqual(t, deltas.Sum(metrics.Family(wait.ProxyAPIRequestTimeMetric+"_count").With("status_code", metrics.Regex("2.."))), float64(len(users)))
+	})
+
 	t.Run("proxy with shared workspace use cases", func(t *testing.T) {
 		// given
 		guestUser := users[0]
@@ -614,9 +558,7 @@ func TestProxyFlow(t *testing.T) {
 			VerifySpaceRelatedResources(t, awaitilities, primaryUser.signup, "appstudio")
 
 			// Start a new websocket watcher which watches for Application CRs in the user's namespace
-			w := newWsWatcher(t, *guestUser, primaryUser.compliantUsername, primaryUserWorkspaceURL)
-			closeConnection := w.Start()
-			defer closeConnection()
+			w := watchApplications(t, guestUser.token, primaryUserWorkspaceURL, primaryUser.compliantUsername)
 			guestUserPrimaryWsCl, err := hostAwait.CreateAPIProxyClient(t, guestUser.token, primaryUserWorkspaceURL)
 			require.NoError(t, err)
 
@@ -629,7 +571,7 @@ func TestProxyFlow(t *testing.T) {
 			require.NoError(t, err) // allowed since guestUser has access to primaryUser's space
 
 			// wait for the websocket watcher which uses the proxy to receive the Application CR
-			found, err := w.WaitForApplication(
+			found, err := w.WaitForObject(
 				expectedApp.Name,
 			)
 			require.NoError(t, err)
@@ -1128,15 +1070,7 @@ func TestSpaceLister(t *testing.T) {
 		require.NoError(t, err)
 
 		t.Run("cannot get apis list", func(t *testing.T) {
-			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", hostAwait.APIProxyURL, "/apis"), nil)
-			require.NoError(t, err)
-
-			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", bannedUser.token))
-			tr := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}} // nolint:gosec
-			client := &http.Client{Transport: tr}
-			res, err := client.Do(req)
-			require.NoError(t, err)
-			defer res.Body.Close()
+			res := proxy.NewHTTPClient(t, bannedUser.token, hostAwait.APIProxyURL).Do(http.MethodGet, "/apis", nil)
 
 			require.Equal(t, http.StatusForbidden, res.StatusCode)
 		})
@@ -1212,180 +1146,10 @@ func createAppStudioUser(t *testing.T, awaitilities wait.Awaitilities, user *pro
 	require.NoError(t, err)
 }
 
-func newWsWatcher(t *testing.T, user proxyUser, namespace, proxyURL string) *wsWatcher {
-	_, err := url.Parse(proxyURL)
-	require.NoError(t, err)
-	return &wsWatcher{
-		t:            t,
-		namespace:    namespace,
-		user:         user,
-		proxyBaseURL: proxyURL,
-	}
-}
-
-// wsWatcher represents a watcher which leverages a WebSocket connection to watch for Applications in the user's namespace.
-// The connection is established with the reg-service proxy instead of direct connection to the API server.
-type wsWatcher struct {
-	done         chan interface{}
-	interrupt    chan os.Signal
-	t            *testing.T
-	user         proxyUser
-	namespace    string
-	connection   *websocket.Conn
-	proxyBaseURL string
-
-	mu           sync.RWMutex
-	receivedApps map[string]*appstudiov1.Application
-}
-
-// start creates a new WebSocket connection. The method returns a function which is to be used to close the connection when done.
-func (w *wsWatcher) Start() func() {
-	w.done = make(chan interface{})    // Channel to indicate that the receiverHandler is done
-	w.interrupt = make(chan os.Signal) // Channel to listen for interrupt signal to terminate gracefully
-
-	signal.Notify(w.interrupt, os.Interrupt) // Notify the interrupt channel for SIGINT
-
-	encodedToken := base64.RawURLEncoding.EncodeToString([]byte(w.user.token))
-	protocol := fmt.Sprintf("base64url.bearer.authorization.k8s.io.%s", encodedToken)
-
-	trimmedProxyURL := strings.TrimPrefix(w.proxyBaseURL, "https://")
-	socketURL := fmt.Sprintf("wss://%s/apis/appstudio.redhat.com/v1alpha1/namespaces/%s/applications?watch=true", trimmedProxyURL, tenantNsName(w.namespace))
-	w.t.Logf("opening connection to '%s'", socketURL)
-	dialer := &websocket.Dialer{
-		Subprotocols: []string{protocol, "base64.binary.k8s.io"},
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: true, // nolint:gosec
-		},
-	}
-
-	var conn *websocket.Conn
-	var resp *http.Response
-
-	// Retry websocket connection to handle rate limiting issues
-	// From OpenShift 4.19+ (using k8s 1.32), the ResilientWatchCacheInitialization feature is enabled
-	// which can cause 429 rate limiting responses when watchcache is still under initialization
-	err := kubewait.PollUntilContextTimeout(context.TODO(), wait.DefaultRetryInterval, wait.DefaultTimeout, true, func(ctx context.Context) (bool, error) {
-		extraHeaders := make(http.Header, 1)
-		extraHeaders.Add("Origin", "http://localhost")
-
-		var err error
-		conn, resp, err = dialer.Dial(socketURL, extraHeaders) // nolint:bodyclose // see `return func() {...}`
-
-		if err != nil {
-			r, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			if errors.Is(err, websocket.ErrBadHandshake) {
-				if resp.StatusCode == 429 {
-					w.t.Logf("rate limited, retrying: %s", string(r))
-					return false, nil
-				}
-				w.t.Logf("handshake failed with status %d / response %s", resp.StatusCode, string(r))
-				return false, err
-			} else {
-				w.t.Logf("connection failed with status %d / response %s", resp.StatusCode, string(r))
-				return false, err
-			}
-		}
-
-		w.connection = conn
-		return true, nil
-	})
-	require.NoError(w.t, err)
-
-	w.connection = conn
-	w.receivedApps = make(map[string]*appstudiov1.Application)
-
-	go w.receiveHandler()
-	go w.startMainLoop()
-
-	return func() {
-		_ = w.connection.Close()
-		if resp != nil {
-			_, _ = io.Copy(io.Discard, resp.Body)
-			_ = resp.Body.Close()
-		}
-	}
-}
-
-// startMainLoop starts the main loop for the client. Packets are sent here.
-func (w *wsWatcher) startMainLoop() {
-	for {
-		select {
-		case <-time.After(time.Duration(1) * time.Millisecond * 1000):
-			// Send an echo packet every second
-			err := w.connection.WriteMessage(websocket.TextMessage, []byte("Hello from e2e tests!"))
-			if err != nil {
-				w.t.Logf("Exiting main loop. It's normal if the connection has been closed. Reason: %s\n", err.Error())
-				return
-			}
-		case <-w.interrupt:
-			// Received a SIGINT (Ctrl + C). Terminate gracefully...
-			// Close the websocket connection
-			err := w.connection.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-			if err != nil {
-				w.t.Logf("Error during closing websocket: %s", err.Error())
-				return
-			}
-
-			select {
-			case <-w.done:
-				w.t.Log("Receiver Channel Closed! Exiting...")
-			case <-time.After(time.Duration(1) * time.Second):
-				w.t.Log("Timeout in closing receiving channel. Exiting...")
-			}
-			return
-		}
-	}
-}
-
-type message struct {
-	MessageType string                  `json:"type"`
-	Application appstudiov1.Application `json:"object"`
-}
-
-// receiveHandler listens to the incoming messages and stores them as Applications objects
-func (w *wsWatcher) receiveHandler() {
-	defer close(w.done)
-	for {
-		_, msg, err := w.connection.ReadMessage()
-		if err != nil {
-			w.t.Logf("Exiting message receiving loop. It's normal if the connection has been closed. Reason: %s\n", err.Error())
-			return
-		}
-		w.t.Logf("Received: %s", msg)
-		message := message{}
-		err = json.Unmarshal(msg, &message)
-		require.NoError(w.t, err)
-		copyApp := message.Application
-		w.mu.Lock()
-		if message.MessageType == "DELETED" {
-			delete(w.receivedApps, copyApp.Name)
-		} else {
-			w.receivedApps[copyApp.Name] = &copyApp
-		}
-		w.mu.Unlock()
-	}
-}
-
-func (w *wsWatcher) WaitForApplication(expectedAppName string) (*appstudiov1.Application, error) {
-	var foundApp *appstudiov1.Application
-	err := kubewait.PollUntilContextTimeout(context.TODO(), wait.DefaultRetryInterval, wait.DefaultTimeout, true, func(ctx context.Context) (bool, error) {
-		defer w.mu.RUnlock()
-		w.mu.RLock()
-		foundApp = w.receivedApps[expectedAppName]
-		return foundApp != nil, nil
-	})
-	return foundApp, err
-}
-
-func (w *wsWatcher) WaitForApplicationDeletion(expectedAppName string) error {
-	err := kubewait.PollUntilContextTimeout(context.TODO(), wait.DefaultRetryInterval, wait.DefaultTimeout, true, func(ctx context.Context) (bool, error) {
-		defer w.mu.RUnlock()
-		w.mu.RLock()
-		_, present := w.receivedApps[expectedAppName]
-		return !present, nil
-	})
-	return err
+// watchApplications starts a new websocket watcher which watches for the Application CRs in the namespace of the given user
+// through the given proxy URL, with the given token
+func watchApplications(t *testing.T, token, proxyURL, username string) *proxy.Watcher[*appstudiov1.Application] {
+	return proxy.Watch[*appstudiov1.Application](proxy.NewHTTPClient(t, token, proxyURL), appstudiov1.GroupVersion.WithResource("applications"), tenantNsName(username))
 }
 
 func newApplication(applicationName, namespace string) *appstudiov1.Application {
@@ -1434,7 +1198,3 @@ func expectedWorkspaceFor(t *testing.T, hostAwait *wait.HostAwaitility, spaceNam
 	)
 	return *ws
 }
-
-type headerKeyValue struct {
-	key, value string
-}
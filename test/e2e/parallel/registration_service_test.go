@@ -1,9 +1,7 @@
 package parallel
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,6 +18,8 @@ import (
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
 	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/regsvc"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/sms"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/gofrs/uuid"
@@ -108,6 +108,24 @@ func TestRegistrationServiceMetricsEndpoint(t *testing.T) {
 		assert.Contains(t, string(samples), "sandbox_promhttp_request_duration_seconds_sum")
 		assert.Contains(t, string(samples), "sandbox_promhttp_request_duration_seconds_count")
 	})
+
+	t.Run("requests are counted and timed", func(t *testing.T) {
+		// given
+		regsvcMetrics := await.Host().RegistrationServiceMetrics(t)
+		regsvcMetrics.InitMetrics(t)
+		client := regsvc.NewClient(await.Host().RegistrationServiceURL, "")
+
+		// when
+		for i := 0; i < 3; i++ {
+			_, err := client.Health()
+			require.NoError(t, err)
+		}
+
+		// then
+		// other tests running in parallel may also send requests to the registration service, hence the "or more"
+		regsvcMetrics.WaitForMetricDeltaOrMore(t, wait.RegistrationServiceAPIRequestsMetric, 3, "method", "get", "code", "200")
+		regsvcMetrics.WaitForHistogramInfBucketDeltaOrMore(t, wait.RegistrationServiceRequestDurationMetric, 3, "code", "200")
+	})
 }
 
 func TestHealth(t *testing.T) {
@@ -118,40 +136,15 @@ func TestHealth(t *testing.T) {
 
 	t.Run("get healthcheck 200 OK", func(t *testing.T) {
 		// Call health endpoint.
-		req, err := http.NewRequest("GET", route+"/api/v1/health", nil)
-		require.NoError(t, err)
-
-		resp, err := httpClient.Do(req) //nolint:bodyclose // see `defer Close(...)`
-		require.NoError(t, err)
-		defer Close(t, resp)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
-
-		mp := make(map[string]interface{})
-		err = json.Unmarshal([]byte(body), &mp)
+		health, err := regsvc.NewClient(route, "").Health()
 		require.NoError(t, err)
 
 		// Verify JSON response.
-		alive := mp["alive"]
-		require.IsType(t, true, alive)
-		require.True(t, alive.(bool))
-
-		environment := mp["environment"]
-		require.IsType(t, "", environment)
-		require.Equal(t, "e2e-tests", environment.(string))
-
-		revision := mp["revision"]
-		require.NotNil(t, revision)
-
-		buildTime := mp["buildTime"]
-		require.NotNil(t, buildTime)
-
-		startTime := mp["startTime"]
-		require.NotNil(t, startTime)
+		require.True(t, health.Alive)
+		require.Equal(t, "e2e-tests", health.Environment)
+		require.NotEmpty(t, health.Revision)
+		require.NotEmpty(t, health.BuildTime)
+		require.NotEmpty(t, health.StartTime)
 	})
 }
 
@@ -161,36 +154,20 @@ func TestAnalytics(t *testing.T) {
 	await := WaitForDeployments(t)
 	route := await.Host().RegistrationServiceURL
 
-	assertNotSecuredGetResponseEquals := func(t *testing.T, endPointPath, expectedResponseValue string) {
-		// Call analytics domain endpoint.
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/%s", route, endPointPath), nil)
-		require.NoError(t, err)
-
-		resp, err := httpClient.Do(req) //nolint:bodyclose // see `defer Close(...)`
-		require.NoError(t, err)
-		defer Close(t, resp)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
-
-		value := string(body)
-		require.NoError(t, err)
-
-		// Verify JSON response.
-		require.Equal(t, expectedResponseValue, value)
-	}
+	client := regsvc.NewClient(route, "")
 
 	t.Run("get devspaces segment write key 200 OK", func(t *testing.T) {
 		// Call segment write key endpoint.
-		assertNotSecuredGetResponseEquals(t, "segment-write-key", "test devspaces segment write key")
+		key, err := client.DevSpacesSegmentWriteKey()
+		require.NoError(t, err)
+		require.Equal(t, "test devspaces segment write key", key)
 	})
 
 	t.Run("get sandbox segment write key 200 OK", func(t *testing.T) {
 		// Call sandbox segment write key endpoint.
-		assertNotSecuredGetResponseEquals(t, "analytics/segment-write-key", "test sandbox segment write key")
+		key, err := client.SegmentWriteKey()
+		require.NoError(t, err)
+		require.Equal(t, "test sandbox segment write key", key)
 	})
 }
 
@@ -202,18 +179,8 @@ func TestAuthConfig(t *testing.T) {
 
 	t.Run("get authconfig 200 OK", func(t *testing.T) {
 		// Call authconfig endpoint.
-		req, err := http.NewRequest("GET", route+"/api/v1/authconfig", nil)
+		_, err := regsvc.NewClient(route, "").AuthConfig()
 		require.NoError(t, err)
-
-		resp, err := httpClient.Do(req) //nolint:bodyclose // see `defer Close(...)`
-		require.NoError(t, err)
-		defer Close(t, resp)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
 	})
 }
 
@@ -223,108 +190,108 @@ func TestSignupFails(t *testing.T) {
 	await := WaitForDeployments(t)
 	route := await.Host().RegistrationServiceURL
 
-	t.Run("post signup error no token 401 Unauthorized", func(t *testing.T) {
-		// Call signup endpoint without a token.
-		requestBody, err := json.Marshal(map[string]string{})
-		require.NoError(t, err)
-		req, err := http.NewRequest("POST", route+"/api/v1/signup", bytes.NewBuffer(requestBody))
-		require.NoError(t, err)
-		req.Header.Set("content-type", "application/json")
-
-		resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer.Close(...)`
-		defer Close(t, resp)
-		require.NoError(t, err)
-
-		// Retrieve unauthorized http status code.
-		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
-
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
-
-		mp := make(map[string]interface{})
-		err = json.Unmarshal([]byte(body), &mp)
-		require.NoError(t, err)
-
-		// Check token error.
-		tokenErr := mp["error"]
-		require.Equal(t, "no token found", tokenErr.(string))
-	})
-	t.Run("post signup error invalid token 401 Unauthorized", func(t *testing.T) {
-		// Call signup endpoint with an invalid token.
-		mp := NewHTTPRequest(t).
-			InvokeEndpoint("POST", route+"/api/v1/signup", "1223123123", "", http.StatusUnauthorized).
-			UnmarshalMap()
-
-		// Check token error.
-		tokenErr := mp["error"]
-		require.Equal(t, "token is malformed: token contains an invalid number of segments", tokenErr.(string))
-	})
-	t.Run("post signup exp token 401 Unauthorized", func(t *testing.T) {
-		emailAddress := uuid.Must(uuid.NewV4()).String() + "@acme.com"
-		// Not identical to the token used in POST signup - should return resource not found.
-		_, token1, err := authsupport.NewToken(
-			authsupport.WithEmail(emailAddress),
-			authsupport.WithExp(time.Now().Add(-60*time.Second)))
-		require.NoError(t, err)
-		mp := NewHTTPRequest(t).
-			InvokeEndpoint("POST", route+"/api/v1/signup", token1, "", http.StatusUnauthorized).
-			UnmarshalMap()
-
-		// Check token error.
-		tokenErr := mp["error"]
-		require.Contains(t, tokenErr.(string), "token has invalid claims: token is expired")
-	})
-	t.Run("get signup error no token 401 Unauthorized", func(t *testing.T) {
-		// Call signup endpoint without a token.
-		req, err := http.NewRequest("GET", route+"/api/v1/signup", nil)
-		require.NoError(t, err)
-		req.Header.Set("content-type", "application/json")
-
-		resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer.Close(...)`
+	unknownKey, err := authsupport.NewKey("unknown")
+	require.NoError(t, err)
+	// a key which is not the e2e key but which claims to be
+	wrongKey, err := authsupport.NewKey(authsupport.E2EKeyID())
+	require.NoError(t, err)
+	newToken := func(t *testing.T, claims ...authsupport.Claim) string {
+		claims = append(claims, authsupport.WithEmail(uuid.Must(uuid.NewV4()).String()+"@acme.com"))
+		_, token, err := authsupport.NewToken(claims...)
 		require.NoError(t, err)
-		defer Close(t, resp)
-
-		// Retrieve unauthorized http status code.
-		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		return token
+	}
 
-		body, err := io.ReadAll(resp.Body)
-		require.NoError(t, err)
-		require.NotNil(t, body)
+	for name, tc := range map[string]struct {
+		token      func(t *testing.T) string
+		tokenError string
+		// partial is true when the token error of the response contains the expected one, with additional details
+		partial bool
+	}{
+		"no token": {
+			token: func(_ *testing.T) string {
+				return ""
+			},
+			tokenError: "no token found",
+		},
+		"malformed token": {
+			token: func(_ *testing.T) string {
+				return "1223123123"
+			},
+			tokenError: "token is malformed: token contains an invalid number of segments",
+		},
+		"expired token": {
+			token: func(t *testing.T) string {
+				return newToken(t, authsupport.WithExp(time.Now().Add(-60*time.Second)))
+			},
+			tokenError: "token has invalid claims: token is expired",
+			partial:    true,
+		},
+		"token not valid yet": {
+			token: func(t *testing.T) string {
+				return newToken(t, authsupport.WithNotBefore(time.Now().Add(time.Hour)))
+			},
+			tokenError: "token has invalid claims: token is not valid yet",
+			partial:    true,
+		},
+		"token with a wrong audience": {
+			token: func(t *testing.T) string {
+				return newToken(t, authsupport.WithAudience("wrong-audience"))
+			},
+			tokenError: "token has invalid audience",
+			partial:    true,
+		},
+		"token with a wrong issuer": {
+			token: func(t *testing.T) string {
+				return newToken(t, authsupport.WithIssuer("https://wrong-issuer.example.com"))
+			},
+			tokenError: "token has invalid issuer",
+			partial:    true,
+		},
+		"token signed by an unknown key": {
+			token: func(t *testing.T) string {
+				_, token, err := unknownKey.NewToken(authsupport.WithEmail(uuid.Must(uuid.NewV4()).String() + "@acme.com"))
+				require.NoError(t, err)
+				return token
+			},
+			tokenError: "token is unverifiable",
+			partial:    true,
+		},
+		"token signed by another key than the one of its kid": {
+			token: func(t *testing.T) string {
+				_, token, err := wrongKey.NewToken(authsupport.WithEmail(uuid.Must(uuid.NewV4()).String() + "@acme.com"))
+				require.NoError(t, err)
+				return token
+			},
+			tokenError: "token signature is invalid",
+			partial:    true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			token := tc.token(t)
+
+			t.Run("post signup 401 Unauthorized", func(t *testing.T) {
+				// when
+				client := regsvc.NewClient(route, token)
+				err := client.Signup()
+
+				// then
+				regsvcErr := regsvc.RequireStatus(t, client, err, http.StatusUnauthorized)
+				requireTokenError(t, tc.tokenError, tc.partial, regsvcErr)
+			})
 
-		mp := make(map[string]interface{})
-		err = json.Unmarshal([]byte(body), &mp)
-		require.NoError(t, err)
+			t.Run("get signup 401 Unauthorized", func(t *testing.T) {
+				// when
+				client := regsvc.NewClient(route, token)
+				_, err := client.GetSignup()
 
-		// Check token error.
-		tokenErr := mp["error"]
-		require.Equal(t, "no token found", tokenErr.(string))
-	})
-	t.Run("get signup error invalid token 401 Unauthorized", func(t *testing.T) {
-		// Call signup endpoint with an invalid token.
-		mp := NewHTTPRequest(t).
-			InvokeEndpoint("GET", route+"/api/v1/signup", "1223123123", "", http.StatusUnauthorized).
-			UnmarshalMap()
-
-		// Check token error.
-		tokenErr := mp["error"]
-		require.Equal(t, "token is malformed: token contains an invalid number of segments", tokenErr.(string))
-	})
-	t.Run("get signup exp token 401 Unauthorized", func(t *testing.T) {
-		emailAddress := uuid.Must(uuid.NewV4()).String() + "@acme.com"
-		// Not identical to the token used in POST signup - should return resource not found.
-		_, token1, err := authsupport.NewToken(
-			authsupport.WithEmail(emailAddress),
-			authsupport.WithExp(time.Now().Add(-60*time.Second)))
-		require.NoError(t, err)
-		mp := NewHTTPRequest(t).
-			InvokeEndpoint("GET", route+"/api/v1/signup", token1, "", http.StatusUnauthorized).
-			UnmarshalMap()
+				// then
+				regsvcErr := regsvc.RequireStatus(t, client, err, http.StatusUnauthorized)
+				requireTokenError(t, tc.tokenError, tc.partial, regsvcErr)
+			})
+		})
+	}
 
-		// Check token error.
-		tokenErr := mp["error"]
-		require.Contains(t, tokenErr.(string), "token has invalid claims: token is expired")
-	})
 	t.Run("get signup 404 NotFound", func(t *testing.T) {
 		// Get valid generated token for e2e tests. IAT claim is overridden
 		// to avoid token used before issued error.
@@ -350,12 +317,12 @@ func TestSignupFails(t *testing.T) {
 		require.NoError(t, err)
 
 		// Call signup endpoint with a valid token to initiate a signup process
-		response := NewHTTPRequest(t).
-			InvokeEndpoint("POST", route+"/api/v1/signup", token, "", http.StatusForbidden).
-			UnmarshalMap()
-		require.Equal(t, "forbidden: failed to create usersignup for test-crtadmin", response["message"])
-		require.Equal(t, "error creating UserSignup resource", response["details"])
-		require.InDelta(t, float64(403), response["code"], 0.01)
+		client := regsvc.NewClient(route, token)
+		err = client.Signup()
+		regsvcErr := regsvc.RequireStatus(t, client, err, http.StatusForbidden)
+		require.Equal(t, "forbidden: failed to create usersignup for test-crtadmin", regsvcErr.Message)
+		require.Equal(t, "error creating UserSignup resource", regsvcErr.Details)
+		require.Equal(t, http.StatusForbidden, regsvcErr.Code)
 
 		hostAwait := await.Host()
 		hostAwait.WithRetryOptions(wait.TimeoutOption(time.Second*15)).WaitAndVerifyThatUserSignupIsNotCreated(t, identity.ID.String())
@@ -373,18 +340,27 @@ func TestSignupFails(t *testing.T) {
 		require.NoError(t, err)
 
 		// Call signup endpoint with a valid token to initiate a signup process
-		response := NewHTTPRequest(t).
-			InvokeEndpoint("POST", route+"/api/v1/signup", token, "", http.StatusForbidden).
-			UnmarshalMap()
-		require.Equal(t, "forbidden: failed to create usersignup for longer-username-crtadmin", response["message"])
-		require.Equal(t, "error creating UserSignup resource", response["details"])
-		require.InDelta(t, float64(403), response["code"], 0.01)
+		client := regsvc.NewClient(route, token)
+		err = client.Signup()
+		regsvcErr := regsvc.RequireStatus(t, client, err, http.StatusForbidden)
+		require.Equal(t, "forbidden: failed to create usersignup for longer-username-crtadmin", regsvcErr.Message)
+		require.Equal(t, "error creating UserSignup resource", regsvcErr.Details)
+		require.Equal(t, http.StatusForbidden, regsvcErr.Code)
 
 		hostAwait := await.Host()
 		hostAwait.WithRetryOptions(wait.TimeoutOption(time.Second*15)).WaitAndVerifyThatUserSignupIsNotCreated(t, identity.ID.String())
 	})
 }
 
+// requireTokenError requires that the token error of the given response equals the expected one, or contains it if `partial` is true
+func requireTokenError(t *testing.T, expected string, partial bool, regsvcErr *regsvc.Error) {
+	if partial {
+		require.Contains(t, regsvcErr.TokenError, expected)
+		return
+	}
+	require.Equal(t, expected, regsvcErr.TokenError)
+}
+
 func TestSignupOK(t *testing.T) {
 	// given
 	t.Parallel()
@@ -395,7 +371,8 @@ func TestSignupOK(t *testing.T) {
 	memberAwait := await.Member1()
 	signupUser := func(token, email, userSignupName string, identity *commonauth.Identity) *toolchainv1alpha1.UserSignup {
 		// Call signup endpoint with a valid token to initiate a signup process
-		NewHTTPRequest(t).InvokeEndpoint("POST", route+"/api/v1/signup", token, "", http.StatusAccepted)
+		err := regsvc.NewClient(route, token).Signup()
+		require.NoError(t, err)
 
 		// Wait for the UserSignup to be created
 		userSignup, err := hostAwait.WaitForUserSignup(t, userSignupName,
@@ -410,12 +387,12 @@ func TestSignupOK(t *testing.T) {
 		assertGetSignupStatusPendingApproval(t, await, identity.Username, token)
 
 		// Attempt to create same usersignup by calling post signup with same token should return an error
-		mp := NewHTTPRequest(t).
-			InvokeEndpoint("POST", route+"/api/v1/signup", token, "", http.StatusConflict).
-			UnmarshalMap()
+		client := regsvc.NewClient(route, token)
+		err = client.Signup()
+		regsvcErr := regsvc.RequireStatus(t, client, err, http.StatusConflict)
 		assert.Equal(t, fmt.Sprintf("Operation cannot be fulfilled on  \"\": UserSignup [username: %s]. Unable to create UserSignup because there is already an active UserSignup with such a username",
-			identity.Username), mp["message"])
-		assert.Equal(t, "error creating UserSignup resource", mp["details"])
+			identity.Username), regsvcErr.Message)
+		assert.Equal(t, "error creating UserSignup resource", regsvcErr.Details)
 
 		userSignup, err = wait.For(t, hostAwait.Awaitility, &toolchainv1alpha1.UserSignup{}).
 			Update(userSignup.Name, hostAwait.Namespace,
@@ -515,7 +492,8 @@ func TestUserSignupFoundWhenNamedWithEncodedUsername(t *testing.T) {
 	require.NoError(t, err)
 
 	// Call the signup endpoint
-	NewHTTPRequest(t).InvokeEndpoint("POST", route+"/api/v1/signup", token0, "", http.StatusAccepted)
+	err = regsvc.NewClient(route, token0).Signup()
+	require.NoError(t, err)
 
 	// Wait for the UserSignup to be created
 	userSignup, err := hostAwait.WaitForUserSignup(t, "arnold",
@@ -532,12 +510,12 @@ func TestUserSignupFoundWhenNamedWithEncodedUsername(t *testing.T) {
 		authsupport.WithEmail(emailAddress),
 		authsupport.WithPreferredUsername("arnold"))
 	require.NoError(t, err)
-	mp, mpStatus := ParseSignupResponse(t, NewHTTPRequest(t).InvokeEndpoint("GET", route+"/api/v1/signup", token0, "", http.StatusOK).UnmarshalMap())
-	assert.Empty(t, mp["compliantUsername"])
-	assert.Equal(t, "arnold", mp["username"], "got response %+v", mp)
-	require.IsType(t, false, mpStatus["ready"])
-	assert.False(t, mpStatus["ready"].(bool))
-	assert.Equal(t, "PendingApproval", mpStatus["reason"])
+	signup, err := regsvc.NewClient(route, token0).GetSignup()
+	require.NoError(t, err)
+	assert.Empty(t, signup.CompliantUsername)
+	assert.Equal(t, "arnold", signup.Username, "got response %+v", signup)
+	assert.False(t, signup.Status.Ready)
+	assert.Equal(t, "PendingApproval", signup.Status.Reason)
 }
 
 func TestPhoneVerification(t *testing.T) {
@@ -553,9 +531,9 @@ func TestPhoneVerification(t *testing.T) {
 	require.NoError(t, err)
 
 	// Call the signup endpoint
-	NewHTTPRequest(t).
-		InvokeEndpoint("POST", route+"/api/v1/signup", token0, "", http.StatusAccepted).
-		UnmarshalMap()
+	client0 := regsvc.NewClient(route, token0)
+	err = client0.Signup()
+	require.NoError(t, err)
 
 	// Wait for the UserSignup to be created
 	userSignup, err := hostAwait.WaitForUserSignup(t, identity0.Username,
@@ -567,13 +545,13 @@ func TestPhoneVerification(t *testing.T) {
 	assert.Equal(t, emailAddress, email)
 
 	// Call get signup endpoint with a valid token and make sure verificationRequired is true
-	mp, mpStatus := ParseSignupResponse(t, NewHTTPRequest(t).InvokeEndpoint("GET", route+"/api/v1/signup", token0, "", http.StatusOK).UnmarshalMap())
-	assert.Empty(t, mp["compliantUsername"])
-	assert.Equal(t, identity0.Username, mp["username"])
-	require.IsType(t, false, mpStatus["ready"])
-	assert.False(t, mpStatus["ready"].(bool))
-	assert.Equal(t, "PendingApproval", mpStatus["reason"])
-	require.True(t, mpStatus["verificationRequired"].(bool))
+	signup, err := client0.GetSignup()
+	require.NoError(t, err)
+	assert.Empty(t, signup.CompliantUsername)
+	assert.Equal(t, identity0.Username, signup.Username)
+	assert.False(t, signup.Status.Ready)
+	assert.Equal(t, "PendingApproval", signup.Status.Reason)
+	require.True(t, signup.Status.VerificationRequired)
 
 	// Confirm the status of the UserSignup is correct
 	_, err = hostAwait.WaitForUserSignup(t, identity0.Username,
@@ -587,9 +565,17 @@ func TestPhoneVerification(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, apierrors.IsNotFound(err))
 
+	// Count the messages which were already sent to the phone number (only possible if the fake SMS provider is deployed)
+	smsProvider := FakeSMSProvider(t, hostAwait)
+	var sentMessages []sms.Message
+	if smsProvider != nil {
+		sentMessages, err = smsProvider.Messages("+61408999999")
+		require.NoError(t, err)
+	}
+
 	// Initiate the verification process
-	NewHTTPRequest(t).
-		InvokeEndpoint("PUT", route+"/api/v1/signup/verification", token0, `{ "country_code":"+61", "phone_number":"408999999" }`, http.StatusNoContent)
+	err = client0.InitVerification("+61", "408999999")
+	require.NoError(t, err)
 
 	// Retrieve the updated UserSignup
 	userSignup, err = hostAwait.WaitForUserSignup(t, identity0.Username)
@@ -599,11 +585,19 @@ func TestPhoneVerification(t *testing.T) {
 	verificationCode := userSignup.Annotations[toolchainv1alpha1.UserSignupVerificationCodeAnnotationKey]
 	require.NotEmpty(t, verificationCode)
 
+	// Confirm the verification code was sent to the phone number (only possible if the fake SMS provider is deployed)
+	if smsProvider != nil {
+		msg, err := smsProvider.WaitForMessage(t, "+61408999999", len(sentMessages))
+		require.NoError(t, err)
+		assert.Equal(t, verificationCode, msg.VerificationCode(), "unexpected message: %s", msg.Body)
+	}
+
 	// Confirm the expiry time has been set
 	require.NotEmpty(t, userSignup.Annotations[toolchainv1alpha1.UserVerificationExpiryAnnotationKey])
 
 	// Attempt to verify with an incorrect verification code
-	NewHTTPRequest(t).InvokeEndpoint("GET", route+"/api/v1/signup/verification/invalid", token0, "", http.StatusForbidden)
+	err = client0.VerifyCode("invalid")
+	regsvc.RequireStatus(t, client0, err, http.StatusForbidden)
 
 	// Retrieve the updated UserSignup
 	userSignup, err = hostAwait.WaitForUserSignup(t, identity0.Username)
@@ -616,8 +610,8 @@ func TestPhoneVerification(t *testing.T) {
 	require.Equal(t, verificationCode, userSignup.Annotations[toolchainv1alpha1.UserSignupVerificationCodeAnnotationKey])
 
 	// Verify with the correct code
-	NewHTTPRequest(t).InvokeEndpoint("GET", route+fmt.Sprintf("/api/v1/signup/verification/%s",
-		userSignup.Annotations[toolchainv1alpha1.UserSignupVerificationCodeAnnotationKey]), token0, "", http.StatusOK)
+	err = client0.VerifyCode(userSignup.Annotations[toolchainv1alpha1.UserSignupVerificationCodeAnnotationKey])
+	require.NoError(t, err)
 
 	// Retrieve the updated UserSignup
 	userSignup, err = hostAwait.WaitForUserSignup(t, identity0.Username,
@@ -633,16 +627,16 @@ func TestPhoneVerification(t *testing.T) {
 	require.Empty(t, userSignup.Annotations[toolchainv1alpha1.UserSignupVerificationInitTimestampAnnotationKey])
 
 	// Call get signup endpoint with a valid token and make sure it's pending approval
-	mp, mpStatus = ParseSignupResponse(t, NewHTTPRequest(t).InvokeEndpoint("GET", route+"/api/v1/signup", token0, "", http.StatusOK).UnmarshalMap())
-	assert.Empty(t, mp["compliantUsername"])
-	assert.Empty(t, mp["defaultUserNamespace"])
-	assert.Empty(t, mp["rhodsMemberURL"])
-	assert.Empty(t, mp["cheDashboardURL"])
-	assert.Equal(t, identity0.Username, mp["username"])
-	require.IsType(t, false, mpStatus["ready"])
-	assert.False(t, mpStatus["ready"].(bool))
-	assert.Equal(t, "PendingApproval", mpStatus["reason"])
-	require.False(t, mpStatus["verificationRequired"].(bool))
+	signup, err = client0.GetSignup()
+	require.NoError(t, err)
+	assert.Empty(t, signup.CompliantUsername)
+	assert.Empty(t, signup.DefaultUserNamespace)
+	assert.Empty(t, signup.RHODSMemberURL)
+	assert.Empty(t, signup.CheDashboardURL)
+	assert.Equal(t, identity0.Username, signup.Username)
+	assert.False(t, signup.Status.Ready)
+	assert.Equal(t, "PendingApproval", signup.Status.Reason)
+	require.False(t, signup.Status.VerificationRequired)
 
 	userSignup, err = wait.For(t, hostAwait.Awaitility, &toolchainv1alpha1.UserSignup{}).
 		Update(userSignup.Name, hostAwait.Namespace,
@@ -657,10 +651,11 @@ func TestPhoneVerification(t *testing.T) {
 	require.NoError(t, err)
 
 	// Retrieve the UserSignup from the GET endpoint
-	_, mpStatus = ParseSignupResponse(t, NewHTTPRequest(t).InvokeEndpoint("GET", route+"/api/v1/signup", token0, "", http.StatusOK).UnmarshalMap())
+	signup, err = client0.GetSignup()
+	require.NoError(t, err)
 
 	// Confirm that VerificationRequired is no longer true
-	require.False(t, mpStatus["verificationRequired"].(bool))
+	require.False(t, signup.Status.VerificationRequired)
 
 	// Create another token and identity to sign up with
 	otherEmailValue := uuid.Must(uuid.NewV4()).String() + "@other.domain"
@@ -668,7 +663,9 @@ func TestPhoneVerification(t *testing.T) {
 	require.NoError(t, err)
 
 	// Call the signup endpoint
-	NewHTTPRequest(t).InvokeEndpoint("POST", route+"/api/v1/signup", otherToken, "", http.StatusAccepted)
+	otherClient := regsvc.NewClient(route, otherToken)
+	err = otherClient.Signup()
+	require.NoError(t, err)
 
 	// Wait for the UserSignup to be created
 	otherUserSignup, err := hostAwait.WaitForUserSignup(t, otherIdentity.Username,
@@ -680,16 +677,13 @@ func TestPhoneVerification(t *testing.T) {
 	assert.Equal(t, otherEmailValue, otherEmailAnnotation)
 
 	// Initiate the verification process using the same phone number as previously
-	responseMap := NewHTTPRequest(t).
-		InvokeEndpoint("PUT", route+"/api/v1/signup/verification", otherToken,
-			`{ "country_code":"+61", "phone_number":"408999999" }`, http.StatusForbidden).UnmarshalMap()
-
-	require.NotEmpty(t, responseMap)
-	require.InDelta(t, float64(http.StatusForbidden), responseMap["code"], 0.01, "code not found in response body map %s", responseMap)
+	err = otherClient.InitVerification("+61", "408999999")
+	regsvcErr := regsvc.RequireStatus(t, otherClient, err, http.StatusForbidden)
 
-	require.Equal(t, "Forbidden", responseMap["status"])
-	require.Equal(t, "phone number already in use: cannot register using phone number: +61408999999", responseMap["message"])
-	require.Equal(t, "phone number already in use", responseMap["details"])
+	require.Equal(t, http.StatusForbidden, regsvcErr.Code, "code not found in response body %s", regsvcErr.Body)
+	require.Equal(t, "Forbidden", regsvcErr.Status)
+	require.Equal(t, "phone number already in use: cannot register using phone number: +61408999999", regsvcErr.Message)
+	require.Equal(t, "phone number already in use", regsvcErr.Details)
 
 	// Retrieve the updated UserSignup
 	otherUserSignup, err = hostAwait.WaitForUserSignup(t, otherIdentity.Username)
@@ -716,8 +710,8 @@ func TestPhoneVerification(t *testing.T) {
 	require.NoError(t, err)
 
 	// Now attempt the verification again
-	NewHTTPRequest(t).
-		InvokeEndpoint("PUT", route+"/api/v1/signup/verification", otherToken, `{ "country_code":"+61", "phone_number":"408999999" }`, http.StatusNoContent)
+	err = otherClient.InitVerification("+61", "408999999")
+	require.NoError(t, err)
 
 	// Retrieve the updated UserSignup again
 	otherUserSignup, err = hostAwait.WaitForUserSignup(t, otherIdentity.Username)
@@ -727,6 +721,115 @@ func TestPhoneVerification(t *testing.T) {
 	require.NotEmpty(t, otherUserSignup.Annotations[toolchainv1alpha1.UserSignupVerificationCodeAnnotationKey])
 }
 
+// TestPhoneVerificationWithFakeSMSProvider verifies how the registration service deals with the delivery errors, the daily limit
+// and the expiry of the verification codes. It does not run in parallel with the other tests, since it resets the fake SMS provider
+// and injects delivery failures, and it is skipped if the fake SMS provider is not deployed (see `make deploy-fake-sms`).
+func TestPhoneVerificationWithFakeSMSProvider(t *testing.T) {
+	// given
+	await := WaitForDeployments(t)
+	hostAwait := await.Host()
+	smsProvider := FakeSMSProvider(t, hostAwait)
+	if smsProvider == nil {
+		t.Skip("the fake SMS provider is not deployed")
+	}
+	require.NoError(t, smsProvider.Reset())
+	t.Cleanup(func() {
+		require.NoError(t, smsProvider.Reset())
+	})
+
+	t.Run("retry after a delivery failure", func(t *testing.T) {
+		// given
+		userSignup, token := signup(t, hostAwait)
+		client := regsvc.NewClient(hostAwait.RegistrationServiceURL, token)
+		require.NoError(t, smsProvider.FailNext(1))
+
+		// when
+		err := client.InitVerification("+61", "408999901")
+
+		// then
+		regsvc.RequireStatus(t, client, err, http.StatusInternalServerError)
+		messages, err := smsProvider.Messages("+61408999901")
+		require.NoError(t, err)
+		require.Empty(t, messages)
+
+		t.Run("code is sent on retry", func(t *testing.T) {
+			// when
+			err := client.InitVerification("+61", "408999901")
+
+			// then
+			require.NoError(t, err)
+			msg, err := smsProvider.WaitForMessage(t, "+61408999901", 0)
+			require.NoError(t, err)
+			userSignup, err := hostAwait.WaitForUserSignup(t, userSignup.Name)
+			require.NoError(t, err)
+			assert.Equal(t, msg.VerificationCode(), userSignup.Annotations[toolchainv1alpha1.UserSignupVerificationCodeAnnotationKey])
+		})
+	})
+
+	t.Run("daily limit", func(t *testing.T) {
+		// given
+		_, token := signup(t, hostAwait)
+		client := regsvc.NewClient(hostAwait.RegistrationServiceURL, token)
+		dailyLimit := 5 // default value
+		if limit := hostAwait.GetToolchainConfig(t).Spec.Host.RegistrationService.Verification.DailyLimit; limit != nil {
+			dailyLimit = *limit
+		}
+		for i := 0; i < dailyLimit; i++ {
+			err := client.InitVerification("+61", "408999902")
+			require.NoError(t, err)
+			_, err = smsProvider.WaitForMessage(t, "+61408999902", i)
+			require.NoError(t, err)
+		}
+
+		// when
+		err := client.InitVerification("+61", "408999902")
+
+		// then
+		regsvc.RequireStatus(t, client, err, http.StatusForbidden)
+		messages, err := smsProvider.Messages("+61408999902")
+		require.NoError(t, err)
+		assert.Len(t, messages, dailyLimit, "no message should be sent once the daily limit is reached")
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		// given
+		userSignup, token := signup(t, hostAwait)
+		client := regsvc.NewClient(hostAwait.RegistrationServiceURL, token)
+		err := client.InitVerification("+61", "408999903")
+		require.NoError(t, err)
+		msg, err := smsProvider.WaitForMessage(t, "+61408999903", 0)
+		require.NoError(t, err)
+		_, err = wait.For(t, hostAwait.Awaitility, &toolchainv1alpha1.UserSignup{}).
+			Update(userSignup.Name, hostAwait.Namespace,
+				func(instance *toolchainv1alpha1.UserSignup) {
+					// same layout as the one used by the registration service
+					instance.Annotations[toolchainv1alpha1.UserVerificationExpiryAnnotationKey] = time.Now().Add(-time.Minute).Format("2006-01-02T15:04:05.000Z07:00")
+				})
+		require.NoError(t, err)
+
+		// when
+		err = client.VerifyCode(msg.VerificationCode())
+
+		// then
+		regsvc.RequireStatus(t, client, err, http.StatusForbidden)
+
+		t.Run("new code is accepted", func(t *testing.T) {
+			// when
+			err := client.InitVerification("+61", "408999903")
+			require.NoError(t, err)
+			msg, err := smsProvider.WaitForMessage(t, "+61408999903", 1)
+			require.NoError(t, err)
+			err = client.VerifyCode(msg.VerificationCode())
+
+			// then
+			require.NoError(t, err)
+			_, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
+				wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValuePending))
+			require.NoError(t, err)
+		})
+	})
+}
+
 func TestActivationCodeVerification(t *testing.T) {
 	// given
 	t.Parallel()
@@ -770,46 +873,13 @@ func TestActivationCodeVerification(t *testing.T) {
 		}
 
 		// when call verification endpoint with a valid activation code
-		NewHTTPRequest(t).
-			InvokeEndpoint("POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, event.Name), http.StatusOK)
+		err = regsvc.NewClient(route, token).VerifyActivationCode(event.Name)
+		require.NoError(t, err)
 
 		// then
-		userSignup, err = hostAwait.WaitForUserSignup(t, userName,
-			wait.UntilUserSignupHasLabel(toolchainv1alpha1.SocialEventUserSignupLabelKey, event.Name),
-			wait.UntilUserSignupHasConditions(wait.ConditionSet(wait.Default(), wait.ApprovedByAdmin())...))
+		userSignup = VerifySocialEventAttendee(t, hostAwait, event, userName)
 		cleanup.AddCleanTasks(t, hostAwait.Client, userSignup)
-		require.NoError(t, err)
-		// explicitly approve the usersignup (see above, config for parallel test has automatic approval disabled)
-		_, err = wait.For(t, hostAwait.Awaitility, &toolchainv1alpha1.UserSignup{}).
-			Update(userName, hostAwait.Namespace,
-				func(us *toolchainv1alpha1.UserSignup) {
-					states.SetApprovedManually(us, true)
-				})
-		require.NoError(t, err)
-		t.Logf("user signup '%s' approved", userName)
-
-		// check that the MUR and Space are configured as expected
-		// Wait for the UserSignup to have the desired state
-		userSignup, err = hostAwait.WaitForUserSignup(t, userName,
-			wait.UntilUserSignupHasCompliantUsername(),
-			wait.UntilUserSignupHasTargetCluster(targetCluster))
-		require.NoError(t, err)
-		mur, err := hostAwait.WaitForMasterUserRecord(t, userSignup.Status.CompliantUsername,
-			wait.UntilMasterUserRecordHasTierName(event.Spec.UserTier),
-			wait.UntilMasterUserRecordHasCondition(wait.Provisioned()))
-		require.NoError(t, err)
-		assert.Equal(t, event.Spec.UserTier, mur.Spec.TierName)
-		spaceCriterion := []wait.SpaceWaitCriterion{
-			wait.UntilSpaceHasTier(event.Spec.SpaceTier),
-			wait.UntilSpaceHasConditions(wait.Provisioned()),
-		}
-		if targetCluster != "" {
-			spaceCriterion = append(spaceCriterion, wait.UntilSpaceHasStatusTargetCluster(targetCluster))
-		} else {
-			spaceCriterion = append(spaceCriterion, wait.UntilSpaceHasAnyTargetClusterSet())
-		}
-		_, err = hostAwait.WaitForSpace(t, userSignup.Status.CompliantUsername, spaceCriterion...)
-		require.NoError(t, err)
+		assert.Equal(t, targetCluster, userSignup.Spec.TargetCluster)
 
 		// also check that the SocialEvent status was updated accordingly
 		_, err = hostAwait.WaitForSocialEvent(t, event.Name, wait.UntilSocialEventHasActivationCount(1))
@@ -838,12 +908,13 @@ func TestActivationCodeVerification(t *testing.T) {
 			userSignup, token := signup(t, hostAwait)
 
 			// when call verification endpoint with a valid activation code
-			NewHTTPRequest(t).
-				InvokeEndpoint("POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, "unknown"), http.StatusForbidden)
+			client := regsvc.NewClient(route, token)
+			err := client.VerifyActivationCode("unknown")
+			regsvc.RequireStatus(t, client, err, http.StatusForbidden)
 
 			// then
 			// ensure the UserSignup is not approved yet
-			userSignup, err := hostAwait.WaitForUserSignup(t, userSignup.Name,
+			userSignup, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
 				wait.UntilUserSignupHasConditions(wait.ConditionSet(wait.Default(), wait.VerificationRequired())...))
 			require.NoError(t, err)
 			assert.Equal(t, "1", userSignup.Annotations[toolchainv1alpha1.UserVerificationAttemptsAnnotationKey])
@@ -872,8 +943,9 @@ func TestActivationCodeVerification(t *testing.T) {
 			userSignup, token := signup(t, hostAwait)
 
 			// when call verification endpoint with a valid activation code
-			NewHTTPRequest(t).
-				InvokeEndpoint("POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, event.Name), http.StatusForbidden)
+			client := regsvc.NewClient(route, token)
+			err = client.VerifyActivationCode(event.Name)
+			regsvc.RequireStatus(t, client, err, http.StatusForbidden)
 
 			// then
 			// ensure the UserSignup is not approved yet
@@ -894,8 +966,9 @@ func TestActivationCodeVerification(t *testing.T) {
 			userSignup, token := signup(t, hostAwait)
 
 			// when call verification endpoint with a valid activation code
-			NewHTTPRequest(t).
-				InvokeEndpoint("POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, event.Name), http.StatusForbidden)
+			client := regsvc.NewClient(route, token)
+			err = client.VerifyActivationCode(event.Name)
+			regsvc.RequireStatus(t, client, err, http.StatusForbidden)
 
 			// then
 			// ensure the UserSignup is not approved yet
@@ -917,8 +990,9 @@ func TestActivationCodeVerification(t *testing.T) {
 			userSignup, token := signup(t, hostAwait)
 
 			// when call verification endpoint with a valid activation code
-			NewHTTPRequest(t).
-				InvokeEndpoint("POST", route+"/api/v1/signup/verification/activation-code", token, fmt.Sprintf(`{"code":"%s"}`, event.Name), http.StatusForbidden)
+			client := regsvc.NewClient(route, token)
+			err = client.VerifyActivationCode(event.Name)
+			regsvc.RequireStatus(t, client, err, http.StatusForbidden)
 
 			// then
 			// ensure the UserSignup is approved
@@ -957,13 +1031,12 @@ func TestUsernames(t *testing.T) {
 			Execute(t)
 		// when
 		// we call the get usernames endpoint to get the user
-		response := NewHTTPRequest(t).
-			InvokeEndpoint("GET", route+"/api/v1/usernames/"+user.MUR.GetName(), token, "", http.StatusOK).
-			UnmarshalSlice()
+		response, err := regsvc.NewClient(route, token).Usernames(user.MUR.GetName())
 
 		// then
-		assert.Len(t, response, 1)                                   // only one user should be returned
-		assert.Equal(t, "testgetusernames", response[0]["username"]) // the username should match
+		require.NoError(t, err)
+		assert.Len(t, response, 1)                                // only one user should be returned
+		assert.Equal(t, "testgetusernames", response[0].Username) // the username should match
 	})
 
 	t.Run("get usernames 404 response", func(t *testing.T) {
@@ -982,9 +1055,12 @@ func TestUsernames(t *testing.T) {
 
 		for testName, tc := range tests {
 			t.Run(testName, func(t *testing.T) {
-				// when/then
-				NewHTTPRequest(t).
-					InvokeEndpoint("GET", route+"/api/v1/usernames/"+tc.searchQuery, token, "", http.StatusNotFound)
+				// when
+				client := regsvc.NewClient(route, token)
+				_, err := client.Usernames(tc.searchQuery)
+
+				// then
+				regsvc.RequireStatus(t, client, err, http.StatusNotFound)
 			})
 		}
 	})
@@ -997,7 +1073,8 @@ func signup(t *testing.T, hostAwait *wait.HostAwaitility) (*toolchainv1alpha1.Us
 	identity, emailValue, token := userToken(t)
 
 	// Call the signup endpoint
-	NewHTTPRequest(t).InvokeEndpoint("POST", route+"/api/v1/signup", token, "", http.StatusAccepted)
+	err := regsvc.NewClient(route, token).Signup()
+	require.NoError(t, err)
 
 	// Wait for the UserSignup to be created
 	userSignup, err := hostAwait.WaitForUserSignup(t, identity.Username,
@@ -1021,12 +1098,12 @@ func userToken(t *testing.T) (*commonauth.Identity, string, string) {
 
 func signupHasExpectedDates(startDate, endDate time.Time) func(c *GetSignupClient) {
 	return func(c *GetSignupClient) {
-		responseStartDate, err := time.Parse(time.RFC3339, c.responseBody["startDate"].(string))
+		responseStartDate, err := time.Parse(time.RFC3339, c.signup.StartDate)
 		require.NoError(c.t, err)
 		require.WithinDuration(c.t, startDate, responseStartDate, time.Hour,
 			"startDate in response [%s] not in expected range [%s]", responseStartDate, startDate.Format(time.RFC3339))
 
-		responseEndDate, err := time.Parse(time.RFC3339, c.responseBody["endDate"].(string))
+		responseEndDate, err := time.Parse(time.RFC3339, c.signup.EndDate)
 		require.NoError(c.t, err)
 		require.WithinDuration(c.t, endDate, responseEndDate, time.Hour,
 			"endDate in response [%s] not in expected range [%s]", responseEndDate, endDate.Format(time.RFC3339))
@@ -1035,9 +1112,20 @@ func signupHasExpectedDates(startDate, endDate time.Time) func(c *GetSignupClien
 
 func signupHasExpectedClaims(claims map[string]string) func(c *GetSignupClient) {
 	return func(c *GetSignupClient) {
+		actualClaims := map[string]string{
+			"name":          c.signup.Name,
+			"username":      c.signup.Username,
+			"givenName":     c.signup.GivenName,
+			"familyName":    c.signup.FamilyName,
+			"company":       c.signup.Company,
+			"email":         c.signup.Email,
+			"userID":        c.signup.UserID,
+			"accountID":     c.signup.AccountID,
+			"accountNumber": c.signup.AccountNumber,
+		}
 		for expectedClaim, expectedClaimValue := range claims {
-			actualClaimValue, claimFound := c.responseBody[expectedClaim]
-			require.True(c.t, claimFound, "unable to find expected claim [%s]. Claims found %v", expectedClaim, c.responseBody)
+			actualClaimValue, claimFound := actualClaims[expectedClaim]
+			require.True(c.t, claimFound, "unable to find expected claim [%s]. Claims found %v", expectedClaim, actualClaims)
 			require.Equal(c.t, expectedClaimValue, actualClaimValue, "expected claim value [%s] doesn't match actual claim value [%s]", expectedClaimValue, actualClaimValue)
 		}
 	}
@@ -1050,19 +1138,19 @@ func signupIsProvisioned(client *GetSignupClient) {
 	transformedUsername := commonsignup.TransformUsername(client.username, []string{"openshift", "kube", "default", "redhat", "sandbox"}, []string{"admin"})
 	require.NoError(client.t, err)
 	require.True(client.t, found)
-	assert.Equal(client.t, memberCluster.Status.APIEndpoint, client.responseBody["apiEndpoint"])
-	assert.Equal(client.t, hostAwait.APIProxyURL, client.responseBody["proxyURL"])
-	assert.Equal(client.t, fmt.Sprintf("%s-dev", transformedUsername), client.responseBody["defaultUserNamespace"])
-	assertResponseURL(client.t, memberAwait, "rhods-dashboard-redhat-ods-applications", "rhodsMemberURL", client.responseBody)
-	assertResponseURL(client.t, memberAwait, "devspaces", "cheDashboardURL", client.responseBody)
+	assert.Equal(client.t, memberCluster.Status.APIEndpoint, client.signup.APIEndpoint)
+	assert.Equal(client.t, hostAwait.APIProxyURL, client.signup.ProxyURL)
+	assert.Equal(client.t, fmt.Sprintf("%s-dev", transformedUsername), client.signup.DefaultUserNamespace)
+	assertResponseURL(client.t, memberAwait, "rhods-dashboard-redhat-ods-applications", client.signup.RHODSMemberURL)
+	assertResponseURL(client.t, memberAwait, "devspaces", client.signup.CheDashboardURL)
 }
 
 type GetSignupClient struct {
-	t            *testing.T
-	await        wait.Awaitilities
-	username     string
-	bearerToken  string
-	responseBody map[string]interface{}
+	t           *testing.T
+	await       wait.Awaitilities
+	username    string
+	bearerToken string
+	signup      *regsvc.Signup
 }
 
 func NewGetSignupClient(t *testing.T, await wait.Awaitilities, username, bearerToken string) *GetSignupClient {
@@ -1077,11 +1165,11 @@ func NewGetSignupClient(t *testing.T, await wait.Awaitilities, username, bearerT
 func (c *GetSignupClient) Invoke(assertions ...func(client *GetSignupClient)) {
 	hostAwait := c.await.Host()
 	memberAwait := c.await.Member1()
-	c.responseBody = waitForUserSignupReadyInRegistrationService(c.t, hostAwait.RegistrationServiceURL, c.username, c.bearerToken)
+	c.signup = waitForUserSignupReadyInRegistrationService(c.t, hostAwait.RegistrationServiceURL, c.username, c.bearerToken)
 	transformedUsername := commonsignup.TransformUsername(c.username, []string{"openshift", "kube", "default", "redhat", "sandbox"}, []string{"admin"})
-	assert.Equal(c.t, transformedUsername, c.responseBody["compliantUsername"])
-	assert.Equal(c.t, c.username, c.responseBody["username"])
-	assert.Equal(c.t, memberAwait.GetConsoleURL(c.t), c.responseBody["consoleURL"])
+	assert.Equal(c.t, transformedUsername, c.signup.CompliantUsername)
+	assert.Equal(c.t, c.username, c.signup.Username)
+	assert.Equal(c.t, memberAwait.GetConsoleURL(c.t), c.signup.ConsoleURL)
 
 	for _, assertion := range assertions {
 		assertion(c)
@@ -1090,58 +1178,56 @@ func (c *GetSignupClient) Invoke(assertions ...func(client *GetSignupClient)) {
 
 func assertGetSignupStatusPendingApproval(t *testing.T, await wait.Awaitilities, username, bearerToken string) {
 	route := await.Host().RegistrationServiceURL
-	mp, mpStatus := ParseSignupResponse(t, NewHTTPRequest(t).InvokeEndpoint("GET", route+"/api/v1/signup", bearerToken, "", http.StatusOK).UnmarshalMap())
-	assert.Equal(t, username, mp["username"], "unexpected username in response", mp, mpStatus)
-	assert.Empty(t, mp["defaultUserNamespace"])
-	assert.Empty(t, mp["rhodsMemberURL"])
-	assert.Empty(t, mp["cheDashboardURL"])
-	require.IsType(t, false, mpStatus["ready"])
-	assert.False(t, mpStatus["ready"].(bool))
-	assert.Equal(t, "PendingApproval", mpStatus["reason"])
+	signup, err := regsvc.NewClient(route, bearerToken).GetSignup()
+	require.NoError(t, err)
+	assert.Equal(t, username, signup.Username, "unexpected username in response", signup)
+	assert.Empty(t, signup.DefaultUserNamespace)
+	assert.Empty(t, signup.RHODSMemberURL)
+	assert.Empty(t, signup.CheDashboardURL)
+	assert.False(t, signup.Status.Ready)
+	assert.Equal(t, "PendingApproval", signup.Status.Reason)
 }
 
 func assertGetSignupReturnsNotFound(t *testing.T, await wait.Awaitilities, bearerToken string) {
 	route := await.Host().RegistrationServiceURL
-	NewHTTPRequest(t).InvokeEndpoint("GET", route+"/api/v1/signup", bearerToken, "", http.StatusNotFound)
+	client := regsvc.NewClient(route, bearerToken)
+	_, err := client.GetSignup()
+	regsvc.RequireStatus(t, client, err, http.StatusNotFound)
 }
 
-func assertResponseURL(t *testing.T, memberAwait *wait.MemberAwaitility, hostname string, responseKey string, responseField map[string]interface{}) {
+func assertResponseURL(t *testing.T, memberAwait *wait.MemberAwaitility, hostname string, actualURL string) {
 	require.Containsf(t, memberAwait.GetConsoleURL(t), ".apps", "expected to find .apps in the console URL %s", memberAwait.GetConsoleURL(t))
 	index := strings.Index(memberAwait.GetConsoleURL(t), ".apps")
 	appsURL := memberAwait.GetConsoleURL(t)[index:]
 
 	expectedURL := fmt.Sprintf("https://%s%s", hostname, appsURL)
-	assert.Equal(t, expectedURL, responseField[responseKey])
+	assert.Equal(t, expectedURL, actualURL)
 }
 
 // waitForUserSignupReadyInRegistrationService waits and checks that the UserSignup is ready according to registration service /signup endpoint
-func waitForUserSignupReadyInRegistrationService(t *testing.T, registrationServiceURL, name, bearerToken string) map[string]interface{} {
+func waitForUserSignupReadyInRegistrationService(t *testing.T, registrationServiceURL, name, bearerToken string) *regsvc.Signup {
 	t.Logf("waiting and verifying that UserSignup '%s' is ready according to registration service", name)
-	var mp, mpStatus map[string]interface{}
+	client := regsvc.NewClient(registrationServiceURL, bearerToken)
+	var signup *regsvc.Signup
 	err := k8swait.PollUntilContextTimeout(context.TODO(), time.Second, time.Second*60, true, func(ctx context.Context) (done bool, err error) {
-		mp, mpStatus = ParseSignupResponse(t, NewHTTPRequest(t).InvokeEndpoint("GET", registrationServiceURL+"/api/v1/signup", bearerToken, "", http.StatusOK).UnmarshalMap())
-		// check if `ready` field is set
-		if _, ok := mpStatus["ready"]; !ok {
-			t.Logf("usersignup response for %s is missing `ready` field ", name)
-			t.Logf("registration service status response: %s", spew.Sdump(mpStatus))
-			return false, nil
-		}
+		signup, err = client.GetSignup()
+		require.NoError(t, err)
 		// if `ready` field is not true,
 		// means that user signup is not "ready"
-		if !mpStatus["ready"].(bool) {
+		if !signup.Status.Ready {
 			t.Logf("usersignup %s is not ready yet according to registration service", name)
-			t.Logf("registration service status response: %s", spew.Sdump(mpStatus))
+			t.Logf("registration service status response: %s", spew.Sdump(signup.Status))
 			return false, nil
 		}
 		// check signup status reason
-		if mpStatus["reason"] != toolchainv1alpha1.MasterUserRecordProvisionedReason {
+		if signup.Status.Reason != toolchainv1alpha1.MasterUserRecordProvisionedReason {
 			t.Logf("usersignup %s is not Provisioned yet according to registration service", name)
-			t.Logf("registration service status response: %s", spew.Sdump(mpStatus))
+			t.Logf("registration service status response: %s", spew.Sdump(signup.Status))
 			return false, nil
 		}
 
 		return true, nil
 	})
 	require.NoError(t, err)
-	return mp
+	return signup
 }
@@ -128,3 +128,48 @@ func TestCreateSocialEvent(t *testing.T) {
 		})
 	})
 }
+
+func TestSocialEventConcurrentActivations(t *testing.T) {
+	// given
+	t.Parallel()
+
+	// make sure everything is ready before running the actual tests
+	awaitilities := testsupport.WaitForDeployments(t)
+	memberAwait2 := awaitilities.Member2()
+
+	t.Run("more attendees than seats", func(t *testing.T) {
+		// when
+		result := testsupport.NewSocialEventActivationRequest(awaitilities).
+			MaxAttendees(3).
+			Attendees(8).
+			TargetCluster(memberAwait2.ClusterName).
+			Execute(t)
+
+		// then
+		// the registration service may reject more attendees than necessary when their concurrent updates of the
+		// SocialEvent conflict, hence only the capacity of the event is asserted
+		t.Logf("accepted: %d, rejected: %d, max activation count: %d", len(result.Accepted), len(result.Rejected), result.MaxActivationCount)
+		assert.NotEmpty(t, result.Accepted)
+		assert.LessOrEqual(t, len(result.Accepted), 3)
+		assert.Len(t, result.Rejected, 8-len(result.Accepted))
+		assert.Equal(t, len(result.Accepted), result.Event.Status.ActivationCount)
+		for _, attendee := range result.Accepted {
+			assert.Equal(t, memberAwait2.ClusterName, attendee.UserSignup.Spec.TargetCluster)
+		}
+	})
+
+	t.Run("as many attendees as seats", func(t *testing.T) {
+		// when
+		result := testsupport.NewSocialEventActivationRequest(awaitilities).
+			MaxAttendees(4).
+			Execute(t)
+
+		// then
+		// same as above: some attendees may be rejected because of the conflicts between their concurrent updates
+		t.Logf("accepted: %d, rejected: %d, max activation count: %d", len(result.Accepted), len(result.Rejected), result.MaxActivationCount)
+		assert.NotEmpty(t, result.Accepted)
+		assert.LessOrEqual(t, len(result.Accepted), 4)
+		assert.Len(t, result.Rejected, 4-len(result.Accepted))
+		assert.Equal(t, len(result.Accepted), result.Event.Status.ActivationCount)
+	})
+}
@@ -594,3 +594,27 @@ func TerminatingFailed(msg string) toolchainv1alpha1.Condition {
 		Message: msg,
 	}
 }
+
+// TestSpaceLifecycleScenario verifies a multi-step lifecycle of a parentSpace and its subSpace: bindings are inherited
+// and overridden, then the spaces are moved to another tier and retargeted to another member cluster
+func TestSpaceLifecycleScenario(t *testing.T) {
+	t.Parallel()
+	awaitilities := WaitForDeployments(t)
+	member1 := awaitilities.Member1()
+	member2 := awaitilities.Member2()
+
+	NewScenario(t, awaitilities).
+		User("owner").
+		User("contributor").
+		Space("parent", "owner", testspace.WithTierName("appstudio"), testspace.WithSpecTargetCluster(member1.ClusterName)).
+		SubSpace("child", "parent", testspace.WithTierName("appstudio"), testspace.WithSpecTargetCluster(member1.ClusterName)).
+		Bind("contributor", "parent", "contributor").
+		Bind("contributor", "child", "maintainer").
+		UpdateBinding("owner", "parent", "maintainer").
+		Unbind("contributor", "child").
+		MoveToTier("child", "appstudiolarge").
+		Retarget("child", member2).
+		ExpectOnMember(member1, "parent").
+		ExpectOnMember(member2, "child").
+		Run()
+}
@@ -1,28 +1,15 @@
 package parallel
 
 import (
-	"context"
+	"os"
 	"testing"
 	"time"
 
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/kubernetes/scheme"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
-
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
-	openshiftappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/workloads"
 	"github.com/stretchr/testify/require"
-	appsv1 "k8s.io/api/apps/v1"
-	batchv1 "k8s.io/api/batch/v1"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestIdlerAndPriorityClass(t *testing.T) {
@@ -35,40 +22,37 @@ func TestIdlerAndPriorityClass(t *testing.T) {
 	idler, idlerNoise := prepareIdlerUser(t, await, "test-idler")
 
 	// Create payloads for both users
-	podsToIdle := prepareWorkloads(t, await.Member1(), idler.Name, wait.WithSandboxPriorityClass())
-	podsNoise := prepareWorkloads(t, await.Member1(), idlerNoise.Name, wait.WithSandboxPriorityClass())
+	toIdle := workloads.New(memberAwait, idler.Name).Create(t, wait.WithSandboxPriorityClass())
+	noise := workloads.New(memberAwait, idlerNoise.Name).Create(t, wait.WithSandboxPriorityClass())
 
 	// Create more noise pods in non-user namespace
 	memberAwait.CreateNamespace(t, "workloads-noise")
-	externalNsPodsNoise := prepareWorkloads(t, await.Member1(), "workloads-noise", wait.WithOriginalPriorityClass())
+	externalNsNoise := workloads.New(memberAwait, "workloads-noise").Create(t, wait.WithOriginalPriorityClass())
 
 	// Set a short timeout for one of the idler to trigger pod idling
-	// The idler is currently updating its status since it's already been idling the pods. So we need to keep trying to update.
-	idler, err := wait.For(t, memberAwait.Awaitility, &toolchainv1alpha1.Idler{}).
-		Update(idler.Name, memberAwait.Namespace, func(i *toolchainv1alpha1.Idler) {
-			i.Spec.TimeoutSeconds = 5
-		})
-	require.NoError(t, err)
+	idler = workloads.TriggerIdling(t, memberAwait, idler.Name, 5)
 
 	// Check notification was created BEFORE waiting for all pods to be deleted.
 	// The notification is created almost immediately when idling starts, but it's automatically
 	// deleted after a short duration (5s in e2e tests). If we wait for all pods to be deleted
 	// first, the notification may already be gone by the time we check for it, causing intermittent
 	// test failures.
-	_, err = hostAwait.WaitForNotificationWithName(t, "test-idler-dev-idled", toolchainv1alpha1.NotificationTypeIdled, wait.UntilNotificationHasConditions(wait.Sent()))
+	notification, err := hostAwait.WaitForNotificationWithName(t, "test-idler-dev-idled", toolchainv1alpha1.NotificationTypeIdled, wait.UntilNotificationHasConditions(wait.Sent()))
 	require.NoError(t, err)
+	VerifyNotificationEmail(t, hostAwait, notification)
 
-	// Wait for the pods to be deleted
-	for _, p := range podsToIdle {
-		err := memberAwait.WaitUntilPodsDeleted(t, p.Namespace, wait.WithPodName(p.Name))
-		require.NoError(t, err)
-	}
+	// Wait for the pods to be deleted and for each workload to be idled according to its kind.
+	// The AAP resource is idled and the InferenceService is deleted as well: after some time,
+	// the idler idles the second-top-owner when the pod is still running
+	toIdle.VerifyIdled(t)
 
 	// make sure that "noise" pods are still there, and notification is not created for stage namespace
-	_, err = memberAwait.WaitForPods(t, idlerNoise.Name, len(podsNoise), wait.PodRunning(), wait.WithPodLabel("idler", "idler"), wait.WithSandboxPriorityClass())
+	_, err = memberAwait.WaitForPods(t, idlerNoise.Name, len(noise.Pods()), wait.PodRunning(), wait.WithPodLabel("idler", "idler"), wait.WithSandboxPriorityClass())
 	require.NoError(t, err)
-	_, err = memberAwait.WaitForPods(t, "workloads-noise", len(externalNsPodsNoise), wait.PodRunning(), wait.WithPodLabel("idler", "idler"), wait.WithOriginalPriorityClass())
+	noise.VerifyNotIdled(t)
+	_, err = memberAwait.WaitForPods(t, "workloads-noise", len(externalNsNoise.Pods()), wait.PodRunning(), wait.WithPodLabel("idler", "idler"), wait.WithOriginalPriorityClass())
 	require.NoError(t, err)
+	externalNsNoise.VerifyNotIdled(t)
 	err = hostAwait.WaitForNotificationToNotBeCreated(t, "test-idler-stage-idled")
 	require.NoError(t, err)
 
@@ -80,8 +64,8 @@ func TestIdlerAndPriorityClass(t *testing.T) {
 	// In the tests above, the Idler reconcile was triggered after we changed the Idler resource (to set a short timeout).
 	// Now we want to verify that the idler reconcile is triggered without modifying the Idler resource.
 	// Notification shouldn't be created again.
-	pod := createStandalonePod(t, await.Member1(), idler.Name, "idler-test-pod-2") // create just one standalone pod. No need to create all possible pod controllers which may own pods.
-	_, err = memberAwait.WaitForPod(t, idler.Name, "idler-test-pod-2")             // pod was created
+	pod := toIdle.CreateStandalonePod(t, "idler-test-pod-2")           // create just one standalone pod. No need to create all possible pod controllers which may own pods.
+	_, err = memberAwait.WaitForPod(t, idler.Name, "idler-test-pod-2") // pod was created
 	require.NoError(t, err)
 	time.Sleep(time.Duration(2*idler.Spec.TimeoutSeconds) * time.Second)
 	err = memberAwait.WaitUntilPodDeleted(t, pod.Namespace, pod.Name)
@@ -93,20 +77,48 @@ func TestIdlerAndPriorityClass(t *testing.T) {
 	err = memberAwait.WaitUntilPodsDeleted(t, idler.Name, wait.WithPodLabel("idler", "idler"))
 	require.NoError(t, err)
 
-	// Wait for the AAP resource to be idled.
-	// The pods are idled as well, which is verified in the previous step - after some time,
-	// the idler idles the second-top-owner when the pod is still running
-	clnt, err := dynamic.NewForConfig(memberAwait.RestConfig)
-	require.NoError(t, err)
-	_, err = memberAwait.WaitForAAP(t, "test-idler-aap", idler.Name, clnt.Resource(aapRes), true)
-	require.NoError(t, err)
+	// The idled workloads are not restarted
+	toIdle.VerifyNotRestarted(t, 10*time.Second)
+}
 
-	// Wait for the InferenceService to be deleted - the expected action to idle
-	// the workload is by deleting the InferenceService that is old enough.
-	// The pods are idled as well, which is verified in the previous step - after some time,
-	// the idler idles the second-top-owner when the pod is still running
-	err = memberAwait.WaitUntilInferenceServiceDeleted(t, "test-idler-kserve", idler.Name, clnt.Resource(inferenceServiceRes))
-	require.NoError(t, err)
+func TestIdlerWithAllKindsOfWorkloads(t *testing.T) {
+	t.Parallel()
+	await := WaitForDeployments(t)
+	memberAwait := await.Member1()
+	idler, idlerNoise := prepareIdlerUser(t, await, "test-idler-all")
+
+	// given
+	toIdle := workloads.New(memberAwait, idler.Name).Kinds(workloads.AllKinds()...).Create(t, wait.WithSandboxPriorityClass())
+	noise := workloads.New(memberAwait, idlerNoise.Name).Kinds(workloads.AllKinds()...).Create(t, wait.WithSandboxPriorityClass())
+
+	// when
+	workloads.TriggerIdling(t, memberAwait, idler.Name, 5)
+
+	// then
+	toIdle.VerifyIdled(t)
+	toIdle.VerifyNotRestarted(t, 10*time.Second)
+	noise.VerifyNotIdled(t)
+}
+
+func TestIdlerWithCrashLoopingWorkload(t *testing.T) {
+	if os.Getenv(workloads.LongTestsVar) != "true" {
+		t.Skipf("reaching the restart threshold takes %s (set the %s env var to 'true' to run this test)", workloads.RestartsTimeout(), workloads.LongTestsVar)
+	}
+	t.Parallel()
+	await := WaitForDeployments(t)
+	memberAwait := await.Member1()
+	idler, _ := prepareIdlerUser(t, await, "test-idler-crash")
+
+	// given
+	// the timeout of the Idler is much longer than the time it takes for the kubelet to restart the pod more than the threshold,
+	// so that the workloads are not idled because of it during the test
+	idler = workloads.TriggerIdling(t, memberAwait, idler.Name, int32(3*workloads.RestartsTimeout()/time.Second))
+	userWorkloads := workloads.New(memberAwait, idler.Name).Kinds(workloads.Pod).Create(t, wait.WithSandboxPriorityClass())
+	crashLooping := userWorkloads.CreateCrashLooping(t, "idler-test-crashlooping")
+
+	// when the pod is restarted more than the threshold, then only its Deployment is idled
+	userWorkloads.VerifyIdledOnRestarts(t, crashLooping.Name, idler)
+	userWorkloads.VerifyNotIdled(t)
 }
 
 func prepareIdlerUser(t *testing.T, await wait.Awaitilities, name string) (*toolchainv1alpha1.Idler, *toolchainv1alpha1.Idler) {
@@ -130,313 +142,3 @@ func prepareIdlerUser(t *testing.T, await wait.Awaitilities, name string) (*tool
 	require.NoError(t, err)
 	return idler, idlerNoise
 }
-
-func prepareWorkloads(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string, additionalPodCriteria ...wait.PodWaitCriterion) []corev1.Pod {
-	createStandalonePod(t, memberAwait, namespace, "idler-test-pod-1")
-	d := createDeployment(t, memberAwait, namespace)
-	n := 1 + int(*d.Spec.Replicas) // total number of created pods
-
-	rs := createReplicaSet(t, memberAwait, namespace)
-	n = n + int(*rs.Spec.Replicas)
-
-	createDaemonSet(t, memberAwait, namespace)
-	nodes := &corev1.NodeList{}
-	err := memberAwait.Client.List(context.TODO(), nodes, client.MatchingLabels(map[string]string{"node-role.kubernetes.io/worker": ""}))
-	require.NoError(t, err)
-	n = n + len(nodes.Items) // DaemonSet creates N pods where N is the number of worker nodes in the cluster
-
-	createJob(t, memberAwait, namespace)
-	n++
-
-	dc := createDeploymentConfig(t, memberAwait, types.NamespacedName{Namespace: namespace, Name: "idler-test-dc"})
-	n = n + int(dc.Spec.Replicas)
-
-	// create another DeploymentConfig that will be paused to test that the idler will unpause it when scaling it down
-	dcPaused := createDeploymentConfig(t, memberAwait, types.NamespacedName{Namespace: namespace, Name: "idler-test-dc-paused"})
-	n = n + int(dcPaused.Spec.Replicas)
-
-	rc := createReplicationController(t, memberAwait, namespace)
-	n = n + int(*rc.Spec.Replicas)
-
-	// Create an Ansible Automation Platform resource
-	aapDeployment := createAAP(t, memberAwait, "test-idler-aap", namespace)
-	n = n + int(*aapDeployment.Spec.Replicas)
-
-	servingRuntimeDeployment := createKServeWorkloads(t, memberAwait, "test-idler-kserve", namespace)
-	n = n + int(*servingRuntimeDeployment.Spec.Replicas)
-
-	pods, err := memberAwait.WaitForPods(t, namespace, n, append(additionalPodCriteria, wait.PodRunning(),
-		wait.WithPodLabel("idler", "idler"))...)
-	require.NoError(t, err)
-
-	// pause the dcPaused DeploymentConfig now that its pods are running
-	pauseDeploymentConfig(t, memberAwait, types.NamespacedName{Namespace: dcPaused.Namespace, Name: dcPaused.Name})
-	return pods
-}
-
-func createDeployment(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string) *appsv1.Deployment {
-	// Create a Deployment with two pods
-	replicas := int32(3)
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-deployment", Namespace: namespace},
-		Spec: appsv1.DeploymentSpec{
-			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels("idler-deployment")},
-			Replicas: &replicas,
-			Template: podTemplateSpec("idler-deployment"),
-		},
-	}
-	err := memberAwait.Create(t, deployment)
-	require.NoError(t, err)
-
-	return deployment
-}
-
-var aapRes = schema.GroupVersionResource{Group: "aap.ansible.com", Version: "v1alpha1", Resource: "ansibleautomationplatforms"}
-var servingRuntimeRes = schema.GroupVersionResource{Group: "serving.kserve.io", Version: "v1alpha1", Resource: "servingruntimes"}
-var inferenceServiceRes = schema.GroupVersionResource{Group: "serving.kserve.io", Version: "v1beta1", Resource: "inferenceservices"}
-
-// createAAP creates an instance of ansibleautomationplatforms.aap.ansible.com with one deployment owned by this instance
-// returns the underlying deployment
-func createAAP(t *testing.T, memberAwait *wait.MemberAwaitility, name, namespace string) *appsv1.Deployment {
-	clnt, err := dynamic.NewForConfig(memberAwait.RestConfig)
-	require.NoError(t, err)
-
-	// Create an AAP instance
-	aap := aapResource(name)
-	createdAAP, err := clnt.Resource(aapRes).Namespace(namespace).Create(context.TODO(), aap, metav1.CreateOptions{})
-	require.NoError(t, err)
-
-	// Create a Deployment with two replicas and the AAP instance as the owner
-	replicas := int32(2)
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels(name)},
-			Replicas: &replicas,
-			Template: podTemplateSpec(name),
-		},
-	}
-	err = controllerutil.SetOwnerReference(createdAAP, deployment, scheme.Scheme)
-	require.NoError(t, err)
-	err = memberAwait.Create(t, deployment)
-	require.NoError(t, err)
-
-	return deployment
-}
-
-func createReplicaSet(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string) *appsv1.ReplicaSet {
-	// Standalone ReplicaSet
-	replicas := int32(2)
-	rs := &appsv1.ReplicaSet{
-		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-replicaset", Namespace: namespace},
-		Spec: appsv1.ReplicaSetSpec{
-			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels("idler-rs")},
-			Replicas: &replicas,
-			Template: podTemplateSpec("idler-rs"),
-		},
-	}
-	err := memberAwait.Create(t, rs)
-	require.NoError(t, err)
-
-	return rs
-}
-
-func createDaemonSet(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string) *appsv1.DaemonSet {
-	// Standalone ReplicaSet
-	ds := &appsv1.DaemonSet{
-		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-daemonset", Namespace: namespace},
-		Spec: appsv1.DaemonSetSpec{
-			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels("idler-ds")},
-			Template: podTemplateSpec("idler-ds"),
-		},
-	}
-	err := memberAwait.Create(t, ds)
-	require.NoError(t, err)
-
-	return ds
-}
-
-func createJob(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string) *batchv1.Job {
-	// Job
-	job := &batchv1.Job{
-		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-job", Namespace: namespace},
-		Spec: batchv1.JobSpec{
-			Template: podTemplateSpec(""),
-		},
-	}
-	job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
-	err := memberAwait.Create(t, job)
-	require.NoError(t, err)
-
-	return job
-}
-
-func createDeploymentConfig(t *testing.T, memberAwait *wait.MemberAwaitility, namespacedName types.NamespacedName) *openshiftappsv1.DeploymentConfig {
-	// Create a DeploymentConfig with two pods
-	spec := podTemplateSpec("idler-dc")
-	replicas := int32(2)
-	dc := &openshiftappsv1.DeploymentConfig{
-		ObjectMeta: metav1.ObjectMeta{Name: namespacedName.Name, Namespace: namespacedName.Namespace},
-		Spec: openshiftappsv1.DeploymentConfigSpec{
-			Selector: selectorLabels("idler-dc"),
-			Replicas: replicas,
-			Template: &spec,
-		},
-	}
-	err := memberAwait.Create(t, dc)
-	require.NoError(t, err)
-
-	return dc
-}
-
-func pauseDeploymentConfig(t *testing.T, memberAwait *wait.MemberAwaitility, namespacedName types.NamespacedName) *openshiftappsv1.DeploymentConfig {
-	dc, err := wait.For(t, memberAwait.Awaitility, &openshiftappsv1.DeploymentConfig{}).
-		Update(namespacedName.Name, namespacedName.Namespace,
-			func(dc *openshiftappsv1.DeploymentConfig) {
-				dc.Spec.Paused = true
-			})
-	require.NoError(t, err)
-	return dc
-}
-
-func createReplicationController(t *testing.T, memberAwait *wait.MemberAwaitility, namespace string) *corev1.ReplicationController {
-	// Standalone ReplicationController
-	spec := podTemplateSpec("idler-rc")
-	replicas := int32(2)
-	rc := &corev1.ReplicationController{
-		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-rc", Namespace: namespace},
-		Spec: corev1.ReplicationControllerSpec{
-			Selector: selectorLabels("idler-rc"),
-			Replicas: &replicas,
-			Template: &spec,
-		},
-	}
-	err := memberAwait.Create(t, rc)
-	require.NoError(t, err)
-
-	return rc
-}
-
-func createStandalonePod(t *testing.T, memberAwait *wait.MemberAwaitility, namespace, name string) *corev1.Pod {
-	// Create a Deployment with two pods
-	pod := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: namespace,
-			Name:      name,
-			Labels:    map[string]string{"idler": "idler"},
-		},
-		Spec: podSpec(),
-	}
-	pod.Spec.PriorityClassName = "system-cluster-critical"
-	err := memberAwait.Create(t, pod)
-	require.NoError(t, err)
-	return pod
-}
-
-func podSpec() corev1.PodSpec {
-	zero := int64(0)
-	return corev1.PodSpec{
-		TerminationGracePeriodSeconds: &zero,
-		Containers: []corev1.Container{{
-			Name:    "sleep",
-			Image:   "quay.io/prometheus/busybox:latest",
-			Command: []string{"sleep", "36000"}, // 10 hours
-			Resources: corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					"cpu":    resource.MustParse("1m"),
-					"memory": resource.MustParse("8Mi"),
-				},
-				Limits: corev1.ResourceList{
-					"cpu":    resource.MustParse("50m"),
-					"memory": resource.MustParse("80Mi"),
-				},
-			},
-		}},
-	}
-}
-
-func podTemplateSpec(app string) corev1.PodTemplateSpec {
-	return corev1.PodTemplateSpec{
-		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
-			"idler": "idler",
-			"app":   app,
-		}},
-		Spec: podSpec(),
-	}
-}
-
-func selectorLabels(app string) map[string]string {
-	return map[string]string{"app": app}
-}
-
-func aapResource(name string) *unstructured.Unstructured {
-	return &unstructured.Unstructured{
-		Object: map[string]interface{}{
-			"apiVersion": "aap.ansible.com/v1alpha1",
-			"kind":       "AnsibleAutomationPlatform",
-			"metadata": map[string]interface{}{
-				"name": name,
-			},
-			"spec": map[string]interface{}{
-				"idle_aap": false,
-			},
-		},
-	}
-}
-
-// createKServeWorkloads creates ServingRuntime and InferenceService instances with one deployment owned by ServingRuntime
-// returns the underlying deployment
-func createKServeWorkloads(t *testing.T, memberAwait *wait.MemberAwaitility, name, namespace string) *appsv1.Deployment {
-	cl, err := dynamic.NewForConfig(memberAwait.RestConfig)
-	require.NoError(t, err)
-
-	// Create a ServingRuntime instance
-	servingRuntime := servingRuntimeResource(name)
-	createdServingRuntime, err := cl.Resource(servingRuntimeRes).Namespace(namespace).Create(context.TODO(), servingRuntime, metav1.CreateOptions{})
-	require.NoError(t, err)
-
-	// Create an InferenceService instance
-	inferenceService := inferenceServiceResource(name)
-	_, err = cl.Resource(inferenceServiceRes).Namespace(namespace).Create(context.TODO(), inferenceService, metav1.CreateOptions{})
-	require.NoError(t, err)
-
-	// Create a Deployment with two replicas and the ServingRuntime instance as the owner.
-	// The InferenceService doesn't own anything directly.
-	replicas := int32(2)
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels(name)},
-			Replicas: &replicas,
-			Template: podTemplateSpec(name),
-		},
-	}
-	err = controllerutil.SetOwnerReference(createdServingRuntime, deployment, scheme.Scheme)
-	require.NoError(t, err)
-	err = memberAwait.Create(t, deployment)
-	require.NoError(t, err)
-
-	return deployment
-}
-
-func servingRuntimeResource(name string) *unstructured.Unstructured {
-	servingRuntime := &unstructured.Unstructured{}
-	servingRuntime.SetAPIVersion("serving.kserve.io/v1alpha1")
-	servingRuntime.SetKind("ServingRuntime")
-	servingRuntime.SetName(name)
-	return servingRuntime
-}
-
-func inferenceServiceResource(name string) *unstructured.Unstructured {
-	inferenceService := &unstructured.Unstructured{}
-	inferenceService.SetAPIVersion("serving.kserve.io/v1beta1")
-	inferenceService.SetKind("InferenceService")
-	inferenceService.SetName(name)
-	return inferenceService
-}
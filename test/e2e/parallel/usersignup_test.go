@@ -14,6 +14,7 @@ import (
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	v1 "k8s.io/api/core/v1"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -216,3 +217,57 @@ func assertComplaintUsernameForNewSignup(t *testing.T, awaitilities wait.Awaitil
 
 	require.Equal(t, testCase.expectedCompliantUsername, user.UserSignup.Status.CompliantUsername)
 }
+
+// TestProvisionedEmailWithFakeMailDelivery is not run in parallel with the other tests, since the delivery failures which
+// are injected in the fake mail delivery service apply to the next messages, regardless of their recipient
+func TestProvisionedEmailWithFakeMailDelivery(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	mailDelivery := FakeMailDelivery(t, hostAwait)
+	if mailDelivery == nil {
+		t.Skip("the fake mail delivery service is not deployed")
+	}
+	t.Cleanup(func() {
+		require.NoError(t, mailDelivery.FailNext(0))
+	})
+
+	t.Run("provisioned email is delivered", func(t *testing.T) {
+		// when
+		user := NewSignupRequest(awaitilities).
+			Username("provisionedemail").
+			Email("provisionedemail@redhat.com").
+			ManuallyApprove().
+			TargetCluster(awaitilities.Member1()).
+			EnsureMUR().
+			RequireConditions(wait.ConditionSet(wait.Default(), wait.ApprovedByAdmin())...).
+			Execute(t)
+
+		// then
+		msg := VerifyProvisionedEmail(t, hostAwait, user.UserSignup)
+		require.NotNil(t, msg)
+		assert.Equal(t, []string{"provisionedemail@redhat.com"}, msg.To)
+	})
+
+	t.Run("provisioned email is delivered after a delivery failure", func(t *testing.T) {
+		// given
+		require.NoError(t, mailDelivery.FailNext(1))
+
+		// when
+		user := NewSignupRequest(awaitilities).
+			Username("provisionedemailretry").
+			Email("provisionedemailretry@redhat.com").
+			ManuallyApprove().
+			TargetCluster(awaitilities.Member1()).
+			EnsureMUR().
+			RequireConditions(wait.ConditionSet(wait.Default(), wait.ApprovedByAdmin())...).
+			Execute(t)
+
+		// then
+		// the host operator sends the notification again after the failure, and the failed delivery is not recorded
+		VerifyProvisionedEmail(t, hostAwait, user.UserSignup)
+		messages, err := mailDelivery.Messages("provisionedemailretry@redhat.com")
+		require.NoError(t, err)
+		assert.Len(t, messages, 1)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	sandboxui "github.com/codeready-toolchain/toolchain-e2e/testsupport/sandbox-ui"
 	"github.com/playwright-community/playwright-go"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,6 +14,8 @@ import (
 // TestActivitiesPage opens and verifies that activities cards links are not broken
 func TestActivitiesPage(t *testing.T) {
 	page := sandboxui.Setup(t, "test-activities-page")
+	// without SSO, the external articles are not opened, since they may not be reachable from the browser
+	external := viper.GetString("ENVIRONMENT") != sandboxui.LocalEnv
 
 	// navigate to 'Activities' link
 	activitiesLink := page.GetByRole("link", playwright.PageGetByRoleOptions{
@@ -54,6 +57,10 @@ func TestActivitiesPage(t *testing.T) {
 		// extract the title of each article card
 		articleTitle, err := article.TextContent()
 		require.NoError(t, err)
+		assert.NotEmpty(t, strings.TrimSpace(articleTitle))
+		if !external {
+			continue
+		}
 
 		// open the article in a new popup and wait for it to fully load
 		popup, err := sandboxui.ClickAndWaitForPopup(page, article)
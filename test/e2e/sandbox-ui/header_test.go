@@ -5,6 +5,8 @@ import (
 
 	sandboxui "github.com/codeready-toolchain/toolchain-e2e/testsupport/sandbox-ui"
 	"github.com/playwright-community/playwright-go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,6 +14,8 @@ import (
 // and 'Contact Red Hat Sales' links are not broken
 func TestHeader(t *testing.T) {
 	page := sandboxui.Setup(t, "test-header")
+	// without SSO, the external pages linked from the header are not opened, since they may not be reachable from the browser
+	external := viper.GetString("ENVIRONMENT") != sandboxui.LocalEnv
 
 	// opens and verifies that 'Red Hat Developer Hub' popup is not broken
 	rhdhLink := page.GetByRole("link", playwright.PageGetByRoleOptions{
@@ -19,25 +23,35 @@ func TestHeader(t *testing.T) {
 	})
 	sandboxui.IsVisible(t, rhdhLink)
 
-	rhdhPage, err := sandboxui.ClickAndWaitForPopup(page, rhdhLink)
-	require.NoError(t, err)
+	if external {
+		rhdhPage, err := sandboxui.ClickAndWaitForPopup(page, rhdhLink)
+		require.NoError(t, err)
 
-	h1Text, err := rhdhPage.Locator("h1").TextContent()
-	require.NoError(t, err)
-	require.Contains(t, h1Text, "Red Hat Developer Hub")
+		h1Text, err := rhdhPage.Locator("h1").TextContent()
+		require.NoError(t, err)
+		require.Contains(t, h1Text, "Red Hat Developer Hub")
 
-	require.NoError(t, rhdhPage.Close())
+		require.NoError(t, rhdhPage.Close())
+	} else {
+		href, err := rhdhLink.GetAttribute("href")
+		require.NoError(t, err)
+		assert.NotEmpty(t, href)
+	}
 
 	// opens and verifies that 'Contact Red Hat Sales' popup is not broken
 	salesBtn := page.GetByRole("button", playwright.PageGetByRoleOptions{
 		Name: "Contact Red Hat Sales",
 	})
-	salesPage, err := sandboxui.ClickAndWaitForPopup(page, salesBtn)
-	require.NoError(t, err)
+	sandboxui.IsVisible(t, salesBtn)
 
-	h1Text, err = salesPage.Locator("h1").TextContent()
-	require.NoError(t, err)
-	require.Contains(t, h1Text, "Contact Red Hat")
+	if external {
+		salesPage, err := sandboxui.ClickAndWaitForPopup(page, salesBtn)
+		require.NoError(t, err)
 
-	require.NoError(t, salesPage.Close())
+		h1Text, err := salesPage.Locator("h1").TextContent()
+		require.NoError(t, err)
+		require.Contains(t, h1Text, "Contact Red Hat")
+
+		require.NoError(t, salesPage.Close())
+	}
 }
@@ -0,0 +1,156 @@
+package e2e
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	testspace "github.com/codeready-toolchain/toolchain-common/pkg/test/space"
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/spaceprovisionerconfig"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+)
+
+func TestSpacePlacement(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	member1 := awaitilities.Member1().ClusterName
+	member2 := awaitilities.Member2().ClusterName
+	user := NewSignupRequest(awaitilities).
+		Username("placement").
+		Email("placement@redhat.com").
+		TargetCluster(awaitilities.Member1()).
+		ManuallyApprove().
+		RequireConditions(wait.ConditionSet(wait.Default(), wait.ApprovedByAdmin())...).
+		EnsureMUR().
+		Execute(t)
+	hostAwait.UpdateToolchainConfig(t, testconfig.Tiers().DefaultSpaceTier("appstudio"))
+
+	t.Run("spaces are distributed according to the room left in each cluster", func(t *testing.T) {
+		// given
+		placement := spaceprovisionerconfig.NewPlacement(t, hostAwait, user.MUR).
+			RoomFor(member1, 1).
+			RoomFor(member2, 2)
+
+		// when
+		spaces := placement.CreateSpaces(4, testspace.WithTierName("appstudio"))
+
+		// then
+		spaces = placement.ExpectDistribution(spaces, spaceprovisionerconfig.Distribution{
+			PerCluster: map[string]int{member1: 1, member2: 2},
+			Pending:    1,
+		})
+
+		t.Run("pending space is placed when capacity is freed", func(t *testing.T) {
+			// given
+			freed := placedIn(spaces, member2)[0]
+
+			// when
+			placement.DeleteSpaces(freed)
+
+			// then
+			placement.ExpectDistribution(without(spaces, freed), spaceprovisionerconfig.Distribution{
+				PerCluster: map[string]int{member1: 1, member2: 2},
+			})
+		})
+	})
+
+	t.Run("spaces are left pending when every cluster is full", func(t *testing.T) {
+		// given
+		placement := spaceprovisionerconfig.NewPlacement(t, hostAwait, user.MUR).
+			Full(member1).
+			Full(member2)
+
+		// when
+		spaces := placement.CreateSpaces(2, testspace.WithTierName("appstudio"))
+
+		// then
+		placement.ExpectDistribution(spaces, spaceprovisionerconfig.Distribution{Pending: 2})
+
+		t.Run("pending spaces are placed when there is room again", func(t *testing.T) {
+			// when
+			placement.RoomFor(member1, 2)
+
+			// then
+			placement.ExpectDistribution(spaces, spaceprovisionerconfig.Distribution{
+				PerCluster: map[string]int{member1: 2},
+			})
+		})
+	})
+
+	t.Run("spaces are not placed in clusters with exceeded memory utilization", func(t *testing.T) {
+		// given
+		placement := spaceprovisionerconfig.NewPlacement(t, hostAwait, user.MUR).
+			RoomFor(member1, 1).
+			RoomFor(member2, 1).
+			MaxMemoryUtilization(member1, 1)
+
+		// when
+		spaces := placement.CreateSpaces(2, testspace.WithTierName("appstudio"))
+
+		// then
+		placement.ExpectDistribution(spaces, spaceprovisionerconfig.Distribution{
+			PerCluster: map[string]int{member2: 1},
+			Pending:    1,
+		})
+	})
+
+	t.Run("spaces are placed in clusters with the requested placement roles", func(t *testing.T) {
+		// given
+		placement := spaceprovisionerconfig.NewPlacement(t, hostAwait, user.MUR).
+			RoomFor(member1, 3).
+			RoomFor(member2, 3).
+			PlacementRoles(member2, "workspace")
+
+		// when
+		spaces := placement.CreateSpaces(3,
+			testspace.WithTierName("appstudio"),
+			testspace.WithSpecTargetClusterRoles([]string{cluster.RoleLabel("workspace")}))
+
+		// then
+		placement.ExpectDistribution(spaces, spaceprovisionerconfig.Distribution{
+			PerCluster: map[string]int{member2: 3},
+		})
+	})
+
+	t.Run("spaces are not placed in clusters which are not ready", func(t *testing.T) {
+		// given
+		placement := spaceprovisionerconfig.NewPlacement(t, hostAwait, user.MUR).
+			RoomFor(member1, 2).
+			RoomFor(member2, 2).
+			Disable(member1)
+
+		// when
+		spaces := placement.CreateSpaces(3, testspace.WithTierName("appstudio"))
+
+		// then
+		placement.ExpectDistribution(spaces, spaceprovisionerconfig.Distribution{
+			PerCluster: map[string]int{member2: 2},
+			Pending:    1,
+		})
+	})
+}
+
+// placedIn returns the spaces which were placed in the given cluster
+func placedIn(spaces []*toolchainv1alpha1.Space, clusterName string) []*toolchainv1alpha1.Space {
+	var placed []*toolchainv1alpha1.Space
+	for _, s := range spaces {
+		if s.Status.TargetCluster == clusterName {
+			placed = append(placed, s)
+		}
+	}
+	return placed
+}
+
+// without returns all the given spaces but the excluded one
+func without(spaces []*toolchainv1alpha1.Space, excluded *toolchainv1alpha1.Space) []*toolchainv1alpha1.Space {
+	var result []*toolchainv1alpha1.Space
+	for _, s := range spaces {
+		if s.Name != excluded.Name {
+			result = append(result, s)
+		}
+	}
+	return result
+}
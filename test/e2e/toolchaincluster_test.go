@@ -30,6 +30,37 @@ func TestToolchainClusterE2E(t *testing.T) {
 	verifyToolchainCluster(t, memberAwait.Awaitility, hostAwait.Awaitility)
 }
 
+func TestToolchainClusterFaults(t *testing.T) {
+	awaitilities := WaitForDeployments(t)
+	memberAwait2 := awaitilities.Member2()
+
+	for i, fault := range []ToolchainClusterFault{
+		UnreachableCluster(),
+		ExpiredToken(),
+		CAMismatch(),
+		SlowResponses(time.Minute),
+	} {
+		t.Run(fault.Name(), func(t *testing.T) {
+			// when
+			injection := InjectToolchainClusterFault(t, awaitilities, memberAwait2, fault)
+
+			// then
+			injection.WaitUntilToolchainClusterNotReady()
+			injection.WaitUntilMemberNotReadyInToolchainStatus()
+			injection.WaitUntilSpaceProvisionerConfigNotReady()
+			injection.VerifySignupsRoutedAway(fmt.Sprintf("faultyuser%d", i))
+
+			t.Run("recovery", func(t *testing.T) {
+				// when
+				injection.Remove()
+
+				// then
+				injection.VerifyRecovered()
+			})
+		})
+	}
+}
+
 // verifyToolchainCluster verifies existence and correct conditions of ToolchainCluster CRD
 // in the target cluster type operator
 func verifyToolchainCluster(t *testing.T, await *wait.Awaitility, otherAwait *wait.Awaitility) {
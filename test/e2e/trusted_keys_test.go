@@ -0,0 +1,79 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/regsvc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedKeysRotation(t *testing.T) {
+	// given
+	awaitilities := WaitForDeployments(t)
+	hostAwait := awaitilities.Host()
+	route := hostAwait.RegistrationServiceURL
+
+	key1, err := authsupport.NewKey("rotated-key-1")
+	require.NoError(t, err)
+	key2, err := authsupport.NewKey("rotated-key-2")
+	require.NoError(t, err)
+	identity1, token1, err := key1.NewToken(authsupport.WithEmail(uuid.NewString() + "@acme.com"))
+	require.NoError(t, err)
+	_, token2, err := key2.NewToken(authsupport.WithEmail(uuid.NewString() + "@acme.com"))
+	require.NoError(t, err)
+	_, e2eToken, err := authsupport.NewToken(authsupport.WithEmail(uuid.NewString() + "@acme.com"))
+	require.NoError(t, err)
+
+	client1 := regsvc.NewClient(route, token1)
+	client2 := regsvc.NewClient(route, token2)
+	e2eClient := regsvc.NewClient(route, e2eToken)
+
+	// the keys are not trusted yet
+	_, err = client1.GetSignup()
+	regsvc.RequireStatus(t, client1, err, http.StatusUnauthorized)
+
+	t.Run("tokens of a new trusted key are accepted", func(t *testing.T) {
+		// when
+		TrustKeys(t, hostAwait, key1)
+
+		// then
+		err := client1.Signup()
+		require.NoError(t, err)
+		userSignup, err := hostAwait.WaitForUserSignup(t, identity1.Username)
+		require.NoError(t, err)
+		cleanup.AddCleanTasks(t, hostAwait.Client, userSignup)
+
+		// the tokens of the e2e key are still accepted
+		_, err = e2eClient.GetSignup()
+		regsvc.RequireStatus(t, e2eClient, err, http.StatusNotFound)
+		// but not the tokens of the other key
+		_, err = client2.GetSignup()
+		regsvc.RequireStatus(t, client2, err, http.StatusUnauthorized)
+
+		t.Run("tokens of a rotated key are rejected", func(t *testing.T) {
+			// when
+			TrustKeys(t, hostAwait, key2)
+
+			// then
+			_, err := client1.GetSignup()
+			regsvc.RequireStatus(t, client1, err, http.StatusUnauthorized)
+			_, err = client2.GetSignup()
+			regsvc.RequireStatus(t, client2, err, http.StatusNotFound)
+		})
+	})
+
+	t.Run("the original keys are trusted again at the end of the test", func(t *testing.T) {
+		// then
+		_, err := client1.GetSignup()
+		regsvc.RequireStatus(t, client1, err, http.StatusUnauthorized)
+		_, err = client2.GetSignup()
+		regsvc.RequireStatus(t, client2, err, http.StatusUnauthorized)
+		_, err = e2eClient.GetSignup()
+		regsvc.RequireStatus(t, e2eClient, err, http.StatusNotFound)
+	})
+}
@@ -492,6 +492,37 @@ func (s *userManagementTestSuite) TestUserDeactivation() {
 		})
 	})
 
+	s.Run("automatic deactivation timeline", func() {
+		hostAwait.UpdateToolchainConfig(s.T(),
+			testconfig.AutomaticApproval().Enabled(true),
+			testconfig.Deactivation().DeactivatingNotificationDays(3))
+
+		user := NewSignupRequest(s.Awaitilities).
+			Username("deactivationtimeline").
+			Email("deactivationtimeline@redhat.com").
+			EnsureMUR().
+			RequireConditions(wait.ConditionSet(wait.Default(), wait.ApprovedAutomatically())...).
+			Execute(s.T())
+
+		// TODO remove once UserTier migration is completed
+		s.promoteToDefaultUserTier(user.MUR)
+
+		timeline := NewDeactivationTimeline(s.T(), s.Awaitilities, user.UserSignup)
+
+		s.Run("deactivation scheduled at the end of the tier timeout", func() {
+			timeline.VerifyProvisioned()
+		})
+
+		s.Run("user notified at the beginning of the pre-deactivation period", func() {
+			timeline.EnterPreDeactivation()
+		})
+
+		s.Run("user deactivated at the end of the pre-deactivation period", func() {
+			userSignup := timeline.Deactivate()
+			assert.Equal(s.T(), toolchainv1alpha1.UserSignupStateLabelValueDeactivated, userSignup.Labels[toolchainv1alpha1.UserSignupStateLabelKey])
+		})
+	})
+
 	s.Run("reactivated but unverified user reverted back to deactivated after timeout", func() {
 		hostAwait.UpdateToolchainConfig(s.T(),
 			testconfig.AutomaticApproval().Enabled(false))
@@ -8,6 +8,7 @@ import (
 	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
 	testSpc "github.com/codeready-toolchain/toolchain-common/pkg/test/spaceprovisionerconfig"
 	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/regsvc"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/spaceprovisionerconfig"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -382,7 +383,8 @@ func (s *userSignupIntegrationTest) TestGetSignupEndpointUpdatesIdentityClaims()
 	token, err := authsupport.NewTokenFromIdentity(userIdentity, claims...)
 	require.NoError(s.T(), err)
 
-	NewHTTPRequest(s.T()).InvokeEndpoint("GET", hostAwait.RegistrationServiceURL+"/api/v1/signup", token, "", 200)
+	_, err = regsvc.NewClient(hostAwait.RegistrationServiceURL, token).GetSignup()
+	require.NoError(s.T(), err)
 
 	// Reload the UserSignup
 	userSignup, err = hostAwait.WaitForUserSignupByUserIDAndUsername(s.T(), userIdentity.ID.String(), userIdentity.Username)
@@ -16,6 +16,7 @@ import (
 	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
 	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/regsvc"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/spaceprovisionerconfig"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
@@ -279,7 +280,9 @@ func TestVerificationRequiredMetric(t *testing.T) {
 
 		// when
 		// Create UserSignup with verification required
-		NewHTTPRequest(t).InvokeEndpoint("POST", route+"/api/v1/signup", token0, "", http.StatusAccepted)
+		regsvcClient := regsvc.NewClient(route, token0)
+		err = regsvcClient.Signup()
+		require.NoError(t, err)
 
 		// Wait for the UserSignup to be created and in verification required status
 		userSignup, err = hostAwait.WaitForUserSignup(t, identity0.Username,
@@ -300,8 +303,8 @@ func TestVerificationRequiredMetric(t *testing.T) {
 		t.Run("no change to metric when verification initiated", func(t *testing.T) {
 			// when
 			// Initiate the verification process
-			NewHTTPRequest(t).InvokeEndpoint("PUT", route+"/api/v1/signup/verification", token0,
-				`{ "country_code":"+61", "phone_number":"408999999" }`, http.StatusNoContent)
+			err := regsvcClient.InitVerification("+61", "408999999")
+			require.NoError(t, err)
 
 			// then
 			// Retrieve the updated UserSignup
@@ -311,9 +314,11 @@ func TestVerificationRequiredMetric(t *testing.T) {
 			verificationCode := userSignup.Annotations[toolchainv1alpha1.UserSignupVerificationCodeAnnotationKey]
 			require.NotEmpty(t, verificationCode)
 			// Attempt to verify with an incorrect verification code
-			NewHTTPRequest(t).InvokeEndpoint("GET", route+"/api/v1/signup/verification/invalid", token0, "", http.StatusForbidden)
+			err = regsvcClient.VerifyCode("invalid")
+			regsvc.RequireStatus(t, regsvcClient, err, http.StatusForbidden)
 			// verify with the correct code
-			NewHTTPRequest(t).InvokeEndpoint("GET", route+fmt.Sprintf("/api/v1/signup/verification/%s", verificationCode), token0, "", http.StatusOK)
+			err = regsvcClient.VerifyCode(verificationCode)
+			require.NoError(t, err)
 			hostAwait.WaitForMetricDelta(t, wait.UserSignupVerificationRequiredMetric, 1)                                         // no change after verification initiated
 			hostAwait.WaitForMetricDelta(t, wait.UserSignupsMetric, 1)                                                            // user provisioned
 			hostAwait.WaitForMetricDelta(t, wait.UsersPerActivationsAndDomainMetric, 0, "activations", "1", "domain", "internal") // never incremented
@@ -345,7 +350,8 @@ func TestVerificationRequiredMetric(t *testing.T) {
 
 		t.Run("metric incremented when user reactivated", func(t *testing.T) {
 			// when reactivating the user
-			NewHTTPRequest(t).InvokeEndpoint("POST", route+"/api/v1/signup", token0, "", http.StatusAccepted)
+			err := regsvcClient.Signup()
+			require.NoError(t, err)
 			userSignup, err = hostAwait.WaitForUserSignup(t, identity0.Username,
 				wait.UntilUserSignupHasConditions(wait.ConditionSet(wait.Default(), wait.VerificationRequired(), wait.ApprovedDeactivated())...),
 				wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueNotReady))
@@ -0,0 +1,156 @@
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// MigrationHopVar the env var with the name of the version hop being tested, eg: `v1.2.0->v1.3.0`
+	MigrationHopVar = "MIGRATION_HOP"
+	// MigrationReportFileVar the env var with the path of the file in which the outcome of each scenario is appended.
+	// If not set, then the outcomes are appended to `$ARTIFACT_DIR/migration-report.jsonl` (if set)
+	MigrationReportFileVar = "MIGRATION_REPORT_FILE"
+)
+
+// Scenario a migration scenario, ie, some resources which are created with the "old" versions of the operators
+// and which are verified once the operators were upgraded to the "new" versions.
+type Scenario struct {
+	// Name the name of the scenario, used to name the subtests and in the migration report
+	Name string
+	// Setup creates the resources of the scenario. It is optional, and it runs concurrently with the setup of the other scenarios.
+	Setup func(t *testing.T, r *SetupMigrationRunner)
+	// Verify verifies the resources of the scenario after the upgrade. It is called sequentially (in a `lookup <name>`
+	// subtest) for all the scenarios before any verification starts, so that it can look up the resources which may be
+	// affected by the verification of another scenario (eg, listing the banned UserSignups while another scenario bans
+	// a user). It returns the actual verification, which runs concurrently with the verifications of the other scenarios.
+	// The verification of a scenario whose lookup failed is skipped, without affecting the other scenarios.
+	Verify func(t *testing.T, r *VerifyMigrationRunner) func(t *testing.T)
+}
+
+var scenarios = struct {
+	sync.Mutex
+	items []Scenario
+}{}
+
+// Register registers the given migration scenario. It is meant to be called from an `init()` function, eg:
+//
+//	func init() {
+//		Register(Scenario{
+//			Name:   "provisioned-user",
+//			Setup:  setupProvisionedUser,
+//			Verify: verifyProvisionedUser,
+//		})
+//	}
+//
+// Since the setup runs at every version hop (with the "old" and with the "new" versions of the operators), it must be
+// idempotent, ie, it must first delete the resources that a previous hop may have left (see `SetupMigrationRunner.deleteLeftovers`).
+func Register(scenario Scenario) {
+	scenarios.Lock()
+	defer scenarios.Unlock()
+	for _, s := range scenarios.items {
+		if s.Name == scenario.Name {
+			panic(fmt.Sprintf("migration scenario '%s' is already registered", scenario.Name))
+		}
+	}
+	scenarios.items = append(scenarios.items, scenario)
+}
+
+// Scenarios returns all the registered migration scenarios, in the order of their registration
+func Scenarios() []Scenario {
+	scenarios.Lock()
+	defer scenarios.Unlock()
+	return append([]Scenario{}, scenarios.items...)
+}
+
+// Phase the phase of a migration scenario
+type Phase string
+
+const (
+	SetupPhase  Phase = "setup"
+	LookupPhase Phase = "lookup"
+	VerifyPhase Phase = "verify"
+)
+
+// ScenarioResult the outcome of a phase of a migration scenario at a given version hop
+type ScenarioResult struct {
+	Hop      string    `json:"hop"`
+	Scenario string    `json:"scenario"`
+	Phase    Phase     `json:"phase"`
+	Passed   bool      `json:"passed"`
+	Time     time.Time `json:"time"`
+}
+
+// currentHop returns the name of the version hop being tested
+func currentHop() string {
+	if hop := os.Getenv(MigrationHopVar); hop != "" {
+		return hop
+	}
+	return "previous->current"
+}
+
+var reportLock sync.Mutex
+
+// report appends the given result to the migration report (if configured). Since the setup and the verification
+// of each hop run in different processes, the report is a JSON Lines file, with one result per line.
+func report(t *testing.T, result ScenarioResult) {
+	path := os.Getenv(MigrationReportFileVar)
+	if path == "" {
+		artifactDir := os.Getenv("ARTIFACT_DIR")
+		if artifactDir == "" {
+			return
+		}
+		path = filepath.Join(artifactDir, "migration-report.jsonl")
+	}
+	reportLock.Lock()
+	defer reportLock.Unlock()
+	line, err := json.Marshal(result)
+	if err != nil {
+		t.Logf("failed to marshal the migration result: %s", err)
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Logf("failed to open the migration report: %s", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		t.Logf("failed to write the migration report: %s", err)
+	}
+}
+
+// runConcurrently runs the given functions as concurrent subtests, and reports their outcome for the given phase
+func runConcurrently(t *testing.T, phase Phase, toRun map[string]func(t *testing.T)) {
+	hop := currentHop()
+	var wg sync.WaitGroup
+	for name, run := range toRun {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runPhase(t, hop, phase, name, run)
+		}()
+	}
+	wg.Wait()
+}
+
+// runPhase runs the given phase of the given scenario as a subtest, and reports its outcome
+func runPhase(t *testing.T, hop string, phase Phase, name string, run func(t *testing.T)) bool {
+	passed := t.Run(fmt.Sprintf("%s %s", phase, name), run)
+	report(t, ScenarioResult{
+		Hop:      hop,
+		Scenario: name,
+		Phase:    phase,
+		Passed:   passed,
+		Time:     time.Now(),
+	})
+	if !passed {
+		t.Logf("migration scenario '%s' failed during the %s phase of the hop '%s'", name, phase, hop)
+	}
+	return passed
+}
@@ -0,0 +1,160 @@
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func init() {
+	// the following scenarios have no setup: they verify the resources deployed by the operators themselves
+	Register(Scenario{
+		Name:   "additional-deployments-created-using-ssa",
+		Verify: verifyOnly(verifyAdditionalDeploymentsCreatedUsingSSA),
+	})
+	Register(Scenario{
+		Name:   "nstemplatetiers",
+		Verify: verifyOnly(verifyNSTemplateTiers),
+	})
+	Register(Scenario{
+		Name:   "resources-deployed-using-ssa",
+		Verify: verifyOnly(verifyResourcesDeployedUsingSSA),
+	})
+}
+
+// verifyOnly returns a Scenario.Verify function for the given verification, which does not need any lookup beforehand
+func verifyOnly(verify func(t *testing.T, awaitilities wait.Awaitilities)) func(t *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+	return func(_ *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+		return func(t *testing.T) {
+			verify(t, r.Awaitilities)
+		}
+	}
+}
+
+func verifyAdditionalDeploymentsCreatedUsingSSA(t *testing.T, awaitilities wait.Awaitilities) {
+	testDeployment := func(t *testing.T, a *wait.Awaitility, deploymentName, originalFieldManager, expectedFieldManager string) {
+		_, err := wait.For(t, a, &appsv1.Deployment{}).WithNameMatching(deploymentName, func(d *appsv1.Deployment) bool {
+			var applyEntry *metav1.ManagedFieldsEntry
+			var updateEntry *metav1.ManagedFieldsEntry
+			for _, mf := range d.ManagedFields {
+				if mf.Manager == expectedFieldManager {
+					applyEntry = &mf
+				}
+				if mf.Manager == originalFieldManager {
+					updateEntry = &mf
+				}
+			}
+			return applyEntry != nil && applyEntry.Operation == metav1.ManagedFieldsOperationApply && updateEntry == nil
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("verify registration service deployed using SSA in host", func(t *testing.T) {
+		testDeployment(t, awaitilities.Host().Awaitility, "registration-service", "host-operator", "kubesaw-host-operator")
+	})
+
+	// no webhook is deployed in member2, see the "create-host-resources" make target
+	t.Run("verify webhook deployed using SSA in member1", func(t *testing.T) {
+		testDeployment(t, awaitilities.Member1().Awaitility, "member-operator-webhook", "member-operator", "kubesaw-member-operator")
+	})
+
+	t.Run("verify autoscaler deployed using SSA in member1", func(t *testing.T) {
+		testDeployment(t, awaitilities.Member1().Awaitility, "autoscaling-buffer", "member-operator", "kubesaw-member-operator")
+	})
+
+	t.Run("verify autoscaler deployed using SSA in member2", func(t *testing.T) {
+		testDeployment(t, awaitilities.Member2().Awaitility, "autoscaling-buffer", "member-operator", "kubesaw-member-operator")
+	})
+}
+
+func verifyNSTemplateTiers(t *testing.T, awaitilities wait.Awaitilities) {
+	// Let's make sure we have the correct idea about the NSTemplateTIers
+	// present in the cluster.
+	//
+	// We need to make sure that the cluster contains exactly the tiers we expect
+	// (wait.E2eNSTemplateTiers) and also that all the bundled NSTemplateTiers
+	// are annotated as such in the cluster (wait.BundledNSTemplateTiers).
+	//
+	// This makes sure that the setup in the cluster is exactly how the e2e tests
+	// expect it to be.
+	goTemplateRequirement, err := labels.NewRequirement("producer", selection.NotEquals, []string{"toolchain-e2e"})
+	require.NoError(t, err)
+	notCreatedByGoTemplate := client.MatchingLabelsSelector{
+		Selector: labels.NewSelector().Add(*goTemplateRequirement),
+	}
+	list := &toolchainv1alpha1.NSTemplateTierList{}
+	require.NoError(t, awaitilities.Host().Client.List(context.TODO(), list, client.InNamespace(awaitilities.Host().Namespace), notCreatedByGoTemplate))
+
+	assert.Len(t, list.Items, len(wait.AllE2eNSTemplateTiers))
+
+	bundledInCluster := []string{}
+	customInCluster := []string{}
+
+	for _, tier := range list.Items {
+		if tier.Annotations[toolchainv1alpha1.BundledAnnotationKey] == "host-operator" {
+			bundledInCluster = append(bundledInCluster, tier.Name)
+		} else {
+			customInCluster = append(customInCluster, tier.Name)
+		}
+	}
+
+	assert.ElementsMatch(t, wait.BundledNSTemplateTiers, bundledInCluster)
+	assert.ElementsMatch(t, wait.CustomNSTemplateTiers, customInCluster)
+}
+
+func verifyResourcesDeployedUsingSSA(t *testing.T, awaitilities wait.Awaitilities) {
+	testList := func(t *testing.T, obj client.Object, isEligible func(client.Object) bool) {
+		t.Helper()
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			a := awaitilities.Host().Awaitility
+			list := &unstructured.UnstructuredList{}
+			gvks, _, err := a.Client.Scheme().ObjectKinds(obj)
+			require.NoError(t, err)
+			require.Len(t, gvks, 1)
+			list.SetGroupVersionKind(gvks[0])
+			require.NoError(t, a.Client.List(context.TODO(), list, client.InNamespace(a.Namespace)))
+
+			for _, o := range list.Items {
+				if !isEligible(&o) {
+					continue
+				}
+				var applyEntry *metav1.ManagedFieldsEntry
+				for _, mf := range o.GetManagedFields() {
+					if mf.Manager == "kubesaw-host-operator" {
+						applyEntry = &mf
+					}
+				}
+
+				// note that we only check for the presence of the Apply operation here. Unlike in the case of
+				// the deployments tested above, the NSTemplateTiers are updated by the controller, so we're going
+				// to see updates made by it.
+				require.NotNil(t, applyEntry, "NSTemplateTier '%s' doesn't have the expected Apply operation in the managed fields", o.GetName())
+				assert.Equal(t, metav1.ManagedFieldsOperationApply, applyEntry.Operation)
+			}
+		}, 1*time.Minute, 1*time.Second)
+	}
+
+	t.Run("verify bundled UserTiers deployed using SSA", func(t *testing.T) {
+		testList(t, &toolchainv1alpha1.UserTier{}, func(_ client.Object) bool {
+			return true
+		})
+	})
+
+	t.Run("verify bundled NSTemplateTiers deployed using SSA", func(t *testing.T) {
+		testList(t, &toolchainv1alpha1.NSTemplateTier{}, func(o client.Object) bool {
+			return o.GetAnnotations()[toolchainv1alpha1.BundledAnnotationKey] == "host-operator"
+		})
+	})
+}
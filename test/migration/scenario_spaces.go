@@ -0,0 +1,115 @@
+package migration
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	tsspace "github.com/codeready-toolchain/toolchain-e2e/testsupport/space"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ProvisionedAppStudioSpace    = "mig-appst-space"
+	SecondMemberProvisionedSpace = "mig-m2-space"
+	ProvisionedSpaceRequest      = "mig-space-request"
+	ProvisionedParentSpace       = "mig-parent-space"
+)
+
+func init() {
+	Register(Scenario{
+		Name: "appstudio-provisioned-space",
+		Setup: func(t *testing.T, r *SetupMigrationRunner) {
+			r.createAndWaitForSpace(t, ProvisionedAppStudioSpace, "appstudio", r.Awaitilities.Member1())
+		},
+		Verify: verifyProvisionedSpace(ProvisionedAppStudioSpace),
+	})
+	Register(Scenario{
+		Name: "second-member-provisioned-space",
+		Setup: func(t *testing.T, r *SetupMigrationRunner) {
+			r.createAndWaitForSpace(t, SecondMemberProvisionedSpace, tiers.GetDefaultSpaceTierName(t, r.Awaitilities.Host()), r.Awaitilities.Member2())
+		},
+		Verify: verifyProvisionedSpace(SecondMemberProvisionedSpace),
+	})
+	Register(Scenario{
+		Name:   "provisioned-subspace",
+		Setup:  setupProvisionedSubspace,
+		Verify: verifyProvisionedSubspace,
+	})
+}
+
+func verifyProvisionedSpace(name string) func(t *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+	return func(_ *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+		return func(t *testing.T) {
+			space, _ := tsspace.VerifyResourcesProvisionedForSpace(t, r.Awaitilities, name)
+			userSignupForSpace := checkMURMigratedAndGetSignup(t, r.Awaitilities.Host(), name)
+			r.AddCleanTasks(space, userSignupForSpace)
+		}
+	}
+}
+
+func setupProvisionedSubspace(t *testing.T, r *SetupMigrationRunner) {
+	memberAwait := r.Awaitilities.Member2()
+	r.createAndWaitForSpace(t, ProvisionedParentSpace, "appstudio-env", memberAwait)
+
+	srClusterRoles := []string{cluster.RoleLabel(cluster.Tenant)}
+	t.Logf("creating space request %v for parent space %v", ProvisionedSpaceRequest, ProvisionedParentSpace)
+	spaceRequest := createSpaceRequestForParentSpace(t,
+		r.Awaitilities,
+		memberAwait.ClusterName,
+		ProvisionedParentSpace,
+		tsspace.WithName(ProvisionedSpaceRequest),
+		tsspace.WithSpecTargetClusterRoles(srClusterRoles),
+		tsspace.WithSpecTierName("appstudio-env"))
+
+	_, err := memberAwait.WaitForSpaceRequest(t,
+		types.NamespacedName{
+			Namespace: spaceRequest.Namespace,
+			Name:      spaceRequest.Name,
+		},
+		wait.UntilSpaceRequestHasConditions(wait.Provisioned()),
+	)
+	require.NoError(t, err)
+}
+
+func verifyProvisionedSubspace(_ *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+	awaitilities := r.Awaitilities
+	return func(t *testing.T) {
+		parentSpace, _ := tsspace.VerifyResourcesProvisionedForSpace(t, awaitilities, ProvisionedParentSpace)
+		userSignupForSpace := checkMURMigratedAndGetSignup(t, awaitilities.Host(), ProvisionedParentSpace)
+		targetClusterRoles := []string{cluster.RoleLabel(cluster.Tenant)}
+		hostAwait := awaitilities.Host()
+		memberAwait := awaitilities.Member2()
+		memberCluster, found, err := hostAwait.GetToolchainCluster(t, memberAwait.Namespace, toolchainv1alpha1.ConditionReady)
+		require.NoError(t, err)
+		require.True(t, found)
+		subSpaceNamespace := tsspace.GetDefaultNamespace(parentSpace.Status.ProvisionedNamespaces)
+
+		subSpace, err := awaitilities.Host().WaitForSubSpace(t,
+			ProvisionedSpaceRequest,
+			subSpaceNamespace,
+			parentSpace.GetName(),
+			wait.UntilSpaceHasTargetClusterRoles(targetClusterRoles),
+			wait.UntilSpaceHasTier("appstudio-env"),
+			wait.UntilSpaceHasAnyProvisionedNamespaces())
+		require.NoError(t, err)
+
+		spaceRequest, err := memberAwait.WaitForSpaceRequest(t,
+			types.NamespacedName{
+				Namespace: subSpaceNamespace,
+				Name:      ProvisionedSpaceRequest,
+			},
+			wait.UntilSpaceRequestHasStatusTargetClusterURL(memberCluster.Status.APIEndpoint),
+			wait.UntilSpaceRequestHasNamespaceAccess(subSpace),
+			wait.UntilSpaceRequestHasConditions(wait.Provisioned()),
+		)
+		require.NoError(t, err)
+		tsspace.VerifyNamespaceAccessForSpaceRequest(t, memberAwait.Client, spaceRequest)
+
+		r.AddCleanTasks(parentSpace, userSignupForSpace)
+	}
+}
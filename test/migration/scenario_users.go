@@ -0,0 +1,199 @@
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/hash"
+	"github.com/codeready-toolchain/toolchain-common/pkg/states"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ProvisionedUser             = "mig-prov"
+	DeactivatedUser             = "mig-deact"
+	BannedUser                  = "mig-banned"
+	AppStudioProvisionedUser    = "mig-appst"
+	SecondMemberProvisionedUser = "mig-m2-user"
+)
+
+func init() {
+	Register(Scenario{
+		Name: "provisioned-user",
+		Setup: func(t *testing.T, r *SetupMigrationRunner) {
+			r.prepareUser(t, ProvisionedUser, r.Awaitilities.Member1())
+		},
+		Verify: verifyProvisionedUser,
+	})
+	Register(Scenario{
+		Name: "second-member-provisioned-user",
+		Setup: func(t *testing.T, r *SetupMigrationRunner) {
+			r.prepareUser(t, SecondMemberProvisionedUser, r.Awaitilities.Member2())
+		},
+		Verify: verifySecondMemberProvisionedUser,
+	})
+	Register(Scenario{
+		Name:   "deactivated-user",
+		Setup:  setupDeactivatedUser,
+		Verify: verifyDeactivatedUser,
+	})
+	Register(Scenario{
+		Name:   "banned-user",
+		Setup:  setupBannedUser,
+		Verify: verifyBannedUser,
+	})
+	Register(Scenario{
+		Name:   "appstudio-provisioned-user",
+		Setup:  setupAppStudioProvisionedUser,
+		Verify: verifyAppStudioProvisionedUser,
+	})
+}
+
+func verifyProvisionedUser(lookup *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+	awaitilities := r.Awaitilities
+	signup := checkMURMigratedAndGetSignup(lookup, awaitilities.Host(), ProvisionedUser)
+	return func(t *testing.T) {
+		r.AddCleanTasks(signup)
+		testsupport.VerifyResourcesProvisionedForSignup(t, awaitilities, signup)
+		testsupport.DeactivateAndCheckUser(t, awaitilities, signup)
+		testsupport.ReactivateAndCheckUser(t, awaitilities, signup)
+	}
+}
+
+func verifySecondMemberProvisionedUser(lookup *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+	awaitilities := r.Awaitilities
+	signup := checkMURMigratedAndGetSignup(lookup, awaitilities.Host(), SecondMemberProvisionedUser)
+	return func(t *testing.T) {
+		r.AddCleanTasks(signup)
+		testsupport.VerifyResourcesProvisionedForSignup(t, awaitilities, signup)
+		testsupport.CreateBannedUser(t, awaitilities.Host(), signup.Spec.IdentityClaims.Email)
+	}
+}
+
+func setupDeactivatedUser(t *testing.T, r *SetupMigrationRunner) {
+	userSignup := r.prepareUser(t, DeactivatedUser, r.Awaitilities.Member1())
+	hostAwait := r.Awaitilities.Host()
+
+	// deactivate the UserSignup
+	userSignup, err := wait.For(t, hostAwait.Awaitility, &toolchainv1alpha1.UserSignup{}).
+		Update(userSignup.Name, hostAwait.Namespace,
+			func(us *toolchainv1alpha1.UserSignup) {
+				states.SetDeactivated(us, true)
+			})
+	require.NoError(t, err)
+	t.Logf("user signup '%s' set to deactivated", userSignup.Name)
+
+	// verify that MUR is deleted
+	err = hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, userSignup.Status.CompliantUsername) // TODO wait for space deletion too after Space migration is done
+	require.NoError(t, err)
+}
+
+func verifyDeactivatedUser(lookup *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+	awaitilities := r.Awaitilities
+	// note: listing deactivated UserSignups must be done before any verification starts because there can be multiple deactivated UserSignups
+	// at that point which could lead to test flakiness
+	signup := listAndGetSignupWithState(lookup, awaitilities.Host(), toolchainv1alpha1.UserSignupStateLabelValueDeactivated)
+	return func(t *testing.T) {
+		r.AddCleanTasks(signup)
+
+		_, err := awaitilities.Host().WaitForUserSignup(t, signup.Name,
+			wait.UntilUserSignupContainsConditions(wait.ConditionSet(wait.Default(), wait.DeactivatedWithoutPreDeactivation())...),
+			wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueDeactivated))
+		require.NoError(t, err)
+		require.True(t, states.Deactivated(signup), "usersignup should be deactivated")
+
+		err = awaitilities.Host().WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, DeactivatedUser)
+		require.NoError(t, err)
+
+		err = awaitilities.Host().WaitUntilSpaceAndSpaceBindingsDeleted(t, DeactivatedUser)
+		require.NoError(t, err)
+
+		testsupport.ReactivateAndCheckUser(t, awaitilities, signup)
+	}
+}
+
+func setupBannedUser(t *testing.T, r *SetupMigrationRunner) {
+	userSignup := r.prepareUser(t, BannedUser, r.Awaitilities.Member1())
+	hostAwait := r.Awaitilities.Host()
+
+	// Create the BannedUser
+	bannedUser := testsupport.NewBannedUser(hostAwait, userSignup.Spec.IdentityClaims.Email)
+	err := hostAwait.Client.Create(context.TODO(), bannedUser)
+	require.NoError(t, err, "failed to create banned user for %+v", userSignup)
+
+	t.Logf("BannedUser '%s' created", bannedUser.Spec.Email)
+
+	// Confirm the user is banned
+	_, err = hostAwait.WithRetryOptions(wait.TimeoutOption(time.Second*15)).WaitForUserSignup(t, userSignup.Name,
+		wait.ContainsCondition(wait.Banned()[0]))
+	require.NoError(t, err)
+}
+
+func verifyBannedUser(lookup *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+	awaitilities := r.Awaitilities
+	// note: listing banned UserSignups must be done before any verification starts because another scenario bans a user
+	signup := listAndGetSignupWithState(lookup, awaitilities.Host(), toolchainv1alpha1.UserSignupStateLabelValueBanned)
+	return func(t *testing.T) {
+		hostAwait := awaitilities.Host()
+		r.AddCleanTasks(signup)
+
+		// verify that it's still banned
+		_, err := hostAwait.WaitForUserSignup(t, signup.Name,
+			wait.UntilUserSignupHasConditions(wait.ConditionSet(wait.Default(), wait.ApprovedByAdmin(), wait.Banned())...),
+			wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueBanned))
+		require.NoError(t, err)
+
+		err = hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, BannedUser)
+		require.NoError(t, err)
+
+		err = awaitilities.Host().WaitUntilSpaceAndSpaceBindingsDeleted(t, BannedUser)
+		require.NoError(t, err)
+
+		// get the BannedUser resource
+		matchEmailHash := client.MatchingLabels{
+			toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EncodeString(signup.Spec.IdentityClaims.Email),
+		}
+		bannedUsers := &toolchainv1alpha1.BannedUserList{}
+		err = hostAwait.Client.List(context.TODO(), bannedUsers, client.InNamespace(hostAwait.Namespace), matchEmailHash)
+		require.NoError(t, err)
+		require.Len(t, bannedUsers.Items, 1)
+
+		// Unban the user by deleting the BannedUser resource
+		err = hostAwait.Client.Delete(context.TODO(), &bannedUsers.Items[0])
+		require.NoError(t, err)
+
+		// verify that it's unbanned
+		testsupport.VerifyResourcesProvisionedForSignup(t, awaitilities, signup)
+	}
+}
+
+func setupAppStudioProvisionedUser(t *testing.T, r *SetupMigrationRunner) {
+	usersignup := r.prepareUser(t, AppStudioProvisionedUser, r.Awaitilities.Member1())
+	hostAwait := r.Awaitilities.Host()
+
+	// promote to appstudio
+	tiers.MoveSpaceToTier(t, hostAwait, usersignup.Status.CompliantUsername, "appstudio")
+
+	t.Logf("user %s was promoted to appstudio tier", AppStudioProvisionedUser)
+
+	// verify that it's promoted
+	_, err := r.Awaitilities.Host().WaitForMasterUserRecord(t, usersignup.Status.CompliantUsername,
+		wait.UntilMasterUserRecordHasConditions(wait.Provisioned(), wait.ProvisionedNotificationCRCreated()))
+	require.NoError(t, err)
+}
+
+func verifyAppStudioProvisionedUser(lookup *testing.T, r *VerifyMigrationRunner) func(t *testing.T) {
+	awaitilities := r.Awaitilities
+	signup := checkMURMigratedAndGetSignup(lookup, awaitilities.Host(), AppStudioProvisionedUser)
+	return func(t *testing.T) {
+		r.AddCleanTasks(signup)
+		testsupport.VerifyResourcesProvisionedForSignupWithTiers(t, awaitilities, signup, "deactivate30", "appstudio")
+	}
+}
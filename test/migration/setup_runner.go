@@ -2,37 +2,24 @@ package migration
 
 import (
 	"context"
-	"sync"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid"
-	"k8s.io/apimachinery/pkg/types"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
-	commoncluster "github.com/codeready-toolchain/toolchain-common/pkg/cluster"
-	"github.com/codeready-toolchain/toolchain-common/pkg/states"
+	"github.com/codeready-toolchain/toolchain-common/pkg/hash"
 	testspace "github.com/codeready-toolchain/toolchain-common/pkg/test/space"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
 	tsspace "github.com/codeready-toolchain/toolchain-e2e/testsupport/space"
-	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
 	"github.com/stretchr/testify/require"
-)
-
-const (
-	ProvisionedUser             = "mig-prov"
-	DeactivatedUser             = "mig-deact"
-	BannedUser                  = "mig-banned"
-	AppStudioProvisionedUser    = "mig-appst"
-	SecondMemberProvisionedUser = "mig-m2-user"
-
-	ProvisionedAppStudioSpace    = "mig-appst-space"
-	SecondMemberProvisionedSpace = "mig-m2-space"
-	ProvisionedSpaceRequest      = "mig-space-request"
-	ProvisionedParentSpace       = "mig-parent-space"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type SetupMigrationRunner struct {
@@ -40,44 +27,57 @@ type SetupMigrationRunner struct {
 	WithCleanup  bool
 }
 
+// Run runs the setup of all the registered migration scenarios concurrently
 func (r *SetupMigrationRunner) Run(t *testing.T) {
-	var wg sync.WaitGroup
-
-	toRun := []func(t *testing.T){
-		r.prepareAppStudioProvisionedSpace,
-		r.prepareSecondMemberProvisionedSpace,
-		r.prepareProvisionedSubspace,
-		r.prepareProvisionedUser,
-		r.prepareSecondMemberProvisionedUser,
-		r.prepareDeactivatedUser,
-		r.prepareBannedUser,
-		r.prepareAppStudioProvisionedUser}
-
-	for _, funcToRun := range toRun {
-		wg.Add(1)
-		go func(run func(t *testing.T)) {
-			defer wg.Done()
-			run(t)
-		}(funcToRun)
+	toRun := map[string]func(t *testing.T){}
+	for _, scenario := range Scenarios() {
+		if scenario.Setup == nil {
+			continue
+		}
+		toRun[scenario.Name] = func(t *testing.T) {
+			scenario.Setup(t, r)
+		}
 	}
-
-	wg.Wait()
+	runConcurrently(t, SetupPhase, toRun)
 
 	// wait until the ToolchainStatus is updated to make sure that all counters are in sync
 	_, err := r.Awaitilities.Host().WaitForToolchainStatus(t, wait.UntilToolchainStatusUpdatedAfter(time.Now()))
 	require.NoError(t, err)
 }
 
-func (r *SetupMigrationRunner) prepareAppStudioProvisionedSpace(t *testing.T) {
-	r.createAndWaitForSpace(t, ProvisionedAppStudioSpace, "appstudio", r.Awaitilities.Member1())
-}
-
-func (r *SetupMigrationRunner) prepareSecondMemberProvisionedSpace(t *testing.T) {
-	r.createAndWaitForSpace(t, SecondMemberProvisionedSpace, tiers.GetDefaultSpaceTierName(t, r.Awaitilities.Host()), r.Awaitilities.Member2())
+// deleteLeftovers deletes the UserSignup (along with its BannedUser, if any) and the Space with the given name, which may
+// have been left by the setup of a previous version hop (eg, when the verification of the hop failed, in which case the
+// resources are not cleaned up), so that the setup can be re-run with the same names at every hop
+func (r *SetupMigrationRunner) deleteLeftovers(t *testing.T, name string) {
+	hostAwait := r.Awaitilities.Host()
+	signup := &toolchainv1alpha1.UserSignup{}
+	err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: wait.EncodeUserIdentifier(name)}, signup)
+	if err == nil {
+		t.Logf("deleting the UserSignup '%s' left by a previous hop", signup.Name)
+		err = hostAwait.Client.DeleteAllOf(context.TODO(), &toolchainv1alpha1.BannedUser{}, client.InNamespace(hostAwait.Namespace),
+			client.MatchingLabels{toolchainv1alpha1.BannedUserEmailHashLabelKey: hash.EncodeString(signup.Spec.IdentityClaims.Email)})
+		require.NoError(t, err)
+		err = hostAwait.Client.Delete(context.TODO(), signup)
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		require.NoError(t, err)
+	}
+	err = hostAwait.Client.Delete(context.TODO(), &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: hostAwait.Namespace,
+			Name:      name,
+		},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		require.NoError(t, err)
+	}
+	require.NoError(t, hostAwait.WaitUntilUserSignupDeleted(t, wait.EncodeUserIdentifier(name)))
+	require.NoError(t, hostAwait.WaitUntilSpaceAndSpaceBindingsDeleted(t, name))
 }
 
 func (r *SetupMigrationRunner) createAndWaitForSpace(t *testing.T, name, tierName string, targetCluster *wait.MemberAwaitility) {
 	hostAwait := r.Awaitilities.Host()
+	r.deleteLeftovers(t, name)
 	space := testspace.NewSpace(r.Awaitilities.Host().Namespace, name, testspace.WithTierName(tierName), testspace.WithSpecTargetCluster(targetCluster.ClusterName), testspace.WithLabel(toolchainv1alpha1.SpaceCreatorLabelKey, name))
 	err := hostAwait.Client.Create(context.TODO(), space)
 	require.NoError(t, err)
@@ -101,30 +101,6 @@ func (r *SetupMigrationRunner) createAndWaitForSpace(t *testing.T, name, tierNam
 	}
 }
 
-func (r *SetupMigrationRunner) prepareProvisionedSubspace(t *testing.T) {
-	memberAwait := r.Awaitilities.Member2()
-	r.createAndWaitForSpace(t, ProvisionedParentSpace, "appstudio-env", memberAwait)
-
-	srClusterRoles := []string{commoncluster.RoleLabel(commoncluster.Tenant)}
-	t.Logf("creating space request %v for parent space %v", ProvisionedSpaceRequest, ProvisionedParentSpace)
-	spaceRequest := createSpaceRequestForParentSpace(t,
-		r.Awaitilities,
-		memberAwait.ClusterName,
-		ProvisionedParentSpace,
-		tsspace.WithName(ProvisionedSpaceRequest),
-		tsspace.WithSpecTargetClusterRoles(srClusterRoles),
-		tsspace.WithSpecTierName("appstudio-env"))
-
-	_, err := memberAwait.WaitForSpaceRequest(t,
-		types.NamespacedName{
-			Namespace: spaceRequest.Namespace,
-			Name:      spaceRequest.Name,
-		},
-		wait.UntilSpaceRequestHasConditions(wait.Provisioned()),
-	)
-	require.NoError(t, err)
-}
-
 func createSpaceRequestForParentSpace(t *testing.T, awaitilities wait.Awaitilities, memberName, parent string, opts ...tsspace.SpaceRequestOption) *toolchainv1alpha1.SpaceRequest {
 	memberAwait, err := awaitilities.Member(memberName)
 	require.NoError(t, err)
@@ -144,65 +120,8 @@ func createSpaceRequestForParentSpace(t *testing.T, awaitilities wait.Awaitiliti
 	return spaceRequest
 }
 
-func (r *SetupMigrationRunner) prepareProvisionedUser(t *testing.T) {
-	r.prepareUser(t, ProvisionedUser, r.Awaitilities.Member1())
-}
-
-func (r *SetupMigrationRunner) prepareSecondMemberProvisionedUser(t *testing.T) {
-	r.prepareUser(t, SecondMemberProvisionedUser, r.Awaitilities.Member2())
-}
-
-func (r *SetupMigrationRunner) prepareDeactivatedUser(t *testing.T) {
-	userSignup := r.prepareUser(t, DeactivatedUser, r.Awaitilities.Member1())
-	hostAwait := r.Awaitilities.Host()
-
-	// deactivate the UserSignup
-	userSignup, err := wait.For(t, hostAwait.Awaitility, &toolchainv1alpha1.UserSignup{}).
-		Update(userSignup.Name, hostAwait.Namespace,
-			func(us *toolchainv1alpha1.UserSignup) {
-				states.SetDeactivated(us, true)
-			})
-	require.NoError(t, err)
-	t.Logf("user signup '%s' set to deactivated", userSignup.Name)
-
-	// verify that MUR is deleted
-	err = hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t, userSignup.Status.CompliantUsername) // TODO wait for space deletion too after Space migration is done
-	require.NoError(t, err)
-}
-
-func (r *SetupMigrationRunner) prepareBannedUser(t *testing.T) {
-	userSignup := r.prepareUser(t, BannedUser, r.Awaitilities.Member1())
-	hostAwait := r.Awaitilities.Host()
-
-	// Create the BannedUser
-	bannedUser := testsupport.NewBannedUser(hostAwait, userSignup.Spec.IdentityClaims.Email)
-	err := hostAwait.Client.Create(context.TODO(), bannedUser)
-	require.NoError(t, err, "failed to create banned user for %+v", userSignup)
-
-	t.Logf("BannedUser '%s' created", bannedUser.Spec.Email)
-
-	// Confirm the user is banned
-	_, err = hostAwait.WithRetryOptions(wait.TimeoutOption(time.Second*15)).WaitForUserSignup(t, userSignup.Name,
-		wait.ContainsCondition(wait.Banned()[0]))
-	require.NoError(t, err)
-}
-
-func (r *SetupMigrationRunner) prepareAppStudioProvisionedUser(t *testing.T) {
-	usersignup := r.prepareUser(t, AppStudioProvisionedUser, r.Awaitilities.Member1())
-	hostAwait := r.Awaitilities.Host()
-
-	// promote to appstudio
-	tiers.MoveSpaceToTier(t, hostAwait, usersignup.Status.CompliantUsername, "appstudio")
-
-	t.Logf("user %s was promoted to appstudio tier", AppStudioProvisionedUser)
-
-	// verify that it's promoted
-	_, err := r.Awaitilities.Host().WaitForMasterUserRecord(t, usersignup.Status.CompliantUsername,
-		wait.UntilMasterUserRecordHasConditions(wait.Provisioned(), wait.ProvisionedNotificationCRCreated()))
-	require.NoError(t, err)
-}
-
 func (r *SetupMigrationRunner) prepareUser(t *testing.T, name string, targetCluster *wait.MemberAwaitility) *toolchainv1alpha1.UserSignup {
+	r.deleteLeftovers(t, name)
 	requestBuilder := testsupport.NewSignupRequest(r.Awaitilities).
 		Username(name).
 		UserID(uuid.Must(uuid.NewV4()).String()).
@@ -0,0 +1,88 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type VerifyMigrationRunner struct {
+	Awaitilities wait.Awaitilities
+	// parent the test in which the verifications are run
+	parent *testing.T
+}
+
+// Run verifies all the registered migration scenarios concurrently, once all their lookups were done.
+// The resources registered for cleanup with `AddCleanTasks` are deleted once all the verifications are done.
+func (r *VerifyMigrationRunner) Run(t *testing.T) {
+	r.parent = t
+	hop := currentHop()
+	toRun := map[string]func(t *testing.T){}
+	for _, scenario := range Scenarios() {
+		if scenario.Verify == nil {
+			continue
+		}
+		var verify func(t *testing.T)
+		if runPhase(t, hop, LookupPhase, scenario.Name, func(t *testing.T) {
+			verify = scenario.Verify(t, r)
+		}) {
+			toRun[scenario.Name] = verify
+		}
+	}
+	runConcurrently(t, VerifyPhase, toRun)
+
+	cleanup.ExecuteAllCleanTasks(t)
+
+	// wait until the ToolchainStatus is updated to make sure that all counters are in sync
+	_, err := r.Awaitilities.Host().WaitForToolchainStatus(t, wait.UntilToolchainStatusUpdatedAfter(time.Now()))
+	require.NoError(t, err)
+}
+
+// AddCleanTasks registers the given objects to be deleted once the verifications of all the scenarios are done
+// (as opposed to at the end of the subtest of a single scenario, while the other scenarios may still be running)
+func (r *VerifyMigrationRunner) AddCleanTasks(objects ...client.Object) {
+	cleanup.AddCleanTasks(r.parent, r.Awaitilities.Host().Client, objects...)
+}
+
+func checkMURMigratedAndGetSignup(t *testing.T, hostAwait *wait.HostAwaitility, murName string) *toolchainv1alpha1.UserSignup {
+	provisionedMur, err := hostAwait.WithRetryOptions(wait.TimeoutOption(time.Second*120)).WaitForMasterUserRecord(t, murName,
+		wait.UntilMasterUserRecordHasCondition(wait.Provisioned()),
+		wait.UntilMasterUserRecordHasNoTierHashLabel(), // after migration there should be no tier hash label so we should wait for that to confirm migration is completed before proceeding
+	)
+	require.NoError(t, err)
+
+	signup, err := hostAwait.WaitForUserSignup(t, provisionedMur.Labels[toolchainv1alpha1.MasterUserRecordOwnerLabelKey])
+	require.NoError(t, err)
+
+	checkMURMigrated(t, provisionedMur)
+
+	return signup
+}
+
+// checkMURMigrated ensures that all MURs are correctly migrated
+func checkMURMigrated(t *testing.T, mur *toolchainv1alpha1.MasterUserRecord) {
+	// should have tier name set
+	require.NotEmpty(t, mur.Spec.TierName)
+
+	// should not have tier hash label
+	require.Empty(t, mur.Labels[fmt.Sprintf("toolchain.dev.openshift.com/%s-tier-hash", mur.Spec.TierName)])
+
+	require.Len(t, mur.Spec.UserAccounts, 1)
+}
+
+func listAndGetSignupWithState(t *testing.T, hostAwait *wait.HostAwaitility, state string) *toolchainv1alpha1.UserSignup {
+	userSignups := &toolchainv1alpha1.UserSignupList{}
+	err := hostAwait.Client.List(context.TODO(), userSignups, client.InNamespace(hostAwait.Namespace), client.MatchingLabels{toolchainv1alpha1.UserSignupStateLabelKey: state})
+	require.NoError(t, err)
+
+	require.Len(t, userSignups.Items, 1)
+	return &userSignups.Items[0]
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+)
+
+// Key a signing key which is not trusted by the registration service, unless it is added to its trusted key set
+// (see `testsupport.TrustKeys`), contrary to the e2e key which is used by `NewToken`
+type Key struct {
+	ID      string
+	public  *rsa.PublicKey
+	manager *commonauth.TokenManager
+}
+
+// NewKey returns a new signing key with the given key ID. When the given ID is the one of the e2e key (see `E2EKeyID`),
+// then the tokens claim to be signed by the e2e key while they are actually signed by another one.
+func NewKey(kid string) (*Key, error) {
+	manager := commonauth.NewTokenManager()
+	private, err := manager.AddPrivateKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{
+		ID:      kid,
+		public:  &private.PublicKey,
+		manager: manager,
+	}, nil
+}
+
+// E2EKeyID returns the ID of the e2e key, which is always trusted by the registration service in e2e mode
+func E2EKeyID() string {
+	return commonauth.GetE2ETestPublicKey()[0].KeyID
+}
+
+// NewToken returns a new identity along with a token signed by this key
+func (k *Key) NewToken(claims ...Claim) (*commonauth.Identity, string, error) {
+	identity := commonauth.NewIdentity()
+	token, err := k.NewTokenFromIdentity(identity, claims...)
+	return identity, token, err
+}
+
+// NewTokenFromIdentity returns a token of the given identity signed by this key
+func (k *Key) NewTokenFromIdentity(identity *commonauth.Identity, claims ...Claim) (string, error) {
+	return k.manager.GenerateSignedToken(*identity, k.ID, withDefaultClaims(identity, claims)...)
+}
+
+// TrustedKeySet returns the JSON Web Key Set of the e2e key along with the given keys, so that the tokens of the other tests
+// are still accepted when the registration service is configured to trust this set of keys
+func TrustedKeySet(keys ...*Key) ([]byte, error) {
+	jwks := []map[string]string{}
+	for _, key := range commonauth.GetE2ETestPublicKey() {
+		jwks = append(jwks, jwk(key.KeyID, key.Key))
+	}
+	for _, key := range keys {
+		jwks = append(jwks, jwk(key.ID, key.public))
+	}
+	return json.Marshal(map[string]interface{}{
+		"keys": jwks,
+	})
+}
+
+func jwk(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"use": "sig",
+		"alg": "RS256",
+		"kid": kid,
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
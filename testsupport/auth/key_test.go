@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	t.Run("unknown key", func(t *testing.T) {
+		// given
+		key, err := NewKey("unknown")
+		require.NoError(t, err)
+
+		// when
+		identity, token, err := key.NewToken(WithEmail("johnsmith@acme.com"))
+
+		// then
+		require.NoError(t, err)
+		claims, header := parse(t, token, key.public)
+		assert.Equal(t, "unknown", header["kid"])
+		assert.Equal(t, identity.ID.String(), claims["sub"])
+		assert.Equal(t, "johnsmith@acme.com", claims["email"])
+	})
+
+	t.Run("wrong key for the e2e kid", func(t *testing.T) {
+		// given
+		key, err := NewKey(E2EKeyID())
+		require.NoError(t, err)
+
+		// when
+		_, token, err := key.NewToken()
+
+		// then
+		require.NoError(t, err)
+		_, err = jwt.Parse(token, func(_ *jwt.Token) (interface{}, error) {
+			return e2ePublicKey(), nil
+		})
+		require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+}
+
+func TestTrustedKeySet(t *testing.T) {
+	// given
+	key1, err := NewKey("key-1")
+	require.NoError(t, err)
+	key2, err := NewKey("key-2")
+	require.NoError(t, err)
+
+	// when
+	data, err := TrustedKeySet(key1, key2)
+
+	// then
+	require.NoError(t, err)
+	jwks := map[string][]map[string]string{}
+	require.NoError(t, json.Unmarshal(data, &jwks))
+	require.Len(t, jwks["keys"], 3)
+	assert.Equal(t, E2EKeyID(), jwks["keys"][0]["kid"])
+	assert.Equal(t, "key-1", jwks["keys"][1]["kid"])
+	assert.Equal(t, "key-2", jwks["keys"][2]["kid"])
+	for _, key := range jwks["keys"] {
+		assert.Equal(t, "RSA", key["kty"])
+		assert.Equal(t, "AQAB", key["e"])
+		assert.NotEmpty(t, key["n"])
+	}
+}
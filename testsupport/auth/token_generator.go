@@ -1,24 +1,29 @@
 package auth
 
 import (
+	"encoding/json"
 	"time"
 
 	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 func NewToken(claims ...Claim) (*commonauth.Identity, string, error) {
 	identity := commonauth.NewIdentity()
-	claims = append(claims, commonauth.WithSubClaim(identity.ID.String()))
-	token, err := commonauth.GenerateSignedE2ETestToken(*identity, claims...)
+	token, err := NewTokenFromIdentity(identity, claims...)
 	return identity, token, err
 }
 
 func NewTokenFromIdentity(identity *commonauth.Identity, claims ...Claim) (string, error) {
-	claims = append(claims, commonauth.WithSubClaim(identity.ID.String()))
-	token, err := commonauth.GenerateSignedE2ETestToken(*identity, claims...)
+	token, err := commonauth.GenerateSignedE2ETestToken(*identity, withDefaultClaims(identity, claims)...)
 	return token, err
 }
 
+// withDefaultClaims appends the `sub` claim of the identity and the custom claims (which must be applied last) to the given claims
+func withDefaultClaims(identity *commonauth.Identity, claims []Claim) []Claim {
+	return append(claims, commonauth.WithSubClaim(identity.ID.String()), withCustomClaims)
+}
+
 type Claim = commonauth.ExtraClaim
 
 func WithEmail(email string) Claim {
@@ -36,3 +41,101 @@ func WithIAT(iat time.Time) Claim {
 func WithPreferredUsername(username string) Claim {
 	return commonauth.WithPreferredUsernameClaim(username)
 }
+
+func WithNotBefore(nbf time.Time) Claim {
+	return commonauth.WithNotBeforeClaim(nbf)
+}
+
+func WithOriginalSub(originalSub string) Claim {
+	return commonauth.WithOriginalSubClaim(originalSub)
+}
+
+func WithUserID(userID string) Claim {
+	return commonauth.WithUserIDClaim(userID)
+}
+
+func WithAccountID(accountID string) Claim {
+	return commonauth.WithAccountIDClaim(accountID)
+}
+
+func WithAccountNumber(accountNumber string) Claim {
+	return commonauth.WithAccountNumberClaim(accountNumber)
+}
+
+func WithGivenName(givenName string) Claim {
+	return commonauth.WithGivenNameClaim(givenName)
+}
+
+func WithFamilyName(familyName string) Claim {
+	return commonauth.WithFamilyNameClaim(familyName)
+}
+
+func WithCompany(company string) Claim {
+	return commonauth.WithCompanyClaim(company)
+}
+
+func WithAudience(aud ...string) Claim {
+	return commonauth.WithAudClaim(aud)
+}
+
+// WithIssuer sets the `iss` claim (which is `codeready-toolchain` by default)
+func WithIssuer(iss string) Claim {
+	return func(token *jwt.Token) {
+		token.Claims.(*commonauth.MyClaims).Issuer = iss
+	}
+}
+
+// customClaimsHeader the (temporary) header in which the custom claims are kept until all the other claims are applied
+const customClaimsHeader = "e2e-custom-claims"
+
+// WithCustomClaim sets an arbitrary claim in the token. It takes precedence over the standard claims, so it can also be used
+// to set a standard claim with an unexpected type, or to remove a standard claim (with a `nil` value).
+func WithCustomClaim(name string, value interface{}) Claim {
+	return func(token *jwt.Token) {
+		custom, ok := token.Header[customClaimsHeader].(map[string]interface{})
+		if !ok {
+			custom = map[string]interface{}{}
+			token.Header[customClaimsHeader] = custom
+		}
+		custom[name] = value
+	}
+}
+
+// withCustomClaims moves the custom claims (if any) from the header of the token to its claims. It must be applied after
+// all the other claims, since they all expect the claims of the token to be `*commonauth.MyClaims`.
+func withCustomClaims(token *jwt.Token) {
+	custom, ok := token.Header[customClaimsHeader].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(token.Header, customClaimsHeader)
+	token.Claims = &customClaims{
+		MyClaims: token.Claims.(*commonauth.MyClaims),
+		custom:   custom,
+	}
+}
+
+// customClaims the standard claims, along with the custom claims which override them
+type customClaims struct {
+	*commonauth.MyClaims
+	custom map[string]interface{}
+}
+
+func (c *customClaims) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(c.MyClaims)
+	if err != nil {
+		return nil, err
+	}
+	claims := map[string]interface{}{}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, err
+	}
+	for name, value := range c.custom {
+		if value == nil {
+			delete(claims, name)
+			continue
+		}
+		claims[name] = value
+	}
+	return json.Marshal(claims)
+}
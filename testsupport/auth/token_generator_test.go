@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewToken(t *testing.T) {
+	t.Run("default claims", func(t *testing.T) {
+		// when
+		identity, token, err := NewToken(WithEmail("johnsmith@acme.com"))
+
+		// then
+		require.NoError(t, err)
+		claims, header := parse(t, token, e2ePublicKey())
+		assert.Equal(t, E2EKeyID(), header["kid"])
+		assert.Equal(t, identity.ID.String(), claims["sub"])
+		assert.Equal(t, identity.Username, claims["preferred_username"])
+		assert.Equal(t, "johnsmith@acme.com", claims["email"])
+		assert.Equal(t, "codeready-toolchain", claims["iss"])
+	})
+
+	t.Run("identity claims", func(t *testing.T) {
+		// when
+		_, token, err := NewToken(
+			WithOriginalSub("original-sub"),
+			WithUserID("123"),
+			WithAccountID("456"),
+			WithAccountNumber("789"),
+			WithGivenName("John"),
+			WithFamilyName("Smith"),
+			WithCompany("Acme"))
+
+		// then
+		require.NoError(t, err)
+		claims, _ := parse(t, token, e2ePublicKey())
+		assert.Equal(t, "original-sub", claims["original_sub"])
+		assert.Equal(t, "123", claims["user_id"])
+		assert.Equal(t, "456", claims["account_id"])
+		assert.Equal(t, "789", claims["account_number"])
+		assert.Equal(t, "John", claims["given_name"])
+		assert.Equal(t, "Smith", claims["family_name"])
+		assert.Equal(t, "Acme", claims["company"])
+	})
+
+	t.Run("registered claims", func(t *testing.T) {
+		// given
+		nbf := time.Now().Add(time.Hour).Truncate(time.Second)
+
+		// when
+		_, token, err := NewToken(
+			WithNotBefore(nbf),
+			WithAudience("other-client"),
+			WithIssuer("https://other-sso.example.com"))
+
+		// then
+		require.NoError(t, err)
+		claims, _ := parse(t, token, e2ePublicKey(), jwt.WithoutClaimsValidation())
+		assert.InDelta(t, float64(nbf.Unix()), claims["nbf"], 0.01)
+		assert.Equal(t, []interface{}{"other-client"}, claims["aud"])
+		assert.Equal(t, "https://other-sso.example.com", claims["iss"])
+	})
+
+	t.Run("custom claims", func(t *testing.T) {
+		// when
+		identity, token, err := NewToken(
+			WithCustomClaim("email", nil),
+			WithCustomClaim("preferred_username", 42),
+			WithCustomClaim("groups", []string{"admins"}),
+			// custom claims can be set before the standard claims
+			WithEmail("johnsmith@acme.com"))
+
+		// then
+		require.NoError(t, err)
+		claims, header := parse(t, token, e2ePublicKey())
+		assert.NotContains(t, claims, "email")
+		assert.NotContains(t, header, customClaimsHeader)
+		assert.InDelta(t, float64(42), claims["preferred_username"], 0.01)
+		assert.Equal(t, []interface{}{"admins"}, claims["groups"])
+		assert.Equal(t, identity.ID.String(), claims["sub"])
+	})
+}
+
+func e2ePublicKey() *rsa.PublicKey {
+	return commonauth.GetE2ETestPublicKey()[0].Key
+}
+
+// parse verifies the signature of the given token and returns its claims and header
+func parse(t *testing.T, token string, key *rsa.PublicKey, options ...jwt.ParserOption) (jwt.MapClaims, map[string]interface{}) {
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(_ *jwt.Token) (interface{}, error) {
+		return key, nil
+	}, options...)
+	require.NoError(t, err)
+	return claims, parsed.Header
+}
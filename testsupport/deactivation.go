@@ -37,6 +37,7 @@ func DeactivateAndCheckUser(t *testing.T, awaitilities wait.Awaitilities, userSi
 	assert.Equal(t, userSignup.Namespace, notification.Namespace)
 	assert.Equal(t, "userdeactivated", notification.Spec.Template)
 	assert.Equal(t, userSignup.Spec.IdentityClaims.Sub, notification.Spec.Context["Sub"])
+	VerifyNotificationEmail(t, hostAwait, notification)
 
 	userSignup, err = hostAwait.WaitForUserSignup(t, userSignup.Name,
 		wait.UntilUserSignupHasConditions(wait.ConditionSet(wait.Default(), wait.DeactivatedWithoutPreDeactivation())...),
@@ -0,0 +1,197 @@
+package testsupport
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/states"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/metrics"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	day = 24 * time.Hour
+	// scheduledDeactivationTolerance the tolerance when comparing the scheduled deactivation time with its expected value,
+	// since the timestamps are computed by the operator at reconcile time
+	scheduledDeactivationTolerance = time.Hour
+)
+
+// DeactivationTimeline moves a provisioned user through the automatic deactivation timeline of its UserTier, without
+// waiting for days: at each stage, the provisioned time of the MasterUserRecord (and the transition time of the
+// pre-deactivation condition of the UserSignup) are moved in the past, as if the days had elapsed. For example:
+//
+// timeline := NewDeactivationTimeline(t, awaitilities, user.UserSignup)
+// timeline.VerifyProvisioned()
+// timeline.EnterPreDeactivation()
+// timeline.Deactivate()
+//
+// The deactivation metrics are compared with a snapshot taken when the timeline is created, so the timeline must not
+// be used in the parallel tests.
+type DeactivationTimeline struct {
+	t                *testing.T
+	awaitilities     wait.Awaitilities
+	userSignup       *toolchainv1alpha1.UserSignup
+	murName          string
+	timeoutDays      int
+	notificationDays int
+	metricsBefore    metrics.Snapshot
+}
+
+// NewDeactivationTimeline returns a new timeline for the given (provisioned) UserSignup. The deactivation timeout is the one
+// of the UserTier of the user, and the pre-deactivation period is the one of the current ToolchainConfig.
+func NewDeactivationTimeline(t *testing.T, awaitilities wait.Awaitilities, userSignup *toolchainv1alpha1.UserSignup) *DeactivationTimeline {
+	hostAwait := awaitilities.Host()
+	userSignup, err := hostAwait.WaitForUserSignup(t, userSignup.Name, wait.UntilUserSignupHasCompliantUsername())
+	require.NoError(t, err)
+	mur, err := hostAwait.WaitForMasterUserRecord(t, userSignup.Status.CompliantUsername,
+		wait.UntilMasterUserRecordHasCondition(wait.Provisioned()))
+	require.NoError(t, err)
+	userTier, err := hostAwait.WaitForUserTier(t, mur.Spec.TierName)
+	require.NoError(t, err)
+	require.Positive(t, userTier.Spec.DeactivationTimeoutDays, "the UserTier '%s' has no automatic deactivation", userTier.Name)
+
+	// the operator defaults to 3 days when the ToolchainConfig does not specify the pre-deactivation period
+	notificationDays := 3
+	if days := hostAwait.GetToolchainConfig(t).Spec.Host.Deactivation.DeactivatingNotificationDays; days != nil {
+		notificationDays = *days
+	}
+	require.Less(t, notificationDays, userTier.Spec.DeactivationTimeoutDays,
+		"the pre-deactivation period must be shorter than the deactivation timeout of the UserTier '%s'", userTier.Name)
+
+	return &DeactivationTimeline{
+		t:                t,
+		awaitilities:     awaitilities,
+		userSignup:       userSignup,
+		murName:          mur.Name,
+		timeoutDays:      userTier.Spec.DeactivationTimeoutDays,
+		notificationDays: notificationDays,
+		metricsBefore:    hostAwait.TakeMetricsSnapshot(t),
+	}
+}
+
+// VerifyProvisioned verifies that the user is provisioned and that its deactivation is scheduled at the end of the
+// deactivation timeout of its UserTier
+func (tl *DeactivationTimeline) VerifyProvisioned() *toolchainv1alpha1.UserSignup {
+	hostAwait := tl.awaitilities.Host()
+	mur, err := hostAwait.WaitForMasterUserRecord(tl.t, tl.murName, wait.UntilMasterUserRecordHasCondition(wait.Provisioned()))
+	require.NoError(tl.t, err)
+	require.NotNil(tl.t, mur.Status.ProvisionedTime)
+
+	tl.userSignup, err = hostAwait.WaitForUserSignup(tl.t, tl.userSignup.Name,
+		wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueApproved),
+		wait.UntilUserSignupHasScheduledDeactivationTimeAround(
+			mur.Status.ProvisionedTime.Add(time.Duration(tl.timeoutDays)*day), scheduledDeactivationTolerance))
+	require.NoError(tl.t, err)
+	assert.False(tl.t, states.Deactivating(tl.userSignup), "usersignup should not be deactivating")
+	return tl.userSignup
+}
+
+// EnterPreDeactivation moves the provisioned time of the user back to the beginning of the pre-deactivation period, and
+// verifies that the user is notified about its upcoming deactivation, which is scheduled at the end of the period
+func (tl *DeactivationTimeline) EnterPreDeactivation() *toolchainv1alpha1.UserSignup {
+	hostAwait := tl.awaitilities.Host()
+	// an hour into the pre-deactivation period
+	tl.moveProvisionedTime(time.Duration(tl.timeoutDays-tl.notificationDays)*day + time.Hour)
+
+	var err error
+	tl.userSignup, err = hostAwait.WaitForUserSignup(tl.t, tl.userSignup.Name,
+		wait.UntilUserSignupHasConditions(wait.Deactivating()...),
+		wait.UntilUserSignupHasScheduledDeactivationTimeAround(
+			time.Now().Add(time.Duration(tl.notificationDays)*day), scheduledDeactivationTolerance))
+	require.NoError(tl.t, err)
+	require.True(tl.t, states.Deactivating(tl.userSignup), "usersignup should be deactivating")
+	require.False(tl.t, states.Deactivated(tl.userSignup), "usersignup should not be deactivated yet")
+
+	// "deactivating"
+	notifications, err := hostAwait.WaitForNotifications(tl.t, tl.userSignup.Status.CompliantUsername,
+		toolchainv1alpha1.NotificationTypeDeactivating, 1, wait.UntilNotificationHasConditions(wait.Sent()))
+	require.NoError(tl.t, err)
+	require.Len(tl.t, notifications, 1)
+	assert.Equal(tl.t, tl.userSignup.Namespace, notifications[0].Namespace)
+	VerifyNotificationEmail(tl.t, hostAwait, notifications[0])
+
+	// the user is still provisioned and has not been deactivated yet
+	VerifyResourcesProvisionedForSignup(tl.t, tl.awaitilities, tl.userSignup)
+	hostAwait.WaitForMetricsDeltas(tl.t, tl.metricsBefore,
+		metrics.ExpectDeltas().AndNoOtherChangeIn(metrics.Family(wait.UserSignupsAutoDeactivatedMetric)))
+	return tl.userSignup
+}
+
+// Deactivate moves the timeline to the end of the pre-deactivation period (ie, the provisioned time of the user and the
+// time at which it was notified), and verifies that the user is automatically deactivated and deprovisioned
+func (tl *DeactivationTimeline) Deactivate() *toolchainv1alpha1.UserSignup {
+	hostAwait := tl.awaitilities.Host()
+	require.True(tl.t, states.Deactivating(tl.userSignup), "the user must enter the pre-deactivation period first")
+	tl.moveProvisionedTime(time.Duration(tl.timeoutDays)*day + time.Hour)
+
+	// the pre-deactivation period started when the "deactivating" notification was created
+	notifiedAt := metav1.NewTime(time.Now().Add(-time.Duration(tl.notificationDays)*day - time.Hour))
+	_, err := wait.For(tl.t, hostAwait.Awaitility, &toolchainv1alpha1.UserSignup{}).
+		UpdateStatus(tl.userSignup.Name, hostAwait.Namespace, func(us *toolchainv1alpha1.UserSignup) {
+			for i, c := range us.Status.Conditions {
+				if c.Type == toolchainv1alpha1.UserSignupUserDeactivatingNotificationCreated {
+					us.Status.Conditions[i].LastTransitionTime = notifiedAt
+				}
+			}
+		})
+	require.NoError(tl.t, err)
+	tl.t.Logf("usersignup '%s' notified about its deactivation at %s", tl.userSignup.Name, notifiedAt.String())
+	// trigger a reconciliation of the deactivation controller, which only watches the MasterUserRecords
+	tl.touchMasterUserRecord()
+
+	tl.userSignup, err = hostAwait.WaitForUserSignup(tl.t, tl.userSignup.Name,
+		wait.UntilUserSignupHasConditions(wait.Deactivated()...),
+		wait.UntilUserSignupHasStateLabel(toolchainv1alpha1.UserSignupStateLabelValueDeactivated),
+		wait.UntilUserSignupHasNilScheduledDeactivationTime())
+	require.NoError(tl.t, err)
+	require.True(tl.t, states.Deactivated(tl.userSignup), "usersignup should be deactivated")
+
+	err = hostAwait.WaitUntilMasterUserRecordAndSpaceBindingsDeleted(tl.t, tl.murName)
+	require.NoError(tl.t, err)
+	err = hostAwait.WaitUntilSpaceAndSpaceBindingsDeleted(tl.t, tl.murName)
+	require.NoError(tl.t, err)
+
+	// "deactivated"
+	notifications, err := hostAwait.WaitForNotifications(tl.t, tl.userSignup.Status.CompliantUsername,
+		toolchainv1alpha1.NotificationTypeDeactivated, 1, wait.UntilNotificationHasConditions(wait.Sent()))
+	require.NoError(tl.t, err)
+	require.Len(tl.t, notifications, 1)
+	assert.Equal(tl.t, "userdeactivated", notifications[0].Spec.Template)
+	VerifyNotificationEmail(tl.t, hostAwait, notifications[0])
+
+	hostAwait.WaitForMetricsDeltas(tl.t, tl.metricsBefore, metrics.ExpectDeltas(
+		metrics.Family(wait.UserSignupsAutoDeactivatedMetric).Delta(1),
+	))
+	return tl.userSignup
+}
+
+// moveProvisionedTime sets the provisioned time of the MasterUserRecord to the given duration ago
+func (tl *DeactivationTimeline) moveProvisionedTime(ago time.Duration) {
+	mur, err := wait.For(tl.t, tl.awaitilities.Host().Awaitility, &toolchainv1alpha1.MasterUserRecord{}).
+		UpdateStatus(tl.murName, tl.awaitilities.Host().Namespace,
+			func(mur *toolchainv1alpha1.MasterUserRecord) {
+				mur.Status.ProvisionedTime = &metav1.Time{Time: time.Now().Add(-ago)}
+			})
+	require.NoError(tl.t, err)
+	tl.t.Logf("masteruserrecord '%s' provisioned time adjusted to %s", mur.Name, mur.Status.ProvisionedTime.String())
+}
+
+// touchMasterUserRecord updates an annotation of the MasterUserRecord, which may already be deleted if the user was deactivated in the meantime
+func (tl *DeactivationTimeline) touchMasterUserRecord() {
+	_, err := wait.For(tl.t, tl.awaitilities.Host().Awaitility, &toolchainv1alpha1.MasterUserRecord{}).
+		Update(tl.murName, tl.awaitilities.Host().Namespace,
+			func(mur *toolchainv1alpha1.MasterUserRecord) {
+				if mur.Annotations == nil {
+					mur.Annotations = map[string]string{}
+				}
+				mur.Annotations["update-from-e2e-tests"] = "trigger"
+			})
+	if err != nil {
+		require.EqualError(tl.t, err, fmt.Sprintf("masteruserrecords.toolchain.dev.openshift.com \"%s\" not found", tl.murName))
+	}
+}
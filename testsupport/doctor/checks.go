@@ -0,0 +1,350 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// E2EServiceAccountName the name of the ServiceAccount used by the e2e tests
+	E2EServiceAccountName = "e2e-test"
+	// E2EClusterRoleBindingName the name of the ClusterRoleBinding which grants the cluster-admin role to the ServiceAccount of the e2e tests
+	E2EClusterRoleBindingName = "e2e-test-cluster-admin"
+	// OLMNamespace the namespace of the Operator Lifecycle Manager
+	OLMNamespace = "openshift-operator-lifecycle-manager"
+)
+
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// Environment the environment in which the e2e tests or the setup tool run
+type Environment struct {
+	// Client the client to the cluster of the host operator
+	Client client.Client
+	// BearerToken the token used to call the endpoints of the secured routes
+	BearerToken string
+	// HostNamespace the namespace of the host operator
+	HostNamespace string
+	// RegistrationServiceNamespace the namespace of the registration service
+	RegistrationServiceNamespace string
+	// Members the locations of the member operators. If empty, then the locations are looked up as in the e2e tests
+	Members []testsupport.MemberLocation
+	// ClientForContext returns a client to the cluster of the given kubeconfig context, in which a member operator is running
+	ClientForContext func(kubeContext string) (client.Client, error)
+	// CRDsDir the directory of the third-party CRDs which must be installed in the cluster
+	CRDsDir string
+}
+
+// E2EChecks returns the checks of all the prerequisites of the awaitilities (see `testsupport.WaitForDeployments`), ie,
+// the prerequisites of the operators (see OperatorChecks) and the resources created when preparing the e2e tests
+func E2EChecks(ctx context.Context, env Environment) []Check {
+	checks := []Check{
+		NamespaceHasLabel(env.Client, env.HostNamespace, "app", "host-operator"),
+		OLMNamespaceHasLabel(env.Client),
+	}
+	checks = append(checks, CRDsInstalled(env.Client, env.CRDsDir)...)
+	checks = append(checks, E2EServiceAccountExists(env.Client, env.HostNamespace))
+	// the members are resolved once for all the checks, so that a failure is only reported once
+	members, err := resolveMembers(ctx, env)
+	if err != nil {
+		checks = append(checks, memberLocationsCheck(err))
+	}
+	for _, m := range members {
+		if m.err != nil {
+			checks = append(checks, kubeContextCheck(m.location, m.err))
+			continue
+		}
+		checks = append(checks, NamespaceHasLabel(m.client, m.location.Namespace, "app", "member-operator"))
+	}
+	return append(checks, operatorChecks(env, members)...)
+}
+
+// OperatorChecks returns the checks of the host and member operators, of the registration service and of the ToolchainClusters
+func OperatorChecks(ctx context.Context, env Environment) []Check {
+	members, err := resolveMembers(ctx, env)
+	checks := operatorChecks(env, members)
+	for _, m := range members {
+		if m.err != nil {
+			checks = append(checks, kubeContextCheck(m.location, m.err))
+		}
+	}
+	if err != nil {
+		checks = append(checks, memberLocationsCheck(err))
+	}
+	return checks
+}
+
+// operatorChecks returns the checks of the host and member operators, of the registration service and of the ToolchainClusters,
+// except for the members without a client to their cluster (whose failure is reported by the caller)
+func operatorChecks(env Environment, members []member) []Check {
+	checks := []Check{
+		DeploymentReady(env.Client, env.HostNamespace, "host-operator-controller-manager", 1),
+		DeploymentReady(env.Client, env.RegistrationServiceNamespace, "registration-service", 3),
+		RouteAvailable(env.Client, env.BearerToken, env.RegistrationServiceNamespace, "registration-service", "/"),
+		RouteAvailable(env.Client, env.BearerToken, env.RegistrationServiceNamespace, "api", "/proxyhealth"),
+	}
+	for _, m := range members {
+		if m.err != nil {
+			continue
+		}
+		checks = append(checks,
+			DeploymentReady(m.client, m.location.Namespace, "member-operator-controller-manager", 1),
+			ToolchainClusterReady(env.Client, env.HostNamespace, m.location.Namespace),
+			ToolchainClusterReady(m.client, m.location.Namespace, env.HostNamespace),
+		)
+	}
+	return checks
+}
+
+// member a member operator, with the client to the cluster in which it is running, or the error which occurred
+// while creating this client (eg, when its kubeconfig context is unusable)
+type member struct {
+	location testsupport.MemberLocation
+	client   client.Client
+	err      error
+}
+
+// resolveMembers returns the member operators of the environment, or looks them up as in the e2e tests
+func resolveMembers(ctx context.Context, env Environment) ([]member, error) {
+	locations := env.Members
+	if len(locations) == 0 {
+		var err error
+		if locations, err = testsupport.MemberLocations(ctx, env.Client, env.HostNamespace); err != nil {
+			return nil, err
+		}
+	}
+	members := make([]member, 0, len(locations))
+	for _, location := range locations {
+		cl, err := clientFor(env, location)
+		members = append(members, member{location: location, client: cl, err: err})
+	}
+	return members, nil
+}
+
+func memberLocationsCheck(err error) Check {
+	return Check{
+		Name: "member operators are located",
+		Hint: fmt.Sprintf("set the '%s' env var, or the '%s' env var (and '%s'), or register the member clusters with `make setup-toolchainclusters`",
+			wait.TopologyFileVar, wait.MemberNsVar, wait.SecondMemberModeVar),
+		Verify: func(_ context.Context) error {
+			return err
+		},
+	}
+}
+
+// clientFor returns the client to the cluster in which the given member operator is running
+func clientFor(env Environment, location testsupport.MemberLocation) (client.Client, error) {
+	if location.Context == "" {
+		return env.Client, nil
+	}
+	if env.ClientForContext == nil {
+		return nil, fmt.Errorf("no client for kubeconfig context '%s'", location.Context)
+	}
+	return env.ClientForContext(location.Context)
+}
+
+func kubeContextCheck(location testsupport.MemberLocation, err error) Check {
+	return Check{
+		Name: fmt.Sprintf("kubeconfig context '%s' of member operator in namespace '%s' is usable", location.Context, location.Namespace),
+		Hint: "check the contexts of your kubeconfig with `oc config get-contexts`",
+		Verify: func(_ context.Context) error {
+			return err
+		},
+	}
+}
+
+// NamespaceHasLabel checks that the given namespace exists and has the given label
+func NamespaceHasLabel(cl client.Client, name, key, value string) Check {
+	return Check{
+		Name: fmt.Sprintf("namespace '%s' exists with label '%s=%s'", name, key, value),
+		Hint: fmt.Sprintf("create the namespace with `oc new-project %[1]s` and label it with `oc label ns --overwrite=true %[1]s %[2]s=%[3]s`", name, key, value),
+		Verify: func(ctx context.Context) error {
+			return verifyNamespaceLabel(ctx, cl, name, key, value)
+		},
+	}
+}
+
+// OLMNamespaceHasLabel checks that the namespace of the Operator Lifecycle Manager has the label which allows the traffic
+// with the operators when network policies are configured. The check passes if the namespace does not exist.
+func OLMNamespaceHasLabel(cl client.Client) Check {
+	return Check{
+		Name: fmt.Sprintf("namespace '%s' has label 'name=%s'", OLMNamespace, OLMNamespace),
+		Hint: "label the namespace with `make label-olm-ns`",
+		Verify: func(ctx context.Context) error {
+			err := verifyNamespaceLabel(ctx, cl, OLMNamespace, "name", OLMNamespace)
+			if apierrors.IsNotFound(err) {
+				return nil // not an OpenShift cluster, or OLM is not installed
+			}
+			return err
+		},
+	}
+}
+
+func verifyNamespaceLabel(ctx context.Context, cl client.Client, name, key, value string) error {
+	ns := &corev1.Namespace{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return err
+	}
+	if actual, found := ns.Labels[key]; !found {
+		return fmt.Errorf("label '%s' is missing", key)
+	} else if actual != value {
+		return fmt.Errorf("label '%s' has value '%s'", key, actual)
+	}
+	return nil
+}
+
+// CRDsInstalled returns a check for each CRD in the given directory, which verifies that the CRD is installed in the cluster
+func CRDsInstalled(cl client.Client, dir string) []Check {
+	hint := fmt.Sprintf("install the CRDs with `oc create -f %s` (or `make create-thirdparty-crds`)", dir)
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("no CRD found in '%s'", dir)
+	}
+	if err != nil {
+		return []Check{{
+			Name: fmt.Sprintf("CRDs of '%s' are installed", dir),
+			Hint: "run the command from the root of the repository, or set the path to the CRDs",
+			Verify: func(_ context.Context) error {
+				return err
+			},
+		}}
+	}
+	checks := make([]Check, 0, len(files))
+	for _, f := range files {
+		path := f
+		name, err := crdName(path)
+		checks = append(checks, Check{
+			Name: fmt.Sprintf("CRD '%s' is installed", strings.TrimSuffix(filepath.Base(path), ".yaml")),
+			Hint: hint,
+			Verify: func(ctx context.Context) error {
+				if err != nil {
+					return err
+				}
+				crd := &unstructured.Unstructured{}
+				crd.SetGroupVersionKind(crdGVK)
+				if err := cl.Get(ctx, types.NamespacedName{Name: name}, crd); err != nil {
+					if apierrors.IsNotFound(err) {
+						return fmt.Errorf("CRD '%s' not found", name)
+					}
+					return err
+				}
+				return nil
+			},
+		})
+	}
+	return checks
+}
+
+// crdName returns the name of the CRD defined in the given file
+func crdName(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	obj := metav1.PartialObjectMetadata{}
+	if err := yaml.Unmarshal(content, &obj); err != nil {
+		return "", fmt.Errorf("invalid CRD file '%s': %w", path, err)
+	}
+	if obj.Name == "" {
+		return "", fmt.Errorf("no CRD name in file '%s'", path)
+	}
+	return obj.Name, nil
+}
+
+// E2EServiceAccountExists checks that the ServiceAccount of the e2e tests exists in the given namespace, with
+// the ClusterRoleBinding to the cluster-admin role
+func E2EServiceAccountExists(cl client.Client, namespace string) Check {
+	return Check{
+		Name: fmt.Sprintf("service account '%s' exists in namespace '%s' with cluster-admin role", E2EServiceAccountName, namespace),
+		Hint: fmt.Sprintf("the e2e tests create them when logged in with a user who is allowed to, otherwise run "+
+			"`oc create sa %[1]s -n %[2]s && oc create clusterrolebinding %[3]s --clusterrole=cluster-admin --serviceaccount=%[2]s:%[1]s`",
+			E2EServiceAccountName, namespace, E2EClusterRoleBindingName),
+		Verify: func(ctx context.Context) error {
+			if err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: E2EServiceAccountName}, &corev1.ServiceAccount{}); err != nil {
+				return err
+			}
+			crb := &rbacv1.ClusterRoleBinding{}
+			if err := cl.Get(ctx, types.NamespacedName{Name: E2EClusterRoleBindingName}, crb); err != nil {
+				return err
+			}
+			if crb.RoleRef.Name != "cluster-admin" {
+				return fmt.Errorf("cluster role binding '%s' refers to role '%s'", E2EClusterRoleBindingName, crb.RoleRef.Name)
+			}
+			for _, s := range crb.Subjects {
+				if s.Kind == rbacv1.ServiceAccountKind && s.Namespace == namespace && s.Name == E2EServiceAccountName {
+					return nil
+				}
+			}
+			return fmt.Errorf("cluster role binding '%s' does not refer to service account '%s'", E2EClusterRoleBindingName, E2EServiceAccountName)
+		},
+	}
+}
+
+// DeploymentReady checks that the given deployment is ready with the given number of replicas
+func DeploymentReady(cl client.Client, namespace, name string, replicas int) Check {
+	return Check{
+		Name: fmt.Sprintf("deployment '%s' is ready in namespace '%s'", name, namespace),
+		Hint: fmt.Sprintf("check the status and the logs of the deployment with `oc describe deployment %[1]s -n %[2]s` and `oc logs deployment/%[1]s -n %[2]s`, or redeploy with `make deploy-e2e`", name, namespace),
+		Verify: func(ctx context.Context) error {
+			_, err := wait.CheckDeploymentReady(ctx, cl, namespace, name, replicas)
+			return err
+		},
+	}
+}
+
+// RouteAvailable checks that the given route is available and that its endpoint is reachable
+func RouteAvailable(cl client.Client, bearerToken, namespace, name, endpoint string) Check {
+	return Check{
+		Name: fmt.Sprintf("route '%s' is available in namespace '%s'", name, namespace),
+		Hint: fmt.Sprintf("check the route with `oc get route %s -n %s -o yaml` and the status of the registration service", name, namespace),
+		Verify: func(ctx context.Context) error {
+			_, err := wait.CheckRouteAvailable(ctx, cl, bearerToken, namespace, name, endpoint)
+			return err
+		},
+	}
+}
+
+// ToolchainClusterReady checks that there is a ready ToolchainCluster in the given namespace which represents
+// the operator running in the given operator namespace
+func ToolchainClusterReady(cl client.Client, namespace, operatorNamespace string) Check {
+	return Check{
+		Name: fmt.Sprintf("ToolchainCluster of operator in namespace '%s' is ready in namespace '%s'", operatorNamespace, namespace),
+		Hint: fmt.Sprintf("check the conditions with `oc get toolchainclusters -n %s -o yaml`, or register the clusters again with `make setup-toolchainclusters`", namespace),
+		Verify: func(ctx context.Context) error {
+			tc, err := wait.FindToolchainCluster(ctx, cl, namespace, operatorNamespace)
+			if err != nil {
+				return err
+			}
+			if tc == nil {
+				return fmt.Errorf("no ToolchainCluster with operator namespace '%s'", operatorNamespace)
+			}
+			if !condition.IsTrue(tc.Status.Conditions, toolchainv1alpha1.ConditionReady) {
+				if c, found := condition.FindConditionByType(tc.Status.Conditions, toolchainv1alpha1.ConditionReady); found {
+					return fmt.Errorf("ToolchainCluster '%s' is not ready: %s", tc.Name, strings.TrimSpace(c.Reason+" "+c.Message))
+				}
+				return fmt.Errorf("ToolchainCluster '%s' has no ready condition", tc.Name)
+			}
+			return nil
+		},
+	}
+}
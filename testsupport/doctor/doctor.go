@@ -0,0 +1,79 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Check a prerequisite of the e2e tests or of the setup tool
+type Check struct {
+	// Name the description of the prerequisite
+	Name string
+	// Hint the remediation to apply when the prerequisite is not met
+	Hint string
+	// Verify returns an error if the prerequisite is not met
+	Verify func(ctx context.Context) error
+}
+
+// Result the result of a Check
+type Result struct {
+	Check
+	Err error
+}
+
+// Passed returns `true` if the prerequisite is met
+func (r Result) Passed() bool {
+	return r.Err == nil
+}
+
+// Report the results of all the checks, in the order in which they were run
+type Report []Result
+
+// Run runs all the given checks and returns their results
+func Run(ctx context.Context, checks ...Check) Report {
+	report := make(Report, 0, len(checks))
+	for _, c := range checks {
+		report = append(report, Result{
+			Check: c,
+			Err:   c.Verify(ctx),
+		})
+	}
+	return report
+}
+
+// Passed returns `true` if all the prerequisites are met
+func (r Report) Passed() bool {
+	for _, result := range r {
+		if !result.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Failures returns the number of prerequisites which are not met
+func (r Report) Failures() int {
+	failures := 0
+	for _, result := range r {
+		if !result.Passed() {
+			failures++
+		}
+	}
+	return failures
+}
+
+// Print prints the checklist in the given writer, with the remediation hint of each prerequisite which is not met
+func (r Report) Print(out io.Writer) {
+	for _, result := range r {
+		if result.Passed() {
+			fmt.Fprintf(out, "✅ %s\n", result.Name)
+			continue
+		}
+		fmt.Fprintf(out, "❌ %s: %s\n", result.Name, result.Err.Error())
+		if result.Hint != "" {
+			fmt.Fprintf(out, "   👉 %s\n", result.Hint)
+		}
+	}
+	fmt.Fprintf(out, "\n%d check(s), %d failure(s)\n", len(r), r.Failures())
+}
@@ -0,0 +1,424 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReport(t *testing.T) {
+	// given
+	passing := Check{
+		Name: "passing check",
+		Hint: "nothing to do",
+		Verify: func(_ context.Context) error {
+			return nil
+		},
+	}
+	failing := Check{
+		Name: "failing check",
+		Hint: "fix it",
+		Verify: func(_ context.Context) error {
+			return errors.New("mock error")
+		},
+	}
+
+	t.Run("all checks pass", func(t *testing.T) {
+		// when
+		report := Run(context.TODO(), passing, passing)
+
+		// then
+		assert.True(t, report.Passed())
+		assert.Zero(t, report.Failures())
+	})
+
+	t.Run("some checks fail", func(t *testing.T) {
+		// when
+		report := Run(context.TODO(), passing, failing)
+
+		// then
+		assert.False(t, report.Passed())
+		assert.Equal(t, 1, report.Failures())
+		out := &bytes.Buffer{}
+		report.Print(out)
+		assert.Equal(t, "✅ passing check\n"+
+			"❌ failing check: mock error\n"+
+			"   👉 fix it\n"+
+			"\n2 check(s), 1 failure(s)\n", out.String())
+	})
+}
+
+func TestNamespaceHasLabel(t *testing.T) {
+	t.Run("label is set", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, namespace("toolchain-host-operator", map[string]string{"app": "host-operator"}))
+
+		// when
+		err := NamespaceHasLabel(cl, "toolchain-host-operator", "app", "host-operator").Verify(context.TODO())
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("label is missing", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, namespace("toolchain-host-operator", nil))
+
+		// when
+		err := NamespaceHasLabel(cl, "toolchain-host-operator", "app", "host-operator").Verify(context.TODO())
+
+		// then
+		require.EqualError(t, err, "label 'app' is missing")
+	})
+
+	t.Run("label has another value", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, namespace("toolchain-host-operator", map[string]string{"app": "member-operator"}))
+
+		// when
+		err := NamespaceHasLabel(cl, "toolchain-host-operator", "app", "host-operator").Verify(context.TODO())
+
+		// then
+		require.EqualError(t, err, "label 'app' has value 'member-operator'")
+	})
+
+	t.Run("namespace is missing", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t)
+
+		// when
+		err := NamespaceHasLabel(cl, "toolchain-host-operator", "app", "host-operator").Verify(context.TODO())
+
+		// then
+		require.EqualError(t, err, `namespaces "toolchain-host-operator" not found`)
+	})
+
+	t.Run("OLM namespace is missing", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t)
+
+		// when
+		err := OLMNamespaceHasLabel(cl).Verify(context.TODO())
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func TestCRDsInstalled(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "example.com_foos.yaml"), []byte(`---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: foos.example.com
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "example.com_bars.yaml"), []byte(`---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: bars.example.com
+`), 0600))
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	crd.SetName("foos.example.com")
+	cl := newFakeClient(t, crd)
+
+	// when
+	report := Run(context.TODO(), CRDsInstalled(cl, dir)...)
+
+	// then
+	require.Len(t, report, 2)
+	assert.Equal(t, "CRD 'example.com_bars' is installed", report[0].Name)
+	require.EqualError(t, report[0].Err, "CRD 'bars.example.com' not found")
+	assert.Equal(t, "CRD 'example.com_foos' is installed", report[1].Name)
+	require.NoError(t, report[1].Err)
+
+	t.Run("no CRD in directory", func(t *testing.T) {
+		// when
+		report := Run(context.TODO(), CRDsInstalled(cl, t.TempDir())...)
+
+		// then
+		require.Len(t, report, 1)
+		require.ErrorContains(t, report[0].Err, "no CRD found in")
+	})
+}
+
+func TestE2EServiceAccountExists(t *testing.T) {
+	t.Run("service account and cluster role binding exist", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, e2eServiceAccount("toolchain-host-operator"), e2eClusterRoleBinding("toolchain-host-operator"))
+
+		// when
+		err := E2EServiceAccountExists(cl, "toolchain-host-operator").Verify(context.TODO())
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("service account is missing", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, e2eClusterRoleBinding("toolchain-host-operator"))
+
+		// when
+		err := E2EServiceAccountExists(cl, "toolchain-host-operator").Verify(context.TODO())
+
+		// then
+		require.EqualError(t, err, `serviceaccounts "e2e-test" not found`)
+	})
+
+	t.Run("cluster role binding refers to a service account in another namespace", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, e2eServiceAccount("toolchain-host-operator"), e2eClusterRoleBinding("toolchain-host-other"))
+
+		// when
+		err := E2EServiceAccountExists(cl, "toolchain-host-operator").Verify(context.TODO())
+
+		// then
+		require.EqualError(t, err, "cluster role binding 'e2e-test-cluster-admin' does not refer to service account 'e2e-test'")
+	})
+}
+
+func TestToolchainClusterReady(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, toolchainCluster("member-1", "toolchain-host-operator", "toolchain-member-operator", corev1.ConditionTrue))
+
+		// when
+		err := ToolchainClusterReady(cl, "toolchain-host-operator", "toolchain-member-operator").Verify(context.TODO())
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, toolchainCluster("member-1", "toolchain-host-operator", "toolchain-member-operator", corev1.ConditionFalse))
+
+		// when
+		err := ToolchainClusterReady(cl, "toolchain-host-operator", "toolchain-member-operator").Verify(context.TODO())
+
+		// then
+		require.EqualError(t, err, "ToolchainCluster 'member-1' is not ready: ClusterNotReachable")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		// given
+		cl := newFakeClient(t, toolchainCluster("member-1", "toolchain-host-operator", "toolchain-member-other", corev1.ConditionTrue))
+
+		// when
+		err := ToolchainClusterReady(cl, "toolchain-host-operator", "toolchain-member-operator").Verify(context.TODO())
+
+		// then
+		require.EqualError(t, err, "no ToolchainCluster with operator namespace 'toolchain-member-operator'")
+	})
+}
+
+func TestChecks(t *testing.T) {
+	t.Run("with members", func(t *testing.T) {
+		// given
+		env := Environment{
+			Client:                       newFakeClient(t),
+			HostNamespace:                "toolchain-host-operator",
+			RegistrationServiceNamespace: "toolchain-host-operator",
+			Members: []testsupport.MemberLocation{
+				{Namespace: "toolchain-member-operator"},
+				{Namespace: "toolchain-member-operator-2"},
+			},
+			CRDsDir: "../../deploy/crds",
+		}
+
+		// when
+		e2eChecks := E2EChecks(context.TODO(), env)
+		operatorChecks := OperatorChecks(context.TODO(), env)
+
+		// then
+		crds, err := filepath.Glob("../../deploy/crds/*.yaml")
+		require.NoError(t, err)
+		// 2 deployments, 2 routes, and 1 deployment and 2 ToolchainClusters per member
+		assert.Len(t, operatorChecks, 2+2+3*2)
+		// 2 namespace checks, the CRDs, the service account, and 1 namespace check per member, in addition to the operator checks
+		assert.Len(t, e2eChecks, 2+len(crds)+1+1*2+len(operatorChecks))
+	})
+
+	t.Run("with an unusable kubeconfig context", func(t *testing.T) {
+		// given
+		env := Environment{
+			Client:                       newFakeClient(t),
+			HostNamespace:                "toolchain-host-operator",
+			RegistrationServiceNamespace: "toolchain-host-operator",
+			Members: []testsupport.MemberLocation{
+				{Namespace: "toolchain-member-operator", Context: "unknown"},
+				{Namespace: "toolchain-member-operator-2"},
+			},
+			CRDsDir: "../../deploy/crds",
+		}
+
+		// when
+		e2eChecks := E2EChecks(context.TODO(), env)
+
+		// then the other member and the operators are still checked
+		crds, err := filepath.Glob("../../deploy/crds/*.yaml")
+		require.NoError(t, err)
+		operatorChecks := OperatorChecks(context.TODO(), env)
+		// 2 deployments, 2 routes, the kubeconfig context of the first member, and 1 deployment and 2 ToolchainClusters for the second member
+		assert.Len(t, operatorChecks, 2+2+1+3)
+		// 2 namespace checks, the CRDs, the service account, the kubeconfig context of the first member and 1 namespace
+		// check for the second member, in addition to the operator checks (but the kubeconfig context which is only reported once)
+		require.Len(t, e2eChecks, 2+len(crds)+1+1+1+len(operatorChecks)-1)
+		names := make([]string, 0, len(e2eChecks))
+		for _, c := range e2eChecks {
+			names = append(names, c.Name)
+		}
+		assert.Equal(t, 1, countOf(names, "kubeconfig context 'unknown' of member operator in namespace 'toolchain-member-operator' is usable"))
+		assert.Contains(t, names, "namespace 'toolchain-member-operator-2' exists with label 'app=member-operator'")
+	})
+
+	t.Run("without any member", func(t *testing.T) {
+		// given
+		t.Setenv("TOPOLOGY_FILE", "")
+		t.Setenv("MEMBER_NS", "")
+		env := Environment{
+			Client:                       newFakeClient(t),
+			HostNamespace:                "toolchain-host-operator",
+			RegistrationServiceNamespace: "toolchain-host-operator",
+			CRDsDir:                      "../../deploy/crds",
+		}
+
+		// when
+		report := Run(context.TODO(), OperatorChecks(context.TODO(), env)...)
+
+		// then
+		last := report[len(report)-1]
+		assert.Equal(t, "member operators are located", last.Name)
+		require.EqualError(t, last.Err, "no ToolchainCluster with an operator namespace found in namespace 'toolchain-host-operator'")
+
+		t.Run("member lookup failure is reported once", func(t *testing.T) {
+			// when
+			e2eChecks := E2EChecks(context.TODO(), env)
+
+			// then
+			names := make([]string, 0, len(e2eChecks))
+			for _, c := range e2eChecks {
+				names = append(names, c.Name)
+			}
+			assert.Equal(t, 1, countOf(names, "member operators are located"))
+			assert.Contains(t, names, "deployment 'host-operator-controller-manager' is ready in namespace 'toolchain-host-operator'")
+		})
+	})
+
+	t.Run("with a discovered member which is not ready", func(t *testing.T) {
+		// given
+		t.Setenv("TOPOLOGY_FILE", "")
+		t.Setenv("MEMBER_NS", "")
+		env := Environment{
+			Client:                       newFakeClient(t, toolchainCluster("member-1", "toolchain-host-operator", "toolchain-member-operator", corev1.ConditionFalse)),
+			HostNamespace:                "toolchain-host-operator",
+			RegistrationServiceNamespace: "toolchain-host-operator",
+			CRDsDir:                      "../../deploy/crds",
+		}
+
+		// when
+		report := Run(context.TODO(), OperatorChecks(context.TODO(), env)...)
+
+		// then the readiness of the ToolchainCluster is reported by its own check
+		var found bool
+		for _, r := range report {
+			assert.NotEqual(t, "member operators are located", r.Name)
+			if r.Name == "ToolchainCluster of operator in namespace 'toolchain-member-operator' is ready in namespace 'toolchain-host-operator'" {
+				found = true
+				require.EqualError(t, r.Err, "ToolchainCluster 'member-1' is not ready: ClusterNotReachable")
+			}
+		}
+		assert.True(t, found, "no check of the readiness of the ToolchainCluster")
+	})
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	s, err := testsupport.NewSchemeWithAllAPIs()
+	require.NoError(t, err)
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+}
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
+
+func e2eServiceAccount(namespace string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      E2EServiceAccountName,
+		},
+	}
+}
+
+func e2eClusterRoleBinding(namespace string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: E2EClusterRoleBindingName,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      E2EServiceAccountName,
+				Namespace: namespace,
+			},
+		},
+	}
+}
+
+func toolchainCluster(name, namespace, operatorNamespace string, ready corev1.ConditionStatus) *toolchainv1alpha1.ToolchainCluster {
+	c := toolchainv1alpha1.Condition{
+		Type:   toolchainv1alpha1.ConditionReady,
+		Status: ready,
+	}
+	if ready != corev1.ConditionTrue {
+		c.Reason = toolchainv1alpha1.ToolchainClusterClusterNotReachableReason
+	}
+	return &toolchainv1alpha1.ToolchainCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Status: toolchainv1alpha1.ToolchainClusterStatus{
+			OperatorNamespace: operatorNamespace,
+			Conditions:        []toolchainv1alpha1.Condition{c},
+		},
+	}
+}
+
+func countOf(names []string, name string) int {
+	count := 0
+	for _, n := range names {
+		if n == name {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,138 @@
+package testsupport
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/mail"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notificationEmail what is expected in the email of a given type of notification
+type notificationEmail struct {
+	// subject a (case-insensitive) part of the subject of the email
+	subject string
+	// contextKeys the keys of the notification context whose values are rendered in the body of the email
+	contextKeys []string
+	// linkKeys the keys of the notification context whose values are the targets of links in the body of the email
+	linkKeys []string
+}
+
+var notificationEmails = map[string]notificationEmail{
+	toolchainv1alpha1.NotificationTypeProvisioned: {
+		subject:     "provisioned",
+		contextKeys: []string{"FirstName"},
+		linkKeys:    []string{"RegistrationURL"},
+	},
+	toolchainv1alpha1.NotificationTypeDeactivating: {
+		subject:     "deactivat",
+		contextKeys: []string{"FirstName"},
+		linkKeys:    []string{"RegistrationURL"},
+	},
+	toolchainv1alpha1.NotificationTypeDeactivated: {
+		subject:     "deactivated",
+		contextKeys: []string{"FirstName"},
+		linkKeys:    []string{"RegistrationURL"},
+	},
+	toolchainv1alpha1.NotificationTypeIdled: {
+		subject:     "idled",
+		contextKeys: []string{"Namespace"},
+	},
+}
+
+// FakeMailDelivery returns a client of the fake mail delivery service which is deployed next to the host operator
+// (see `make deploy-fake-mail`), or `nil` if there is none, in which case the notifications are sent via the real
+// delivery service and their content cannot be verified by the tests.
+func FakeMailDelivery(t *testing.T, hostAwait *wait.HostAwaitility) *mail.Client {
+	serviceURL := fakeServiceURL(t, hostAwait, hostAwait.Namespace, "fake-mail")
+	if serviceURL == "" {
+		return nil
+	}
+	return mail.NewClient(serviceURL)
+}
+
+// VerifyNotificationEmail verifies the email which was delivered for the given (sent) notification, if the fake mail
+// delivery service is deployed: the recipient, the subject, and the values of the notification context which are rendered
+// in the body of the email (as text or as links). Returns the email, or `nil` if the fake mail delivery service is not deployed.
+func VerifyNotificationEmail(t *testing.T, hostAwait *wait.HostAwaitility, notification toolchainv1alpha1.Notification) *mail.Message {
+	client := FakeMailDelivery(t, hostAwait)
+	if client == nil {
+		return nil
+	}
+	notificationType := notification.Labels[toolchainv1alpha1.NotificationTypeLabelKey]
+	recipient := notification.Spec.Recipient
+	if recipient == "" {
+		recipient = notification.Spec.Context["UserEmail"]
+	}
+	require.NotEmpty(t, recipient, "no recipient for the notification '%s'", notification.Name)
+	sent, found := condition.FindConditionByType(notification.Status.Conditions, toolchainv1alpha1.NotificationSent)
+	require.True(t, found, "the notification '%s' was not sent", notification.Name)
+
+	// the email was delivered right before the notification was marked as sent (allowing for some clock skew)
+	return verifyEmail(t, client, notificationType, recipient, sent.LastTransitionTime.Add(-time.Minute), notification.Spec.Context)
+}
+
+// VerifyProvisionedEmail verifies the email which was delivered when the given UserSignup was provisioned, if the fake
+// mail delivery service is deployed. Since the `provisioned` Notification is deleted shortly after it was sent, the email
+// is verified against the UserSignup. Returns the email, or `nil` if the fake mail delivery service is not deployed.
+func VerifyProvisionedEmail(t *testing.T, hostAwait *wait.HostAwaitility, userSignup *toolchainv1alpha1.UserSignup) *mail.Message {
+	client := FakeMailDelivery(t, hostAwait)
+	if client == nil {
+		return nil
+	}
+	// the email was delivered after the UserSignup was created (allowing for some clock skew)
+	return verifyEmail(t, client, toolchainv1alpha1.NotificationTypeProvisioned, userSignup.Spec.IdentityClaims.Email,
+		userSignup.CreationTimestamp.Add(-time.Minute), map[string]string{
+			"FirstName": userSignup.Spec.IdentityClaims.GivenName,
+			"UserEmail": userSignup.Spec.IdentityClaims.Email,
+		})
+}
+
+// verifyEmail waits for the email of the given type of notification which was delivered to the given recipient after the
+// given time, and verifies that the values of the given notification context are rendered in its body (as text or as links)
+func verifyEmail(t *testing.T, client *mail.Client, notificationType, recipient string, after time.Time, notificationContext map[string]string) *mail.Message {
+	expected, ok := notificationEmails[notificationType]
+	require.True(t, ok, "no expectation for the emails of the '%s' notifications", notificationType)
+	msg, err := client.WaitForMessage(t, recipient, after, func(msg mail.Message) bool {
+		return strings.Contains(strings.ToLower(msg.Subject), expected.subject)
+	})
+	require.NoError(t, err, "no '%s' email was delivered to '%s'", notificationType, recipient)
+
+	body := msg.Body()
+	for _, key := range expected.contextKeys {
+		if value := notificationContext[key]; value != "" {
+			assert.Contains(t, body, value, "the '%s' of the notification context is not rendered in the email", key)
+		}
+	}
+	links := msg.Links()
+	for _, key := range expected.linkKeys {
+		if value := notificationContext[key]; value != "" {
+			assert.True(t, containsLinkTo(links, value), "no link to the '%s' of the notification context (%s) in the email: %v", key, value, links)
+		}
+	}
+	for _, link := range links {
+		if strings.HasPrefix(link, "mailto:") {
+			continue
+		}
+		target, err := url.Parse(link)
+		if assert.NoError(t, err, "invalid link in the email") {
+			assert.True(t, target.IsAbs(), "the link '%s' in the email is not absolute", link)
+		}
+	}
+	return msg
+}
+
+func containsLinkTo(links []string, target string) bool {
+	for _, link := range links {
+		if strings.HasPrefix(link, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package testsupport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeServiceURL returns the URL of the route of the fake service with the given name in the given namespace, once the
+// service is available, or an empty string if the service is not deployed
+func fakeServiceURL(t *testing.T, hostAwait *wait.HostAwaitility, namespace, name string) string {
+	route := &routev1.Route{}
+	if err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, route); err != nil {
+		require.True(t, apierrors.IsNotFound(err), "failed to get the route of the '%s' service: %s", name, err)
+		t.Logf("the '%s' service is not deployed", name)
+		return ""
+	}
+	available, err := hostAwait.WaitForRouteToBeAvailable(t, namespace, route.Name, "/healthz")
+	require.NoError(t, err, "failed while waiting for the route of the '%s' service", name)
+	if available.Spec.TLS != nil {
+		return "https://" + available.Spec.Host
+	}
+	return "http://" + available.Spec.Host
+}
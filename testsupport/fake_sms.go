@@ -0,0 +1,19 @@
+package testsupport
+
+import (
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/sms"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+)
+
+// FakeSMSProvider returns a client of the fake SMS provider which is deployed next to the registration service
+// (see `make deploy-fake-sms`), or `nil` if there is none, in which case the messages are sent via the real provider
+// and cannot be retrieved by the tests.
+func FakeSMSProvider(t *testing.T, hostAwait *wait.HostAwaitility) *sms.Client {
+	serviceURL := fakeServiceURL(t, hostAwait, hostAwait.RegistrationServiceNs, "fake-sms")
+	if serviceURL == "" {
+		return nil
+	}
+	return sms.NewClient(serviceURL)
+}
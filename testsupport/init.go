@@ -44,9 +44,10 @@ import (
 
 var (
 	initHostAwait    *wait.HostAwaitility
-	initMemberAwait  *wait.MemberAwaitility
-	initMember2Await *wait.MemberAwaitility
-	initOnce         sync.Once
+	initMemberAwaits []*wait.MemberAwaitility
+	// the locations of the member operators, in the same order as the member awaitilities
+	initMemberLocations []MemberLocation
+	initOnce            sync.Once
 )
 
 // WaitForOperators initializes test context, registers schemes and waits until both operators (host, member)
@@ -57,18 +58,12 @@ func WaitForOperators(t *testing.T) wait.Awaitilities {
 	initOnce.Do(func() {
 		waitForOperators(t)
 	})
-	return wait.NewAwaitilities(initHostAwait, initMemberAwait, initMember2Await)
+	return wait.NewAwaitilities(initHostAwait, initMemberAwaits...)
 }
 func waitForOperators(t *testing.T) {
-	memberNs := os.Getenv(wait.MemberNsVar)
-	memberNs2 := os.Getenv(wait.MemberNsVar2)
 	hostNs := os.Getenv(wait.HostNsVar)
 	registrationServiceNs := os.Getenv(wait.RegistrationServiceVar)
 	t.Logf("Host Operator namespace: %s", hostNs)
-	t.Logf("Member1 Operator namespace: %s", memberNs)
-	if IsSecondMemberMode(t) {
-		t.Logf("Member2 Operator namespace: %s", memberNs2)
-	}
 	t.Logf("Registration Service namespace: %s", registrationServiceNs)
 
 	apiConfig, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
@@ -78,12 +73,12 @@ func waitForOperators(t *testing.T) {
 	require.NoError(t, err)
 
 	cl, err := client.New(kubeconfig, client.Options{
-		Scheme: schemeWithAllAPIs(t),
+		Scheme: SchemeWithAllAPIs(t),
 	})
 	require.NoError(t, err)
 
 	//updating the kubeconfig with the bearer token created
-	kubeconfig.BearerToken = getE2EServiceAccountToken(t, hostNs, apiConfig, cl)
+	kubeconfig.BearerToken = getE2EServiceAccountToken(t, hostNs, kubeconfig, cl)
 
 	initHostAwait = wait.NewHostAwaitility(kubeconfig, cl, hostNs, registrationServiceNs)
 
@@ -104,40 +99,71 @@ func waitForOperators(t *testing.T) {
 	initHostAwait.RegistrationServiceURL = registrationServiceURL
 
 	// wait for member operators to be ready
-	initMemberAwait = getMemberAwaitility(t, initHostAwait, kubeconfig, memberNs)
-
-	_, err = initMemberAwait.WaitForToolchainClusterWithCondition(t, initHostAwait.Namespace, toolchainv1alpha1.ConditionReady)
-	require.NoError(t, err)
-
-	if IsSecondMemberMode(t) {
-		initMember2Await = getMemberAwaitility(t, initHostAwait, kubeconfig, memberNs2)
+	initMemberAwaits = nil
+	initMemberLocations = memberLocations(t, initHostAwait)
+	for i, location := range initMemberLocations {
+		t.Logf("Member%d Operator namespace: %s (context: '%s')", i+1, location.Namespace, location.Context)
+		memberKubeconfig := kubeconfig
+		if location.Context != "" {
+			memberKubeconfig = getKubeconfigForContext(t, apiConfig, location)
+		}
+		memberAwait := getMemberAwaitility(t, initHostAwait, memberKubeconfig, location.Namespace)
 
-		_, err = initMember2Await.WaitForToolchainClusterWithCondition(t, initHostAwait.Namespace, toolchainv1alpha1.ConditionReady)
+		_, err = memberAwait.WaitForToolchainClusterWithCondition(t, initHostAwait.Namespace, toolchainv1alpha1.ConditionReady)
 		require.NoError(t, err)
+		initMemberAwaits = append(initMemberAwaits, memberAwait)
 	}
 	t.Log("all operators are ready and in running state")
 }
 
-func getE2EServiceAccountToken(t *testing.T, hostNs string, apiConfigsa *api.Config, sacl client.Client) string {
+// getKubeconfigForContext returns the config to access the cluster of the given kubeconfig context, authenticated
+// with the token of the e2e service account created in the namespace of the member operator
+func getKubeconfigForContext(t *testing.T, apiConfig *api.Config, location MemberLocation) *rest.Config {
+	contextConfig := apiConfig.DeepCopy()
+	_, found := contextConfig.Contexts[location.Context]
+	require.True(t, found, "kubeconfig context '%s' not found", location.Context)
+	contextConfig.CurrentContext = location.Context
+
+	kubeconfig, err := util.BuildKubernetesRESTConfig(*contextConfig)
+	require.NoError(t, err)
+
+	cl, err := client.New(kubeconfig, client.Options{
+		Scheme: SchemeWithAllAPIs(t),
+	})
+	require.NoError(t, err)
+
+	kubeconfig.BearerToken = getE2EServiceAccountToken(t, location.Namespace, kubeconfig, cl)
+	return kubeconfig
+}
+
+// getE2EServiceAccountToken returns a token of the e2e service account in the given namespace of the cluster which is
+// accessed with the given config and client, after creating the service account and binding it to the `cluster-admin`
+// role if needed. The given config and client must target the same cluster, so that the service account, its cluster
+// role binding and its token are all in the cluster under test (and not in the cluster of the current kubeconfig context).
+func getE2EServiceAccountToken(t *testing.T, namespace string, kubeconfig *rest.Config, sacl client.Client) string {
 	// creating another config which is used for creating only resclient,
 	//so that the main kubeconfig is not altered
-	restkubeconfig, err := util.BuildKubernetesRESTConfig(*apiConfigsa)
-	require.NoError(t, err)
+	restkubeconfig := rest.CopyConfig(kubeconfig)
 
 	sa := &corev1.ServiceAccount{}
-	err = sacl.Get(context.TODO(), types.NamespacedName{Namespace: hostNs, Name: "e2e-test"}, sa)
+	err := sacl.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "e2e-test"}, sa)
 	// If not found proceed to create the e2e service account and the cluster role binding
 	if errors.IsNotFound(err) {
 		t.Logf("No Service Account for e2e test found, proceeding to create it")
 		err := sacl.Create(context.TODO(), &corev1.ServiceAccount{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "e2e-test",
-				Namespace: hostNs}})
+				Namespace: namespace}})
 		require.NoError(t, err, "Error in creating Service account for e2e test")
 	} else if err != nil {
 		require.NoError(t, err, "Error fetching service accounts")
 	}
 
+	subject := rbacv1.Subject{
+		Kind:      "ServiceAccount",
+		Name:      "e2e-test",
+		Namespace: namespace,
+	}
 	sacrb := &rbacv1.ClusterRoleBinding{}
 	err = sacl.Get(context.TODO(), types.NamespacedName{Name: "e2e-test-cluster-admin"}, sacrb)
 	// check if there are any clusterrolebinding present from the previous run of e2e test
@@ -151,19 +177,20 @@ func getE2EServiceAccountToken(t *testing.T, hostNs string, apiConfigsa *api.Con
 				Kind:     "ClusterRole",
 				Name:     "cluster-admin",
 			},
-			Subjects: []rbacv1.Subject{
-				{
-					Kind:      "ServiceAccount",
-					Name:      "e2e-test",
-					Namespace: hostNs,
-				},
-			},
+			Subjects: []rbacv1.Subject{subject},
 		}
 		t.Logf("Proceeding to create Cluster Role Binding for the Service Account")
 		err = sacl.Create(context.TODO(), &crb)
 		require.NoError(t, err, "Error in Creating Cluster role binding")
 	} else if err != nil {
 		require.NoError(t, err, "Error fetching clusterrolebinding")
+	} else if !hasSubject(sacrb, subject) {
+		// the cluster role binding was created for the service account of another namespace of the same cluster
+		// (eg, the host and a member operator share the cluster but are accessed via different contexts)
+		t.Logf("Proceeding to add the Service Account in namespace '%s' to the Cluster Role Binding", namespace)
+		sacrb.Subjects = append(sacrb.Subjects, subject)
+		err = sacl.Update(context.TODO(), sacrb)
+		require.NoError(t, err, "Error in updating Cluster role binding")
 	}
 
 	//upating the restkubeconfig ,which requires groupversion to create restclient
@@ -178,11 +205,20 @@ func getE2EServiceAccountToken(t *testing.T, hostNs string, apiConfigsa *api.Con
 	require.NoError(t, err, "Error in creating restclient")
 
 	//Creating a bearer token to be used for authentication(which is valid for 24 hrs)
-	bt, err := toolchaincommon.CreateTokenRequest(context.TODO(), rclient, types.NamespacedName{Namespace: hostNs, Name: "e2e-test"}, 86400)
+	bt, err := toolchaincommon.CreateTokenRequest(context.TODO(), rclient, types.NamespacedName{Namespace: namespace, Name: "e2e-test"}, 86400)
 	require.NoError(t, err, "Error in creating Token")
 	return bt
 }
 
+func hasSubject(crb *rbacv1.ClusterRoleBinding, subject rbacv1.Subject) bool {
+	for _, s := range crb.Subjects {
+		if s.Kind == subject.Kind && s.Name == subject.Name && s.Namespace == subject.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
 // WaitForDeployments waits for all member Webhooks and autoscaling buffer apps in addition to waiting for
 // host and member operators and the registration service to be ready.
 //
@@ -210,25 +246,29 @@ func WaitForDeployments(t *testing.T) wait.Awaitilities {
 		initHostAwait.MetricsURL = hostMetricsRoute.Status.Ingress[0].Host
 
 		// setup member metrics route for metrics verification in tests
-		memberMetricsRoute, err := initMemberAwait.SetupRouteForService(t, "member-operator-metrics-service", "/metrics")
+		memberMetricsRoute, err := initMemberAwaits[0].SetupRouteForService(t, "member-operator-metrics-service", "/metrics")
 		require.NoError(t, err, "failed while setting up or waiting for the route to the 'member-operator-metrics' service to be available")
-		initMemberAwait.MetricsURL = memberMetricsRoute.Status.Ingress[0].Host
+		initMemberAwaits[0].MetricsURL = memberMetricsRoute.Status.Ingress[0].Host
 
-		// Wait for the webhooks in Member 1 only because we do not deploy webhooks for Member 2
-		// (we can't deploy the same webhook multiple times on the same cluster)
-		// Also verify the autoscaling buffer in both members
-		webhookImage := initMemberAwait.GetContainerEnv(t, "MEMBER_OPERATOR_WEBHOOK_IMAGE")
+		// Wait for the webhooks in Member 1 only because we do not deploy webhooks for the other members running
+		// in the same cluster (we can't deploy the same webhook multiple times on the same cluster)
+		// Also verify the autoscaling buffer in all members
+		webhookImage := initMemberAwaits[0].GetContainerEnv(t, "MEMBER_OPERATOR_WEBHOOK_IMAGE")
 		require.NotEmpty(t, webhookImage, "The value of the env var MEMBER_OPERATOR_WEBHOOK_IMAGE wasn't found in the deployment of the member operator.")
-		if IsSecondMemberMode(t) {
-			err = initMember2Await.WaitUntilWebhookDeleted(t) // webhook on member2 should be deleted
+		for i, memberAwait := range initMemberAwaits[1:] {
+			if initMemberLocations[i+1].Context != initMemberLocations[0].Context {
+				// a member running in another cluster has its own webhooks
+				memberAwait.WaitForMemberWebhooks(t, memberAwait.GetContainerEnv(t, "MEMBER_OPERATOR_WEBHOOK_IMAGE"))
+				continue
+			}
+			err = memberAwait.WaitUntilWebhookDeleted(t) // webhook on the other members of the same cluster should be deleted
 			require.NoError(t, err)
 		}
-		initMemberAwait.WaitForMemberWebhooks(t, webhookImage)
+		initMemberAwaits[0].WaitForMemberWebhooks(t, webhookImage)
 
 		// wait for autoscaler buffer apps
-		initMemberAwait.WaitForAutoscalingBufferApp(t)
-		if IsSecondMemberMode(t) {
-			initMember2Await.WaitForAutoscalingBufferApp(t)
+		for _, memberAwait := range initMemberAwaits {
+			memberAwait.WaitForAutoscalingBufferApp(t)
 		}
 
 		// check that the tier exists, and all its namespace other cluster-scoped resource revisions
@@ -248,16 +288,12 @@ func WaitForDeployments(t *testing.T) wait.Awaitilities {
 		require.NoError(t, err)
 	})
 
-	if !IsSecondMemberMode(t) {
-		return wait.NewAwaitilities(initHostAwait, initMemberAwait)
-	}
-
-	return wait.NewAwaitilities(initHostAwait, initMemberAwait, initMember2Await)
+	return wait.NewAwaitilities(initHostAwait, initMemberAwaits...)
 }
 
 func getMemberAwaitility(t *testing.T, hostAwait *wait.HostAwaitility, restconfig *rest.Config, namespace string) *wait.MemberAwaitility {
 	memberClient, err := client.New(restconfig, client.Options{
-		Scheme: schemeWithAllAPIs(t),
+		Scheme: SchemeWithAllAPIs(t),
 	})
 	require.NoError(t, err)
 
@@ -271,7 +307,15 @@ func getMemberAwaitility(t *testing.T, hostAwait *wait.HostAwaitility, restconfi
 	return memberAwait
 }
 
-func schemeWithAllAPIs(t *testing.T) *runtime.Scheme {
+// SchemeWithAllAPIs returns a scheme with all the APIs used by the toolchain operators and by the tests
+func SchemeWithAllAPIs(t *testing.T) *runtime.Scheme {
+	s, err := NewSchemeWithAllAPIs()
+	require.NoError(t, err)
+	return s
+}
+
+// NewSchemeWithAllAPIs returns a new scheme with all the APIs used by the toolchain operators and by the tests
+func NewSchemeWithAllAPIs() (*runtime.Scheme, error) {
 	s := runtime.NewScheme()
 	builder := append(runtime.SchemeBuilder{}, toolchainv1alpha1.AddToScheme,
 		userv1.Install,
@@ -291,8 +335,7 @@ func schemeWithAllAPIs(t *testing.T) *runtime.Scheme {
 		batchv1.AddToScheme,
 		operatorsv1alpha1.AddToScheme,
 	)
-	require.NoError(t, builder.AddToScheme(s))
-	return s
+	return s, builder.AddToScheme(s)
 }
 
 func IsSecondMemberMode(t *testing.T) bool {
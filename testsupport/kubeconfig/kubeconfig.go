@@ -52,3 +52,32 @@ func getCurrentCluster(kc *clientcmdapi.Config) *clientcmdapi.Cluster {
 func getCurrentAuth(kc *clientcmdapi.Config) *clientcmdapi.AuthInfo {
 	return kc.AuthInfos[kc.Contexts[kc.CurrentContext].AuthInfo]
 }
+
+// CertificateAuthority is a modifier to update the certificate authority data (PEM-encoded) of the current cluster in the kubeconfig.
+// Intended to be used with the ModifyKubeConfig function.
+func CertificateAuthority(data []byte) func(*clientcmdapi.Config) {
+	return func(kc *clientcmdapi.Config) {
+		c := getCurrentCluster(kc)
+		c.CertificateAuthority = ""
+		c.CertificateAuthorityData = data
+		c.InsecureSkipTLSVerify = false
+	}
+}
+
+// TLSServerName is a modifier to update the server name which is used to verify the certificate of the current cluster
+// in the kubeconfig, eg. when the API endpoint is a proxy in front of the cluster.
+// Intended to be used with the ModifyKubeConfig function.
+func TLSServerName(serverName string) func(*clientcmdapi.Config) {
+	return func(kc *clientcmdapi.Config) {
+		c := getCurrentCluster(kc)
+		c.TLSServerName = serverName
+	}
+}
+
+// CurrentServer returns the "Server" of the current context in the kubeconfig stored in the given secret
+func CurrentServer(t *testing.T, secret *corev1.Secret) string {
+	t.Helper()
+	kc, err := clientcmd.Load(secret.Data["kubeconfig"])
+	require.NoError(t, err)
+	return getCurrentCluster(kc).Server
+}
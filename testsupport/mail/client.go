@@ -0,0 +1,65 @@
+package mail
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/recorder"
+)
+
+// Client a client of the fake mail delivery service, to retrieve the messages which were sent by the host operator
+type Client struct {
+	*recorder.Client[Message]
+}
+
+// NewClient returns a new Client of the fake mail delivery service at the given URL
+func NewClient(url string) *Client {
+	return &Client{
+		Client: recorder.NewClient[Message](url),
+	}
+}
+
+// Messages returns all the messages which were sent to the given email address, in the order in which they were sent
+func (c *Client) Messages(recipient string) ([]Message, error) {
+	return c.List(url.Values{"recipient": {recipient}})
+}
+
+// WaitForMessage waits until a message matching the given predicate was sent to the given email address after the given
+// time, and returns the first one
+func (c *Client) WaitForMessage(t *testing.T, recipient string, after time.Time, matches func(Message) bool) (*Message, error) {
+	t.Logf("waiting for a message sent to '%s' after %s", recipient, after.Format(time.RFC3339))
+	msg, err := c.WaitFor(url.Values{"recipient": {recipient}}, func(messages []Message) *Message {
+		for i := range messages {
+			if messages[i].SentAt.After(after) && (matches == nil || matches(messages[i])) {
+				return &messages[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Logf("no matching message sent to '%s' after %s", recipient, after.Format(time.RFC3339))
+	}
+	return msg, err
+}
+
+var hrefRegexp = regexp.MustCompile(`(?i)href\s*=\s*["']([^"']+)["']`)
+
+// Body returns the HTML body of the message, or its text body if there is no HTML body
+func (m Message) Body() string {
+	if m.HTML != "" {
+		return m.HTML
+	}
+	return m.Text
+}
+
+// Links returns the targets of the links in the HTML body of the message, in the order in which they appear
+func (m Message) Links() []string {
+	var links []string
+	for _, match := range hrefRegexp.FindAllStringSubmatch(m.HTML, -1) {
+		links = append(links, html.UnescapeString(match[1]))
+	}
+	return links
+}
@@ -0,0 +1,74 @@
+package mail
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	// given
+	server := NewServer()
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	client := NewClient(httpServer.URL + "/")
+	sendTo := func(recipient, subject string) {
+		resp := send(server, "sandbox.example.com", url.Values{
+			"from":    {"noreply@sandbox.example.com"},
+			"to":      {recipient},
+			"subject": {subject},
+			"text":    {"hello"},
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+	}
+
+	t.Run("no message", func(t *testing.T) {
+		// when
+		messages, err := client.Messages("johnsmith@acme.com")
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("messages of a recipient", func(t *testing.T) {
+		// given
+		sendTo("johnsmith@acme.com", "provisioned")
+		sendTo("jane@acme.com", "provisioned")
+		sendTo("John Smith <johnsmith@acme.com>", "deactivated")
+
+		// when
+		messages, err := client.Messages("johnsmith@acme.com")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+		assert.Equal(t, "provisioned", messages[0].Subject)
+		assert.Equal(t, "deactivated", messages[1].Subject)
+	})
+
+	t.Run("wait for message", func(t *testing.T) {
+		// given
+		after := time.Now()
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			send(server, "sandbox.example.com", url.Values{"from": {"noreply@sandbox.example.com"}, "to": {"johnsmith@acme.com"}, "subject": {"other"}, "text": {"hello"}})
+			send(server, "sandbox.example.com", url.Values{"from": {"noreply@sandbox.example.com"}, "to": {"johnsmith@acme.com"}, "subject": {"idled"}, "text": {"hello"}})
+		}()
+
+		// when
+		msg, err := client.WaitForMessage(t, "johnsmith@acme.com", after, func(msg Message) bool {
+			return strings.Contains(msg.Subject, "idled")
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "idled", msg.Subject)
+	})
+}
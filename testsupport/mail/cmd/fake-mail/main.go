@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/mail"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/recorder"
+)
+
+// main runs the fake mail delivery service which records the messages sent by the host operator instead of delivering them.
+func main() {
+	cmd := recorder.NewCommand("fake-mail", "run a fake mail delivery service (Mailgun API) which records the messages instead of delivering them", mail.NewServer())
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
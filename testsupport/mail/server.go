@@ -0,0 +1,141 @@
+package mail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/recorder"
+	"github.com/google/uuid"
+)
+
+// Message an email which was "sent" by the host operator via the fake mail delivery service
+type Message struct {
+	ID      string    `json:"id"`
+	Domain  string    `json:"domain"`
+	From    string    `json:"from"`
+	To      []string  `json:"to"`
+	Cc      []string  `json:"cc,omitempty"`
+	Bcc     []string  `json:"bcc,omitempty"`
+	ReplyTo string    `json:"replyTo,omitempty"`
+	Subject string    `json:"subject"`
+	Text    string    `json:"text,omitempty"`
+	HTML    string    `json:"html,omitempty"`
+	Tags    []string  `json:"tags,omitempty"`
+	SentAt  time.Time `json:"sentAt"`
+}
+
+// Server a stand-in for the mail delivery service of the host operator. It implements the "send message" endpoint of the
+// Mailgun API (ie, the `mailgun` notification delivery service of the host operator) and records the messages instead of
+// delivering them. The recorded messages can then be retrieved (and reset) via the `/messages` endpoint, optionally
+// filtered by `recipient`.
+type Server struct {
+	recorder *recorder.Recorder[Message]
+}
+
+// NewServer returns a new Server without any recorded message
+func NewServer() *Server {
+	return &Server{
+		recorder: recorder.New(func(msg Message, query url.Values) bool {
+			recipient := query.Get("recipient")
+			return recipient == "" || msg.SentTo(recipient)
+		}),
+	}
+}
+
+// ServeHTTP serves the Mailgun API on `/v3/<domain>/messages`, as well as the `/messages` and `/failures` endpoints which
+// are used by the tests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/v3/") && strings.HasSuffix(r.URL.Path, "/messages") && r.Method == http.MethodPost {
+		s.send(w, r, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v3/"), "/messages"))
+		return
+	}
+	s.recorder.ServeHTTP(w, r)
+}
+
+// sendResponse the response of the Mailgun API when a message was accepted or rejected
+type sendResponse struct {
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+func (s *Server) send(w http.ResponseWriter, r *http.Request, domain string) {
+	if _, _, ok := r.BasicAuth(); !ok {
+		writeJSON(w, http.StatusUnauthorized, sendResponse{Message: "Invalid private key"})
+		return
+	}
+	// the Mailgun client uses a multipart form when there are attachments, and an url-encoded form otherwise
+	if err := r.ParseMultipartForm(10 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		writeJSON(w, http.StatusBadRequest, sendResponse{Message: err.Error()})
+		return
+	}
+	msg := Message{
+		ID:      fmt.Sprintf("<%s@%s>", uuid.NewString(), domain),
+		Domain:  domain,
+		From:    r.PostForm.Get("from"),
+		To:      r.PostForm["to"],
+		Cc:      r.PostForm["cc"],
+		Bcc:     r.PostForm["bcc"],
+		ReplyTo: r.PostForm.Get("h:Reply-To"),
+		Subject: r.PostForm.Get("subject"),
+		Text:    r.PostForm.Get("text"),
+		HTML:    r.PostForm.Get("html"),
+		Tags:    r.PostForm["o:tag"],
+		SentAt:  time.Now(),
+	}
+	switch {
+	case msg.From == "":
+		writeJSON(w, http.StatusBadRequest, sendResponse{Message: "from parameter is missing"})
+		return
+	case len(msg.To) == 0:
+		writeJSON(w, http.StatusBadRequest, sendResponse{Message: "to parameter is missing"})
+		return
+	case msg.Text == "" && msg.HTML == "":
+		writeJSON(w, http.StatusBadRequest, sendResponse{Message: "Need at least one of 'text' or 'html' parameters specified"})
+		return
+	}
+
+	if !s.recorder.Record(msg) {
+		writeJSON(w, http.StatusInternalServerError, sendResponse{Message: "failure injected by the fake mail delivery service"})
+		return
+	}
+	writeJSON(w, http.StatusOK, sendResponse{
+		ID:      msg.ID,
+		Message: "Queued. Thank you.",
+	})
+}
+
+// SentTo returns `true` if the given email address is one of the recipients (to, cc or bcc) of the message
+func (m Message) SentTo(address string) bool {
+	for _, recipients := range [][]string{m.To, m.Cc, m.Bcc} {
+		for _, recipient := range recipients {
+			// a recipient may contain several addresses, with display names (eg, `John Smith <john@acme.com>, jane@acme.com`)
+			list, err := netmail.ParseAddressList(recipient)
+			if err != nil {
+				if strings.EqualFold(strings.TrimSpace(recipient), address) {
+					return true
+				}
+				continue
+			}
+			for _, addr := range list {
+				if strings.EqualFold(addr.Address, address) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false) // keep the `<` and `>` of the message IDs as-is, like the Mailgun API
+	_ = encoder.Encode(body)
+}
@@ -0,0 +1,163 @@
+package mail
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// given
+		server := NewServer()
+
+		// when
+		resp := send(server, "sandbox.example.com", url.Values{
+			"from":       {"Developer Sandbox <noreply@sandbox.example.com>"},
+			"to":         {"John Smith <johnsmith@acme.com>"},
+			"subject":    {"Notice: Your account is provisioned"},
+			"html":       {`<p>Hello John</p><a href="https://sandbox.example.com/?a=1&amp;b=2">Start</a>`},
+			"h:Reply-To": {"devsandbox@example.com"},
+			"o:tag":      {"userprovisioned"},
+		})
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"message":"Queued. Thank you."`)
+		require.Len(t, server.recorder.Messages(), 1)
+		msg := server.recorder.Messages()[0]
+		assert.Contains(t, resp.Body.String(), msg.ID)
+		assert.True(t, strings.HasSuffix(msg.ID, "@sandbox.example.com>"))
+		assert.Equal(t, "sandbox.example.com", msg.Domain)
+		assert.Equal(t, "Developer Sandbox <noreply@sandbox.example.com>", msg.From)
+		assert.Equal(t, []string{"John Smith <johnsmith@acme.com>"}, msg.To)
+		assert.Equal(t, "devsandbox@example.com", msg.ReplyTo)
+		assert.Equal(t, "Notice: Your account is provisioned", msg.Subject)
+		assert.Equal(t, []string{"userprovisioned"}, msg.Tags)
+		assert.Equal(t, []string{"https://sandbox.example.com/?a=1&b=2"}, msg.Links())
+		assert.False(t, msg.SentAt.IsZero())
+	})
+
+	t.Run("multipart form", func(t *testing.T) {
+		// given
+		server := NewServer()
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		require.NoError(t, writer.WriteField("from", "noreply@sandbox.example.com"))
+		require.NoError(t, writer.WriteField("to", "johnsmith@acme.com"))
+		require.NoError(t, writer.WriteField("subject", "Notice"))
+		require.NoError(t, writer.WriteField("text", "Hello John"))
+		require.NoError(t, writer.Close())
+		req := httptest.NewRequest(http.MethodPost, "/v3/sandbox.example.com/messages", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.SetBasicAuth("api", "key")
+		resp := httptest.NewRecorder()
+
+		// when
+		server.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Len(t, server.recorder.Messages(), 1)
+		assert.Equal(t, "Hello John", server.recorder.Messages()[0].Body())
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		t.Run("missing credentials", func(t *testing.T) {
+			// given
+			server := NewServer()
+			req := httptest.NewRequest(http.MethodPost, "/v3/sandbox.example.com/messages", nil)
+			resp := httptest.NewRecorder()
+
+			// when
+			server.ServeHTTP(resp, req)
+
+			// then
+			assert.Equal(t, http.StatusUnauthorized, resp.Code)
+			assert.Empty(t, server.recorder.Messages())
+		})
+
+		t.Run("missing recipient", func(t *testing.T) {
+			// given
+			server := NewServer()
+
+			// when
+			resp := send(server, "sandbox.example.com", url.Values{"from": {"noreply@sandbox.example.com"}, "text": {"hello"}})
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Contains(t, resp.Body.String(), "to parameter is missing")
+			assert.Empty(t, server.recorder.Messages())
+		})
+
+		t.Run("missing body", func(t *testing.T) {
+			// given
+			server := NewServer()
+
+			// when
+			resp := send(server, "sandbox.example.com", url.Values{"from": {"noreply@sandbox.example.com"}, "to": {"johnsmith@acme.com"}})
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Empty(t, server.recorder.Messages())
+		})
+
+		t.Run("injected failures", func(t *testing.T) {
+			// given
+			server := NewServer()
+			server.recorder.FailNext(1)
+			values := url.Values{"from": {"noreply@sandbox.example.com"}, "to": {"johnsmith@acme.com"}, "text": {"hello"}}
+
+			// when
+			first := send(server, "sandbox.example.com", values)
+			second := send(server, "sandbox.example.com", values)
+
+			// then
+			assert.Equal(t, http.StatusInternalServerError, first.Code)
+			assert.Equal(t, http.StatusOK, second.Code)
+			assert.Len(t, server.recorder.Messages(), 1)
+		})
+	})
+}
+
+func TestSentTo(t *testing.T) {
+	// given
+	msg := Message{
+		To:  []string{"John Smith <JohnSmith@acme.com>, jane@acme.com"},
+		Bcc: []string{"admin@acme.com"},
+	}
+
+	// then
+	assert.True(t, msg.SentTo("johnsmith@acme.com"))
+	assert.True(t, msg.SentTo("jane@acme.com"))
+	assert.True(t, msg.SentTo("admin@acme.com"))
+	assert.False(t, msg.SentTo("other@acme.com"))
+}
+
+func TestUnknownEndpoint(t *testing.T) {
+	// given
+	server := NewServer()
+	resp := httptest.NewRecorder()
+
+	// when
+	server.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	// then
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func send(server *Server, domain string, values url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v3/"+domain+"/messages", strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("api", "key")
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+	return resp
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	dto "github.com/prometheus/client_model/go"
@@ -14,58 +15,44 @@ import (
 )
 
 func GetMetricValue(restConfig *rest.Config, url string, family string, expectedLabels []string) (float64, error) {
-	value, err := getMetricValue(restConfig, url, family, expectedLabels, getValue)
-	if value == nil {
+	families, err := scrape(restConfig, url)
+	if err != nil {
 		return -1, err
 	}
-	return *value, err
+	return MetricValue(families, family, expectedLabels)
 }
 
 func GetHistogramBuckets(restConfig *rest.Config, url string, family string, expectedLabels []string) ([]*dto.Bucket, error) {
-	value, err := getMetricValue(restConfig, url, family, expectedLabels, getBuckets)
-	if value == nil {
+	families, err := scrape(restConfig, url)
+	if err != nil {
 		return nil, err
 	}
-	return *value, err
+	return HistogramBuckets(families, family, expectedLabels)
 }
 
-func getMetricValue[T any](restConfig *rest.Config, url string, family string, expectedLabels []string, getValue func(dto.MetricType, *dto.Metric) (*T, error)) (*T, error) {
-	if len(expectedLabels)%2 != 0 {
-		return nil, fmt.Errorf("received odd number of label arguments, labels must be key-value pairs")
+// MetricValue returns the value of the (counter, gauge or untyped) metric with the given family and labels
+// among the given (already scraped) metric families
+func MetricValue(families map[string]*dto.MetricFamily, family string, expectedLabels []string) (float64, error) {
+	value, err := getMetricValue(families, family, expectedLabels, getValue)
+	if value == nil {
+		return -1, err
 	}
-	uri := fmt.Sprintf("https://%s/metrics", url)
-	var metrics []byte
+	return *value, err
+}
 
-	client := http.Client{
-		Timeout: time.Duration(30 * time.Second),
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
-		},
-	}
-	request, err := http.NewRequest("Get", uri, nil)
-	if err != nil {
-		return nil, err
-	}
-	if restConfig.BearerToken != "" {
-		request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", restConfig.BearerToken))
-	}
-	resp, err := client.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-	metrics, err = io.ReadAll(resp.Body)
-	if err != nil {
+// HistogramBuckets returns the buckets of the histogram with the given family and labels
+// among the given (already scraped) metric families
+func HistogramBuckets(families map[string]*dto.MetricFamily, family string, expectedLabels []string) ([]*dto.Bucket, error) {
+	value, err := getMetricValue(families, family, expectedLabels, getBuckets)
+	if value == nil {
 		return nil, err
 	}
+	return *value, err
+}
 
-	// parse the metrics
-	parser := expfmt.TextParser{}
-	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
-	if err != nil {
-		return nil, err
+func getMetricValue[T any](families map[string]*dto.MetricFamily, family string, expectedLabels []string, getValue func(dto.MetricType, *dto.Metric) (*T, error)) (*T, error) {
+	if len(expectedLabels)%2 != 0 {
+		return nil, fmt.Errorf("received odd number of label arguments, labels must be key-value pairs")
 	}
 	for _, f := range families {
 		if f.GetName() == family {
@@ -129,9 +116,41 @@ func getBuckets(t dto.MetricType, m *dto.Metric) (*[]*dto.Bucket, error) {
 
 // GetMetricLabels return all labels (indexed by key) for all metrics of the given `family`
 func GetMetricLabels(restConfig *rest.Config, url string, family string) ([]map[string]string, error) {
-	uri := fmt.Sprintf("https://%s/metrics", url)
-	var metrics []byte
+	families, err := scrape(restConfig, url)
+	if err != nil {
+		return nil, err
+	}
+	return MetricLabels(families, family), nil
+}
+
+// MetricLabels return all labels (indexed by key) for all metrics of the given `family` among the given (already scraped)
+// metric families
+func MetricLabels(families map[string]*dto.MetricFamily, family string) []map[string]string {
+	labels := make([]map[string]string, 0, len(families))
+	for _, f := range families {
+		if f.GetName() == family {
+			lbls := map[string]string{}
+			labels = append(labels, lbls)
+			for _, m := range f.GetMetric() {
+				for _, kv := range m.GetLabel() {
+					if kv.GetName() != "" {
+						lbls[kv.GetName()] = kv.GetValue()
+					}
+				}
+			}
+		}
+	}
+	return labels
+}
 
+// scrape retrieves and parses all the metrics exposed by the endpoint at the given URL.
+// The URL is either the host of the metrics route (in which case, `https://<url>/metrics` is scraped)
+// or a complete URL including the scheme and the path.
+func scrape(restConfig *rest.Config, url string) (map[string]*dto.MetricFamily, error) {
+	uri := url
+	if !strings.Contains(url, "://") {
+		uri = fmt.Sprintf("https://%s/metrics", url)
+	}
 	client := http.Client{
 		Timeout: time.Duration(30 * time.Second),
 		Transport: &http.Transport{
@@ -142,7 +161,9 @@ func GetMetricLabels(restConfig *rest.Config, url string, family string) ([]map[
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", restConfig.BearerToken))
+	if restConfig != nil && restConfig.BearerToken != "" {
+		request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", restConfig.BearerToken))
+	}
 	resp, err := client.Do(request)
 	if err != nil {
 		return nil, err
@@ -150,32 +171,109 @@ func GetMetricLabels(restConfig *rest.Config, url string, family string) ([]map[
 	defer func() {
 		_ = resp.Body.Close()
 	}()
-	metrics, err = io.ReadAll(resp.Body)
+	metrics, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
 
 	// parse the metrics
 	parser := expfmt.TextParser{}
-	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
-	if err != nil {
-		return nil, err
-	}
+	return parser.TextToMetricFamilies(bytes.NewReader(metrics))
+}
 
-	labels := make([]map[string]string, 0, len(families))
-	for _, f := range families {
-		if f.GetName() == family {
-			lbls := map[string]string{}
-			labels = append(labels, lbls)
+// Scrape retrieves and parses all the metrics exposed by the endpoints at the given URLs (see `scrape`), and sums the
+// values of the metrics with the same family and labels, so that the metrics of all the replicas of a component can be
+// verified as a whole, regardless of the replica which handled the requests.
+// The quantiles of the summaries can't be summed, hence only their count and sum are kept.
+func Scrape(restConfig *rest.Config, urls ...string) (map[string]*dto.MetricFamily, error) {
+	result := map[string]*dto.MetricFamily{}
+	for _, url := range urls {
+		families, err := scrape(restConfig, url)
+		if err != nil {
+			return nil, err
+		}
+		for name, f := range families {
+			if _, found := result[name]; !found {
+				result[name] = &dto.MetricFamily{
+					Name: f.Name,
+					Help: f.Help,
+					Type: f.Type,
+				}
+			}
 			for _, m := range f.GetMetric() {
-				for _, kv := range m.GetLabel() {
-					if kv.GetName() != "" {
-						lbls[kv.GetName()] = kv.GetValue()
-					}
+				addMetric(result[name], m)
+			}
+		}
+	}
+	return result, nil
+}
+
+// addMetric adds the values of the given metric to the metric of the given family which has the same labels
+// (or adds a copy of the metric to the family if there is no such metric)
+func addMetric(family *dto.MetricFamily, m *dto.Metric) {
+	var sum *dto.Metric
+	for _, existing := range family.GetMetric() {
+		if sameLabels(existing.GetLabel(), m.GetLabel()) {
+			sum = existing
+			break
+		}
+	}
+	if sum == nil {
+		sum = &dto.Metric{
+			Label: m.GetLabel(),
+		}
+		family.Metric = append(family.Metric, sum)
+	}
+	switch family.GetType() { // nolint:exhaustive
+	case dto.MetricType_COUNTER:
+		sum.Counter = &dto.Counter{Value: ptr(sum.GetCounter().GetValue() + m.GetCounter().GetValue())}
+	case dto.MetricType_GAUGE:
+		sum.Gauge = &dto.Gauge{Value: ptr(sum.GetGauge().GetValue() + m.GetGauge().GetValue())}
+	case dto.MetricType_HISTOGRAM:
+		buckets := make([]*dto.Bucket, 0, len(m.GetHistogram().GetBucket()))
+		for _, b := range m.GetHistogram().GetBucket() {
+			count := b.GetCumulativeCount()
+			for _, existing := range sum.GetHistogram().GetBucket() {
+				if existing.GetUpperBound() == b.GetUpperBound() {
+					count += existing.GetCumulativeCount()
 				}
 			}
+			buckets = append(buckets, &dto.Bucket{
+				UpperBound:      ptr(b.GetUpperBound()),
+				CumulativeCount: ptr(count),
+			})
+		}
+		sum.Histogram = &dto.Histogram{
+			SampleCount: ptr(sum.GetHistogram().GetSampleCount() + m.GetHistogram().GetSampleCount()),
+			SampleSum:   ptr(sum.GetHistogram().GetSampleSum() + m.GetHistogram().GetSampleSum()),
+			Bucket:      buckets,
 		}
+	case dto.MetricType_SUMMARY:
+		sum.Summary = &dto.Summary{
+			SampleCount: ptr(sum.GetSummary().GetSampleCount() + m.GetSummary().GetSampleCount()),
+			SampleSum:   ptr(sum.GetSummary().GetSampleSum() + m.GetSummary().GetSampleSum()),
+		}
+	default:
+		sum.Untyped = &dto.Untyped{Value: ptr(sum.GetUntyped().GetValue() + m.GetUntyped().GetValue())}
 	}
-	// here we can return `0` is the metric does not exist, which may be valid if the expected value is `0`, too.
-	return labels, nil
+}
+
+func sameLabels(a, b []*dto.LabelPair) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	values := make(map[string]string, len(a))
+	for _, l := range a {
+		values[l.GetName()] = l.GetValue()
+	}
+	for _, l := range b {
+		if v, found := values[l.GetName()]; !found || v != l.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+func ptr[T any](value T) *T {
+	return &value
 }
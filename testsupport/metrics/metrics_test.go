@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/rest"
@@ -127,3 +128,114 @@ func TestGetMetricValue(t *testing.T) {
 		})
 	})
 }
+
+func TestScrape(t *testing.T) {
+	// given
+	newReplica := func(requests, durationCount int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprintf(w, `# TYPE sandbox_promhttp_client_api_requests_total counter
+sandbox_promhttp_client_api_requests_total{code="200",method="get"} %[1]d
+# TYPE sandbox_promhttp_request_duration_seconds histogram
+sandbox_promhttp_request_duration_seconds_bucket{code="200",le="0.5"} %[2]d
+sandbox_promhttp_request_duration_seconds_bucket{code="200",le="+Inf"} %[2]d
+sandbox_promhttp_request_duration_seconds_sum{code="200"} 1
+sandbox_promhttp_request_duration_seconds_count{code="200"} %[2]d
+`, requests, durationCount)
+		}))
+	}
+	replica1 := newReplica(3, 2)
+	defer replica1.Close()
+	replica2 := newReplica(4, 5)
+	defer replica2.Close()
+
+	// when
+	families, err := Scrape(&rest.Config{}, replica1.URL+"/metrics", replica2.URL+"/metrics")
+
+	// then
+	require.NoError(t, err)
+	requests, err := MetricValue(families, "sandbox_promhttp_client_api_requests_total", []string{"code", "200", "method", "get"})
+	require.NoError(t, err)
+	assert.InDelta(t, float64(7), requests, 0.01)
+	buckets, err := HistogramBuckets(families, "sandbox_promhttp_request_duration_seconds", []string{"code", "200"})
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+	assert.Equal(t, uint64(7), buckets[0].GetCumulativeCount())
+	assert.Equal(t, uint64(7), buckets[1].GetCumulativeCount())
+	snapshot := NewSnapshot(families)
+	assert.InDelta(t, float64(2), snapshot.Sum(Family("sandbox_promhttp_request_duration_seconds_sum")), 0.01)
+}
+
+func TestSnapshot(t *testing.T) {
+	// given
+	before := newSnapshot(t, `
+sandbox_user_signups_total 7
+sandbox_user_signups_approved_total 7
+sandbox_master_user_records{domain="external"} 3
+sandbox_master_user_records{domain="internal"} 4
+sandbox_spaces_current{cluster_name="member-1"} 5
+workqueue_depth{name="usersignup-controller"} 0
+`)
+	after := newSnapshot(t, `
+sandbox_user_signups_total 8
+sandbox_user_signups_approved_total 8
+sandbox_master_user_records{domain="external"} 4
+sandbox_master_user_records{domain="internal"} 4
+sandbox_spaces_current{cluster_name="member-1"} 5
+sandbox_spaces_current{cluster_name="member-2"} 1
+workqueue_depth{name="usersignup-controller"} 2
+`)
+
+	t.Run("select", func(t *testing.T) {
+		assert.InDelta(t, 7.0, before.Sum(Family("sandbox_master_user_records")), 0.01)
+		assert.InDelta(t, 3.0, before.Sum(Family("sandbox_master_user_records").WithLabels("domain", "external")), 0.01)
+		assert.InDelta(t, 4.0, before.Sum(Family("sandbox_master_user_records").With("domain", Regex("int.*"))), 0.01)
+		assert.Len(t, before.Select(Family("sandbox_master_user_records").With("domain", AnyValue())), 2)
+		assert.Empty(t, before.Select(Family("sandbox_master_user_records").With("cluster_name", AnyValue())))
+		assert.Empty(t, before.Select(Family("sandbox_master_user_records").ExactLabels()))
+		assert.Len(t, before.Select(FamiliesWithPrefix("sandbox_")), 5)
+	})
+
+	t.Run("deltas", func(t *testing.T) {
+		// when
+		deltas := after.DeltasSince(before)
+
+		// then
+		require.Len(t, deltas, 5)
+		assert.InDelta(t, 1.0, deltas.Sum(Family("sandbox_spaces_current")), 0.01)
+		assert.InDelta(t, 2.0, deltas.Sum(Family("workqueue_depth")), 0.01)
+	})
+
+	t.Run("expectation met", func(t *testing.T) {
+		// when
+		err := ExpectDeltas(
+			Family("sandbox_user_signups_total").Delta(1),
+			Family("sandbox_user_signups_approved_total").Delta(1),
+			Family("sandbox_master_user_records").WithLabels("domain", "external").Delta(1),
+			Family("sandbox_spaces_current").With("cluster_name", AnyValue()).Delta(1),
+		).AndNoOtherChangeIn(FamiliesWithPrefix("sandbox_")).Check(after.DeltasSince(before))
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("unexpected change", func(t *testing.T) {
+		// when
+		err := ExpectDeltas(
+			Family("sandbox_user_signups_total").Delta(1),
+			Family("sandbox_user_signups_approved_total").Delta(2),
+		).AndNoOtherChangeIn(FamiliesWithPrefix("sandbox_")).Check(after.DeltasSince(before))
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `expected delta of sandbox_user_signups_approved_total{...} to be +2 but was +1`)
+		assert.Contains(t, err.Error(), `sandbox_master_user_records{domain="external"}: +1 (3 -> 4)`)
+		assert.Contains(t, err.Error(), `sandbox_spaces_current{cluster_name="member-2"}: +1 (0 -> 1)`)
+		assert.NotContains(t, err.Error(), `workqueue_depth`)
+	})
+}
+
+func newSnapshot(t *testing.T, content string) Snapshot {
+	families, err := (&expfmt.TextParser{}).TextToMetricFamilies(strings.NewReader(content))
+	require.NoError(t, err)
+	return NewSnapshot(families)
+}
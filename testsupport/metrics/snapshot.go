@@ -0,0 +1,382 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/client-go/rest"
+)
+
+// Sample a single value of a metric family with a given set of labels.
+// Histograms and summaries are exposed as 2 samples: `<family>_count` and `<family>_sum`.
+type Sample struct {
+	Family string
+	Labels map[string]string
+	Value  float64
+}
+
+func (s Sample) String() string {
+	return fmt.Sprintf("%s%s", s.Family, formatLabels(s.Labels))
+}
+
+// key returns a unique key for the sample, based on its family and labels
+func (s Sample) key() string {
+	return s.String()
+}
+
+// Snapshot the values of all the metrics exposed by an endpoint, taken with a single scrape
+type Snapshot struct {
+	Time    time.Time
+	Samples []Sample
+}
+
+// TakeSnapshot scrapes all the metrics exposed by the endpoint at the given URL.
+// The URL is either the host of the metrics route (in which case, `https://<url>/metrics` is scraped)
+// or a complete URL including the scheme and the path.
+func TakeSnapshot(restConfig *rest.Config, url string) (Snapshot, error) {
+	families, err := scrape(restConfig, url)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return NewSnapshot(families), nil
+}
+
+// NewSnapshot returns a snapshot of the given metric families
+func NewSnapshot(families map[string]*dto.MetricFamily) Snapshot {
+	snapshot := Snapshot{
+		Time: time.Now(),
+	}
+	for name, f := range families {
+		for _, m := range f.GetMetric() {
+			labels := make(map[string]string, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			switch f.GetType() { // nolint:exhaustive
+			case dto.MetricType_COUNTER:
+				snapshot.Samples = append(snapshot.Samples, Sample{Family: name, Labels: labels, Value: m.GetCounter().GetValue()})
+			case dto.MetricType_GAUGE:
+				snapshot.Samples = append(snapshot.Samples, Sample{Family: name, Labels: labels, Value: m.GetGauge().GetValue()})
+			case dto.MetricType_HISTOGRAM:
+				snapshot.Samples = append(snapshot.Samples,
+					Sample{Family: name + "_count", Labels: labels, Value: float64(m.GetHistogram().GetSampleCount())},
+					Sample{Family: name + "_sum", Labels: labels, Value: m.GetHistogram().GetSampleSum()})
+			case dto.MetricType_SUMMARY:
+				snapshot.Samples = append(snapshot.Samples,
+					Sample{Family: name + "_count", Labels: labels, Value: float64(m.GetSummary().GetSampleCount())},
+					Sample{Family: name + "_sum", Labels: labels, Value: m.GetSummary().GetSampleSum()})
+			default:
+				snapshot.Samples = append(snapshot.Samples, Sample{Family: name, Labels: labels, Value: m.GetUntyped().GetValue()})
+			}
+		}
+	}
+	sort.Slice(snapshot.Samples, func(i, j int) bool {
+		return snapshot.Samples[i].key() < snapshot.Samples[j].key()
+	})
+	return snapshot
+}
+
+// Select returns the samples which match the given selector
+func (s Snapshot) Select(selector Selector) []Sample {
+	var samples []Sample
+	for _, sample := range s.Samples {
+		if selector.Matches(sample.Family, sample.Labels) {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// Sum returns the sum of the values of the samples which match the given selector
+func (s Snapshot) Sum(selector Selector) float64 {
+	sum := 0.0
+	for _, sample := range s.Select(selector) {
+		sum += sample.Value
+	}
+	return sum
+}
+
+// DeltasSince returns the changes of all the samples between the given (previous) snapshot and this one.
+// Samples which appeared (or disappeared) in the meantime are compared with a value of `0`.
+func (s Snapshot) DeltasSince(before Snapshot) Deltas {
+	previous := make(map[string]Sample, len(before.Samples))
+	for _, sample := range before.Samples {
+		previous[sample.key()] = sample
+	}
+	var deltas Deltas
+	for _, sample := range s.Samples {
+		prev, found := previous[sample.key()]
+		delete(previous, sample.key())
+		if found && prev.Value == sample.Value {
+			continue
+		}
+		deltas = append(deltas, Delta{Family: sample.Family, Labels: sample.Labels, Before: prev.Value, After: sample.Value})
+	}
+	for _, prev := range previous {
+		if prev.Value != 0 {
+			deltas = append(deltas, Delta{Family: prev.Family, Labels: prev.Labels, Before: prev.Value})
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].String() < deltas[j].String()
+	})
+	return deltas
+}
+
+// Delta the change of a sample between 2 snapshots
+type Delta struct {
+	Family string
+	Labels map[string]string
+	Before float64
+	After  float64
+}
+
+// Value returns the difference between the value after and the value before
+func (d Delta) Value() float64 {
+	return d.After - d.Before
+}
+
+func (d Delta) String() string {
+	return fmt.Sprintf("%s%s", d.Family, formatLabels(d.Labels))
+}
+
+// Deltas the changes of the samples between 2 snapshots
+type Deltas []Delta
+
+// Select returns the deltas which match the given selector
+func (d Deltas) Select(selector Selector) Deltas {
+	var selected Deltas
+	for _, delta := range d {
+		if selector.Matches(delta.Family, delta.Labels) {
+			selected = append(selected, delta)
+		}
+	}
+	return selected
+}
+
+// Sum returns the sum of the deltas which match the given selector
+func (d Deltas) Sum(selector Selector) float64 {
+	sum := 0.0
+	for _, delta := range d.Select(selector) {
+		sum += delta.Value()
+	}
+	return sum
+}
+
+func (d Deltas) String() string {
+	lines := make([]string, 0, len(d))
+	for _, delta := range d {
+		lines = append(lines, fmt.Sprintf("%s: %+g (%g -> %g)", delta, delta.Value(), delta.Before, delta.After))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LabelMatcher matches the value of a label
+type LabelMatcher struct {
+	description string
+	match       func(string) bool
+}
+
+// AnyValue returns a LabelMatcher which matches any value of the label (as long as the label exists)
+func AnyValue() LabelMatcher {
+	return LabelMatcher{
+		description: "*",
+		match: func(string) bool {
+			return true
+		},
+	}
+}
+
+// Value returns a LabelMatcher which matches the given value of the label
+func Value(expected string) LabelMatcher {
+	return LabelMatcher{
+		description: fmt.Sprintf("%q", expected),
+		match: func(actual string) bool {
+			return actual == expected
+		},
+	}
+}
+
+// Regex returns a LabelMatcher which matches the values of the label with the given (fully anchored) regular expression
+func Regex(expr string) LabelMatcher {
+	re := regexp.MustCompile("^(?:" + expr + ")$")
+	return LabelMatcher{
+		description: fmt.Sprintf("~%q", expr),
+		match:       re.MatchString,
+	}
+}
+
+// Selector selects the samples of a metric family (or of all families with a given prefix) which have
+// a subset of labels matching the given LabelMatchers. The labels which are not specified are ignored,
+// unless `ExactLabels()` is called.
+type Selector struct {
+	family      string
+	prefix      bool
+	labels      map[string]LabelMatcher
+	exactLabels bool
+}
+
+// Family returns a Selector for the samples of the metric family with the given name
+func Family(name string) Selector {
+	return Selector{family: name}
+}
+
+// FamiliesWithPrefix returns a Selector for the samples of all metric families whose name starts with the given prefix
+func FamiliesWithPrefix(prefix string) Selector {
+	return Selector{family: prefix, prefix: true}
+}
+
+// With returns a copy of this Selector with an additional LabelMatcher on the label with the given name
+func (s Selector) With(label string, matcher LabelMatcher) Selector {
+	labels := make(map[string]LabelMatcher, len(s.labels)+1)
+	for k, v := range s.labels {
+		labels[k] = v
+	}
+	labels[label] = matcher
+	s.labels = labels
+	return s
+}
+
+// WithLabels returns a copy of this Selector which also matches the given label key-value pairs
+func (s Selector) WithLabels(labelAndValues ...string) Selector {
+	if len(labelAndValues)%2 != 0 {
+		panic("`labelAndValues` must be pairs of labels and values")
+	}
+	for i := 0; i < len(labelAndValues); i += 2 {
+		s = s.With(labelAndValues[i], Value(labelAndValues[i+1]))
+	}
+	return s
+}
+
+// ExactLabels returns a copy of this Selector which only matches the samples which have no other labels than
+// the ones specified in the selector
+func (s Selector) ExactLabels() Selector {
+	s.exactLabels = true
+	return s
+}
+
+// Matches returns `true` if the given family and labels match this Selector
+func (s Selector) Matches(family string, labels map[string]string) bool {
+	if s.prefix && !strings.HasPrefix(family, s.family) || !s.prefix && family != s.family {
+		return false
+	}
+	if s.exactLabels && len(labels) != len(s.labels) {
+		return false
+	}
+	for name, matcher := range s.labels {
+		value, found := labels[name]
+		if !found || !matcher.match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Selector) String() string {
+	family := s.family
+	if s.prefix {
+		family += "*"
+	}
+	labels := make([]string, 0, len(s.labels))
+	for name, matcher := range s.labels {
+		labels = append(labels, name+"="+matcher.description)
+	}
+	sort.Strings(labels)
+	if s.exactLabels {
+		return fmt.Sprintf("%s{%s}", family, strings.Join(labels, ","))
+	}
+	return fmt.Sprintf("%s{%s}", family, strings.Join(append(labels, "..."), ","))
+}
+
+// ExpectedDelta the expected sum of the deltas of the samples which match a selector
+type ExpectedDelta struct {
+	Selector Selector
+	Delta    float64
+}
+
+// Delta returns an ExpectedDelta with the given value for the samples which match this Selector
+func (s Selector) Delta(delta float64) ExpectedDelta {
+	return ExpectedDelta{Selector: s, Delta: delta}
+}
+
+// DeltaExpectation the expected changes between 2 snapshots, eg:
+//
+//	ExpectDeltas(
+//		Family("sandbox_user_signups_total").Delta(1),
+//		Family("sandbox_user_signups_approved_total").Delta(1),
+//		Family("sandbox_master_user_records").With("domain", Value("external")).Delta(1),
+//	).AndNoOtherChangeIn(FamiliesWithPrefix("sandbox_"))
+type DeltaExpectation struct {
+	expected []ExpectedDelta
+	scope    []Selector
+}
+
+// ExpectDeltas returns a new DeltaExpectation with the given expected deltas
+func ExpectDeltas(expected ...ExpectedDelta) *DeltaExpectation {
+	return &DeltaExpectation{
+		expected: expected,
+	}
+}
+
+// AndNoOtherChangeIn specifies that the samples which match the given selectors but none of the expected deltas
+// must not have changed
+func (e *DeltaExpectation) AndNoOtherChangeIn(scope ...Selector) *DeltaExpectation {
+	e.scope = append(e.scope, scope...)
+	return e
+}
+
+// Check verifies that the given deltas match this expectation, and returns an error with all the mismatches otherwise
+func (e *DeltaExpectation) Check(deltas Deltas) error {
+	var mismatches []string
+	for _, expected := range e.expected {
+		if actual := deltas.Sum(expected.Selector); math.Abs(actual-expected.Delta) > 1e-9 {
+			mismatches = append(mismatches, fmt.Sprintf("expected delta of %s to be %+g but was %+g", expected.Selector, expected.Delta, actual))
+		}
+	}
+	var unexpected Deltas
+	for _, delta := range deltas {
+		if !e.inScope(delta) {
+			continue
+		}
+		expected := false
+		for _, exp := range e.expected {
+			if exp.Selector.Matches(delta.Family, delta.Labels) {
+				expected = true
+				break
+			}
+		}
+		if !expected {
+			unexpected = append(unexpected, delta)
+		}
+	}
+	if len(unexpected) > 0 {
+		mismatches = append(mismatches, fmt.Sprintf("unexpected changes:\n%s", unexpected))
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%s", strings.Join(mismatches, "\n"))
+	}
+	return nil
+}
+
+func (e *DeltaExpectation) inScope(delta Delta) bool {
+	for _, s := range e.scope {
+		if s.Matches(delta.Family, delta.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, value))
+	}
+	sort.Strings(pairs)
+	return "{" + strings.Join(pairs, ",") + "}"
+}
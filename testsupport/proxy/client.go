@@ -0,0 +1,149 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	kubewait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Client a client of the API proxy of the registration service, on behalf of a user.
+// It combines a typed client (for the CRUD operations on the resources of the user's workspaces) with an HTTP client
+// (for the requests with arbitrary headers, eg, in negative tests) and a websocket client (for the watches, see `Watch`).
+type Client struct {
+	client.Client
+	*HTTPClient
+}
+
+// NewClient returns a new Client of the API proxy for the given user token, without any workspace context
+func NewClient(t *testing.T, hostAwait *wait.HostAwaitility, token string) *Client {
+	return newClient(t, hostAwait, token, hostAwait.APIProxyURL)
+}
+
+// NewClientWithWorkspaceContext returns a new Client of the API proxy for the given user token, with the given workspace context
+// (ie, all the requests are sent to `<proxy>/workspaces/<workspace>/...`)
+func NewClientWithWorkspaceContext(t *testing.T, hostAwait *wait.HostAwaitility, token, workspace string) *Client {
+	return newClient(t, hostAwait, token, hostAwait.ProxyURLWithWorkspaceContext(workspace))
+}
+
+func newClient(t *testing.T, hostAwait *wait.HostAwaitility, token, proxyURL string) *Client {
+	cl, err := hostAwait.CreateAPIProxyClient(t, token, proxyURL)
+	require.NoError(t, err)
+	return &Client{
+		Client:     cl,
+		HTTPClient: NewHTTPClient(t, token, proxyURL),
+	}
+}
+
+// NewPluginClient returns a new HTTPClient of the given proxy plugin for the given user token, with the given workspace context
+// (ie, all the requests are sent to `<proxy>/plugins/<plugin>/workspaces/<workspace>/...`)
+func NewPluginClient(t *testing.T, hostAwait *wait.HostAwaitility, token, plugin, workspace string) *HTTPClient {
+	return NewHTTPClient(t, token, hostAwait.PluginProxyURLWithWorkspaceContext(plugin, workspace))
+}
+
+// ListWorkspaces returns all the workspaces which the user has access to
+func (c *Client) ListWorkspaces() []toolchainv1alpha1.Workspace {
+	workspaces := &toolchainv1alpha1.WorkspaceList{}
+	err := c.List(context.TODO(), workspaces)
+	require.NoError(c.t, err)
+	return workspaces.Items
+}
+
+// GetWorkspace returns the workspace with the given name. Since in some test cases the workspace is not expected to be found,
+// it only retries for a few seconds and it returns the last error instead of asserting it.
+func (c *Client) GetWorkspace(name string) (*toolchainv1alpha1.Workspace, error) {
+	workspace := &toolchainv1alpha1.Workspace{}
+	var cause error
+	_ = kubewait.PollUntilContextTimeout(context.TODO(), wait.DefaultRetryInterval, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		cause = c.Get(context.TODO(), types.NamespacedName{Name: name}, workspace)
+		return cause == nil, nil
+	})
+	return workspace, cause
+}
+
+// HTTPClient a raw HTTP client of the API proxy (or of a proxy plugin), on behalf of a user
+type HTTPClient struct {
+	t     *testing.T
+	token string
+	// URL the base URL of all the requests, including the workspace context or the plugin path (if any)
+	URL  string
+	http *http.Client
+}
+
+// NewHTTPClient returns a new HTTPClient which sends the requests to the given base URL with the given user token
+func NewHTTPClient(t *testing.T, token, baseURL string) *HTTPClient {
+	return &HTTPClient{
+		t:     t,
+		token: token,
+		URL:   strings.TrimSuffix(baseURL, "/"),
+		http: &http.Client{
+			Timeout: 30 * time.Second, // because sometimes the network connection may be a bit slow
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true, // nolint:gosec
+				},
+			},
+		},
+	}
+}
+
+// Header an HTTP header, which can be set multiple times in the same request (eg, `Impersonate-Group`)
+type Header struct {
+	Key, Value string
+}
+
+// Response the status code, headers and body of a response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// Do sends a request with the given method and body to the given path (relative to the base URL) along with the given headers,
+// and returns the response. The `Authorization` header with the user token is set unless it is part of the given headers,
+// so that requests with another token (or none, with an empty value) can be sent as well.
+func (c *HTTPClient) Do(method, path string, body []byte, headers ...Header) *Response {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequest(method, c.URL+path, reader)
+	require.NoError(c.t, err)
+	authorization := true
+	for _, header := range headers {
+		if http.CanonicalHeaderKey(header.Key) == "Authorization" {
+			authorization = false
+			if header.Value == "" {
+				continue
+			}
+		}
+		request.Header.Add(header.Key, header.Value)
+	}
+	if authorization {
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	resp, err := c.http.Do(request)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(c.t, err)
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       string(content),
+	}
+}
@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	// given
+	var received *http.Request
+	var receivedBody string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+		w.Header().Set("X-Test", "ok")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("forbidden"))
+	}))
+	defer server.Close()
+	cl := NewHTTPClient(t, "my-token", server.URL+"/workspaces/johnsmith/")
+
+	t.Run("with user token and additional headers", func(t *testing.T) {
+		// when
+		resp := cl.Do(http.MethodGet, "/api/v1/nodes", nil,
+			Header{"Impersonate-Group", "system:cluster-admins"},
+			Header{"Impersonate-Group", "system:node-admins"})
+
+		// then
+		require.NotNil(t, received)
+		assert.Equal(t, "/workspaces/johnsmith/api/v1/nodes", received.URL.Path)
+		assert.Equal(t, "Bearer my-token", received.Header.Get("Authorization"))
+		assert.Equal(t, []string{"system:cluster-admins", "system:node-admins"}, received.Header.Values("Impersonate-Group"))
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, "ok", resp.Header.Get("X-Test"))
+		assert.Equal(t, "forbidden", resp.Body)
+	})
+
+	t.Run("with other token and body", func(t *testing.T) {
+		// when
+		cl.Do(http.MethodPost, "/api/v1/namespaces/johnsmith-dev/configmaps", []byte(`{"kind":"ConfigMap"}`),
+			Header{"authorization", "Bearer other-token"})
+
+		// then
+		assert.Equal(t, http.MethodPost, received.Method)
+		assert.Equal(t, []string{"Bearer other-token"}, received.Header.Values("Authorization"))
+		assert.Equal(t, `{"kind":"ConfigMap"}`, receivedBody)
+	})
+
+	t.Run("without token", func(t *testing.T) {
+		// when
+		cl.Do(http.MethodGet, "/apis", nil, Header{"Authorization", ""})
+
+		// then
+		assert.Empty(t, received.Header.Values("Authorization"))
+	})
+}
@@ -0,0 +1,198 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubewait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Event a watch event received over the websocket connection
+type Event[T client.Object] struct {
+	// Type the type of event: `ADDED`, `MODIFIED` or `DELETED`
+	Type   string `json:"type"`
+	Object T      `json:"object"`
+}
+
+// Watcher watches the resources of a given kind over a websocket connection to the API proxy (instead of a direct connection
+// to the API server), and keeps track of the events and of the resources which currently exist
+type Watcher[T client.Object] struct {
+	client     *HTTPClient
+	connection *websocket.Conn
+	done       chan interface{}
+
+	mu      sync.RWMutex
+	events  []Event[T]
+	objects map[string]T // indexed by name
+}
+
+// ResourcePath returns the path of the resources of the given kind in the given namespace (or cluster-scoped if the namespace is empty)
+func ResourcePath(gvr schema.GroupVersionResource, namespace string) string {
+	path := "/api/" + gvr.Version
+	if gvr.Group != "" {
+		path = fmt.Sprintf("/apis/%s/%s", gvr.Group, gvr.Version)
+	}
+	if namespace != "" {
+		path = fmt.Sprintf("%s/namespaces/%s", path, namespace)
+	}
+	return fmt.Sprintf("%s/%s", path, gvr.Resource)
+}
+
+// Watch opens a websocket connection to watch the resources of the given kind in the given namespace. The connection is closed
+// at the end of the test, or when the `Close()` method of the returned Watcher is called.
+func Watch[T client.Object](c *HTTPClient, gvr schema.GroupVersionResource, namespace string) *Watcher[T] {
+	encodedToken := base64.RawURLEncoding.EncodeToString([]byte(c.token))
+	protocol := fmt.Sprintf("base64url.bearer.authorization.k8s.io.%s", encodedToken)
+	socketURL := websocketURL(c.URL) + ResourcePath(gvr, namespace) + "?watch=true"
+	c.t.Logf("opening connection to '%s'", socketURL)
+	dialer := &websocket.Dialer{
+		Subprotocols: []string{protocol, "base64.binary.k8s.io"},
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true, // nolint:gosec
+		},
+	}
+
+	// Retry websocket connection to handle rate limiting issues
+	// From OpenShift 4.19+ (using k8s 1.32), the ResilientWatchCacheInitialization feature is enabled
+	// which can cause 429 rate limiting responses when watchcache is still under initialization
+	var conn *websocket.Conn
+	err := kubewait.PollUntilContextTimeout(context.TODO(), wait.DefaultRetryInterval, wait.DefaultTimeout, true, func(ctx context.Context) (bool, error) {
+		extraHeaders := make(http.Header, 1)
+		extraHeaders.Add("Origin", "http://localhost")
+		var resp *http.Response
+		var err error
+		conn, resp, err = dialer.Dial(socketURL, extraHeaders)
+		if resp != nil && resp.Body != nil {
+			defer resp.Body.Close()
+		}
+		if err != nil {
+			if resp == nil {
+				return false, err
+			}
+			r, _ := io.ReadAll(resp.Body)
+			if errors.Is(err, websocket.ErrBadHandshake) && resp.StatusCode == http.StatusTooManyRequests {
+				c.t.Logf("rate limited, retrying: %s", string(r))
+				return false, nil
+			}
+			c.t.Logf("connection failed with status %d / response %s", resp.StatusCode, string(r))
+			return false, err
+		}
+		return true, nil
+	})
+	require.NoError(c.t, err)
+
+	w := &Watcher[T]{
+		client:     c,
+		connection: conn,
+		done:       make(chan interface{}),
+		objects:    map[string]T{},
+	}
+	go w.receive()
+	go w.keepAlive()
+	c.t.Cleanup(w.Close)
+	return w
+}
+
+// websocketURL returns the given URL with the `wss` (or `ws`) scheme
+func websocketURL(url string) string {
+	if strings.HasPrefix(url, "http://") {
+		return "ws://" + strings.TrimPrefix(url, "http://")
+	}
+	return "wss://" + strings.TrimPrefix(url, "https://")
+}
+
+// Close closes the websocket connection
+func (w *Watcher[T]) Close() {
+	_ = w.connection.Close()
+	<-w.done
+}
+
+// keepAlive sends an echo packet every second, until the connection is closed
+func (w *Watcher[T]) keepAlive() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-time.After(time.Second):
+			if err := w.connection.WriteMessage(websocket.TextMessage, []byte("Hello from e2e tests!")); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// receive listens to the incoming messages and stores them as events, until the connection is closed
+func (w *Watcher[T]) receive() {
+	defer close(w.done)
+	for {
+		_, msg, err := w.connection.ReadMessage()
+		if err != nil {
+			w.client.t.Logf("Exiting message receiving loop. It's normal if the connection has been closed. Reason: %s", err.Error())
+			return
+		}
+		event := Event[T]{}
+		if err := json.Unmarshal(msg, &event); err != nil {
+			w.client.t.Logf("unable to decode the received message '%s': %s", msg, err.Error())
+			continue
+		}
+		if v := reflect.ValueOf(event.Object); !v.IsValid() || v.IsNil() {
+			// not a watch event (eg, an error status)
+			w.client.t.Logf("ignoring the received message '%s'", msg)
+			continue
+		}
+		w.mu.Lock()
+		w.events = append(w.events, event)
+		if event.Type == "DELETED" {
+			delete(w.objects, event.Object.GetName())
+		} else {
+			w.objects[event.Object.GetName()] = event.Object
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Events returns all the events received so far
+func (w *Watcher[T]) Events() []Event[T] {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return append([]Event[T]{}, w.events...)
+}
+
+// WaitForObject waits until an object with the given name was added (or modified) and not deleted since, and returns it
+func (w *Watcher[T]) WaitForObject(name string) (T, error) {
+	var found T
+	err := kubewait.PollUntilContextTimeout(context.TODO(), wait.DefaultRetryInterval, wait.DefaultTimeout, true, func(ctx context.Context) (bool, error) {
+		w.mu.RLock()
+		defer w.mu.RUnlock()
+		obj, exists := w.objects[name]
+		found = obj
+		return exists, nil
+	})
+	return found, err
+}
+
+// WaitForDeletion waits until the object with the given name does not exist (anymore)
+func (w *Watcher[T]) WaitForDeletion(name string) error {
+	return kubewait.PollUntilContextTimeout(context.TODO(), wait.DefaultRetryInterval, wait.DefaultTimeout, true, func(ctx context.Context) (bool, error) {
+		w.mu.RLock()
+		defer w.mu.RUnlock()
+		_, exists := w.objects[name]
+		return !exists, nil
+	})
+}
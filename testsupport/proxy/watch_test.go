@@ -0,0 +1,96 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourcePath(t *testing.T) {
+	assert.Equal(t, "/api/v1/namespaces/johnsmith-dev/configmaps",
+		ResourcePath(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, "johnsmith-dev"))
+	assert.Equal(t, "/apis/appstudio.redhat.com/v1alpha1/namespaces/johnsmith-tenant/applications",
+		ResourcePath(schema.GroupVersionResource{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "applications"}, "johnsmith-tenant"))
+	assert.Equal(t, "/apis/toolchain.dev.openshift.com/v1alpha1/workspaces",
+		ResourcePath(schema.GroupVersionResource{Group: "toolchain.dev.openshift.com", Version: "v1alpha1", Resource: "workspaces"}, ""))
+}
+
+func TestWatch(t *testing.T) {
+	// given
+	events := []string{
+		`{"type":"ADDED","object":{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cm-1"},"data":{"foo":"bar"}}}`,
+		`{"type":"ADDED","object":{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cm-2"}}}`,
+		`not a watch event`,
+		`{"type":"ERROR"}`,
+		`{"type":"DELETED","object":{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cm-2"}}}`,
+	}
+	var protocols []string
+	var path string
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{"base64.binary.k8s.io"},
+		CheckOrigin: func(_ *http.Request) bool {
+			return true
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protocols = websocket.Subprotocols(r)
+		path = r.URL.RequestURI()
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, event := range events {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(event)); err != nil {
+				return
+			}
+		}
+		// keep the connection open until the client closes it
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+	cl := NewHTTPClient(t, "my-token", server.URL)
+
+	// when
+	w := Watch[*corev1.ConfigMap](cl, schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, "johnsmith-dev")
+
+	// then
+	require.Eventually(t, func() bool {
+		return len(w.Events()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	cm, err := w.WaitForObject("cm-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"foo": "bar"}, cm.Data)
+	require.NoError(t, w.WaitForDeletion("cm-2"))
+	received := w.Events()
+	assert.Equal(t, "ADDED", received[1].Type)
+	assert.Equal(t, "cm-2", received[1].Object.Name)
+	assert.Equal(t, "DELETED", received[2].Type)
+	assert.Equal(t, "/api/v1/namespaces/johnsmith-dev/configmaps?watch=true", path)
+	require.Len(t, protocols, 2)
+	assert.True(t, strings.HasPrefix(protocols[0], "base64url.bearer.authorization.k8s.io."))
+
+	t.Run("close", func(t *testing.T) {
+		// when
+		w.Close()
+
+		// then
+		select {
+		case <-w.done:
+		default:
+			assert.Fail(t, "the receiving loop should be stopped")
+		}
+	})
+}
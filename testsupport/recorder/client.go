@@ -0,0 +1,92 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/util"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Client a client of a fake service which records the messages of type M instead of delivering them (see `Recorder`)
+type Client[M any] struct {
+	URL  string
+	http *http.Client
+}
+
+// NewClient returns a new Client of the fake service at the given URL
+func NewClient[M any](url string) *Client[M] {
+	return &Client[M]{
+		URL:  strings.TrimSuffix(url, "/"),
+		http: util.NewInsecureHTTPClient(),
+	}
+}
+
+// List returns the recorded messages which match the given query, in the order in which they were sent
+func (c *Client[M]) List(query url.Values) ([]M, error) {
+	resp, err := c.http.Get(c.URL + "/messages?" + query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %d with body: %s", resp.StatusCode, body)
+	}
+	var messages []M
+	if err := json.Unmarshal(body, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// WaitFor waits until the given function finds a message among the recorded messages which match the given query,
+// and returns it
+func (c *Client[M]) WaitFor(query url.Values, find func([]M) *M) (*M, error) {
+	var msg *M
+	err := k8swait.PollUntilContextTimeout(context.TODO(), time.Second, 30*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		messages, err := c.List(query)
+		if err != nil {
+			return false, err
+		}
+		msg = find(messages)
+		return msg != nil, nil
+	})
+	return msg, err
+}
+
+// Reset deletes all the recorded messages and the pending failures
+func (c *Client[M]) Reset() error {
+	return c.send(http.MethodDelete, "/messages")
+}
+
+// FailNext makes the fake service reject the next `count` messages with an internal error
+func (c *Client[M]) FailNext(count int) error {
+	return c.send(http.MethodPost, fmt.Sprintf("/failures?count=%d", count))
+}
+
+func (c *Client[M]) send(method, path string) error {
+	req, err := http.NewRequest(method, c.URL+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected response status %d with body: %s", resp.StatusCode, body)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package recorder
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand returns the command which runs a fake service with the given handler. The handler is served on `--address`,
+// and also on `--tls-address` if a certificate is provided (eg, when the operator only sends the messages over HTTPS,
+// while the tests retrieve them over plain HTTP, behind an edge route).
+func NewCommand(name, short string, handler http.Handler) *cobra.Command {
+	var address, tlsAddress, tlsCert, tlsKey string
+	cmd := &cobra.Command{
+		Use:           name,
+		Short:         short,
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		Args:          cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			errs := make(chan error, 2)
+			go func() {
+				fmt.Printf("recording the messages on '%s'\n", address)
+				errs <- newServer(address, handler).ListenAndServe()
+			}()
+			if tlsCert != "" {
+				go func() {
+					fmt.Printf("recording the messages on '%s' (TLS)\n", tlsAddress)
+					errs <- newServer(tlsAddress, handler).ListenAndServeTLS(tlsCert, tlsKey)
+				}()
+			}
+			// stop as soon as one of the servers fails
+			return <-errs
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", ":8080", "the address to listen on")
+	cmd.Flags().StringVar(&tlsAddress, "tls-address", ":8443", "the address to listen on with TLS (only if a certificate is provided)")
+	cmd.Flags().StringVar(&tlsCert, "tls-cert", "", "the path to the TLS certificate")
+	cmd.Flags().StringVar(&tlsKey, "tls-key", "", "the path to the TLS private key")
+	return cmd
+}
+
+func newServer(address string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
@@ -0,0 +1,113 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// Filter returns `true` if the given message matches the query parameters of the `/messages` endpoint
+type Filter[M any] func(msg M, query url.Values) bool
+
+// Recorder records the messages which are "sent" via a fake service instead of delivering them. The recorded messages can
+// then be retrieved (and reset) by the tests via the `/messages` endpoint, and the next messages can be made to fail via
+// the `/failures` endpoint, so that the tests can verify how the operators deal with delivery errors.
+type Recorder[M any] struct {
+	mu       sync.Mutex
+	messages []M
+	failures int // number of upcoming messages which should fail
+	filter   Filter[M]
+}
+
+// New returns a new Recorder without any recorded message, which uses the given filter for the `/messages` endpoint
+func New[M any](filter Filter[M]) *Recorder[M] {
+	return &Recorder[M]{
+		filter: filter,
+	}
+}
+
+// Record records the given message, unless a failure was injected for it, in which case the message is dropped and
+// `false` is returned
+func (r *Recorder[M]) Record(msg M) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		return false
+	}
+	r.messages = append(r.messages, msg)
+	return true
+}
+
+// Messages returns the recorded messages, in the order in which they were sent
+func (r *Recorder[M]) Messages() []M {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]M{}, r.messages...)
+}
+
+// Failures returns the number of upcoming messages which should fail
+func (r *Recorder[M]) Failures() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failures
+}
+
+// FailNext makes the next `count` messages fail
+func (r *Recorder[M]) FailNext(count int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = count
+}
+
+// ServeHTTP serves the `/messages`, `/failures` and `/healthz` endpoints which are used by the tests
+func (r *Recorder[M]) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.Path == "/messages" && req.Method == http.MethodGet:
+		r.listMessages(w, req)
+	case req.URL.Path == "/messages" && req.Method == http.MethodDelete:
+		r.reset(w)
+	case req.URL.Path == "/failures" && req.Method == http.MethodPost:
+		r.setFailures(w, req)
+	case req.URL.Path == "/healthz" && req.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// listMessages returns the recorded messages (in the order in which they were sent) which match the query parameters
+func (r *Recorder[M]) listMessages(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	messages := []M{}
+	for _, msg := range r.Messages() {
+		if r.filter == nil || r.filter(msg, query) {
+			messages = append(messages, msg)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false) // keep the messages as-is (eg, the `<` and `>` of the email message IDs)
+	_ = encoder.Encode(messages)
+}
+
+func (r *Recorder[M]) reset(w http.ResponseWriter) {
+	r.mu.Lock()
+	r.messages = nil
+	r.failures = 0
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Recorder[M]) setFailures(w http.ResponseWriter, req *http.Request) {
+	count, err := strconv.Atoi(req.URL.Query().Get("count"))
+	if err != nil || count < 0 {
+		http.Error(w, fmt.Sprintf("invalid count '%s'", req.URL.Query().Get("count")), http.StatusBadRequest)
+		return
+	}
+	r.FailNext(count)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,160 @@
+package recorder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+func newRecorder() *Recorder[message] {
+	return New(func(msg message, query url.Values) bool {
+		to := query.Get("to")
+		return to == "" || msg.To == to
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	t.Run("record", func(t *testing.T) {
+		// given
+		recorder := newRecorder()
+
+		// when
+		recorded := recorder.Record(message{To: "john", Body: "hello"})
+
+		// then
+		assert.True(t, recorded)
+		assert.Equal(t, []message{{To: "john", Body: "hello"}}, recorder.Messages())
+	})
+
+	t.Run("injected failures", func(t *testing.T) {
+		// given
+		recorder := newRecorder()
+		recorder.FailNext(1)
+
+		// when
+		first := recorder.Record(message{To: "john", Body: "hello"})
+		second := recorder.Record(message{To: "john", Body: "hello again"})
+
+		// then
+		assert.False(t, first)
+		assert.True(t, second)
+		assert.Equal(t, []message{{To: "john", Body: "hello again"}}, recorder.Messages())
+		assert.Zero(t, recorder.Failures())
+	})
+
+	t.Run("healthz", func(t *testing.T) {
+		// given
+		recorder := newRecorder()
+		resp := httptest.NewRecorder()
+
+		// when
+		recorder.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("unknown endpoint", func(t *testing.T) {
+		// given
+		recorder := newRecorder()
+		resp := httptest.NewRecorder()
+
+		// when
+		recorder.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/messages", nil))
+
+		// then
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestClient(t *testing.T) {
+	// given
+	recorder := newRecorder()
+	httpServer := httptest.NewServer(recorder)
+	t.Cleanup(httpServer.Close)
+	client := NewClient[message](httpServer.URL + "/")
+
+	t.Run("no message", func(t *testing.T) {
+		// when
+		messages, err := client.List(url.Values{})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("filtered messages", func(t *testing.T) {
+		// given
+		recorder.Record(message{To: "john", Body: "hello"})
+		recorder.Record(message{To: "jane", Body: "hello"})
+		recorder.Record(message{To: "john", Body: "bye"})
+
+		// when
+		messages, err := client.List(url.Values{"to": {"john"}})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []message{{To: "john", Body: "hello"}, {To: "john", Body: "bye"}}, messages)
+	})
+
+	t.Run("wait for message", func(t *testing.T) {
+		// given
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			recorder.Record(message{To: "jane", Body: "bye"})
+		}()
+
+		// when
+		msg, err := client.WaitFor(url.Values{"to": {"jane"}}, func(messages []message) *message {
+			for i := range messages {
+				if messages[i].Body == "bye" {
+					return &messages[i]
+				}
+			}
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, message{To: "jane", Body: "bye"}, *msg)
+	})
+
+	t.Run("fail next messages", func(t *testing.T) {
+		// when
+		err := client.FailNext(2)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, recorder.Failures())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		// when
+		err := client.Reset()
+
+		// then
+		require.NoError(t, err)
+		messages, err := client.List(url.Values{})
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+		assert.Zero(t, recorder.Failures())
+	})
+
+	t.Run("invalid failure count", func(t *testing.T) {
+		// when
+		err := client.FailNext(-1)
+
+		// then
+		require.EqualError(t, err, "unexpected response status 400 with body: invalid count '-1'\n")
+	})
+}
@@ -0,0 +1,295 @@
+package regsvc
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Client a typed client of the registration service REST API, on behalf of the user with the given token.
+// Non-2xx responses are returned as an `*Error`, so that the tests can check the status and the body of the response.
+// The status of the last response is available via `StatusCode` (hence, a Client should not be shared between goroutines).
+type Client struct {
+	// URL the base URL of the registration service (ie, without the `/api/v1` path)
+	URL        string
+	token      string
+	http       *http.Client
+	statusCode int
+}
+
+// NewClient returns a new Client of the registration service at the given URL. The token is sent as a bearer token
+// with all the requests, unless it is empty.
+func NewClient(url, token string) *Client {
+	return &Client{
+		URL:   strings.TrimSuffix(url, "/"),
+		token: token,
+		http: &http.Client{
+			Timeout: time.Second * 10,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true, // nolint:gosec
+				},
+				DisableKeepAlives: true,
+			},
+		},
+	}
+}
+
+// Signup the signup of the user, as returned by the `GET /api/v1/signup` endpoint
+type Signup struct {
+	Name                 string       `json:"name"`
+	Username             string       `json:"username"`
+	CompliantUsername    string       `json:"compliantUsername"`
+	GivenName            string       `json:"givenName"`
+	FamilyName           string       `json:"familyName"`
+	Company              string       `json:"company"`
+	Email                string       `json:"email"`
+	UserID               string       `json:"userID"`
+	AccountID            string       `json:"accountID"`
+	AccountNumber        string       `json:"accountNumber"`
+	ConsoleURL           string       `json:"consoleURL,omitempty"`
+	CheDashboardURL      string       `json:"cheDashboardURL,omitempty"`
+	APIEndpoint          string       `json:"apiEndpoint,omitempty"`
+	ClusterName          string       `json:"clusterName,omitempty"`
+	ProxyURL             string       `json:"proxyURL,omitempty"`
+	RHODSMemberURL       string       `json:"rhodsMemberURL,omitempty"`
+	DefaultUserNamespace string       `json:"defaultUserNamespace,omitempty"`
+	StartDate            string       `json:"startDate,omitempty"`
+	EndDate              string       `json:"endDate,omitempty"`
+	Status               SignupStatus `json:"status"`
+}
+
+// SignupStatus the status of the signup of the user
+type SignupStatus struct {
+	Ready                bool   `json:"ready"`
+	Reason               string `json:"reason"`
+	VerificationRequired bool   `json:"verificationRequired"`
+}
+
+// Health the health of the registration service, as returned by the `GET /api/v1/health` endpoint
+type Health struct {
+	Alive       bool   `json:"alive"`
+	Environment string `json:"environment"`
+	Revision    string `json:"revision"`
+	BuildTime   string `json:"buildTime"`
+	StartTime   string `json:"startTime"`
+}
+
+// AuthConfig the configuration of the authentication client, as returned by the `GET /api/v1/authconfig` endpoint
+type AuthConfig struct {
+	AuthClientLibraryURL string `json:"auth-client-library-url"`
+	AuthClientConfigRaw  string `json:"auth-client-config"`
+	SignupURL            string `json:"signup-url"`
+}
+
+// Username a username matching a search, as returned by the `GET /api/v1/usernames/<username>` endpoint
+type Username struct {
+	Username string `json:"username"`
+}
+
+// Error the error returned when the registration service responds with a non-2xx status
+type Error struct {
+	StatusCode int    `json:"-"`
+	Body       string `json:"-"`
+	// Status, Code, Message and Details are set when the body is an error of the registration service
+	Status  string `json:"status"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+	// TokenError is set when the token is missing or invalid
+	TokenError string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("unexpected response status %d with body: %s", e.StatusCode, e.Body)
+}
+
+// StatusCode returns the status code of the last response received by the client, or 0 if the last request failed without a response
+func (c *Client) StatusCode() int {
+	return c.statusCode
+}
+
+// RequireStatus requires that the last response received by the given client has the given status, and that the given error
+// (returned by the last request of the client) matches this status, ie, that there is no error for a 2xx status,
+// or that there is an `*Error` with the same status code, in which case it is returned.
+func RequireStatus(t *testing.T, c *Client, err error, status int) *Error {
+	if status >= 200 && status < 300 {
+		require.NoError(t, err)
+		require.Equal(t, status, c.StatusCode(), "unexpected response status")
+		return nil
+	}
+	var regsvcErr *Error
+	require.ErrorAs(t, err, &regsvcErr)
+	require.Equal(t, status, regsvcErr.StatusCode, "unexpected response status with body: %s", regsvcErr.Body)
+	return regsvcErr
+}
+
+// SignupOption an option of the signup request
+type SignupOption func(url.Values)
+
+// NoSpace skips the creation of the Space of the user
+func NoSpace() SignupOption {
+	return func(params url.Values) {
+		params.Set("no-space", "true")
+	}
+}
+
+// Signup signs up the user (`POST /api/v1/signup`)
+func (c *Client) Signup(options ...SignupOption) error {
+	params := url.Values{}
+	for _, apply := range options {
+		apply(params)
+	}
+	path := "/api/v1/signup"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	return c.do(http.MethodPost, path, nil, nil)
+}
+
+// GetSignup returns the signup of the user (`GET /api/v1/signup`)
+func (c *Client) GetSignup() (*Signup, error) {
+	signup := &Signup{}
+	if err := c.do(http.MethodGet, "/api/v1/signup", nil, signup); err != nil {
+		return nil, err
+	}
+	return signup, nil
+}
+
+// InitVerification sends a verification code to the given phone number (`PUT /api/v1/signup/verification`)
+func (c *Client) InitVerification(countryCode, phoneNumber string) error {
+	return c.do(http.MethodPut, "/api/v1/signup/verification", map[string]string{
+		"country_code": countryCode,
+		"phone_number": phoneNumber,
+	}, nil)
+}
+
+// VerifyCode verifies the user with the code which was sent to their phone (`GET /api/v1/signup/verification/<code>`)
+func (c *Client) VerifyCode(code string) error {
+	return c.do(http.MethodGet, "/api/v1/signup/verification/"+url.PathEscape(code), nil, nil)
+}
+
+// VerifyActivationCode verifies the user with the activation code of a SocialEvent (`POST /api/v1/signup/verification/activation-code`)
+func (c *Client) VerifyActivationCode(code string) error {
+	return c.do(http.MethodPost, "/api/v1/signup/verification/activation-code", map[string]string{
+		"code": code,
+	}, nil)
+}
+
+// AuthConfig returns the configuration of the authentication client (`GET /api/v1/authconfig`)
+func (c *Client) AuthConfig() (*AuthConfig, error) {
+	config := &AuthConfig{}
+	if err := c.do(http.MethodGet, "/api/v1/authconfig", nil, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// SegmentWriteKey returns the Segment write key of the Developer Sandbox (`GET /api/v1/analytics/segment-write-key`)
+func (c *Client) SegmentWriteKey() (string, error) {
+	return c.getText("/api/v1/analytics/segment-write-key")
+}
+
+// DevSpacesSegmentWriteKey returns the Segment write key of Dev Spaces (`GET /api/v1/segment-write-key`)
+func (c *Client) DevSpacesSegmentWriteKey() (string, error) {
+	return c.getText("/api/v1/segment-write-key")
+}
+
+// Health returns the health of the registration service (`GET /api/v1/health`)
+func (c *Client) Health() (*Health, error) {
+	health := &Health{}
+	if err := c.do(http.MethodGet, "/api/v1/health", nil, health); err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+// Usernames returns the usernames matching the given username (`GET /api/v1/usernames/<username>`)
+func (c *Client) Usernames(username string) ([]Username, error) {
+	var usernames []Username
+	if err := c.do(http.MethodGet, "/api/v1/usernames/"+url.PathEscape(username), nil, &usernames); err != nil {
+		return nil, err
+	}
+	return usernames, nil
+}
+
+func (c *Client) getText(path string) (string, error) {
+	body, err := c.send(http.MethodGet, path, nil)
+	return string(body), err
+}
+
+// do sends the request with the given body (marshalled as JSON, if not nil) and unmarshals the response in the given output (if not nil)
+func (c *Client) do(method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	respBody, err := c.send(method, path, body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unable to decode the response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// send sends the request and returns the body of the response, or an `*Error` if the status of the response is not 2xx
+func (c *Client) send(method, path string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, c.URL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	c.statusCode = 0
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	c.statusCode = resp.StatusCode
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		regsvcErr := &Error{
+			StatusCode: resp.StatusCode,
+			Body:       string(respBody),
+		}
+		// the body is not always JSON, in which case only the status code and the raw body are set
+		_ = json.Unmarshal(respBody, regsvcErr)
+		return nil, regsvcErr
+	}
+	return respBody, nil
+}
+
+// IsNotFound returns true if the given error is an `*Error` with a 404 status
+func IsNotFound(err error) bool {
+	var regsvcErr *Error
+	return errors.As(err, &regsvcErr) && regsvcErr.StatusCode == http.StatusNotFound
+}
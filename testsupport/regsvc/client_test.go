@@ -0,0 +1,265 @@
+package regsvc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type request struct {
+	method, path, query, authorization, body string
+}
+
+// newServer returns a server which records the received requests and responds with the given status and body
+func newServer(t *testing.T, status int, body string) (*httptest.Server, *[]request) {
+	requests := &[]request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*requests = append(*requests, request{
+			method:        r.Method,
+			path:          r.URL.Path,
+			query:         r.URL.RawQuery,
+			authorization: r.Header.Get("Authorization"),
+			body:          string(content),
+		})
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestGetSignup(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusOK, `{
+			"name": "johnsmith",
+			"username": "johnsmith",
+			"compliantUsername": "johnsmith",
+			"defaultUserNamespace": "johnsmith-dev",
+			"startDate": "2024-01-01T00:00:00Z",
+			"status": {"ready": true, "reason": "Provisioned", "verificationRequired": false}
+		}`)
+
+		// when
+		signup, err := NewClient(server.URL+"/", "token").GetSignup()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []request{{method: http.MethodGet, path: "/api/v1/signup", authorization: "Bearer token"}}, *requests)
+		assert.Equal(t, &Signup{
+			Name:                 "johnsmith",
+			Username:             "johnsmith",
+			CompliantUsername:    "johnsmith",
+			DefaultUserNamespace: "johnsmith-dev",
+			StartDate:            "2024-01-01T00:00:00Z",
+			Status: SignupStatus{
+				Ready:  true,
+				Reason: "Provisioned",
+			},
+		}, signup)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// given
+		server, _ := newServer(t, http.StatusNotFound, "")
+
+		// when
+		client := NewClient(server.URL, "token")
+		signup, err := client.GetSignup()
+
+		// then
+		assert.Nil(t, signup)
+		assert.True(t, IsNotFound(err))
+		RequireStatus(t, client, err, http.StatusNotFound)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusUnauthorized, `{"error":"no token found"}`)
+
+		// when
+		client := NewClient(server.URL, "")
+		_, err := client.GetSignup()
+
+		// then
+		regsvcErr := RequireStatus(t, client, err, http.StatusUnauthorized)
+		assert.Equal(t, "no token found", regsvcErr.TokenError)
+		assert.Empty(t, (*requests)[0].authorization)
+		assert.False(t, IsNotFound(err))
+	})
+}
+
+func TestSignup(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusAccepted, "")
+
+		// when
+		client := NewClient(server.URL, "token")
+		err := client.Signup()
+
+		// then
+		RequireStatus(t, client, err, http.StatusAccepted)
+		assert.Equal(t, http.StatusAccepted, client.StatusCode())
+		assert.Equal(t, []request{{method: http.MethodPost, path: "/api/v1/signup", authorization: "Bearer token"}}, *requests)
+	})
+
+	t.Run("without space", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusAccepted, "")
+
+		// when
+		err := NewClient(server.URL, "token").Signup(NoSpace())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "no-space=true", (*requests)[0].query)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		// given
+		server, _ := newServer(t, http.StatusConflict, `{"status":"Conflict","code":409,"message":"already exists","details":"error creating UserSignup resource"}`)
+
+		// when
+		client := NewClient(server.URL, "token")
+		err := client.Signup()
+
+		// then
+		regsvcErr := RequireStatus(t, client, err, http.StatusConflict)
+		assert.Equal(t, http.StatusConflict, client.StatusCode())
+		assert.Equal(t, &Error{
+			StatusCode: http.StatusConflict,
+			Body:       `{"status":"Conflict","code":409,"message":"already exists","details":"error creating UserSignup resource"}`,
+			Status:     "Conflict",
+			Code:       http.StatusConflict,
+			Message:    "already exists",
+			Details:    "error creating UserSignup resource",
+		}, regsvcErr)
+		assert.EqualError(t, err, `unexpected response status 409 with body: {"status":"Conflict","code":409,"message":"already exists","details":"error creating UserSignup resource"}`)
+	})
+}
+
+func TestVerification(t *testing.T) {
+	t.Run("init verification", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusNoContent, "")
+
+		// when
+		err := NewClient(server.URL, "token").InitVerification("+61", "408999999")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, (*requests)[0].method)
+		assert.Equal(t, "/api/v1/signup/verification", (*requests)[0].path)
+		assert.JSONEq(t, `{"country_code":"+61","phone_number":"408999999"}`, (*requests)[0].body)
+	})
+
+	t.Run("verify code", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusForbidden, "invalid code")
+
+		// when
+		client := NewClient(server.URL, "token")
+		err := client.VerifyCode("abc123")
+
+		// then
+		regsvcErr := RequireStatus(t, client, err, http.StatusForbidden)
+		assert.Equal(t, "invalid code", regsvcErr.Body)
+		assert.Equal(t, "/api/v1/signup/verification/abc123", (*requests)[0].path)
+	})
+
+	t.Run("verify activation code", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusOK, "")
+
+		// when
+		err := NewClient(server.URL, "token").VerifyActivationCode("abc123")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, (*requests)[0].method)
+		assert.Equal(t, "/api/v1/signup/verification/activation-code", (*requests)[0].path)
+		assert.JSONEq(t, `{"code":"abc123"}`, (*requests)[0].body)
+	})
+}
+
+func TestUnauthenticatedEndpoints(t *testing.T) {
+	t.Run("health", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusOK, `{"alive":true,"environment":"e2e-tests","revision":"abc","buildTime":"0","startTime":"1"}`)
+
+		// when
+		health, err := NewClient(server.URL, "").Health()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "/api/v1/health", (*requests)[0].path)
+		assert.Equal(t, &Health{Alive: true, Environment: "e2e-tests", Revision: "abc", BuildTime: "0", StartTime: "1"}, health)
+	})
+
+	t.Run("authconfig", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusOK, `{"auth-client-library-url":"https://sso/js","auth-client-config":"{}","signup-url":"https://signup"}`)
+
+		// when
+		config, err := NewClient(server.URL, "").AuthConfig()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "/api/v1/authconfig", (*requests)[0].path)
+		assert.Equal(t, &AuthConfig{AuthClientLibraryURL: "https://sso/js", AuthClientConfigRaw: "{}", SignupURL: "https://signup"}, config)
+	})
+
+	t.Run("segment write keys", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusOK, "some key")
+		client := NewClient(server.URL, "")
+
+		// when
+		sandboxKey, err := client.SegmentWriteKey()
+		require.NoError(t, err)
+		devSpacesKey, err := client.DevSpacesSegmentWriteKey()
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "some key", sandboxKey)
+		assert.Equal(t, "some key", devSpacesKey)
+		assert.Equal(t, "/api/v1/analytics/segment-write-key", (*requests)[0].path)
+		assert.Equal(t, "/api/v1/segment-write-key", (*requests)[1].path)
+	})
+}
+
+func TestUsernames(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		// given
+		server, requests := newServer(t, http.StatusOK, `[{"username":"johnsmith"}]`)
+
+		// when
+		usernames, err := NewClient(server.URL, "token").Usernames("johnsmith")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "/api/v1/usernames/johnsmith", (*requests)[0].path)
+		assert.Equal(t, []Username{{Username: "johnsmith"}}, usernames)
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		// given
+		server, _ := newServer(t, http.StatusOK, `{"username":"johnsmith"}`)
+
+		// when
+		_, err := NewClient(server.URL, "token").Usernames("johnsmith")
+
+		// then
+		require.ErrorContains(t, err, "unable to decode the response of GET /api/v1/usernames/johnsmith")
+		var regsvcErr *Error
+		assert.False(t, errors.As(err, &regsvcErr))
+	})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	sandboxui "github.com/codeready-toolchain/toolchain-e2e/testsupport/sandbox-ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	address   string
+	issuer    string
+	publicURL string
+	clientID  string
+	username  string
+)
+
+// main runs the mock OIDC provider which signs in the Developer Sandbox UI users in the local mode of the UI e2e tests.
+func main() {
+	cmd := &cobra.Command{
+		Use:           "mock-oidc",
+		Short:         "run a mock OIDC provider which signs in the given user without any login page",
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		Args:          cobra.NoArgs,
+		RunE:          run,
+	}
+
+	cmd.Flags().StringVar(&address, "address", ":9090", "the address to listen on")
+	cmd.Flags().StringVar(&issuer, "issuer", "", "the URL of the provider, as seen by the Developer Sandbox UI backend")
+	cmd.Flags().StringVar(&publicURL, "public-url", "", "the URL of the provider, as seen by the browser (defaults to the issuer URL)")
+	cmd.Flags().StringVar(&clientID, "client-id", "sandbox-public", "the client ID of the Developer Sandbox UI")
+	cmd.Flags().StringVar(&username, "username", "", "the username of the user to sign in")
+	_ = cmd.MarkFlagRequired("issuer")
+	_ = cmd.MarkFlagRequired("username")
+
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(_ *cobra.Command, _ []string) error {
+	provider := sandboxui.NewOIDCProvider(issuer, publicURL, clientID, username)
+	fmt.Printf("signing in '%s' (sub '%s') with issuer '%s' on '%s'\n", provider.Identity.Username, provider.Identity.ID, provider.Issuer, address)
+	server := &http.Server{
+		Addr:              address,
+		Handler:           provider,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server.ListenAndServe()
+}
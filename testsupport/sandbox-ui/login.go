@@ -49,6 +49,8 @@ func NewLoginPage(page playwright.Page, environment string) *LoginPage {
 		lp.LoginBtn = page.GetByRole("button", playwright.PageGetByRoleOptions{
 			Name: "Sign in",
 		})
+	case LocalEnv:
+		// no login page, the users are signed in by the mock OIDC provider
 	default:
 		log.Fatalf("unsupported environment: %s", environment)
 	}
@@ -78,7 +80,12 @@ func (lp *LoginPage) Login(t *testing.T, loginUsername, loginPw string) {
 	err = lp.LoginBtn.Click()
 	require.NoError(t, err)
 
-	err = lp.Header.WaitFor()
+	lp.WaitForHeader(t)
+}
+
+// WaitForHeader waits until the user is signed in, ie, until the header of the Developer Sandbox UI is displayed
+func (lp *LoginPage) WaitForHeader(t *testing.T) {
+	err := lp.Header.WaitFor()
 	require.NoError(t, err)
 
 	text, err := lp.Header.TextContent()
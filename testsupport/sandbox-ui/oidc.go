@@ -0,0 +1,237 @@
+package sandboxui
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const tokenLifespan = time.Hour
+
+// OIDCProvider a mock OpenID Connect provider which signs in a single user without any login page, and which issues
+// the same tokens as `auth.NewToken` (ie, signed with the e2e key which is trusted by the registration service in e2e mode).
+// It replaces the SSO in the local mode of the Developer Sandbox UI tests.
+type OIDCProvider struct {
+	// Issuer the URL of the provider, as seen by the Developer Sandbox UI backend (which retrieves the tokens)
+	Issuer string
+	// PublicURL the URL of the provider, as seen by the browser (which is redirected to the authorization endpoint)
+	PublicURL string
+	ClientID  string
+	Identity  *commonauth.Identity
+
+	mu    sync.Mutex
+	codes map[string]string // nonces indexed by authorization code
+}
+
+// NewOIDCProvider returns a new OIDCProvider which signs in the user with the given username.
+// The user ID is derived from the username, so that the same user is signed in across restarts of the provider.
+func NewOIDCProvider(issuer, publicURL, clientID, username string) *OIDCProvider {
+	if publicURL == "" {
+		publicURL = issuer
+	}
+	return &OIDCProvider{
+		Issuer:    strings.TrimSuffix(issuer, "/"),
+		PublicURL: strings.TrimSuffix(publicURL, "/"),
+		ClientID:  clientID,
+		Identity: &commonauth.Identity{
+			ID:       uuid.NewSHA1(uuid.NameSpaceOID, []byte(username)),
+			Username: username,
+			Email:    username + "@acme.com",
+		},
+		codes: map[string]string{},
+	}
+}
+
+// ServeHTTP serves the discovery document, as well as the authorization, token, userinfo and keys endpoints
+func (p *OIDCProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	issuerURL, err := url.Parse(p.Issuer)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	switch strings.TrimPrefix(r.URL.Path, issuerURL.Path) {
+	case "/.well-known/openid-configuration":
+		p.discovery(w)
+	case "/protocol/openid-connect/auth":
+		p.authorize(w, r)
+	case "/protocol/openid-connect/token":
+		p.token(w, r)
+	case "/protocol/openid-connect/userinfo":
+		p.userInfo(w)
+	case "/protocol/openid-connect/certs":
+		p.keys(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (p *OIDCProvider) discovery(w http.ResponseWriter) {
+	writeJSON(w, map[string]interface{}{
+		"issuer":                                p.Issuer,
+		"authorization_endpoint":                p.PublicURL + "/protocol/openid-connect/auth",
+		"token_endpoint":                        p.Issuer + "/protocol/openid-connect/token",
+		"userinfo_endpoint":                     p.Issuer + "/protocol/openid-connect/userinfo",
+		"jwks_uri":                              p.Issuer + "/protocol/openid-connect/certs",
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code", "refresh_token"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      []string{"openid", "profile", "email"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+	})
+}
+
+// authorize immediately redirects the browser to the client with a new authorization code, ie, without any login page
+func (p *OIDCProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if clientID := query.Get("client_id"); clientID != p.ClientID {
+		http.Error(w, fmt.Sprintf("unknown client '%s'", clientID), http.StatusBadRequest)
+		return
+	}
+	redirectURL, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURL.IsAbs() {
+		http.Error(w, fmt.Sprintf("invalid redirect_uri '%s'", query.Get("redirect_uri")), http.StatusBadRequest)
+		return
+	}
+	code := uuid.NewString()
+	p.mu.Lock()
+	p.codes[code] = query.Get("nonce")
+	p.mu.Unlock()
+
+	params := redirectURL.Query()
+	params.Set("code", code)
+	if state := query.Get("state"); state != "" {
+		params.Set("state", state)
+	}
+	redirectURL.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+// token exchanges an authorization code (or a refresh token) for a new set of tokens
+func (p *OIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var nonce string
+	switch grantType := r.PostForm.Get("grant_type"); grantType {
+	case "authorization_code":
+		code := r.PostForm.Get("code")
+		p.mu.Lock()
+		n, found := p.codes[code]
+		delete(p.codes, code) // codes can only be used once
+		p.mu.Unlock()
+		if !found {
+			writeTokenError(w, "invalid_grant", fmt.Sprintf("unknown authorization code '%s'", code))
+			return
+		}
+		nonce = n
+	case "refresh_token":
+		if err := p.verify(r.PostForm.Get("refresh_token")); err != nil {
+			writeTokenError(w, "invalid_grant", err.Error())
+			return
+		}
+	default:
+		writeTokenError(w, "unsupported_grant_type", fmt.Sprintf("unsupported grant type '%s'", grantType))
+		return
+	}
+
+	token, err := p.newToken(nonce)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"access_token":  token,
+		"id_token":      token,
+		"refresh_token": token,
+		"token_type":    "Bearer",
+		"expires_in":    int(tokenLifespan.Seconds()),
+		"scope":         "openid profile email",
+	})
+}
+
+func (p *OIDCProvider) userInfo(w http.ResponseWriter) {
+	writeJSON(w, map[string]interface{}{
+		"sub":                p.Identity.ID.String(),
+		"preferred_username": p.Identity.Username,
+		"email":              p.Identity.Email,
+		"email_verified":     true,
+	})
+}
+
+// keys returns the public e2e key, so that the client can verify the signature of the tokens
+func (p *OIDCProvider) keys(w http.ResponseWriter) {
+	keys := []map[string]string{}
+	for _, key := range commonauth.GetE2ETestPublicKey() {
+		keys = append(keys, map[string]string{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": key.KeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(key.Key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.Key.E)).Bytes()),
+		})
+	}
+	writeJSON(w, map[string]interface{}{
+		"keys": keys,
+	})
+}
+
+// oidcClaims the e2e token claims, along with the nonce of the authorization request (if any)
+type oidcClaims struct {
+	*commonauth.MyClaims
+	Nonce string `json:"nonce,omitempty"`
+}
+
+func (p *OIDCProvider) newToken(nonce string) (string, error) {
+	now := time.Now()
+	return commonauth.GenerateSignedE2ETestToken(*p.Identity,
+		commonauth.WithEmailClaim(p.Identity.Email),
+		commonauth.WithPreferredUsernameClaim(p.Identity.Username),
+		commonauth.WithAudClaim([]string{p.ClientID}),
+		commonauth.WithIATClaim(now),
+		commonauth.WithExpClaim(now.Add(tokenLifespan)),
+		func(token *jwt.Token) {
+			claims := token.Claims.(*commonauth.MyClaims)
+			claims.Issuer = p.Issuer
+			token.Claims = &oidcClaims{MyClaims: claims, Nonce: nonce}
+		})
+}
+
+// verify verifies the signature, the issuer and the expiry of the given token
+func (p *OIDCProvider) verify(token string) error {
+	_, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		for _, key := range commonauth.GetE2ETestPublicKey() {
+			if key.KeyID == t.Header["kid"] {
+				return key.Key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown kid '%v'", t.Header["kid"])
+	}, jwt.WithIssuer(p.Issuer), jwt.WithValidMethods([]string{"RS256"}))
+	return err
+}
+
+func writeTokenError(w http.ResponseWriter, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
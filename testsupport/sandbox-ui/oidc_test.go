@@ -0,0 +1,203 @@
+package sandboxui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCProvider(t *testing.T) {
+	// given
+	provider := NewOIDCProvider("http://oidc.example.com/auth/realms/sandbox-dev/", "http://localhost:9090/auth/realms/sandbox-dev", "sandbox-public", "johnsmith")
+
+	t.Run("discovery", func(t *testing.T) {
+		// when
+		resp := serve(provider, http.MethodGet, "/auth/realms/sandbox-dev/.well-known/openid-configuration", nil)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		config := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &config))
+		assert.Equal(t, "http://oidc.example.com/auth/realms/sandbox-dev", config["issuer"])
+		assert.Equal(t, "http://localhost:9090/auth/realms/sandbox-dev/protocol/openid-connect/auth", config["authorization_endpoint"])
+		assert.Equal(t, "http://oidc.example.com/auth/realms/sandbox-dev/protocol/openid-connect/token", config["token_endpoint"])
+		assert.Equal(t, "http://oidc.example.com/auth/realms/sandbox-dev/protocol/openid-connect/certs", config["jwks_uri"])
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		// when
+		resp := serve(provider, http.MethodGet, "/auth/realms/sandbox-dev/protocol/openid-connect/certs", nil)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		keys := map[string][]map[string]string{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &keys))
+		require.Len(t, keys["keys"], 1)
+		assert.Equal(t, commonauth.GetE2ETestPublicKey()[0].KeyID, keys["keys"][0]["kid"])
+		assert.Equal(t, "AQAB", keys["keys"][0]["e"])
+	})
+
+	t.Run("sign in", func(t *testing.T) {
+		// when
+		resp := serve(provider, http.MethodGet, "/auth/realms/sandbox-dev/protocol/openid-connect/auth?"+url.Values{
+			"client_id":     {"sandbox-public"},
+			"redirect_uri":  {"https://rhdh.example.com/api/auth/oidc/handler/frame?env=production"},
+			"response_type": {"code"},
+			"state":         {"some-state"},
+			"nonce":         {"some-nonce"},
+		}.Encode(), nil)
+
+		// then
+		require.Equal(t, http.StatusFound, resp.Code)
+		location, err := url.Parse(resp.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, "rhdh.example.com", location.Host)
+		assert.Equal(t, "/api/auth/oidc/handler/frame", location.Path)
+		assert.Equal(t, "production", location.Query().Get("env"))
+		assert.Equal(t, "some-state", location.Query().Get("state"))
+		code := location.Query().Get("code")
+		require.NotEmpty(t, code)
+
+		t.Run("exchange code", func(t *testing.T) {
+			// when
+			resp := serve(provider, http.MethodPost, "/auth/realms/sandbox-dev/protocol/openid-connect/token", url.Values{
+				"grant_type": {"authorization_code"},
+				"code":       {code},
+			})
+
+			// then
+			require.Equal(t, http.StatusOK, resp.Code)
+			tokens := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &tokens))
+			assert.Equal(t, "Bearer", tokens["token_type"])
+			claims := parseE2EToken(t, tokens["id_token"].(string))
+			assert.Equal(t, "http://oidc.example.com/auth/realms/sandbox-dev", claims["iss"])
+			assert.Equal(t, []interface{}{"sandbox-public"}, claims["aud"])
+			assert.Equal(t, "some-nonce", claims["nonce"])
+			assert.Equal(t, provider.Identity.ID.String(), claims["sub"])
+			assert.Equal(t, "johnsmith", claims["preferred_username"])
+			assert.Equal(t, "johnsmith@acme.com", claims["email"])
+
+			t.Run("refresh", func(t *testing.T) {
+				// when
+				resp := serve(provider, http.MethodPost, "/auth/realms/sandbox-dev/protocol/openid-connect/token", url.Values{
+					"grant_type":    {"refresh_token"},
+					"refresh_token": {tokens["refresh_token"].(string)},
+				})
+
+				// then
+				require.Equal(t, http.StatusOK, resp.Code)
+				refreshed := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &refreshed))
+				claims := parseE2EToken(t, refreshed["id_token"].(string))
+				assert.Equal(t, provider.Identity.ID.String(), claims["sub"])
+				assert.NotContains(t, claims, "nonce")
+			})
+
+			t.Run("code cannot be reused", func(t *testing.T) {
+				// when
+				resp := serve(provider, http.MethodPost, "/auth/realms/sandbox-dev/protocol/openid-connect/token", url.Values{
+					"grant_type": {"authorization_code"},
+					"code":       {code},
+				})
+
+				// then
+				assert.Equal(t, http.StatusBadRequest, resp.Code)
+				assert.Contains(t, resp.Body.String(), "invalid_grant")
+			})
+		})
+	})
+
+	t.Run("same user across restarts", func(t *testing.T) {
+		// when
+		other := NewOIDCProvider("http://oidc.example.com", "", "sandbox-public", "johnsmith")
+
+		// then
+		assert.Equal(t, provider.Identity.ID, other.Identity.ID)
+		assert.Equal(t, "http://oidc.example.com", other.PublicURL)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		t.Run("unknown client", func(t *testing.T) {
+			// when
+			resp := serve(provider, http.MethodGet, "/auth/realms/sandbox-dev/protocol/openid-connect/auth?client_id=other&redirect_uri=https://rhdh.example.com", nil)
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+		})
+
+		t.Run("missing redirect uri", func(t *testing.T) {
+			// when
+			resp := serve(provider, http.MethodGet, "/auth/realms/sandbox-dev/protocol/openid-connect/auth?client_id=sandbox-public", nil)
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+		})
+
+		t.Run("refresh token from another issuer", func(t *testing.T) {
+			// given
+			token, err := commonauth.GenerateSignedE2ETestToken(*commonauth.NewIdentity())
+			require.NoError(t, err)
+
+			// when
+			resp := serve(provider, http.MethodPost, "/auth/realms/sandbox-dev/protocol/openid-connect/token", url.Values{
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {token},
+			})
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Contains(t, resp.Body.String(), "invalid_grant")
+		})
+
+		t.Run("unsupported grant type", func(t *testing.T) {
+			// when
+			resp := serve(provider, http.MethodPost, "/auth/realms/sandbox-dev/protocol/openid-connect/token", url.Values{
+				"grant_type": {"password"},
+			})
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Contains(t, resp.Body.String(), "unsupported_grant_type")
+		})
+
+		t.Run("unknown path", func(t *testing.T) {
+			// when
+			resp := serve(provider, http.MethodGet, "/auth/realms/sandbox-dev/unknown", nil)
+
+			// then
+			assert.Equal(t, http.StatusNotFound, resp.Code)
+		})
+	})
+}
+
+func serve(provider *OIDCProvider, method, target string, form url.Values) *httptest.ResponseRecorder {
+	var req *http.Request
+	if form != nil {
+		req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	resp := httptest.NewRecorder()
+	provider.ServeHTTP(resp, req)
+	return resp
+}
+
+// parseE2EToken verifies that the given token is signed with the e2e key and returns its claims
+func parseE2EToken(t *testing.T, token string) jwt.MapClaims {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(_ *jwt.Token) (interface{}, error) {
+		return commonauth.GetE2ETestPublicKey()[0].Key, nil
+	})
+	require.NoError(t, err)
+	return claims
+}
@@ -15,6 +15,9 @@ var (
 	setupOnce sync.Once
 
 	UIE2ETestsEnv = "ui-e2e-tests"
+	// LocalEnv the environment in which the Developer Sandbox UI signs in the users via the mock OIDC provider (see `OIDCProvider`),
+	// ie, without any SSO login page nor cookie consent
+	LocalEnv = "local"
 )
 
 func Setup(t *testing.T, testName string) playwright.Page {
@@ -30,16 +33,14 @@ func Setup(t *testing.T, testName string) playwright.Page {
 
 	env := viper.GetString("ENVIRONMENT")
 	baseURL := viper.GetString("BASE_URL")
-	username := viper.GetString("SSO_USERNAME")
-	password := viper.GetString("SSO_PASSWORD")
 
 	pw, err := playwright.Run()
 	require.NoError(t, err)
 
-	browser := launchBrowser(t, pw)
+	browser := launchBrowser(t, pw, env)
 
 	opts := playwright.BrowserNewContextOptions{}
-	if env == UIE2ETestsEnv {
+	if env == UIE2ETestsEnv || env == LocalEnv {
 		opts.IgnoreHttpsErrors = playwright.Bool(true)
 	}
 
@@ -55,13 +56,19 @@ func Setup(t *testing.T, testName string) playwright.Page {
 	login := NewLoginPage(page, env)
 	login.Navigate(t, baseURL)
 
+	if env == LocalEnv {
+		// the mock OIDC provider redirects back to the Developer Sandbox UI right away
+		login.WaitForHeader(t)
+		return page
+	}
+
 	if env == "dev" {
 		// handle cookie consent
 		// on dev environment, the cookie consent appears after the login page is loaded
 		handleCookiesConsent(t, page)
 	}
 
-	login.Login(t, username, password)
+	login.Login(t, viper.GetString("SSO_USERNAME"), viper.GetString("SSO_PASSWORD"))
 
 	// handle cookie consent
 	handleCookiesConsent(t, page)
@@ -69,19 +76,24 @@ func Setup(t *testing.T, testName string) playwright.Page {
 	return page
 }
 
-func launchBrowser(t *testing.T, pw *playwright.Playwright) playwright.Browser {
+func launchBrowser(t *testing.T, pw *playwright.Playwright, env string) playwright.Browser {
 	var browser playwright.Browser
 	var err error
 
 	browserName := viper.GetString("BROWSER")
+	opts := playwright.BrowserTypeLaunchOptions{}
+	if env == LocalEnv {
+		// always headless, so that the tests can run in any CI job
+		opts.Headless = playwright.Bool(true)
+	}
 
 	switch browserName {
 	case "chromium":
-		browser, err = pw.Chromium.Launch()
+		browser, err = pw.Chromium.Launch(opts)
 	case "firefox":
-		browser, err = pw.Firefox.Launch()
+		browser, err = pw.Firefox.Launch(opts)
 	case "webkit":
-		browser, err = pw.WebKit.Launch()
+		browser, err = pw.WebKit.Launch(opts)
 	default:
 		t.Fatalf("unsupported browser: %s", browserName)
 	}
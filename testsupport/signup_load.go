@@ -0,0 +1,298 @@
+package testsupport
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/regsvc"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// SignupLoadRequest provides an API for signing up many users concurrently via the registration service REST endpoint,
+// in order to verify the behaviour of the platform under contention (eg, capacity limits or automatic approval).
+// Contrary to SignupRequest, it does not fail the test when a signup is rejected or when a user does not become ready:
+// the outcome of each signup is collected in the SignupLoadResult so that the test can make its own assertions, eg:
+//
+// result := NewSignupLoadRequest(awaitilities).
+// Users("load-testuser", 20).
+// Concurrency(5).
+// ArrivalRate(10).
+// WaitForReady().
+// Execute(t)
+// assert.Equal(t, 20, result.CountByHTTPStatus()[http.StatusAccepted])
+type SignupLoadRequest struct {
+	awaitilities    wait.Awaitilities
+	identities      []*commonauth.Identity
+	concurrency     int
+	arrivalRate     float64
+	waitForReady    bool
+	readyTimeout    time.Duration
+	cleanupDisabled bool
+}
+
+// SignupLoadRecord the outcome of a single signup of a SignupLoadRequest
+type SignupLoadRecord struct {
+	// Username the username of the user who signed up
+	Username string
+	// HTTPStatus the status code of the response of the signup endpoint (0 if the request could not be sent)
+	HTTPStatus int
+	// Latency the time it took to get the response of the signup endpoint
+	Latency time.Duration
+	// UserSignup the UserSignup resource of the user, if it was created
+	UserSignup *toolchainv1alpha1.UserSignup
+	// Ready whether the UserSignup reached the `Complete` condition (only set if WaitForReady() was called)
+	Ready bool
+	// TimeToReady the time between the signup request and the UserSignup reaching the `Complete` condition
+	TimeToReady time.Duration
+	// Err the error that occurred while signing up or while waiting for the user to be ready, if any
+	Err error
+}
+
+// SignupLoadResult the outcome of all the signups of a SignupLoadRequest
+type SignupLoadResult struct {
+	// Records the outcome of each signup, in the order of the identities
+	Records []SignupLoadRecord
+	// Duration the total duration of the signups (including the wait for the users to be ready)
+	Duration time.Duration
+}
+
+// NewSignupLoadRequest creates a new request to sign up many users concurrently. By default, the signups are sent
+// one at a time, as fast as possible.
+func NewSignupLoadRequest(awaitilities wait.Awaitilities) *SignupLoadRequest {
+	return &SignupLoadRequest{
+		awaitilities: awaitilities,
+		concurrency:  1,
+		readyTimeout: awaitilities.Host().Timeout,
+	}
+}
+
+// Identities specifies the identities of the users to sign up
+func (r *SignupLoadRequest) Identities(identities ...*commonauth.Identity) *SignupLoadRequest {
+	r.identities = append(r.identities, identities...)
+	return r
+}
+
+// Users generates the identities of `count` users whose usernames start with the given prefix
+func (r *SignupLoadRequest) Users(prefix string, count int) *SignupLoadRequest {
+	for i := 0; i < count; i++ {
+		r.identities = append(r.identities, &commonauth.Identity{
+			ID:       uuid.New(),
+			Username: fmt.Sprintf("%s-%d-%s", prefix, i, uuid.NewString()[:8]),
+		})
+	}
+	return r
+}
+
+// Concurrency specifies the maximum number of signups that are in progress at the same time
+func (r *SignupLoadRequest) Concurrency(concurrency int) *SignupLoadRequest {
+	r.concurrency = concurrency
+	return r
+}
+
+// ArrivalRate specifies the number of signups that are started per second. If not specified, then the signups
+// are started as soon as the concurrency allows it.
+func (r *SignupLoadRequest) ArrivalRate(perSecond float64) *SignupLoadRequest {
+	r.arrivalRate = perSecond
+	return r
+}
+
+// WaitForReady will wait until the UserSignups of the accepted signups reach the `Complete` condition,
+// and record how long it took.
+func (r *SignupLoadRequest) WaitForReady() *SignupLoadRequest {
+	r.waitForReady = true
+	return r
+}
+
+// ReadyTimeout overrides how long to wait for each user to be ready. Defaults to the host awaitility timeout.
+func (r *SignupLoadRequest) ReadyTimeout(timeout time.Duration) *SignupLoadRequest {
+	r.readyTimeout = timeout
+	return r
+}
+
+// DisableCleanup disables automatic cleanup of the UserSignup resources after the test has completed
+func (r *SignupLoadRequest) DisableCleanup() *SignupLoadRequest {
+	r.cleanupDisabled = true
+	return r
+}
+
+// Execute sends all the signup requests against the Registration service REST endpoint and waits until all of them
+// completed. This function may only be called once, and must be called after all other functions.
+func (r *SignupLoadRequest) Execute(t *testing.T) *SignupLoadResult {
+	require.NotEmpty(t, r.identities, "no identity to sign up")
+	require.Positive(t, r.concurrency, "concurrency must be positive")
+	hostAwait := r.awaitilities.Host()
+	err := hostAwait.WaitUntilBaseNSTemplateTierIsUpdated(t)
+	require.NoError(t, err)
+
+	tokens := make([]string, len(r.identities))
+	for i, identity := range r.identities {
+		usernamesInParallel.add(t, identity.Username)
+		tokens[i], err = authsupport.NewTokenFromIdentity(identity, commonauth.WithEmailClaim(identity.Username+"@test.com"))
+		require.NoError(t, err)
+	}
+
+	t.Logf("signing up %d users with a concurrency of %d", len(r.identities), r.concurrency)
+	result := &SignupLoadResult{
+		Records: make([]SignupLoadRecord, len(r.identities)),
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < r.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result.Records[i] = r.signup(t, hostAwait, r.identities[i], tokens[i])
+			}
+		}()
+	}
+
+	start := time.Now()
+	var ticker *time.Ticker
+	if r.arrivalRate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / r.arrivalRate))
+		defer ticker.Stop()
+	}
+	for i := range r.identities {
+		if ticker != nil && i > 0 {
+			<-ticker.C
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	result.Duration = time.Since(start)
+
+	if !r.cleanupDisabled {
+		for _, record := range result.Records {
+			if record.UserSignup != nil {
+				cleanup.AddCleanTasks(t, hostAwait.Client, record.UserSignup)
+			}
+		}
+	}
+	t.Logf("signed up %d users in %s: %v", len(r.identities), result.Duration, result.CountByHTTPStatus())
+	return result
+}
+
+// signup signs up a single user. It must not fail the test since it runs in a separate goroutine.
+func (r *SignupLoadRequest) signup(t *testing.T, hostAwait *wait.HostAwaitility, identity *commonauth.Identity, token string) SignupLoadRecord {
+	record := SignupLoadRecord{
+		Username: identity.Username,
+	}
+	start := time.Now()
+	regsvcClient := regsvc.NewClient(hostAwait.RegistrationServiceURL, token)
+	err := regsvcClient.Signup()
+	record.Latency = time.Since(start)
+	record.HTTPStatus = regsvcClient.StatusCode()
+	var regsvcErr *regsvc.Error
+	switch {
+	case errors.As(err, &regsvcErr):
+		return record
+	case err != nil:
+		record.Err = err
+		return record
+	}
+
+	name := wait.EncodeUserIdentifier(identity.Username)
+	if !r.waitForReady {
+		record.UserSignup, record.Err = hostAwait.WaitForUserSignup(t, name)
+		return record
+	}
+	record.UserSignup, record.Err = hostAwait.WithRetryOptions(wait.TimeoutOption(r.readyTimeout)).
+		WaitForUserSignup(t, name, wait.ContainsCondition(wait.Complete()))
+	if record.Err == nil {
+		record.Ready = true
+		record.TimeToReady = time.Since(start)
+	}
+	return record
+}
+
+// CountByHTTPStatus returns the number of signups per HTTP status code of the signup endpoint
+func (r *SignupLoadResult) CountByHTTPStatus() map[int]int {
+	counts := map[int]int{}
+	for _, record := range r.Records {
+		counts[record.HTTPStatus]++
+	}
+	return counts
+}
+
+// Ready returns the records of the users who became ready
+func (r *SignupLoadResult) Ready() []SignupLoadRecord {
+	var ready []SignupLoadRecord
+	for _, record := range r.Records {
+		if record.Ready {
+			ready = append(ready, record)
+		}
+	}
+	return ready
+}
+
+// Errors returns the errors that occurred while signing up or while waiting for the users to be ready
+func (r *SignupLoadResult) Errors() []error {
+	var errs []error
+	for _, record := range r.Records {
+		if record.Err != nil {
+			errs = append(errs, fmt.Errorf("signup of '%s' failed: %w", record.Username, record.Err))
+		}
+	}
+	return errs
+}
+
+// Throughput returns the number of accepted signups per second
+func (r *SignupLoadResult) Throughput() float64 {
+	if r.Duration == 0 {
+		return 0
+	}
+	return float64(r.CountByHTTPStatus()[http.StatusAccepted]) / r.Duration.Seconds()
+}
+
+// LatencyPercentile returns the given percentile (between 0 and 100) of the latencies of the signup endpoint
+func (r *SignupLoadResult) LatencyPercentile(p float64) time.Duration {
+	latencies := make([]time.Duration, 0, len(r.Records))
+	for _, record := range r.Records {
+		latencies = append(latencies, record.Latency)
+	}
+	return percentile(latencies, p)
+}
+
+// TimeToReadyPercentile returns the given percentile (between 0 and 100) of the time it took for the users to be ready.
+// Only the users who became ready are taken into account.
+func (r *SignupLoadResult) TimeToReadyPercentile(p float64) time.Duration {
+	var durations []time.Duration
+	for _, record := range r.Ready() {
+		durations = append(durations, record.TimeToReady)
+	}
+	return percentile(durations, p)
+}
+
+// percentile returns the given percentile of the durations, using the nearest-rank method
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,38 @@
+package testsupport_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignupLoadResult(t *testing.T) {
+	// given
+	result := &SignupLoadResult{
+		Records: []SignupLoadRecord{
+			{Username: "user-1", HTTPStatus: http.StatusAccepted, Latency: 100 * time.Millisecond, Ready: true, TimeToReady: 3 * time.Second},
+			{Username: "user-2", HTTPStatus: http.StatusAccepted, Latency: 300 * time.Millisecond, Ready: true, TimeToReady: 5 * time.Second},
+			{Username: "user-3", HTTPStatus: http.StatusAccepted, Latency: 200 * time.Millisecond, Err: errors.New("timed out")},
+			{Username: "user-4", HTTPStatus: http.StatusForbidden, Latency: 400 * time.Millisecond},
+		},
+		Duration: 2 * time.Second,
+	}
+
+	// then
+	assert.Equal(t, map[int]int{http.StatusAccepted: 3, http.StatusForbidden: 1}, result.CountByHTTPStatus())
+	assert.Len(t, result.Ready(), 2)
+	errs := result.Errors()
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "signup of 'user-3' failed: timed out")
+	assert.InDelta(t, 1.5, result.Throughput(), 0.001)
+	assert.Equal(t, 200*time.Millisecond, result.LatencyPercentile(50))
+	assert.Equal(t, 400*time.Millisecond, result.LatencyPercentile(99))
+	assert.Equal(t, 100*time.Millisecond, result.LatencyPercentile(0))
+	assert.Equal(t, 5*time.Second, result.TimeToReadyPercentile(90))
+	assert.Equal(t, time.Duration(0), (&SignupLoadResult{}).LatencyPercentile(50))
+}
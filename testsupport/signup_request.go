@@ -1,7 +1,6 @@
 package testsupport
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,6 +14,7 @@ import (
 	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
 	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/regsvc"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
@@ -22,8 +22,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var httpClient = HTTPClient
-
 // SignupRequest provides an API for creating a new UserSignup via the registration service REST endpoint. It operates
 // with a set of sensible default values which can be overridden via its various functions.  Function chaining may
 // be used to achieve an efficient "single-statement" UserSignup creation, for example:
@@ -228,30 +226,31 @@ func (r *SignupRequest) Execute(t *testing.T) *SignupResult {
 		ID:       r.identityID,
 		Username: r.username,
 	}
-	claims := []commonauth.ExtraClaim{commonauth.WithEmailClaim(r.email)}
+	claims := []authsupport.Claim{authsupport.WithEmail(r.email)}
 	if r.originalSub != "" {
-		claims = append(claims, commonauth.WithOriginalSubClaim(r.originalSub))
+		claims = append(claims, authsupport.WithOriginalSub(r.originalSub))
 	}
 	if r.userID != "" {
-		claims = append(claims, commonauth.WithUserIDClaim(r.userID))
+		claims = append(claims, authsupport.WithUserID(r.userID))
 	}
 	if r.accountID != "" {
-		claims = append(claims, commonauth.WithAccountIDClaim(r.accountID))
+		claims = append(claims, authsupport.WithAccountID(r.accountID))
 	}
 	if r.accountNumber != "" {
-		claims = append(claims, commonauth.WithAccountNumberClaim(r.accountNumber))
+		claims = append(claims, authsupport.WithAccountNumber(r.accountNumber))
 	}
 	r.token, err = authsupport.NewTokenFromIdentity(userIdentity, claims...)
 	require.NoError(t, err)
 
-	queryParams := map[string]string{}
+	var options []regsvc.SignupOption
 	if r.noSpace {
-		queryParams["no-space"] = "true"
+		options = append(options, regsvc.NoSpace())
 	}
 
 	// Call the signup POST endpoint
-	invokeEndpoint(t, "POST", hostAwait.RegistrationServiceURL+"/api/v1/signup",
-		r.token, "", r.requiredHTTPStatus, queryParams)
+	client := regsvc.NewClient(hostAwait.RegistrationServiceURL, r.token)
+	err = client.Signup(options...)
+	regsvc.RequireStatus(t, client, err, r.requiredHTTPStatus)
 
 	// Wait for the UserSignup to be created
 	userSignup, err := hostAwait.WaitForUserSignup(t, wait.EncodeUserIdentifier(userIdentity.Username))
@@ -333,42 +332,6 @@ func (r *SignupRequest) Execute(t *testing.T) *SignupResult {
 	}
 }
 
-func invokeEndpoint(t *testing.T, method, path, authToken, requestBody string, requiredStatus int, queryParams map[string]string) map[string]interface{} {
-	var reqBody io.Reader
-	if requestBody != "" {
-		reqBody = strings.NewReader(requestBody)
-	}
-	req, err := http.NewRequest(method, path, reqBody)
-	require.NoError(t, err)
-	req.Header.Set("Authorization", "Bearer "+authToken)
-	req.Header.Set("content-type", "application/json")
-
-	if len(queryParams) > 0 {
-		q := req.URL.Query()
-		for key, val := range queryParams {
-			q.Add(key, val)
-		}
-		req.URL.RawQuery = q.Encode()
-	}
-
-	req.Close = true
-	resp, err := httpClient.Do(req) // nolint:bodyclose // see `defer Close(t, resp)`
-	require.NoError(t, err, "error posting signup request.\nmethod : %s\npath : %s\nauthToken : %s\nbody : %s", method, path, authToken, requestBody)
-	defer Close(t, resp)
-
-	body, err := io.ReadAll(resp.Body)
-	require.NoError(t, err)
-	require.NotNil(t, body)
-	require.Equal(t, requiredStatus, resp.StatusCode, "unexpected response status with body: %s", body)
-
-	mp := make(map[string]interface{})
-	if len(body) > 0 {
-		err = json.Unmarshal(body, &mp)
-		require.NoError(t, err)
-	}
-	return mp
-}
-
 func Close(t *testing.T, resp *http.Response) {
 	if resp == nil {
 		return
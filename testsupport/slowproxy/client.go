@@ -0,0 +1,79 @@
+package slowproxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/util"
+)
+
+// Client a client of the control API of the slow proxy, to change the delay of the responses and the upstream address
+// during the tests
+type Client struct {
+	URL  string
+	http *http.Client
+}
+
+// NewClient returns a new Client of the control API of the slow proxy at the given URL
+func NewClient(url string) *Client {
+	return &Client{
+		URL:  strings.TrimSuffix(url, "/"),
+		http: util.NewInsecureHTTPClient(),
+	}
+}
+
+// Delay returns the current delay of the responses of the proxy
+func (c *Client) Delay() (time.Duration, error) {
+	body, err := c.do(http.MethodGet, c.URL+"/delay", http.StatusOK)
+	if err != nil {
+		return 0, err
+	}
+	resp := delayResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, err
+	}
+	return time.ParseDuration(resp.Delay)
+}
+
+// SetDelay sets the delay of the responses of the proxy
+func (c *Client) SetDelay(delay time.Duration) error {
+	_, err := c.do(http.MethodPut, c.URL+"/delay?"+url.Values{"duration": {delay.String()}}.Encode(), http.StatusOK)
+	return err
+}
+
+// SetUpstream sets the upstream address (`host:port`) of the proxy
+func (c *Client) SetUpstream(address string) error {
+	_, err := c.do(http.MethodPut, c.URL+"/upstream?"+url.Values{"address": {address}}.Encode(), http.StatusOK)
+	return err
+}
+
+// Reset removes the delay of the responses of the proxy
+func (c *Client) Reset() error {
+	_, err := c.do(http.MethodDelete, c.URL+"/delay", http.StatusNoContent)
+	return err
+}
+
+func (c *Client) do(method, url string, expectedStatus int) ([]byte, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != expectedStatus {
+		return nil, fmt.Errorf("unexpected response status %d with body: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
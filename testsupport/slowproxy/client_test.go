@@ -0,0 +1,65 @@
+package slowproxy
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	// given
+	proxy := NewProxy("127.0.0.1:1", discardLogger)
+	httpServer := httptest.NewServer(proxy)
+	t.Cleanup(httpServer.Close)
+	client := NewClient(httpServer.URL + "/")
+
+	t.Run("no delay", func(t *testing.T) {
+		// when
+		delay, err := client.Delay()
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, delay)
+	})
+
+	t.Run("set delay", func(t *testing.T) {
+		// when
+		err := client.SetDelay(1500 * time.Millisecond)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1500*time.Millisecond, proxy.Delay())
+		delay, err := client.Delay()
+		require.NoError(t, err)
+		assert.Equal(t, 1500*time.Millisecond, delay)
+	})
+
+	t.Run("set upstream", func(t *testing.T) {
+		// when
+		err := client.SetUpstream("api.member.example.com:6443")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "api.member.example.com:6443", proxy.Upstream())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		// when
+		err := client.Reset()
+
+		// then
+		require.NoError(t, err)
+		assert.Zero(t, proxy.Delay())
+	})
+
+	t.Run("invalid delay", func(t *testing.T) {
+		// when
+		err := client.SetDelay(-time.Second)
+
+		// then
+		require.EqualError(t, err, "unexpected response status 400 with body: invalid duration '-1s'\n")
+	})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/slowproxy"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	address        string
+	controlAddress string
+	upstream       string
+	delay          time.Duration
+)
+
+// main runs the slow proxy which forwards the connections to the API server of a member cluster and delays its responses.
+func main() {
+	cmd := &cobra.Command{
+		Use:           "slow-proxy",
+		Short:         "run a TCP proxy which delays the responses of the upstream server",
+		SilenceErrors: true,
+		SilenceUsage:  false,
+		Args:          cobra.NoArgs,
+		RunE:          run,
+	}
+
+	cmd.Flags().StringVar(&address, "address", ":6443", "the address to listen on for the connections to forward")
+	cmd.Flags().StringVar(&controlAddress, "control-address", ":8080", "the address to listen on for the control API")
+	cmd.Flags().StringVar(&upstream, "upstream", "kubernetes.default.svc:443", "the initial address (host:port) of the upstream server, which can be changed via the control API")
+	cmd.Flags().DurationVar(&delay, "delay", 0, "the initial delay of the responses")
+
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(_ *cobra.Command, _ []string) error {
+	proxy := slowproxy.NewProxy(upstream, log.New(os.Stderr, "", log.LstdFlags))
+	proxy.SetDelay(delay)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	errs := make(chan error, 2)
+	go func() {
+		fmt.Printf("forwarding the connections on '%s' to '%s'\n", address, upstream)
+		errs <- proxy.Serve(listener)
+	}()
+	go func() {
+		fmt.Printf("serving the control API on '%s'\n", controlAddress)
+		server := &http.Server{
+			Addr:              controlAddress,
+			Handler:           proxy,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		errs <- server.ListenAndServe()
+	}()
+	// stop as soon as one of the servers fails
+	return <-errs
+}
@@ -0,0 +1,168 @@
+package slowproxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Proxy a TCP proxy which forwards the connections to an upstream address (typically, the API server of a member cluster),
+// and which delays the responses of the upstream server. Since the bytes are forwarded as-is, the TLS connections are
+// established end-to-end with the upstream server, so the clients only need to use the hostname of the upstream server
+// as the TLS server name. The delay and the upstream address can be changed at any time via the `/delay` and `/upstream`
+// endpoints of the control API.
+type Proxy struct {
+	logger   *log.Logger
+	mu       sync.RWMutex
+	upstream string
+	delay    time.Duration
+}
+
+// NewProxy returns a new Proxy to the given upstream address (`host:port`), without any delay. The errors which occur
+// while forwarding the connections are logged with the given logger.
+func NewProxy(upstream string, logger *log.Logger) *Proxy {
+	return &Proxy{
+		logger:   logger,
+		upstream: upstream,
+	}
+}
+
+// Upstream returns the current upstream address of the proxy
+func (p *Proxy) Upstream() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.upstream
+}
+
+// SetUpstream sets the upstream address (`host:port`) of the proxy, which applies to the new connections only
+func (p *Proxy) SetUpstream(upstream string) {
+	p.mu.Lock()
+	p.upstream = upstream
+	p.mu.Unlock()
+}
+
+// Delay returns the current delay of the responses
+func (p *Proxy) Delay() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.delay
+}
+
+// SetDelay sets the delay of the responses, which applies to the new and to the existing connections
+func (p *Proxy) SetDelay(delay time.Duration) {
+	p.mu.Lock()
+	p.delay = delay
+	p.mu.Unlock()
+}
+
+// Serve accepts the connections on the given listener and forwards them to the upstream address, until the listener is closed
+func (p *Proxy) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			if err := p.forward(conn); err != nil {
+				p.logger.Printf("failed to forward the connection from '%s': %s", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// forward forwards the given connection to the upstream address, until one of the sides is closed
+func (p *Proxy) forward(conn net.Conn) error {
+	defer conn.Close()
+	address := p.Upstream()
+	upstream, err := net.DialTimeout("tcp", address, 10*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to connect to '%s': %w", address, err)
+	}
+	defer upstream.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(&delayedWriter{proxy: p, out: conn}, upstream)
+		done <- struct{}{}
+	}()
+	// close both connections as soon as one of the sides is closed
+	<-done
+	return nil
+}
+
+// delayedWriter delays each write (ie, each chunk of the response of the upstream server) by the current delay of the proxy
+type delayedWriter struct {
+	proxy *Proxy
+	out   io.Writer
+}
+
+func (w *delayedWriter) Write(data []byte) (int, error) {
+	if delay := w.proxy.Delay(); delay > 0 {
+		time.Sleep(delay)
+	}
+	return w.out.Write(data)
+}
+
+// delayResponse the current delay of the proxy, as returned by the control API
+type delayResponse struct {
+	Delay string `json:"delay"`
+}
+
+// upstreamResponse the current upstream address of the proxy, as returned by the control API
+type upstreamResponse struct {
+	Upstream string `json:"upstream"`
+}
+
+// ServeHTTP serves the control API of the proxy: `/delay` (GET to retrieve the delay, PUT with a `duration` query
+// parameter to set it, DELETE to reset it), `/upstream` (GET to retrieve the upstream address, PUT with an `address` query
+// parameter to set it) and `/healthz`
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/delay" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, delayResponse{Delay: p.Delay().String()})
+	case r.URL.Path == "/delay" && r.Method == http.MethodPut:
+		delay, err := time.ParseDuration(r.URL.Query().Get("duration"))
+		if err != nil || delay < 0 {
+			http.Error(w, fmt.Sprintf("invalid duration '%s'", r.URL.Query().Get("duration")), http.StatusBadRequest)
+			return
+		}
+		p.SetDelay(delay)
+		writeJSON(w, http.StatusOK, delayResponse{Delay: delay.String()})
+	case r.URL.Path == "/delay" && r.Method == http.MethodDelete:
+		p.SetDelay(0)
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Path == "/upstream" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, upstreamResponse{Upstream: p.Upstream()})
+	case r.URL.Path == "/upstream" && r.Method == http.MethodPut:
+		address := r.URL.Query().Get("address")
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			http.Error(w, fmt.Sprintf("invalid address '%s'", address), http.StatusBadRequest)
+			return
+		}
+		p.SetUpstream(address)
+		writeJSON(w, http.StatusOK, upstreamResponse{Upstream: address})
+	case r.URL.Path == "/healthz" && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
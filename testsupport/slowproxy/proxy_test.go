@@ -0,0 +1,260 @@
+package slowproxy
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForward(t *testing.T) {
+	// given
+	upstream := startEchoServer(t)
+	proxy := NewProxy(upstream, discardLogger)
+	address := startProxy(t, proxy)
+
+	t.Run("without delay", func(t *testing.T) {
+		// when
+		elapsed, response := roundTrip(t, address, "hello\n")
+
+		// then
+		assert.Equal(t, "hello\n", response)
+		assert.Less(t, elapsed, 500*time.Millisecond)
+	})
+
+	t.Run("with delay", func(t *testing.T) {
+		// given
+		proxy.SetDelay(500 * time.Millisecond)
+		t.Cleanup(func() {
+			proxy.SetDelay(0)
+		})
+
+		// when
+		elapsed, response := roundTrip(t, address, "hello\n")
+
+		// then
+		assert.Equal(t, "hello\n", response)
+		assert.GreaterOrEqual(t, elapsed, 500*time.Millisecond)
+	})
+
+	t.Run("upstream not available", func(t *testing.T) {
+		// given
+		logs := &logBuffer{}
+		address := startProxy(t, NewProxy("127.0.0.1:1", log.New(logs, "", 0)))
+		conn, err := net.Dial("tcp", address)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// when
+		_, err = bufio.NewReader(conn).ReadString('\n')
+
+		// then the connection is closed by the proxy, and the error is logged
+		require.Error(t, err)
+		assert.Eventually(t, func() bool {
+			return strings.Contains(logs.String(), "failed to connect to '127.0.0.1:1'")
+		}, time.Second, 10*time.Millisecond, "logs: %s", logs.String())
+	})
+
+	t.Run("change of upstream", func(t *testing.T) {
+		// given
+		proxy := NewProxy("127.0.0.1:1", discardLogger)
+		address := startProxy(t, proxy)
+
+		// when
+		proxy.SetUpstream(upstream)
+
+		// then
+		_, response := roundTrip(t, address, "hello again\n")
+		assert.Equal(t, "hello again\n", response)
+	})
+}
+
+func TestControlAPI(t *testing.T) {
+	t.Run("set delay", func(t *testing.T) {
+		// given
+		proxy := NewProxy("127.0.0.1:1", discardLogger)
+		req := httptest.NewRequest(http.MethodPut, "/delay?duration=3s", nil)
+		resp := httptest.NewRecorder()
+
+		// when
+		proxy.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"delay":"3s"}`, resp.Body.String())
+		assert.Equal(t, 3*time.Second, proxy.Delay())
+	})
+
+	t.Run("invalid delay", func(t *testing.T) {
+		// given
+		proxy := NewProxy("127.0.0.1:1", discardLogger)
+		req := httptest.NewRequest(http.MethodPut, "/delay?duration=-3s", nil)
+		resp := httptest.NewRecorder()
+
+		// when
+		proxy.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, "invalid duration '-3s'\n", resp.Body.String())
+		assert.Zero(t, proxy.Delay())
+	})
+
+	t.Run("reset delay", func(t *testing.T) {
+		// given
+		proxy := NewProxy("127.0.0.1:1", discardLogger)
+		proxy.SetDelay(time.Second)
+		req := httptest.NewRequest(http.MethodDelete, "/delay", nil)
+		resp := httptest.NewRecorder()
+
+		// when
+		proxy.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Zero(t, proxy.Delay())
+	})
+
+	t.Run("get upstream", func(t *testing.T) {
+		// given
+		proxy := NewProxy("127.0.0.1:1", discardLogger)
+		req := httptest.NewRequest(http.MethodGet, "/upstream", nil)
+		resp := httptest.NewRecorder()
+
+		// when
+		proxy.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"upstream":"127.0.0.1:1"}`, resp.Body.String())
+	})
+
+	t.Run("set upstream", func(t *testing.T) {
+		// given
+		proxy := NewProxy("127.0.0.1:1", discardLogger)
+		req := httptest.NewRequest(http.MethodPut, "/upstream?address=api.member.example.com:6443", nil)
+		resp := httptest.NewRecorder()
+
+		// when
+		proxy.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"upstream":"api.member.example.com:6443"}`, resp.Body.String())
+		assert.Equal(t, "api.member.example.com:6443", proxy.Upstream())
+	})
+
+	t.Run("invalid upstream", func(t *testing.T) {
+		// given
+		proxy := NewProxy("127.0.0.1:1", discardLogger)
+		req := httptest.NewRequest(http.MethodPut, "/upstream?address=api.member.example.com", nil)
+		resp := httptest.NewRecorder()
+
+		// when
+		proxy.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, "invalid address 'api.member.example.com'\n", resp.Body.String())
+		assert.Equal(t, "127.0.0.1:1", proxy.Upstream())
+	})
+
+	t.Run("unknown endpoint", func(t *testing.T) {
+		// given
+		proxy := NewProxy("127.0.0.1:1", discardLogger)
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		resp := httptest.NewRecorder()
+
+		// when
+		proxy.ServeHTTP(resp, req)
+
+		// then
+		require.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+var discardLogger = log.New(io.Discard, "", 0)
+
+// logBuffer collects the logs of a proxy, which are written by the goroutines which forward the connections
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// startEchoServer starts a TCP server which echoes the lines it receives, and returns its address
+func startEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if _, err := conn.Write([]byte(line)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// startProxy starts the given proxy on a random port, and returns its address
+func startProxy(t *testing.T, proxy *Proxy) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go func() {
+		_ = proxy.Serve(listener)
+	}()
+	return listener.Addr().String()
+}
+
+func roundTrip(t *testing.T, address, line string) (time.Duration, string) {
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+	start := time.Now()
+	_, err = conn.Write([]byte(line))
+	require.NoError(t, err)
+	response, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	return time.Since(start), response
+}
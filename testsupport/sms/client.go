@@ -0,0 +1,63 @@
+package sms
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/recorder"
+)
+
+// Client a client of the fake SMS provider, to retrieve the messages which were sent by the registration service
+type Client struct {
+	*recorder.Client[Message]
+}
+
+// NewClient returns a new Client of the fake SMS provider at the given URL
+func NewClient(url string) *Client {
+	return &Client{
+		Client: recorder.NewClient[Message](url),
+	}
+}
+
+// Messages returns all the messages which were sent to the given phone number (in E.164 format, eg `+61408999999`),
+// in the order in which they were sent
+func (c *Client) Messages(phoneNumber string) ([]Message, error) {
+	return c.List(url.Values{"phoneNumber": {phoneNumber}})
+}
+
+// LastMessage returns the last message which was sent to the given phone number, or `nil` if there is none
+func (c *Client) LastMessage(phoneNumber string) (*Message, error) {
+	messages, err := c.Messages(phoneNumber)
+	if err != nil || len(messages) == 0 {
+		return nil, err
+	}
+	return &messages[len(messages)-1], nil
+}
+
+// WaitForMessage waits until more than `previous` messages were sent to the given phone number, and returns the last one.
+// The `previous` count is the number of messages which were sent before the action which is expected to send a new message
+// (see `Messages`), so that the wait does not depend on the clocks of the tests and of the provider.
+func (c *Client) WaitForMessage(t *testing.T, phoneNumber string, previous int) (*Message, error) {
+	t.Logf("waiting for a message sent to '%s' after the %d previous one(s)", phoneNumber, previous)
+	msg, err := c.WaitFor(url.Values{"phoneNumber": {phoneNumber}}, func(messages []Message) *Message {
+		if len(messages) <= previous {
+			return nil
+		}
+		return &messages[len(messages)-1]
+	})
+	if err != nil {
+		t.Logf("no message sent to '%s' after the %d previous one(s)", phoneNumber, previous)
+	}
+	return msg, err
+}
+
+// VerificationCode returns the verification code of the message, ie, its last word (as in the default message
+// template of the registration service: `Developer Sandbox for Red Hat OpenShift: Your verification code is %s`)
+func (m Message) VerificationCode() string {
+	words := strings.Fields(m.Body)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.TrimRight(words[len(words)-1], ".")
+}
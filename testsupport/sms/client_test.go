@@ -0,0 +1,69 @@
+package sms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	// given
+	server := NewServer()
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	client := NewClient(httpServer.URL + "/")
+	send := func(phoneNumber, body string) {
+		resp := publish(server, url.Values{"Action": {"Publish"}, "PhoneNumber": {phoneNumber}, "Message": {body}})
+		require.Equal(t, http.StatusOK, resp.Code)
+	}
+
+	t.Run("no message", func(t *testing.T) {
+		// when
+		msg, err := client.LastMessage("+61408999999")
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, msg)
+	})
+
+	t.Run("messages of a phone number", func(t *testing.T) {
+		// given
+		send("+61408999999", "Your verification code is 111111")
+		send("+16135550199", "Your verification code is 222222")
+		send("+61408999999", "Your verification code is 333333")
+
+		// when
+		messages, err := client.Messages("+61408999999")
+		require.NoError(t, err)
+		last, err := client.LastMessage("+61408999999")
+		require.NoError(t, err)
+
+		// then
+		require.Len(t, messages, 2)
+		assert.Equal(t, "111111", messages[0].VerificationCode())
+		assert.Equal(t, "333333", messages[1].VerificationCode())
+		assert.Equal(t, messages[1], *last)
+	})
+
+	t.Run("wait for message", func(t *testing.T) {
+		// given
+		previous, err := client.Messages("+61408999999")
+		require.NoError(t, err)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			publish(server, url.Values{"Action": {"Publish"}, "PhoneNumber": {"+61408999999"}, "Message": {"Your verification code is 444444."}})
+		}()
+
+		// when
+		msg, err := client.WaitForMessage(t, "+61408999999", len(previous))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "444444", msg.VerificationCode())
+	})
+}
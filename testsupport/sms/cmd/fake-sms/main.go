@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/recorder"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/sms"
+)
+
+// main runs the fake SMS provider which records the messages sent by the registration service instead of delivering them.
+func main() {
+	cmd := recorder.NewCommand("fake-sms", "run a fake SMS provider (AWS SNS API) which records the messages instead of delivering them", sms.NewServer())
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,126 @@
+package sms
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/recorder"
+	"github.com/google/uuid"
+)
+
+// Message an SMS which was "sent" by the registration service via the fake provider
+type Message struct {
+	ID          string    `json:"id"`
+	PhoneNumber string    `json:"phoneNumber"`
+	Body        string    `json:"body"`
+	SenderID    string    `json:"senderID,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	SentAt      time.Time `json:"sentAt"`
+}
+
+// Server a stand-in for the SMS provider of the registration service. It implements the `Publish` action of the AWS SNS API
+// (ie, the `aws` notification sender of the registration service) and records the messages instead of delivering them.
+// The recorded messages can then be retrieved (and reset) via the `/messages` endpoint, optionally filtered by `phoneNumber`.
+type Server struct {
+	recorder *recorder.Recorder[Message]
+}
+
+// NewServer returns a new Server without any recorded message
+func NewServer() *Server {
+	return &Server{
+		recorder: recorder.New(func(msg Message, query url.Values) bool {
+			phoneNumber := query.Get("phoneNumber")
+			return phoneNumber == "" || msg.PhoneNumber == phoneNumber
+		}),
+	}
+}
+
+// ServeHTTP serves the SNS API on `/`, as well as the `/messages` and `/failures` endpoints which are used by the tests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/" && r.Method == http.MethodPost {
+		s.publish(w, r)
+		return
+	}
+	s.recorder.ServeHTTP(w, r)
+}
+
+// publishResponse the response of the SNS `Publish` action
+type publishResponse struct {
+	XMLName   xml.Name `xml:"PublishResponse"`
+	Namespace string   `xml:"xmlns,attr"`
+	MessageID string   `xml:"PublishResult>MessageId"`
+	RequestID string   `xml:"ResponseMetadata>RequestId"`
+}
+
+// errorResponse the response of the SNS API when a request failed
+type errorResponse struct {
+	XMLName   xml.Name `xml:"ErrorResponse"`
+	Type      string   `xml:"Error>Type"`
+	Code      string   `xml:"Error>Code"`
+	Message   string   `xml:"Error>Message"`
+	RequestID string   `xml:"RequestId"`
+}
+
+func (s *Server) publish(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "Sender", "MalformedQueryString", err.Error())
+		return
+	}
+	if action := r.PostForm.Get("Action"); action != "Publish" {
+		writeError(w, http.StatusBadRequest, "Sender", "InvalidAction", fmt.Sprintf("unsupported action '%s'", action))
+		return
+	}
+	msg := Message{
+		ID:          uuid.NewString(),
+		PhoneNumber: r.PostForm.Get("PhoneNumber"),
+		Body:        r.PostForm.Get("Message"),
+		SentAt:      time.Now(),
+	}
+	if msg.PhoneNumber == "" || msg.Body == "" {
+		writeError(w, http.StatusBadRequest, "Sender", "InvalidParameter", "the phone number and the message are required")
+		return
+	}
+	// message attributes are sent as `MessageAttributes.entry.N.Name` and `MessageAttributes.entry.N.Value.StringValue`
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("MessageAttributes.entry.%d.", i)
+		name := r.PostForm.Get(prefix + "Name")
+		if name == "" {
+			break
+		}
+		switch value := r.PostForm.Get(prefix + "Value.StringValue"); name {
+		case "AWS.SNS.SMS.SenderID":
+			msg.SenderID = value
+		case "AWS.SNS.SMS.SMSType":
+			msg.Type = value
+		}
+	}
+
+	if !s.recorder.Record(msg) {
+		writeError(w, http.StatusInternalServerError, "Receiver", "InternalFailure", "failure injected by the fake SMS provider")
+		return
+	}
+	writeXML(w, http.StatusOK, publishResponse{
+		Namespace: "http://sns.amazonaws.com/doc/2010-03-31/",
+		MessageID: msg.ID,
+		RequestID: uuid.NewString(),
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, errorType, code, message string) {
+	writeXML(w, status, errorResponse{
+		Type:      errorType,
+		Code:      code,
+		Message:   message,
+		RequestID: uuid.NewString(),
+	})
+}
+
+func writeXML(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,110 @@
+package sms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublish(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// given
+		server := NewServer()
+
+		// when
+		resp := publish(server, url.Values{
+			"Action":                         {"Publish"},
+			"Version":                        {"2010-03-31"},
+			"PhoneNumber":                    {"+61408999999"},
+			"Message":                        {"Developer Sandbox for Red Hat OpenShift: Your verification code is 123456"},
+			"MessageAttributes.entry.1.Name": {"AWS.SNS.SMS.SenderID"},
+			"MessageAttributes.entry.1.Value.DataType":    {"String"},
+			"MessageAttributes.entry.1.Value.StringValue": {"DevSandbox"},
+			"MessageAttributes.entry.2.Name":              {"AWS.SNS.SMS.SMSType"},
+			"MessageAttributes.entry.2.Value.DataType":    {"String"},
+			"MessageAttributes.entry.2.Value.StringValue": {"Transactional"},
+		})
+
+		// then
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), "<PublishResponse xmlns=\"http://sns.amazonaws.com/doc/2010-03-31/\"><PublishResult><MessageId>")
+		require.Len(t, server.recorder.Messages(), 1)
+		msg := server.recorder.Messages()[0]
+		assert.Contains(t, resp.Body.String(), "<MessageId>"+msg.ID+"</MessageId>")
+		assert.Equal(t, "+61408999999", msg.PhoneNumber)
+		assert.Equal(t, "Developer Sandbox for Red Hat OpenShift: Your verification code is 123456", msg.Body)
+		assert.Equal(t, "DevSandbox", msg.SenderID)
+		assert.Equal(t, "Transactional", msg.Type)
+		assert.False(t, msg.SentAt.IsZero())
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		t.Run("unsupported action", func(t *testing.T) {
+			// given
+			server := NewServer()
+
+			// when
+			resp := publish(server, url.Values{"Action": {"CreateTopic"}})
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Contains(t, resp.Body.String(), "<Code>InvalidAction</Code>")
+			assert.Empty(t, server.recorder.Messages())
+		})
+
+		t.Run("missing phone number", func(t *testing.T) {
+			// given
+			server := NewServer()
+
+			// when
+			resp := publish(server, url.Values{"Action": {"Publish"}, "Message": {"hello"}})
+
+			// then
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Contains(t, resp.Body.String(), "<Code>InvalidParameter</Code>")
+			assert.Empty(t, server.recorder.Messages())
+		})
+
+		t.Run("injected failures", func(t *testing.T) {
+			// given
+			server := NewServer()
+			server.recorder.FailNext(1)
+			values := url.Values{"Action": {"Publish"}, "PhoneNumber": {"+61408999999"}, "Message": {"hello"}}
+
+			// when
+			first := publish(server, values)
+			second := publish(server, values)
+
+			// then
+			assert.Equal(t, http.StatusInternalServerError, first.Code)
+			assert.Contains(t, first.Body.String(), "<Code>InternalFailure</Code>")
+			assert.Equal(t, http.StatusOK, second.Code)
+			assert.Len(t, server.recorder.Messages(), 1)
+		})
+	})
+}
+
+func TestUnknownEndpoint(t *testing.T) {
+	// given
+	server := NewServer()
+	resp := httptest.NewRecorder()
+
+	// when
+	server.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	// then
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func publish(server *Server, values url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+	return resp
+}
@@ -0,0 +1,243 @@
+package testsupport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commonsocialevent "github.com/codeready-toolchain/toolchain-common/pkg/socialevent"
+	commonauth "github.com/codeready-toolchain/toolchain-common/pkg/test/auth"
+	testsocialevent "github.com/codeready-toolchain/toolchain-common/pkg/test/socialevent"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/regsvc"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SocialEventActivationRequest creates a SocialEvent and makes a given number of attendees use its activation code
+// concurrently, in order to verify that the event never gets more activations than its capacity. For example:
+//
+// result := NewSocialEventActivationRequest(awaitilities).
+// MaxAttendees(3).
+// Attendees(10).
+// TargetCluster(memberAwait.ClusterName).
+// Execute(t)
+//
+// The attendees are new users (ie, without any prior UserSignup), and the accepted ones are verified to be approved and provisioned
+// with the tiers and on the target cluster of the event (see `VerifySocialEventAttendee`).
+type SocialEventActivationRequest struct {
+	awaitilities  wait.Awaitilities
+	maxAttendees  int
+	attendees     int
+	userTier      string
+	spaceTier     string
+	targetCluster string
+}
+
+// SocialEventAttendee an attendee who used the activation code of the SocialEvent
+type SocialEventAttendee struct {
+	Identity *commonauth.Identity
+	Token    string
+	// Err the error returned by the registration service when the activation was rejected, `nil` otherwise
+	Err error
+	// UserSignup the UserSignup of the attendee (only set for the accepted attendees)
+	UserSignup *toolchainv1alpha1.UserSignup
+}
+
+// SocialEventActivationResult the outcome of the concurrent activations
+type SocialEventActivationResult struct {
+	Event    *toolchainv1alpha1.SocialEvent
+	Accepted []SocialEventAttendee
+	Rejected []SocialEventAttendee
+	// MaxActivationCount the highest activation count which was observed in the status of the event during the activations
+	MaxActivationCount int
+}
+
+// NewSocialEventActivationRequest returns a new request for an event with 5 seats, and as many attendees as there are seats
+func NewSocialEventActivationRequest(awaitilities wait.Awaitilities) *SocialEventActivationRequest {
+	return &SocialEventActivationRequest{
+		awaitilities: awaitilities,
+		maxAttendees: 5,
+		userTier:     "deactivate80",
+		spaceTier:    "base1ns6didler",
+	}
+}
+
+// MaxAttendees specifies the capacity of the event
+func (r *SocialEventActivationRequest) MaxAttendees(maxAttendees int) *SocialEventActivationRequest {
+	r.maxAttendees = maxAttendees
+	return r
+}
+
+// Attendees specifies the number of users who concurrently use the activation code (defaults to the capacity of the event)
+func (r *SocialEventActivationRequest) Attendees(attendees int) *SocialEventActivationRequest {
+	r.attendees = attendees
+	return r
+}
+
+// UserTier specifies the UserTier of the event
+func (r *SocialEventActivationRequest) UserTier(tier string) *SocialEventActivationRequest {
+	r.userTier = tier
+	return r
+}
+
+// SpaceTier specifies the NSTemplateTier of the event
+func (r *SocialEventActivationRequest) SpaceTier(tier string) *SocialEventActivationRequest {
+	r.spaceTier = tier
+	return r
+}
+
+// TargetCluster specifies the cluster on which the attendees are provisioned (any cluster if not set)
+func (r *SocialEventActivationRequest) TargetCluster(cluster string) *SocialEventActivationRequest {
+	r.targetCluster = cluster
+	return r
+}
+
+// Execute creates the event, makes all the attendees use its activation code at the same time, and verifies that:
+// - each activation was either accepted or rejected with a `403 Forbidden` status,
+// - no more attendees than the capacity of the event were accepted, and the activation count never exceeded it,
+// - the accepted attendees were provisioned with the tiers and on the target cluster of the event.
+func (r *SocialEventActivationRequest) Execute(t *testing.T) *SocialEventActivationResult {
+	hostAwait := r.awaitilities.Host()
+	attendees := r.attendees
+	if attendees == 0 {
+		attendees = r.maxAttendees
+	}
+
+	// create the event
+	event := testsocialevent.NewSocialEvent(hostAwait.Namespace, commonsocialevent.NewName(),
+		testsocialevent.WithUserTier(r.userTier),
+		testsocialevent.WithSpaceTier(r.spaceTier),
+		testsocialevent.WithMaxAttendees(r.maxAttendees),
+		testsocialevent.WithTargetCluster(r.targetCluster))
+	require.NoError(t, hostAwait.CreateWithCleanup(t, event))
+	event, err := hostAwait.WaitForSocialEvent(t, event.Name, wait.UntilSocialEventHasConditions(toolchainv1alpha1.Condition{
+		Type:   toolchainv1alpha1.ConditionReady,
+		Status: corev1.ConditionTrue,
+	}))
+	require.NoError(t, err)
+
+	result := &SocialEventActivationResult{}
+	all := make([]SocialEventAttendee, attendees)
+	for i := range all {
+		identity := commonauth.NewIdentity()
+		token, err := authsupport.NewTokenFromIdentity(identity, authsupport.WithEmail(identity.Username+"@some.domain"))
+		require.NoError(t, err)
+		all[i] = SocialEventAttendee{
+			Identity: identity,
+			Token:    token,
+		}
+	}
+
+	// record the highest activation count while the activations are in progress
+	done := make(chan struct{})
+	defer close(done)
+	var maxActivationCount atomic.Int64
+	go r.monitorActivationCount(hostAwait, event.Name, &maxActivationCount, done)
+
+	// use the activation code, all at the same time
+	t.Logf("%d attendees are using the activation code of the SocialEvent '%s' with %d seats", attendees, event.Name, r.maxAttendees)
+	start := make(chan struct{})
+	var activations sync.WaitGroup
+	for i := range all {
+		activations.Add(1)
+		go func(attendee *SocialEventAttendee) {
+			defer activations.Done()
+			<-start
+			attendee.Err = regsvc.NewClient(hostAwait.RegistrationServiceURL, attendee.Token).VerifyActivationCode(event.Name)
+		}(&all[i])
+	}
+	close(start)
+	activations.Wait()
+
+	for _, attendee := range all {
+		var regsvcErr *regsvc.Error
+		switch {
+		case attendee.Err == nil:
+			result.Accepted = append(result.Accepted, attendee)
+		case errors.As(attendee.Err, &regsvcErr) && regsvcErr.StatusCode == http.StatusForbidden:
+			result.Rejected = append(result.Rejected, attendee)
+		default:
+			require.NoError(t, attendee.Err, "unexpected error for the activation of '%s'", attendee.Identity.Username)
+		}
+		// the UserSignups of the rejected attendees may have been created too
+		r.cleanupUserSignup(t, hostAwait, attendee.Identity.Username)
+	}
+	t.Logf("%d attendees were accepted and %d were rejected by the SocialEvent '%s'", len(result.Accepted), len(result.Rejected), event.Name)
+	require.LessOrEqual(t, len(result.Accepted), r.maxAttendees, "more attendees than seats were accepted")
+
+	// then
+	for i, attendee := range result.Accepted {
+		result.Accepted[i].UserSignup = VerifySocialEventAttendee(t, hostAwait, event, wait.EncodeUserIdentifier(attendee.Identity.Username))
+	}
+	result.Event, err = hostAwait.WaitForSocialEvent(t, event.Name, wait.UntilSocialEventHasActivationCount(len(result.Accepted)))
+	require.NoError(t, err)
+	result.MaxActivationCount = max(int(maxActivationCount.Load()), result.Event.Status.ActivationCount)
+	assert.LessOrEqual(t, result.MaxActivationCount, r.maxAttendees, "the activation count exceeded the capacity of the SocialEvent")
+	return result
+}
+
+// monitorActivationCount polls the given SocialEvent until the `done` channel is closed, and records the highest activation count
+func (r *SocialEventActivationRequest) monitorActivationCount(hostAwait *wait.HostAwaitility, name string, maxCount *atomic.Int64, done <-chan struct{}) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		event := &toolchainv1alpha1.SocialEvent{}
+		if err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: name}, event); err == nil &&
+			int64(event.Status.ActivationCount) > maxCount.Load() {
+			maxCount.Store(int64(event.Status.ActivationCount))
+		}
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *SocialEventActivationRequest) cleanupUserSignup(t *testing.T, hostAwait *wait.HostAwaitility, username string) {
+	userSignup := &toolchainv1alpha1.UserSignup{}
+	err := hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: wait.EncodeUserIdentifier(username)}, userSignup)
+	if apierrors.IsNotFound(err) {
+		return
+	}
+	require.NoError(t, err)
+	cleanup.AddCleanTasks(t, hostAwait.Client, userSignup)
+}
+
+// VerifySocialEventAttendee verifies that the UserSignup with the given name was approved with the activation code of the given
+// SocialEvent, and that the user is provisioned with the tiers and on the target cluster of the event
+func VerifySocialEventAttendee(t *testing.T, hostAwait *wait.HostAwaitility, event *toolchainv1alpha1.SocialEvent, userSignupName string) *toolchainv1alpha1.UserSignup {
+	userSignup, err := hostAwait.WaitForUserSignup(t, userSignupName,
+		wait.UntilUserSignupHasLabel(toolchainv1alpha1.SocialEventUserSignupLabelKey, event.Name),
+		wait.UntilUserSignupHasConditions(wait.ConditionSet(wait.Default(), wait.ApprovedByAdmin())...),
+		wait.UntilUserSignupHasCompliantUsername(),
+		wait.UntilUserSignupHasTargetCluster(event.Spec.TargetCluster))
+	require.NoError(t, err)
+	_, err = hostAwait.WaitForMasterUserRecord(t, userSignup.Status.CompliantUsername,
+		wait.UntilMasterUserRecordHasTierName(event.Spec.UserTier),
+		wait.UntilMasterUserRecordHasCondition(wait.Provisioned()))
+	require.NoError(t, err)
+	spaceCriteria := []wait.SpaceWaitCriterion{
+		wait.UntilSpaceHasTier(event.Spec.SpaceTier),
+		wait.UntilSpaceHasConditions(wait.Provisioned()),
+	}
+	if event.Spec.TargetCluster != "" {
+		spaceCriteria = append(spaceCriteria, wait.UntilSpaceHasStatusTargetCluster(event.Spec.TargetCluster))
+	} else {
+		spaceCriteria = append(spaceCriteria, wait.UntilSpaceHasAnyTargetClusterSet())
+	}
+	_, err = hostAwait.WaitForSpace(t, userSignup.Status.CompliantUsername, spaceCriteria...)
+	require.NoError(t, err)
+	return userSignup
+}
@@ -0,0 +1,371 @@
+package space
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	testspace "github.com/codeready-toolchain/toolchain-common/pkg/test/space"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	testsupportsb "github.com/codeready-toolchain/toolchain-e2e/testsupport/spacebinding"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/util"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Scenario describes the lifecycle of a set of users and spaces (sub-spaces, bindings, tier moves, retargets, etc.) as a sequence
+// of steps, which are executed one by one by `Run`. After each step, the Space(s) affected by the step (and their sub-spaces)
+// are verified against the state expected by the scenario: tier, target cluster, inherited and own space roles, and all the
+// resources provisioned on the target member cluster.
+// All the resources which are created by the scenario are deleted at the end of the test.
+//
+// Example:
+//
+//	scenario := NewScenario(t, awaitilities).
+//		User("alice").
+//		User("bob").
+//		Space("parent", "alice", testspace.WithTierName("appstudio"), testspace.WithSpecTargetCluster(member1.ClusterName)).
+//		SubSpace("child", "parent", testspace.WithTierName("appstudio"), testspace.WithSpecTargetCluster(member1.ClusterName)).
+//		Bind("bob", "child", "contributor").
+//		Retarget("parent", member2).
+//		ExpectOnMember(member1, "child").
+//		ExpectOnMember(member2, "parent").
+//		Run()
+type Scenario struct {
+	t            *testing.T
+	awaitilities wait.Awaitilities
+	users        map[string]*toolchainv1alpha1.MasterUserRecord // indexed by alias
+	spaces       map[string]*scenarioSpace                      // indexed by alias
+	steps        []scenarioStep
+}
+
+type scenarioStep struct {
+	description string
+	run         func()
+}
+
+// scenarioSpace the state of a Space expected by the scenario
+type scenarioSpace struct {
+	alias              string
+	name               string
+	tierName           string
+	targetCluster      string
+	parent             *scenarioSpace
+	disableInheritance bool
+	bindings           map[string]*toolchainv1alpha1.SpaceBinding // indexed by MUR name
+}
+
+// NewScenario returns a new, empty Scenario
+func NewScenario(t *testing.T, awaitilities wait.Awaitilities) *Scenario {
+	return &Scenario{
+		t:            t,
+		awaitilities: awaitilities,
+		users:        map[string]*toolchainv1alpha1.MasterUserRecord{},
+		spaces:       map[string]*scenarioSpace{},
+	}
+}
+
+func (s *Scenario) step(description string, run func()) *Scenario {
+	s.steps = append(s.steps, scenarioStep{description: description, run: run})
+	return s
+}
+
+// Run executes all the steps of the scenario, in the order in which they were added
+func (s *Scenario) Run() *Scenario {
+	for i, step := range s.steps {
+		s.t.Logf("scenario step %d/%d: %s", i+1, len(s.steps), step.description)
+		step.run()
+	}
+	s.steps = nil
+	return s
+}
+
+// MUR returns the MasterUserRecord of the user with the given alias (only once the scenario has run)
+func (s *Scenario) MUR(alias string) *toolchainv1alpha1.MasterUserRecord {
+	mur, found := s.users[alias]
+	require.True(s.t, found, "unknown user '%s'", alias)
+	return mur
+}
+
+// SpaceName returns the name of the Space with the given alias (only once the scenario has run)
+func (s *Scenario) SpaceName(alias string) string {
+	return s.space(alias).name
+}
+
+func (s *Scenario) space(alias string) *scenarioSpace {
+	sp, found := s.spaces[alias]
+	require.True(s.t, found, "unknown space '%s'", alias)
+	return sp
+}
+
+// User adds a step which signs up a new user (without any Space), which is referred to with the given alias in the other steps
+func (s *Scenario) User(alias string) *Scenario {
+	return s.step(fmt.Sprintf("create user '%s'", alias), func() {
+		username := uuid.Must(uuid.NewV4()).String()
+		user := testsupport.NewSignupRequest(s.awaitilities).
+			Username(username).
+			Email(username + "@acme.com").
+			ManuallyApprove().
+			RequireConditions(wait.ConditionSet(wait.Default(), wait.ApprovedByAdmin())...).
+			NoSpace().
+			WaitForMUR().
+			Execute(s.t)
+		s.users[alias] = user.MUR
+	})
+}
+
+// Space adds a step which creates a new Space with the given options, along with a SpaceBinding with the `admin` role for
+// the given owner (otherwise the Space would be deleted by the SpaceCleanup controller)
+func (s *Scenario) Space(alias, ownerAlias string, opts ...testspace.Option) *Scenario {
+	return s.step(fmt.Sprintf("create space '%s' owned by '%s'", alias, ownerAlias), func() {
+		hostAwait := s.awaitilities.Host()
+		mur := s.MUR(ownerAlias)
+		space := testspace.NewSpaceWithGeneratedName(hostAwait.Namespace, util.NewObjectNamePrefix(s.t), opts...)
+		space, binding, err := hostAwait.CreateSpaceAndSpaceBinding(s.t, mur, space, "admin")
+		require.NoError(s.t, err)
+		sp := s.newScenarioSpace(alias, space.Name, nil, space.Spec.DisableInheritance)
+		sp.bindings[mur.Name] = binding
+		s.verify(sp)
+	})
+}
+
+// SubSpace adds a step which creates a new sub-space of the given parent Space, with the given options
+func (s *Scenario) SubSpace(alias, parentAlias string, opts ...testspace.Option) *Scenario {
+	return s.step(fmt.Sprintf("create sub-space '%s' of '%s'", alias, parentAlias), func() {
+		parent := s.space(parentAlias)
+		space := CreateSubSpace(s.t, s.awaitilities, append(opts, testspace.WithSpecParentSpace(parent.name))...)
+		sp := s.newScenarioSpace(alias, space.Name, parent, space.Spec.DisableInheritance)
+		s.verify(sp)
+	})
+}
+
+// newScenarioSpace registers the Space with the given name once its tier and target cluster are set
+func (s *Scenario) newScenarioSpace(alias, name string, parent *scenarioSpace, disableInheritance bool) *scenarioSpace {
+	require.NotContains(s.t, s.spaces, alias, "space '%s' already exists", alias)
+	space, err := s.awaitilities.Host().WaitForSpace(s.t, name,
+		wait.UntilSpaceHasAnyTargetClusterSet(),
+		wait.UntilSpaceHasAnyTierNameSet())
+	require.NoError(s.t, err)
+	sp := &scenarioSpace{
+		alias:              alias,
+		name:               name,
+		tierName:           space.Spec.TierName,
+		targetCluster:      space.Status.TargetCluster,
+		parent:             parent,
+		disableInheritance: disableInheritance,
+		bindings:           map[string]*toolchainv1alpha1.SpaceBinding{},
+	}
+	s.spaces[alias] = sp
+	return sp
+}
+
+// Bind adds a step which creates a SpaceBinding with the given role for the given user in the given Space
+func (s *Scenario) Bind(userAlias, spaceAlias, role string) *Scenario {
+	return s.step(fmt.Sprintf("bind user '%s' to space '%s' with role '%s'", userAlias, spaceAlias, role), func() {
+		mur := s.MUR(userAlias)
+		sp := s.space(spaceAlias)
+		require.NotContains(s.t, sp.bindings, mur.Name, "user '%s' is already bound to space '%s'", userAlias, spaceAlias)
+		hostAwait := s.awaitilities.Host()
+		testsupportsb.CreateSpaceBindingStr(s.t, hostAwait, mur.Name, sp.name, hostAwait.Namespace, role)
+		sp.bindings[mur.Name] = testsupportsb.VerifySpaceBinding(s.t, hostAwait, mur.Name, sp.name, role)
+		s.verify(sp)
+	})
+}
+
+// UpdateBinding adds a step which changes the role of the SpaceBinding of the given user in the given Space
+func (s *Scenario) UpdateBinding(userAlias, spaceAlias, role string) *Scenario {
+	return s.step(fmt.Sprintf("change role of user '%s' in space '%s' to '%s'", userAlias, spaceAlias, role), func() {
+		mur := s.MUR(userAlias)
+		sp := s.space(spaceAlias)
+		binding, found := sp.bindings[mur.Name]
+		require.True(s.t, found, "user '%s' is not bound to space '%s'", userAlias, spaceAlias)
+		hostAwait := s.awaitilities.Host()
+		_, err := wait.For(s.t, hostAwait.Awaitility, &toolchainv1alpha1.SpaceBinding{}).
+			Update(binding.Name, hostAwait.Namespace, func(sb *toolchainv1alpha1.SpaceBinding) {
+				sb.Spec.SpaceRole = role
+			})
+		require.NoError(s.t, err)
+		sp.bindings[mur.Name] = testsupportsb.VerifySpaceBinding(s.t, hostAwait, mur.Name, sp.name, role)
+		s.verify(sp)
+	})
+}
+
+// Unbind adds a step which deletes the SpaceBinding of the given user in the given Space
+func (s *Scenario) Unbind(userAlias, spaceAlias string) *Scenario {
+	return s.step(fmt.Sprintf("unbind user '%s' from space '%s'", userAlias, spaceAlias), func() {
+		mur := s.MUR(userAlias)
+		sp := s.space(spaceAlias)
+		binding, found := sp.bindings[mur.Name]
+		require.True(s.t, found, "user '%s' is not bound to space '%s'", userAlias, spaceAlias)
+		hostAwait := s.awaitilities.Host()
+		require.NoError(s.t, hostAwait.Client.Delete(context.TODO(), binding))
+		require.NoError(s.t, hostAwait.WaitUntilSpaceBindingDeleted(binding.Name))
+		delete(sp.bindings, mur.Name)
+		s.verify(sp)
+	})
+}
+
+// MoveToTier adds a step which moves the given Space to the given tier
+func (s *Scenario) MoveToTier(spaceAlias, tierName string) *Scenario {
+	return s.step(fmt.Sprintf("move space '%s' to tier '%s'", spaceAlias, tierName), func() {
+		sp := s.space(spaceAlias)
+		tiers.MoveSpaceToTier(s.t, s.awaitilities.Host(), sp.name, tierName)
+		sp.tierName = tierName
+		s.verify(sp)
+	})
+}
+
+// Retarget adds a step which moves the given Space to the given member cluster, and verifies that its resources
+// are deleted from the previous member cluster
+func (s *Scenario) Retarget(spaceAlias string, targetCluster *wait.MemberAwaitility) *Scenario {
+	return s.step(fmt.Sprintf("retarget space '%s' to '%s'", spaceAlias, targetCluster.ClusterName), func() {
+		sp := s.space(spaceAlias)
+		hostAwait := s.awaitilities.Host()
+		previous := sp.targetCluster
+		_, err := wait.For(s.t, hostAwait.Awaitility, &toolchainv1alpha1.Space{}).
+			Update(sp.name, hostAwait.Namespace, func(space *toolchainv1alpha1.Space) {
+				space.Spec.TargetCluster = targetCluster.ClusterName
+			})
+		require.NoError(s.t, err)
+		sp.targetCluster = targetCluster.ClusterName
+		if previous != targetCluster.ClusterName {
+			previousMember, err := s.awaitilities.Member(previous)
+			require.NoError(s.t, err)
+			require.NoError(s.t, previousMember.WaitUntilNSTemplateSetDeleted(s.t, sp.name))
+		}
+		s.verify(sp)
+	})
+}
+
+// ExpectOnMember adds a step which verifies that exactly the given Spaces of the scenario are provisioned on the given member
+// cluster, ie, that their resources are provisioned as expected and that the resources of the other Spaces of the scenario
+// do not exist on this member cluster
+func (s *Scenario) ExpectOnMember(memberAwait *wait.MemberAwaitility, spaceAliases ...string) *Scenario {
+	return s.step(fmt.Sprintf("expect spaces %v on '%s'", spaceAliases, memberAwait.ClusterName), func() {
+		expected := map[string]bool{}
+		for _, alias := range spaceAliases {
+			sp := s.space(alias)
+			require.Equal(s.t, memberAwait.ClusterName, sp.targetCluster, "space '%s' is not expected on '%s' by the scenario", alias, memberAwait.ClusterName)
+			expected[alias] = true
+			s.verifySpace(sp)
+		}
+		for _, alias := range sortedAliases(s.spaces) {
+			if expected[alias] {
+				continue
+			}
+			sp := s.spaces[alias]
+			assert.NotEqual(s.t, memberAwait.ClusterName, sp.targetCluster, "space '%s' is expected on '%s' by the scenario", alias, memberAwait.ClusterName)
+			require.NoError(s.t, memberAwait.WaitUntilNSTemplateSetDeleted(s.t, sp.name))
+		}
+	})
+}
+
+// Verify adds a step which verifies all the Spaces of the scenario
+func (s *Scenario) Verify() *Scenario {
+	return s.step("verify all spaces", func() {
+		for _, alias := range sortedAliases(s.spaces) {
+			s.verifySpace(s.spaces[alias])
+		}
+	})
+}
+
+// verify verifies the given Space and all its sub-spaces, which may inherit its bindings
+func (s *Scenario) verify(sp *scenarioSpace) {
+	s.verifySpace(sp)
+	for _, alias := range sortedAliases(s.spaces) {
+		if other := s.spaces[alias]; other.parent == sp {
+			s.verify(other)
+		}
+	}
+}
+
+func (s *Scenario) verifySpace(sp *scenarioSpace) {
+	hostAwait := s.awaitilities.Host()
+	memberAwait, err := s.awaitilities.Member(sp.targetCluster)
+	require.NoError(s.t, err)
+	tier, err := hostAwait.WaitForNSTemplateTier(s.t, sp.tierName, wait.HasStatusTierTemplateRevisionKeys())
+	require.NoError(s.t, err)
+	_, err = memberAwait.WaitForNSTmplSet(s.t, sp.name,
+		wait.UntilNSTemplateSetHasTier(sp.tierName),
+		untilNSTemplateSetHasSpaceRoles(expectedSpaceRoles(tier, sp.effectiveBindings())))
+	require.NoError(s.t, err)
+	VerifyResourcesProvisionedForSpace(s.t, s.awaitilities, sp.name,
+		wait.UntilSpaceHasTier(sp.tierName),
+		wait.UntilSpaceHasStatusTargetCluster(sp.targetCluster))
+}
+
+// effectiveBindings returns the roles of the users in the Space, indexed by MUR name: the roles inherited from the parent
+// Space (unless the inheritance is disabled) are overridden by the SpaceBindings of the Space itself
+func (sp *scenarioSpace) effectiveBindings() map[string]string {
+	bindings := map[string]string{}
+	if sp.parent != nil && !sp.disableInheritance {
+		for murName, role := range sp.parent.effectiveBindings() {
+			bindings[murName] = role
+		}
+	}
+	for murName, binding := range sp.bindings {
+		bindings[murName] = binding.Spec.SpaceRole
+	}
+	return bindings
+}
+
+// expectedSpaceRoles returns the space roles of the NSTemplateSet for the given bindings (indexed by MUR name),
+// sorted by template ref and with sorted usernames. The roles which do not exist in the tier are ignored.
+func expectedSpaceRoles(tier *toolchainv1alpha1.NSTemplateTier, bindings map[string]string) []toolchainv1alpha1.NSTemplateSetSpaceRole {
+	usernames := map[string][]string{}
+	for murName, role := range bindings {
+		usernames[role] = append(usernames[role], murName)
+	}
+	spaceRoles := []toolchainv1alpha1.NSTemplateSetSpaceRole{}
+	for role, names := range usernames {
+		tierSpaceRole, found := tier.Spec.SpaceRoles[role]
+		if !found {
+			continue
+		}
+		templateRef := tierSpaceRole.TemplateRef
+		if revision, found := tier.Status.Revisions[templateRef]; found {
+			templateRef = revision
+		}
+		spaceRoles = append(spaceRoles, wait.SpaceRole(templateRef, names...))
+	}
+	return sortSpaceRoles(spaceRoles)
+}
+
+func sortSpaceRoles(spaceRoles []toolchainv1alpha1.NSTemplateSetSpaceRole) []toolchainv1alpha1.NSTemplateSetSpaceRole {
+	sorted := make([]toolchainv1alpha1.NSTemplateSetSpaceRole, len(spaceRoles))
+	for i, spaceRole := range spaceRoles {
+		sorted[i] = wait.SpaceRole(spaceRole.TemplateRef, append([]string{}, spaceRole.Usernames...)...)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].TemplateRef < sorted[j].TemplateRef
+	})
+	return sorted
+}
+
+// untilNSTemplateSetHasSpaceRoles checks that the NSTemplateSet has the expected space roles, regardless of their order
+func untilNSTemplateSetHasSpaceRoles(expected []toolchainv1alpha1.NSTemplateSetSpaceRole) wait.NSTemplateSetWaitCriterion {
+	return wait.NSTemplateSetWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.NSTemplateSet) bool {
+			return reflect.DeepEqual(expected, sortSpaceRoles(actual.Spec.SpaceRoles))
+		},
+		Diff: func(actual *toolchainv1alpha1.NSTemplateSet) string {
+			return fmt.Sprintf("expected space roles to match:\n%s", wait.Diff(expected, sortSpaceRoles(actual.Spec.SpaceRoles)))
+		},
+	}
+}
+
+func sortedAliases(spaces map[string]*scenarioSpace) []string {
+	aliases := make([]string, 0, len(spaces))
+	for alias := range spaces {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
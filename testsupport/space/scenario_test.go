@@ -0,0 +1,120 @@
+package space
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEffectiveBindings(t *testing.T) {
+	// given
+	parent := &scenarioSpace{
+		alias: "parent",
+		bindings: map[string]*toolchainv1alpha1.SpaceBinding{
+			"alice": spaceBinding("admin"),
+			"bob":   spaceBinding("contributor"),
+		},
+	}
+	child := &scenarioSpace{
+		alias:  "child",
+		parent: parent,
+		bindings: map[string]*toolchainv1alpha1.SpaceBinding{
+			"bob": spaceBinding("maintainer"),
+		},
+	}
+	grandChild := &scenarioSpace{
+		alias:    "grandchild",
+		parent:   child,
+		bindings: map[string]*toolchainv1alpha1.SpaceBinding{},
+	}
+
+	t.Run("inherited and overridden", func(t *testing.T) {
+		// when
+		bindings := grandChild.effectiveBindings()
+
+		// then
+		assert.Equal(t, map[string]string{
+			"alice": "admin",
+			"bob":   "maintainer",
+		}, bindings)
+	})
+
+	t.Run("inheritance disabled", func(t *testing.T) {
+		// given
+		child := *child
+		child.disableInheritance = true
+
+		// when
+		bindings := child.effectiveBindings()
+
+		// then
+		assert.Equal(t, map[string]string{
+			"bob": "maintainer",
+		}, bindings)
+	})
+}
+
+func TestExpectedSpaceRoles(t *testing.T) {
+	// given
+	tier := &toolchainv1alpha1.NSTemplateTier{
+		Spec: toolchainv1alpha1.NSTemplateTierSpec{
+			SpaceRoles: map[string]toolchainv1alpha1.NSTemplateTierSpaceRole{
+				"admin":      {TemplateRef: "appstudio-admin-123abc"},
+				"maintainer": {TemplateRef: "appstudio-maintainer-123abc"},
+			},
+		},
+		Status: toolchainv1alpha1.NSTemplateTierStatus{
+			Revisions: map[string]string{
+				"appstudio-admin-123abc": "appstudio-admin-123abc-ttr",
+			},
+		},
+	}
+
+	// when
+	spaceRoles := expectedSpaceRoles(tier, map[string]string{
+		"charlie": "admin",
+		"alice":   "admin",
+		"bob":     "maintainer",
+		"dave":    "unknown",
+	})
+
+	// then
+	assert.Equal(t, []toolchainv1alpha1.NSTemplateSetSpaceRole{
+		wait.SpaceRole("appstudio-admin-123abc-ttr", "alice", "charlie"),
+		wait.SpaceRole("appstudio-maintainer-123abc", "bob"),
+	}, spaceRoles)
+
+	t.Run("matches regardless of the order", func(t *testing.T) {
+		// given
+		nsTmplSet := &toolchainv1alpha1.NSTemplateSet{
+			Spec: toolchainv1alpha1.NSTemplateSetSpec{
+				SpaceRoles: []toolchainv1alpha1.NSTemplateSetSpaceRole{
+					{TemplateRef: "appstudio-maintainer-123abc", Usernames: []string{"bob"}},
+					{TemplateRef: "appstudio-admin-123abc-ttr", Usernames: []string{"charlie", "alice"}},
+				},
+			},
+		}
+
+		// when
+		match := untilNSTemplateSetHasSpaceRoles(spaceRoles).Match(nsTmplSet)
+
+		// then
+		assert.True(t, match)
+		assert.Equal(t, []string{"charlie", "alice"}, nsTmplSet.Spec.SpaceRoles[1].Usernames, "the NSTemplateSet is not modified")
+	})
+}
+
+func spaceBinding(role string) *toolchainv1alpha1.SpaceBinding {
+	return &toolchainv1alpha1.SpaceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: role,
+		},
+		Spec: toolchainv1alpha1.SpaceBindingSpec{
+			SpaceRole: role,
+		},
+	}
+}
@@ -109,15 +109,14 @@ func VerifyResourcesProvisionedForSpace(t *testing.T, awaitilities wait.Awaitili
 	targetCluster := getSpaceTargetMember(t, awaitilities, space)
 	tier, err := awaitilities.Host().WaitForNSTemplateTier(t, space.Spec.TierName)
 	require.NoError(t, err)
-	checks, err := tiers.NewChecksForTier(tier)
-	require.NoError(t, err)
+	checks := tiers.ChecksForTier(t, awaitilities.Host(), tier)
 
 	t.Logf("verifying resources provisioned for space '%s' with tier '%s'", space.Name, space.Spec.TierName)
 	return verifyResourcesProvisionedForSpace(t, awaitilities.Host(), targetCluster, spaceName, tier, checks)
 }
 
 func VerifyResourcesProvisionedForSpaceWithCustomTier(t *testing.T, hostAwait *wait.HostAwaitility, targetCluster *wait.MemberAwaitility, spaceName string, tier *tiers.CustomNSTemplateTier) (*toolchainv1alpha1.Space, *toolchainv1alpha1.NSTemplateSet) {
-	checks := tiers.NewChecksForCustomTier(t, tier)
+	checks := tiers.NewChecksForCustomTier(t, hostAwait, tier)
 	return verifyResourcesProvisionedForSpace(t, hostAwait, targetCluster, spaceName, tier.NSTemplateTier, checks)
 }
 
@@ -0,0 +1,294 @@
+package spaceprovisionerconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test/assertions"
+	testspace "github.com/codeready-toolchain/toolchain-common/pkg/test/space"
+	testSpc "github.com/codeready-toolchain/toolchain-common/pkg/test/spaceprovisionerconfig"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/util"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pendingDuration the duration during which a Space must remain pending to be considered as left pending by the capacity manager
+const pendingDuration = 2 * time.Second
+
+// Placement a harness to verify how the capacity manager of the host operator places the Spaces in the member clusters,
+// given the capacity thresholds, the placement roles and the readiness of their SpaceProvisionerConfigs. For example:
+//
+//	placement := spaceprovisionerconfig.NewPlacement(t, hostAwait, mur).
+//		RoomFor(memberAwait1.ClusterName, 1).
+//		RoomFor(memberAwait2.ClusterName, 2)
+//	spaces := placement.CreateSpaces(4, testspace.WithTierName("appstudio"))
+//	placement.ExpectDistribution(spaces, Distribution{
+//		PerCluster: map[string]int{memberAwait1.ClusterName: 1, memberAwait2.ClusterName: 2},
+//		Pending:    1,
+//	})
+//
+// The SpaceProvisionerConfigs are restored at the end of the test. Since the capacity is computed from the number of Spaces
+// which are currently deployed in each cluster, the harness must not be used in the parallel tests.
+type Placement struct {
+	t         *testing.T
+	hostAwait *wait.HostAwaitility
+	owner     *toolchainv1alpha1.MasterUserRecord
+	// configured the names of the member clusters whose SpaceProvisionerConfig was updated by the harness
+	configured map[string]bool
+}
+
+// Distribution the expected distribution of Spaces: the number of Spaces provisioned per member cluster, and the number
+// of Spaces which are left pending because no member cluster can host them
+type Distribution struct {
+	PerCluster map[string]int
+	Pending    int
+}
+
+// String returns a representation of the distribution which does not depend on the order of the member clusters, and
+// which ignores the member clusters without any Space
+func (d Distribution) String() string {
+	clusters := make([]string, 0, len(d.PerCluster))
+	for name, count := range d.PerCluster {
+		if count > 0 {
+			clusters = append(clusters, name)
+		}
+	}
+	sort.Strings(clusters)
+	parts := make([]string, 0, len(clusters)+1)
+	for _, name := range clusters {
+		parts = append(parts, fmt.Sprintf("%s=%d", name, d.PerCluster[name]))
+	}
+	parts = append(parts, fmt.Sprintf("pending=%d", d.Pending))
+	return strings.Join(parts, ", ")
+}
+
+// NewPlacement returns a new placement harness, in which the Spaces are created with an admin SpaceBinding for the given MasterUserRecord
+func NewPlacement(t *testing.T, hostAwait *wait.HostAwaitility, owner *toolchainv1alpha1.MasterUserRecord) *Placement {
+	return &Placement{
+		t:          t,
+		hostAwait:  hostAwait,
+		owner:      owner,
+		configured: map[string]bool{},
+	}
+}
+
+// Configure updates the SpaceProvisionerConfig of the given member cluster with the given options (eg, the capacity
+// thresholds or the placement roles)
+func (p *Placement) Configure(clusterName string, opts ...testSpc.CreateOption) *Placement {
+	UpdateForCluster(p.t, p.hostAwait.Awaitility, clusterName, opts...)
+	p.configured[clusterName] = true
+	return p
+}
+
+// RoomFor enables the SpaceProvisionerConfig of the given member cluster and sets its maximum number of Spaces so that
+// exactly the given number of new Spaces can be placed in the cluster
+func (p *Placement) RoomFor(clusterName string, spaces int, opts ...testSpc.CreateOption) *Placement {
+	count := p.SpaceCount(clusterName)
+	// a maximum number of 0 Spaces means that the number of Spaces is not limited
+	require.Positive(p.t, count+spaces, "cannot leave room for 0 Space in '%s' with the maximum number of Spaces, use `Full` instead", clusterName)
+	//the value of this is not going beyond 100 and it won't overflow, hence its okay to ignore the overflow linter error
+	return p.Configure(clusterName, append([]testSpc.CreateOption{testSpc.Enabled(true), testSpc.MaxNumberOfSpaces(uint(count + spaces))}, opts...)...) //nolint:gosec
+}
+
+// Full sets the maximum number of Spaces of the given member cluster to the number of Spaces which are already deployed in it.
+// If there is no Space in the cluster, then its SpaceProvisionerConfig is disabled instead, since a maximum number of 0 Spaces
+// means that the number of Spaces is not limited.
+func (p *Placement) Full(clusterName string) *Placement {
+	count := p.SpaceCount(clusterName)
+	if count == 0 {
+		return p.Disable(clusterName)
+	}
+	return p.Configure(clusterName, testSpc.Enabled(true), testSpc.MaxNumberOfSpaces(uint(count))) //nolint:gosec
+}
+
+// MaxMemoryUtilization sets the maximum memory utilization (in percent) of the given member cluster
+func (p *Placement) MaxMemoryUtilization(clusterName string, percent uint) *Placement {
+	return p.Configure(clusterName, testSpc.MaxMemoryUtilizationPercent(percent))
+}
+
+// PlacementRoles sets the placement roles of the given member cluster
+func (p *Placement) PlacementRoles(clusterName string, roles ...string) *Placement {
+	placementRoles := make([]string, len(roles))
+	for i, r := range roles {
+		placementRoles[i] = testSpc.PlacementRole(r)
+	}
+	return p.Configure(clusterName, testSpc.WithPlacementRoles(placementRoles...))
+}
+
+// Disable disables the SpaceProvisionerConfig of the given member cluster, and waits until it is not ready
+func (p *Placement) Disable(clusterName string) *Placement {
+	p.Configure(clusterName, testSpc.Enabled(false))
+	_, err := wait.For(p.t, p.hostAwait.Awaitility, &toolchainv1alpha1.SpaceProvisionerConfig{}).FirstThat(
+		assertions.Has(ReferenceToToolchainCluster(clusterName)),
+		assertions.Is(testSpc.NotReady()))
+	require.NoError(p.t, err)
+	return p
+}
+
+// SpaceCount waits until the consumed capacity of the SpaceProvisionerConfig of the given member cluster accounts for all
+// the Spaces which target the cluster, and returns their number
+func (p *Placement) SpaceCount(clusterName string) int {
+	var spc *toolchainv1alpha1.SpaceProvisionerConfig
+	var count int
+	err := k8swait.PollUntilContextTimeout(context.TODO(), p.hostAwait.RetryInterval, p.hostAwait.Timeout, true, func(ctx context.Context) (bool, error) {
+		spaces := &toolchainv1alpha1.SpaceList{}
+		if err := p.hostAwait.Client.List(ctx, spaces, client.InNamespace(p.hostAwait.Namespace)); err != nil {
+			return false, err
+		}
+		count = 0
+		for _, s := range spaces.Items {
+			if s.Spec.TargetCluster == clusterName {
+				count++
+			}
+		}
+		spcs := &toolchainv1alpha1.SpaceProvisionerConfigList{}
+		if err := p.hostAwait.Client.List(ctx, spcs, client.InNamespace(p.hostAwait.Namespace)); err != nil {
+			return false, err
+		}
+		spc = findSpcForCluster(spcs.Items, clusterName)
+		return spc != nil && spc.Status.ConsumedCapacity != nil && spc.Status.ConsumedCapacity.SpaceCount == count, nil
+	})
+	require.NoError(p.t, err, "the consumed capacity of the SpaceProvisionerConfig of '%s' does not account for the %d Spaces "+
+		"which target the cluster: %+v", clusterName, count, spc)
+	return count
+}
+
+// CreateSpaces creates the given number of Spaces without any target cluster, so that they are placed by the capacity manager.
+// The Spaces are created once the status of the SpaceProvisionerConfigs which were updated by the harness reflects their
+// new configuration, ie, once the host operator has observed the changes.
+func (p *Placement) CreateSpaces(n int, opts ...testspace.Option) []*toolchainv1alpha1.Space {
+	for clusterName := range p.configured {
+		_, err := wait.For(p.t, p.hostAwait.Awaitility, &toolchainv1alpha1.SpaceProvisionerConfig{}).FirstThat(
+			assertions.Has(ReferenceToToolchainCluster(clusterName)),
+			assertions.Has(observedConfig()))
+		require.NoError(p.t, err, "the status of the SpaceProvisionerConfig of '%s' does not reflect its configuration", clusterName)
+	}
+	spaces := make([]*toolchainv1alpha1.Space, 0, n)
+	for i := 0; i < n; i++ {
+		space := testspace.NewSpaceWithGeneratedName(p.hostAwait.Namespace, util.NewObjectNamePrefix(p.t), opts...)
+		space, _, err := p.hostAwait.CreateSpaceAndSpaceBinding(p.t, p.owner, space, "admin")
+		require.NoError(p.t, err)
+		spaces = append(spaces, space)
+	}
+	return spaces
+}
+
+// DeleteSpaces deletes the given Spaces and waits until they are gone, so that their capacity is freed
+func (p *Placement) DeleteSpaces(spaces ...*toolchainv1alpha1.Space) {
+	for _, space := range spaces {
+		err := p.hostAwait.Client.Delete(context.TODO(), space)
+		if !apierrors.IsNotFound(err) {
+			require.NoError(p.t, err)
+		}
+	}
+	for _, space := range spaces {
+		err := p.hostAwait.WaitUntilSpaceAndSpaceBindingsDeleted(p.t, space.Name)
+		require.NoError(p.t, err)
+	}
+}
+
+// ExpectDistribution waits until each of the given Spaces is either provisioned in a member cluster or left pending, and
+// until the number of Spaces per member cluster and the number of pending Spaces match the expected distribution.
+// Returns the latest version of the Spaces.
+func (p *Placement) ExpectDistribution(spaces []*toolchainv1alpha1.Space, expected Distribution) []*toolchainv1alpha1.Space {
+	var settled []*toolchainv1alpha1.Space
+	var actual Distribution
+	err := k8swait.PollUntilContextTimeout(context.TODO(), p.hostAwait.RetryInterval, p.hostAwait.Timeout, true, func(ctx context.Context) (bool, error) {
+		settled = make([]*toolchainv1alpha1.Space, 0, len(spaces))
+		for _, space := range spaces {
+			s := &toolchainv1alpha1.Space{}
+			if err := p.hostAwait.Client.Get(ctx, types.NamespacedName{Namespace: space.Namespace, Name: space.Name}, s); err != nil {
+				return false, err
+			}
+			if !isPlacedOrPending(s) {
+				return false, nil
+			}
+			settled = append(settled, s)
+		}
+		actual = distributionOf(settled)
+		return actual.String() == expected.String(), nil
+	})
+	require.NoError(p.t, err, "unexpected distribution of the Spaces\nexpected: %s\nactual: %s", expected, actual)
+	return settled
+}
+
+// distributionOf returns the distribution of the given (placed or pending) Spaces
+func distributionOf(spaces []*toolchainv1alpha1.Space) Distribution {
+	d := Distribution{
+		PerCluster: map[string]int{},
+	}
+	for _, s := range spaces {
+		if isPending(s) {
+			d.Pending++
+			continue
+		}
+		d.PerCluster[s.Status.TargetCluster]++
+	}
+	return d
+}
+
+// isPlacedOrPending returns `true` if the Space is either provisioned in a member cluster, or has been pending for
+// some time because no member cluster can host it
+func isPlacedOrPending(space *toolchainv1alpha1.Space) bool {
+	if isPending(space) {
+		c, _ := condition.FindConditionByType(space.Status.Conditions, toolchainv1alpha1.ConditionReady)
+		return c.LastTransitionTime.Time.Before(time.Now().Add(-pendingDuration))
+	}
+	return space.Status.TargetCluster != "" && condition.IsTrue(space.Status.Conditions, toolchainv1alpha1.ConditionReady)
+}
+
+// isPending returns `true` if the Space is waiting for a member cluster to be placed in
+func isPending(space *toolchainv1alpha1.Space) bool {
+	return space.Labels[toolchainv1alpha1.SpaceStateLabelKey] == toolchainv1alpha1.SpaceStateLabelValuePending &&
+		condition.IsFalseWithReason(space.Status.Conditions, toolchainv1alpha1.ConditionReady, toolchainv1alpha1.SpaceProvisioningPendingReason)
+}
+
+var _ assertions.Predicate[*toolchainv1alpha1.SpaceProvisionerConfig] = (*configObserved)(nil)
+
+// configObserved checks that the ready condition of the SpaceProvisionerConfig is consistent with its spec and its
+// consumed capacity: not ready when it is disabled or when one of its capacity thresholds is reached, ready otherwise
+type configObserved struct{}
+
+func observedConfig() assertions.Predicate[*toolchainv1alpha1.SpaceProvisionerConfig] {
+	return &configObserved{}
+}
+
+func (c *configObserved) Matches(obj *toolchainv1alpha1.SpaceProvisionerConfig) bool {
+	if obj.Status.ConsumedCapacity == nil {
+		return false
+	}
+	switch {
+	case !obj.Spec.Enabled:
+		return condition.IsFalseWithReason(obj.Status.Conditions, toolchainv1alpha1.ConditionReady, toolchainv1alpha1.SpaceProvisionerConfigDisabledReason)
+	case capacityReached(obj):
+		return condition.IsFalseWithReason(obj.Status.Conditions, toolchainv1alpha1.ConditionReady, toolchainv1alpha1.SpaceProvisionerConfigInsufficientCapacityReason)
+	default:
+		return condition.IsTrue(obj.Status.Conditions, toolchainv1alpha1.ConditionReady)
+	}
+}
+
+// capacityReached returns `true` if the consumed capacity of the SpaceProvisionerConfig reaches one of its thresholds
+func capacityReached(spc *toolchainv1alpha1.SpaceProvisionerConfig) bool {
+	thresholds := spc.Spec.CapacityThresholds
+	if thresholds.MaxNumberOfSpaces > 0 && uint(spc.Status.ConsumedCapacity.SpaceCount) >= thresholds.MaxNumberOfSpaces { //nolint:gosec
+		return true
+	}
+	if thresholds.MaxMemoryUtilizationPercent > 0 {
+		for _, usage := range spc.Status.ConsumedCapacity.MemoryUsagePercentPerNodeRole {
+			if uint(usage) >= thresholds.MaxMemoryUtilizationPercent { //nolint:gosec
+				return true
+			}
+		}
+	}
+	return false
+}
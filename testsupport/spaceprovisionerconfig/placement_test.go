@@ -0,0 +1,149 @@
+package spaceprovisionerconfig
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDistribution(t *testing.T) {
+	t.Run("string ignores the order and the empty member clusters", func(t *testing.T) {
+		// given
+		d1 := Distribution{PerCluster: map[string]int{"member-2": 2, "member-1": 1, "member-3": 0}, Pending: 1}
+		d2 := Distribution{PerCluster: map[string]int{"member-1": 1, "member-2": 2}, Pending: 1}
+
+		// when
+		s1 := d1.String()
+		s2 := d2.String()
+
+		// then
+		assert.Equal(t, "member-1=1, member-2=2, pending=1", s1)
+		assert.Equal(t, s1, s2)
+	})
+
+	t.Run("distribution of spaces", func(t *testing.T) {
+		// given
+		spaces := []*toolchainv1alpha1.Space{
+			provisionedSpace("member-1"),
+			provisionedSpace("member-2"),
+			provisionedSpace("member-2"),
+			pendingSpace(),
+			pendingSpace(),
+		}
+
+		// when
+		d := distributionOf(spaces)
+
+		// then
+		assert.Equal(t, Distribution{PerCluster: map[string]int{"member-1": 1, "member-2": 2}, Pending: 2}, d)
+	})
+
+	t.Run("space with pending label but without pending condition is not pending", func(t *testing.T) {
+		// given
+		space := provisionedSpace("member-1")
+		space.Labels = map[string]string{toolchainv1alpha1.SpaceStateLabelKey: toolchainv1alpha1.SpaceStateLabelValuePending}
+
+		// when
+		pending := isPending(space)
+
+		// then
+		assert.False(t, pending)
+	})
+}
+
+func provisionedSpace(targetCluster string) *toolchainv1alpha1.Space {
+	return &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{},
+		},
+		Status: toolchainv1alpha1.SpaceStatus{
+			TargetCluster: targetCluster,
+			Conditions: []toolchainv1alpha1.Condition{
+				{
+					Type:   toolchainv1alpha1.ConditionReady,
+					Status: corev1.ConditionTrue,
+					Reason: toolchainv1alpha1.SpaceProvisionedReason,
+				},
+			},
+		},
+	}
+}
+
+func pendingSpace() *toolchainv1alpha1.Space {
+	return &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				toolchainv1alpha1.SpaceStateLabelKey: toolchainv1alpha1.SpaceStateLabelValuePending,
+			},
+		},
+		Status: toolchainv1alpha1.SpaceStatus{
+			Conditions: []toolchainv1alpha1.Condition{
+				{
+					Type:   toolchainv1alpha1.ConditionReady,
+					Status: corev1.ConditionFalse,
+					Reason: toolchainv1alpha1.SpaceProvisioningPendingReason,
+				},
+			},
+		},
+	}
+}
+
+func TestObservedConfig(t *testing.T) {
+	observed := func(spec toolchainv1alpha1.SpaceProvisionerConfigSpec, spaceCount, memoryUsage int, ready corev1.ConditionStatus, reason string) bool {
+		spc := &toolchainv1alpha1.SpaceProvisionerConfig{
+			Spec: spec,
+			Status: toolchainv1alpha1.SpaceProvisionerConfigStatus{
+				ConsumedCapacity: &toolchainv1alpha1.ConsumedCapacity{
+					SpaceCount:                    spaceCount,
+					MemoryUsagePercentPerNodeRole: map[string]int{"worker": memoryUsage},
+				},
+				Conditions: []toolchainv1alpha1.Condition{
+					{
+						Type:   toolchainv1alpha1.ConditionReady,
+						Status: ready,
+						Reason: reason,
+					},
+				},
+			},
+		}
+		return observedConfig().Matches(spc)
+	}
+	enabled := func(maxSpaces, maxMemory uint) toolchainv1alpha1.SpaceProvisionerConfigSpec {
+		return toolchainv1alpha1.SpaceProvisionerConfigSpec{
+			Enabled: true,
+			CapacityThresholds: toolchainv1alpha1.SpaceProvisionerCapacityThresholds{
+				MaxNumberOfSpaces:           maxSpaces,
+				MaxMemoryUtilizationPercent: maxMemory,
+			},
+		}
+	}
+
+	t.Run("ready with room left", func(t *testing.T) {
+		assert.True(t, observed(enabled(3, 0), 2, 50, corev1.ConditionTrue, toolchainv1alpha1.SpaceProvisionerConfigValidReason))
+		assert.False(t, observed(enabled(3, 0), 2, 50, corev1.ConditionFalse, toolchainv1alpha1.SpaceProvisionerConfigInsufficientCapacityReason))
+	})
+
+	t.Run("not ready when the max number of spaces is reached", func(t *testing.T) {
+		assert.True(t, observed(enabled(2, 0), 2, 50, corev1.ConditionFalse, toolchainv1alpha1.SpaceProvisionerConfigInsufficientCapacityReason))
+		assert.False(t, observed(enabled(2, 0), 2, 50, corev1.ConditionTrue, toolchainv1alpha1.SpaceProvisionerConfigValidReason))
+	})
+
+	t.Run("not ready when the max memory utilization is reached", func(t *testing.T) {
+		assert.True(t, observed(enabled(0, 1), 2, 50, corev1.ConditionFalse, toolchainv1alpha1.SpaceProvisionerConfigInsufficientCapacityReason))
+		assert.False(t, observed(enabled(0, 1), 2, 50, corev1.ConditionTrue, toolchainv1alpha1.SpaceProvisionerConfigValidReason))
+	})
+
+	t.Run("not ready when disabled", func(t *testing.T) {
+		disabled := toolchainv1alpha1.SpaceProvisionerConfigSpec{Enabled: false}
+		assert.True(t, observed(disabled, 0, 50, corev1.ConditionFalse, toolchainv1alpha1.SpaceProvisionerConfigDisabledReason))
+		assert.False(t, observed(disabled, 0, 50, corev1.ConditionTrue, toolchainv1alpha1.SpaceProvisionerConfigValidReason))
+	})
+
+	t.Run("consumed capacity not known", func(t *testing.T) {
+		spc := &toolchainv1alpha1.SpaceProvisionerConfig{Spec: enabled(0, 0)}
+		assert.False(t, observedConfig().Matches(spc))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -37,6 +38,15 @@ const (
 	baseCPULimit = "40000m"
 )
 
+// the tiers with bespoke checks (see `NewChecksForTier`)
+var bespokeCheckedTiers = []string{base, base1ns, base1nsnoidling, base1ns6didler, appstudio, appstudiolarge, appstudioEnv}
+
+// hasChecks returns true if `NewChecksForTier` returns the checks of the given tier, ie, if the tier has bespoke checks
+// or if its resources are compared with golden files
+func hasChecks(tierName string) bool {
+	return slices.Contains(bespokeCheckedTiers, tierName) || isSnapshotVerifiedTier(tierName)
+}
+
 var providerMatchingLabels = client.MatchingLabels(map[string]string{toolchainv1alpha1.ProviderLabelKey: toolchainv1alpha1.ProviderLabelValue})
 
 type TierChecks interface {
@@ -63,15 +73,23 @@ func NewChecksForTier(tier *toolchainv1alpha1.NSTemplateTier) (TierChecks, error
 	case appstudioEnv:
 		return &appstudioEnvTierChecks{tierName: appstudioEnv}, nil
 	default:
-		return nil, fmt.Errorf("no assertion implementation found for %s", tier.Name)
+		// fall back to the golden files for the tiers without bespoke checks which are verified with snapshots
+		if isSnapshotVerifiedTier(tier.Name) {
+			return NewSnapshotChecksForTier(tier.Name), nil
+		}
+		return nil, fmt.Errorf("no assertion implementation nor golden files found for %s", tier.Name)
 	}
 }
 
-// NewChecksForCustomTier returns a `TierChecks` initialized with the tiers used in the CustomNSTemplateTier
-func NewChecksForCustomTier(t *testing.T, tier *CustomNSTemplateTier) TierChecks {
+// NewChecksForCustomTier returns a `TierChecks` initialized with the tiers used in the CustomNSTemplateTier.
+// The resources which do not come from a tier with bespoke checks (eg, the ones of the Go-template TierTemplates created
+// with `WithGoTemplateNamespace`, `WithGoTemplateClusterResources` or `WithGoTemplateSpaceRole`) are verified against the
+// local render of the TierTemplates of the custom tier (see `NewGenericChecksForTier`)
+func NewChecksForCustomTier(t *testing.T, hostAwait *wait.HostAwaitility, tier *CustomNSTemplateTier) TierChecks {
 	c := &customTierChecks{
-		t:    t,
-		tier: tier,
+		t:         t,
+		hostAwait: hostAwait,
+		tier:      tier,
 	}
 	return c
 }
@@ -79,26 +97,37 @@ func NewChecksForCustomTier(t *testing.T, tier *CustomNSTemplateTier) TierChecks
 var _ TierChecks = &customTierChecks{}
 
 type customTierChecks struct {
-	t    *testing.T
-	tier *CustomNSTemplateTier
+	t         *testing.T
+	hostAwait *wait.HostAwaitility
+	tier      *CustomNSTemplateTier
+	generic   TierChecks
+}
+
+// checksFor returns the bespoke checks of the given source tier, or the generic checks of the custom tier
+// if there is no source tier or if it has no bespoke checks. The snapshot checks are never used here, since the golden
+// files of the source tier do not reflect the parameters nor the modifications of the duplicated TierTemplates.
+func (c *customTierChecks) checksFor(sourceTier *toolchainv1alpha1.NSTemplateTier) TierChecks {
+	if sourceTier != nil && slices.Contains(bespokeCheckedTiers, sourceTier.Name) {
+		checks, err := NewChecksForTier(sourceTier)
+		require.NoError(c.t, err)
+		return checks
+	}
+	if c.generic == nil {
+		c.generic = NewGenericChecksForTier(c.t, c.hostAwait, c.tier.NSTemplateTier)
+	}
+	return c.generic
 }
 
 func (c *customTierChecks) GetNamespaceObjectChecks(nsType string) []namespaceObjectsCheck {
-	checks, err := NewChecksForTier(c.tier.NamespaceResourcesTier)
-	require.NoError(c.t, err)
-	return checks.GetNamespaceObjectChecks(nsType)
+	return c.checksFor(c.tier.NamespaceResourcesTier).GetNamespaceObjectChecks(nsType)
 }
 
 func (c *customTierChecks) GetSpaceRoleChecks(spaceRoles map[string][]string) ([]spaceRoleObjectsCheck, error) {
-	checks, err := NewChecksForTier(c.tier.SpaceRolesTier)
-	require.NoError(c.t, err)
-	return checks.GetSpaceRoleChecks(spaceRoles)
+	return c.checksFor(c.tier.SpaceRolesTier).GetSpaceRoleChecks(spaceRoles)
 }
 
 func (c *customTierChecks) GetClusterObjectChecks() []clusterObjectsCheck {
-	checks, err := NewChecksForTier(c.tier.ClusterResourcesTier)
-	require.NoError(c.t, err)
-	return checks.GetClusterObjectChecks()
+	return c.checksFor(c.tier.ClusterResourcesTier).GetClusterObjectChecks()
 }
 
 func (c *customTierChecks) GetExpectedTemplateRefs(t *testing.T, hostAwait *wait.HostAwaitility) TemplateRefs {
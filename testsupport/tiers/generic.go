@@ -0,0 +1,339 @@
+package tiers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"text/template"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontemplate "github.com/codeready-toolchain/toolchain-common/pkg/template"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ChecksForTier returns the bespoke `TierChecks` of the given tier if there are some (see `NewChecksForTier`),
+// or the generic `TierChecks` derived from the TierTemplates of the tier otherwise (see `NewGenericChecksForTier`)
+func ChecksForTier(t *testing.T, hostAwait *wait.HostAwaitility, tier *toolchainv1alpha1.NSTemplateTier) TierChecks {
+	if !hasChecks(tier.Name) {
+		t.Logf("no bespoke checks for the tier '%s', using the checks derived from its TierTemplates", tier.Name)
+		return NewGenericChecksForTier(t, hostAwait, tier)
+	}
+	checks, err := NewChecksForTier(tier)
+	require.NoError(t, err)
+	return checks
+}
+
+// NewGenericChecksForTier returns a `TierChecks` which works for any tier: it processes the TierTemplates of the given tier
+// (or rather their TierTemplateRevisions, when they are listed in the status of the tier) with the same parameters as the
+// member operator (ie, the parameters of the tier or of the revision, the name of the Space, the namespace and the
+// username of each SpaceBinding) and verifies that every expected object exists in the right namespace and that its content
+// contains the content of the template (ie, the fields which are defaulted or added by the cluster are ignored).
+func NewGenericChecksForTier(t *testing.T, hostAwait *wait.HostAwaitility, tier *toolchainv1alpha1.NSTemplateTier) TierChecks {
+	c := &genericTierChecks{
+		t:                  t,
+		tier:               tier,
+		scheme:             hostAwait.Client.Scheme(),
+		namespaceTemplates: map[string]*toolchainv1alpha1.TierTemplate{},
+		spaceRoleTemplates: map[string]*toolchainv1alpha1.TierTemplate{},
+		revisionParameters: map[string]map[string]string{},
+		hostAwait:          hostAwait,
+	}
+	if tier.Spec.ClusterResources != nil {
+		c.clusterTemplate = c.getTierTemplate(tier.Spec.ClusterResources.TemplateRef)
+	}
+	for _, ns := range tier.Spec.Namespaces {
+		tmpl := c.getTierTemplate(ns.TemplateRef)
+		for _, nsType := range c.typesOf(ns.TemplateRef, tmpl) {
+			c.namespaceTemplates[nsType] = tmpl
+		}
+	}
+	for role, spaceRole := range tier.Spec.SpaceRoles {
+		tmpl := c.getTierTemplate(spaceRole.TemplateRef)
+		c.spaceRoleTemplates[role] = tmpl
+		for _, roleType := range c.typesOf(spaceRole.TemplateRef, tmpl) {
+			c.spaceRoleTemplates[roleType] = tmpl
+		}
+	}
+	return c
+}
+
+var _ TierChecks = &genericTierChecks{}
+
+type genericTierChecks struct {
+	t                  *testing.T
+	tier               *toolchainv1alpha1.NSTemplateTier
+	scheme             *runtime.Scheme
+	hostAwait          *wait.HostAwaitility
+	clusterTemplate    *toolchainv1alpha1.TierTemplate
+	namespaceTemplates map[string]*toolchainv1alpha1.TierTemplate // indexed by namespace type
+	spaceRoleTemplates map[string]*toolchainv1alpha1.TierTemplate // indexed by space role
+	revisionParameters map[string]map[string]string               // parameters of the TierTemplateRevisions, indexed by TierTemplate
+}
+
+// getTierTemplate returns the TierTemplate with the given name, in which the `templateObjects` are the ones of its
+// TierTemplateRevision if the tier has one in its status (since this is the revision which is provisioned by the member operator).
+// Otherwise, the TierTemplate is returned as-is.
+func (c *genericTierChecks) getTierTemplate(name string) *toolchainv1alpha1.TierTemplate {
+	tmpl, err := c.hostAwait.WaitForTierTemplate(c.t, name)
+	require.NoError(c.t, err)
+	ttrName, found := c.tier.Status.Revisions[name]
+	if !found {
+		return tmpl
+	}
+	ttr, err := wait.For(c.t, c.hostAwait.Awaitility, &toolchainv1alpha1.TierTemplateRevision{}).WithNameThat(ttrName)
+	require.NoError(c.t, err)
+	tmpl = tmpl.DeepCopy()
+	tmpl.Spec.TemplateObjects = ttr.Spec.TemplateObjects
+	params := map[string]string{}
+	for _, p := range ttr.Spec.Parameters {
+		params[p.Name] = p.Value
+	}
+	c.revisionParameters[name] = params
+	return tmpl
+}
+
+// typesOf returns the type of the given TierTemplate, as well as the type parsed from the name of its revision
+// (which is the templateRef set in the NSTemplateSets, and from which the type of the namespaces and space roles are derived)
+func (c *genericTierChecks) typesOf(templateRef string, tmpl *toolchainv1alpha1.TierTemplate) []string {
+	var types []string
+	if tmpl.Spec.Type != "" {
+		types = append(types, tmpl.Spec.Type)
+	}
+	if revision, found := c.tier.Status.Revisions[templateRef]; found && strings.Count(revision, "-") >= 3 {
+		if _, tmplType, err := wait.TierAndType(revision); err == nil {
+			types = append(types, tmplType)
+		}
+	}
+	return types
+}
+
+func (c *genericTierChecks) GetNamespaceObjectChecks(nsType string) []namespaceObjectsCheck {
+	tmpl, found := c.namespaceTemplates[nsType]
+	require.True(c.t, found, "no namespace template of type '%s' in tier '%s'", nsType, c.tier.Name)
+	return []namespaceObjectsCheck{
+		func(t *testing.T, _ *corev1.Namespace, memberAwait *wait.MemberAwaitility, owner string) {
+			c.verifyObjects(t, memberAwait, tmpl, map[string]string{
+				"SPACE_NAME": owner,
+			})
+		},
+	}
+}
+
+func (c *genericTierChecks) GetSpaceRoleChecks(spaceRoles map[string][]string) ([]spaceRoleObjectsCheck, error) {
+	checks := []spaceRoleObjectsCheck{}
+	for role, usernames := range spaceRoles {
+		tmpl, found := c.spaceRoleTemplates[role]
+		if !found {
+			return nil, fmt.Errorf("no template for the space role '%s' in tier '%s'", role, c.tier.Name)
+		}
+		for _, username := range usernames {
+			checks = append(checks, func(t *testing.T, ns *corev1.Namespace, memberAwait *wait.MemberAwaitility, owner string) {
+				c.verifyObjects(t, memberAwait, tmpl, map[string]string{
+					"SPACE_NAME": owner,
+					"NAMESPACE":  ns.Name,
+					"USERNAME":   username,
+				})
+			})
+		}
+	}
+	return checks, nil
+}
+
+func (c *genericTierChecks) GetClusterObjectChecks() []clusterObjectsCheck {
+	if c.clusterTemplate == nil {
+		return nil
+	}
+	return []clusterObjectsCheck{
+		func(t *testing.T, memberAwait *wait.MemberAwaitility, userName, _ string) {
+			c.verifyObjects(t, memberAwait, c.clusterTemplate, map[string]string{
+				"SPACE_NAME": userName,
+			})
+		},
+	}
+}
+
+func (c *genericTierChecks) GetExpectedTemplateRefs(t *testing.T, hostAwait *wait.HostAwaitility) TemplateRefs {
+	return GetTemplateRefs(t, hostAwait, c.tier.Name)
+}
+
+// verifyObjects processes the given template with the given parameters, and verifies that all the resulting objects
+// exist in the member cluster and contain the content of the template
+func (c *genericTierChecks) verifyObjects(t *testing.T, memberAwait *wait.MemberAwaitility, tmpl *toolchainv1alpha1.TierTemplate, params map[string]string) {
+	// the parameters of the revision take precedence over the ones of the tier, but not over the given ones
+	values := map[string]string{}
+	for k, v := range c.revisionParameters[tmpl.Name] {
+		values[k] = v
+	}
+	for k, v := range params {
+		values[k] = v
+	}
+	expectedObjs, err := RenderTierTemplate(c.scheme, c.tier, tmpl, values)
+	require.NoError(t, err)
+	for _, expected := range expectedObjs {
+		key := client.ObjectKeyFromObject(expected)
+		description := fmt.Sprintf("%s '%s'", expected.GetKind(), key)
+		var diff string
+		err := memberAwait.WaitUntil(t, description, func() (bool, error) {
+			actual := &unstructured.Unstructured{}
+			actual.SetGroupVersionKind(expected.GroupVersionKind())
+			if err := memberAwait.Client.Get(context.TODO(), key, actual); err != nil {
+				if errors.IsNotFound(err) {
+					diff = "not found"
+					return false, nil
+				}
+				return false, err
+			}
+			diff = ContentDiff(expected.Object, actual.Object)
+			return diff == "", nil
+		})
+		require.NoError(t, err, "%s does not match the TierTemplate '%s': %s", description, tmpl.Name, diff)
+	}
+}
+
+// RenderTierTemplate renders locally the objects of the given TierTemplate of the given tier, with the parameters of the tier
+// and the given parameters (eg, `SPACE_NAME`, `NAMESPACE` and `USERNAME`), as the member operator does:
+// - the `templateObjects` are processed as Go templates, if any,
+// - otherwise the OpenShift template is processed.
+func RenderTierTemplate(s *runtime.Scheme, tier *toolchainv1alpha1.NSTemplateTier, tmpl *toolchainv1alpha1.TierTemplate, params map[string]string) ([]*unstructured.Unstructured, error) {
+	// the given parameters take precedence over the ones of the tier
+	values := map[string]string{}
+	for _, p := range tier.Spec.Parameters {
+		values[p.Name] = p.Value
+	}
+	for k, v := range params {
+		values[k] = v
+	}
+	// TierTemplates with Go templates
+	if len(tmpl.Spec.TemplateObjects) > 0 {
+		objs := make([]*unstructured.Unstructured, 0, len(tmpl.Spec.TemplateObjects))
+		for i, raw := range tmpl.Spec.TemplateObjects {
+			obj, err := ProcessGoTemplate(fmt.Sprintf("%s[%d]", tmpl.Name, i), raw.Raw, values)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, obj)
+		}
+		return objs, nil
+	}
+	// TierTemplates with OpenShift templates
+	processed, err := commontemplate.NewProcessor(s).Process(tmpl.Spec.Template.DeepCopy(), values)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]*unstructured.Unstructured, 0, len(processed))
+	for _, obj := range processed {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: content})
+	}
+	return objs, nil
+}
+
+// ProcessGoTemplate processes the given raw object (of the `templateObjects` of a TierTemplate) as a Go template, with the given parameters
+func ProcessGoTemplate(name string, raw []byte, params map[string]string) (*unstructured.Unstructured, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(raw))
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	if err := tmpl.Execute(out, params); err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(out.Bytes(), &obj.Object); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal the processed object '%s': %w", name, err)
+	}
+	return obj, nil
+}
+
+// ContentDiff returns a description of the differences between the expected content of an object (ie, its template)
+// and its actual content in the cluster, or an empty string if the actual content contains the expected one:
+// - the status and the metadata other than the labels and annotations are ignored,
+// - the fields and the map entries which are not in the expected content are ignored (since they may be defaulted or added by the cluster),
+// - the quantities are compared by value (eg, `1000m` equals `1`)
+func ContentDiff(expected, actual map[string]interface{}) string {
+	var diffs []string
+	for key, expectedValue := range expected {
+		switch key {
+		case "status":
+			continue
+		case "metadata":
+			expectedMeta, _ := expectedValue.(map[string]interface{})
+			actualMeta, _ := actual["metadata"].(map[string]interface{})
+			for _, field := range []string{"labels", "annotations"} {
+				diffs = append(diffs, contentDiff("metadata."+field, expectedMeta[field], actualMeta[field])...)
+			}
+		default:
+			diffs = append(diffs, contentDiff(key, expectedValue, actual[key])...)
+		}
+	}
+	sort.Strings(diffs)
+	return strings.Join(diffs, "; ")
+}
+
+func contentDiff(path string, expected, actual interface{}) []string {
+	switch expected := expected.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an object but got '%v'", path, actual)}
+		}
+		var diffs []string
+		for key, value := range expected {
+			diffs = append(diffs, contentDiff(path+"."+key, value, actualMap[key])...)
+		}
+		return diffs
+	case []interface{}:
+		actualList, ok := actual.([]interface{})
+		if !ok || len(actualList) != len(expected) {
+			return []string{fmt.Sprintf("%s: expected '%v' but got '%v'", path, expected, actual)}
+		}
+		var diffs []string
+		for i := range expected {
+			diffs = append(diffs, contentDiff(fmt.Sprintf("%s[%d]", path, i), expected[i], actualList[i])...)
+		}
+		return diffs
+	default:
+		if scalarEquals(expected, actual) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: expected '%v' but got '%v'", path, expected, actual)}
+	}
+}
+
+// scalarEquals compares the given scalar values, regardless of the numeric types and of the notation of the quantities
+func scalarEquals(expected, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	e, a := fmt.Sprintf("%v", expected), fmt.Sprintf("%v", actual)
+	if e == a {
+		return true
+	}
+	eq, err := resource.ParseQuantity(e)
+	if err != nil {
+		return false
+	}
+	aq, err := resource.ParseQuantity(a)
+	if err != nil {
+		return false
+	}
+	return eq.Cmp(aq) == 0
+}
@@ -0,0 +1,117 @@
+package tiers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentDiff(t *testing.T) {
+	// given
+	expected := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ResourceQuota",
+		"metadata": map[string]interface{}{
+			"name":      "compute-deploy",
+			"namespace": "johnsmith-dev",
+			"labels": map[string]interface{}{
+				"toolchain.dev.openshift.com/provider": "codeready-toolchain",
+			},
+		},
+		"spec": map[string]interface{}{
+			"hard": map[string]interface{}{
+				"limits.cpu":    "20000m",
+				"limits.memory": "7Gi",
+				"count/pods":    float64(50),
+			},
+			"scopes":   []interface{}{"NotTerminating"},
+			"selector": nil,
+		},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		// given
+		actual := map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ResourceQuota",
+			"metadata": map[string]interface{}{
+				"name":            "compute-deploy",
+				"namespace":       "johnsmith-dev",
+				"uid":             "0f1a2b3c",
+				"resourceVersion": "12345",
+				"labels": map[string]interface{}{
+					"toolchain.dev.openshift.com/provider": "codeready-toolchain",
+					"toolchain.dev.openshift.com/space":    "johnsmith",
+				},
+			},
+			"spec": map[string]interface{}{
+				"hard": map[string]interface{}{
+					"limits.cpu":    "20",
+					"limits.memory": "7Gi",
+					"count/pods":    int64(50),
+				},
+				"scopes": []interface{}{"NotTerminating"},
+			},
+			"status": map[string]interface{}{
+				"used": map[string]interface{}{},
+			},
+		}
+
+		// when
+		diff := ContentDiff(expected, actual)
+
+		// then
+		assert.Empty(t, diff)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		// given
+		actual := map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ResourceQuota",
+			"metadata": map[string]interface{}{
+				"name":      "compute-deploy",
+				"namespace": "johnsmith-dev",
+			},
+			"spec": map[string]interface{}{
+				"hard": map[string]interface{}{
+					"limits.cpu":    "10",
+					"limits.memory": "7Gi",
+					"count/pods":    int64(50),
+				},
+				"scopes": []interface{}{"NotTerminating", "Terminating"},
+			},
+		}
+
+		// when
+		diff := ContentDiff(expected, actual)
+
+		// then
+		assert.Equal(t, "metadata.labels: expected an object but got '<nil>'; "+
+			"spec.hard.limits.cpu: expected '20000m' but got '10'; "+
+			"spec.scopes: expected '[NotTerminating]' but got '[NotTerminating Terminating]'", diff)
+	})
+}
+
+func TestProcessGoTemplate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// when
+		obj, err := ProcessGoTemplate("ns", []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"{{.SPACE_NAME}}-dev"}}`),
+			map[string]string{"SPACE_NAME": "johnsmith"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "johnsmith-dev", obj.GetName())
+		assert.Equal(t, "Namespace", obj.GetKind())
+	})
+
+	t.Run("missing parameter", func(t *testing.T) {
+		// when
+		_, err := ProcessGoTemplate("ns", []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"{{.SPACE_NAME}}-dev"}}`),
+			map[string]string{})
+
+		// then
+		require.ErrorContains(t, err, `map has no entry for key "SPACE_NAME"`)
+	})
+}
@@ -0,0 +1,363 @@
+package tiers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// UpdateGoldenFilesVar the env var which, when set to `true`, makes the snapshot checks (re)generate the golden files
+	// from the resources provisioned in the cluster instead of comparing them
+	UpdateGoldenFilesVar = "UPDATE_TIER_GOLDEN_FILES"
+	// GoldenFilesDirVar the env var with the path of the directory containing the golden files of the tiers.
+	// If not set, then the `testdata/tiers` directory at the root of this repository is used
+	GoldenFilesDirVar = "TIER_GOLDEN_FILES_DIR"
+
+	// SpaceNamePlaceholder the placeholder of the name of the Space (ie, of the NSTemplateSet) in the golden files
+	SpaceNamePlaceholder = "${SPACE_NAME}"
+	// UsernamePlaceholder the placeholder of the name of the user bound to the Space in the golden files
+	UsernamePlaceholder = "${USERNAME}"
+	// TierNamePlaceholder the placeholder of the name of the tier in the golden files
+	TierNamePlaceholder = "${TIER_NAME}"
+)
+
+// the tiers whose resources are compared with the golden files in the `testdata/tiers/<tier>` directory,
+// ie, the tiers without bespoke checks which are provisioned during the tests
+var snapshotVerifiedTiers = []string{"base1ns-gotemplate"}
+
+// the kinds of resources provisioned by the namespace and space role templates, which are captured in the snapshots
+var namespaceSnapshotKinds = []schema.GroupVersionKind{
+	{Version: "v1", Kind: "ConfigMap"},
+	{Version: "v1", Kind: "LimitRange"},
+	{Version: "v1", Kind: "ResourceQuota"},
+	{Version: "v1", Kind: "ServiceAccount"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"},
+}
+
+// the kinds of resources provisioned by the cluster resources templates, which are captured in the snapshots
+var clusterSnapshotKinds = []schema.GroupVersionKind{
+	{Group: "quota.openshift.io", Version: "v1", Kind: "ClusterResourceQuota"},
+	{Group: "toolchain.dev.openshift.com", Version: "v1alpha1", Kind: "Idler"},
+}
+
+// the metadata fields which are set by the cluster and which are stripped from the snapshots
+var volatileMetadataFields = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp",
+	"deletionGracePeriodSeconds", "managedFields", "ownerReferences", "selfLink", "namespace"}
+
+func updateGoldenFiles() bool {
+	return os.Getenv(UpdateGoldenFilesVar) == "true"
+}
+
+// goldenFilesDir returns the directory containing the golden files of the given tier
+func goldenFilesDir(tierName string) (string, error) {
+	if dir := os.Getenv(GoldenFilesDirVar); dir != "" {
+		return filepath.Join(dir, tierName), nil
+	}
+	// look for the root of the repository, since the tests run in the directory of their package
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return filepath.Join(dir, "testdata", "tiers", tierName), nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("unable to find the root of the repository")
+		}
+		dir = parent
+	}
+}
+
+// isSnapshotVerifiedTier returns true if the resources of the given tier are compared with golden files
+func isSnapshotVerifiedTier(tierName string) bool {
+	return slices.Contains(snapshotVerifiedTiers, tierName)
+}
+
+// NewSnapshotChecksForTier returns a `TierChecks` which compares the resources provisioned for the given tier with the
+// golden files in the `testdata/tiers/<tier>` directory, instead of asserting each resource individually:
+// - the resources provisioned in each namespace (including the ones of the space roles) are compared with the `namespace-<suffix>.yaml` file,
+// where `<suffix>` is the name of the namespace without the name of the Space (eg, `dev` for the `johnsmith-dev` namespace),
+// - the cluster-scoped resources are compared with the `cluster.yaml` file.
+//
+// The provisioned resources match a golden file if they have the same kinds and names as the resources of the golden file,
+// and if they contain their content (see `SnapshotDiff`).
+// The golden files of the tiers listed in `snapshotVerifiedTiers` are (re)generated from the resources provisioned in the cluster
+// when the `UPDATE_TIER_GOLDEN_FILES` env var is set to `true`.
+func NewSnapshotChecksForTier(tierName string) TierChecks {
+	return &snapshotTierChecks{tierName: tierName}
+}
+
+var _ TierChecks = &snapshotTierChecks{}
+
+type snapshotTierChecks struct {
+	tierName string
+}
+
+func (c *snapshotTierChecks) GetNamespaceObjectChecks(_ string) []namespaceObjectsCheck {
+	return []namespaceObjectsCheck{
+		func(t *testing.T, ns *corev1.Namespace, memberAwait *wait.MemberAwaitility, owner string) {
+			verifySnapshot(t, memberAwait, c.tierName, namespaceGoldenFile(ns.Name, owner), owner, func() (string, error) {
+				objs, err := listSnapshotObjects(memberAwait.Client, namespaceSnapshotKinds, owner, client.InNamespace(ns.Name))
+				if err != nil {
+					return "", err
+				}
+				return RenderSnapshot(objs, owner)
+			})
+		},
+	}
+}
+
+func (c *snapshotTierChecks) GetSpaceRoleChecks(_ map[string][]string) ([]spaceRoleObjectsCheck, error) {
+	// the resources of the space roles are part of the snapshots of the namespaces
+	return nil, nil
+}
+
+func (c *snapshotTierChecks) GetClusterObjectChecks() []clusterObjectsCheck {
+	return []clusterObjectsCheck{
+		func(t *testing.T, memberAwait *wait.MemberAwaitility, userName, _ string) {
+			verifySnapshot(t, memberAwait, c.tierName, "cluster.yaml", userName, func() (string, error) {
+				objs, err := listSnapshotObjects(memberAwait.Client, clusterSnapshotKinds, userName)
+				if err != nil {
+					return "", err
+				}
+				return RenderSnapshot(objs, userName)
+			})
+		},
+	}
+}
+
+func (c *snapshotTierChecks) GetExpectedTemplateRefs(t *testing.T, hostAwait *wait.HostAwaitility) TemplateRefs {
+	return GetTemplateRefs(t, hostAwait, c.tierName)
+}
+
+// namespaceGoldenFile returns the name of the golden file of the given namespace of the given Space
+func namespaceGoldenFile(namespace, spaceName string) string {
+	return fmt.Sprintf("namespace-%s.yaml", strings.TrimPrefix(namespace, spaceName+"-"))
+}
+
+// verifySnapshot waits until the snapshot returned by the given function matches the content of the given golden file,
+// or (re)generates the golden file once the NSTemplateSet is provisioned if the `UPDATE_TIER_GOLDEN_FILES` env var is set to `true`
+// and if the tier is one of the `snapshotVerifiedTiers`
+func verifySnapshot(t *testing.T, memberAwait *wait.MemberAwaitility, tierName, filename, owner string, snapshot func() (string, error)) {
+	dir, err := goldenFilesDir(tierName)
+	require.NoError(t, err)
+	path := filepath.Join(dir, filename)
+
+	if updateGoldenFiles() && isSnapshotVerifiedTier(tierName) {
+		_, err := memberAwait.WaitForNSTmplSet(t, owner, wait.UntilNSTemplateSetHasConditions(wait.Provisioned()))
+		require.NoError(t, err)
+		actual, err := snapshot()
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0o600))
+		t.Logf("updated the golden file '%s'", path)
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "unable to read the golden file (set the %s env var to 'true' to generate it)", UpdateGoldenFilesVar)
+	var diff string
+	err = memberAwait.WaitUntil(t, path, func() (bool, error) {
+		actual, err := snapshot()
+		if err != nil {
+			return false, err
+		}
+		diff, err = SnapshotDiff(string(expected), actual)
+		if err != nil {
+			return false, err
+		}
+		return diff == "", nil
+	})
+	require.NoError(t, err, "the provisioned resources do not match the golden file '%s': %s "+
+		"(set the %s env var to 'true' to update it)", path, diff, UpdateGoldenFilesVar)
+}
+
+// listSnapshotObjects lists the resources of the given kinds which belong to the given space
+func listSnapshotObjects(cl client.Client, kinds []schema.GroupVersionKind, spaceName string, opts ...client.ListOption) ([]unstructured.Unstructured, error) {
+	opts = append(opts, client.MatchingLabels(toolchainLabels(spaceName)))
+	var objs []unstructured.Unstructured
+	for _, kind := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+		if err := cl.List(context.TODO(), list, opts...); err != nil {
+			return nil, err
+		}
+		objs = append(objs, list.Items...)
+	}
+	return objs, nil
+}
+
+// RenderSnapshot renders the given resources as a normalized multi-document YAML, in which:
+// - the resources are sorted by kind and name,
+// - the status and the metadata set by the cluster (UIDs, timestamps, resource versions, etc.) are removed,
+// - the template ref label (which contains the revision hash of the template) is removed,
+// - the value of the tier label is replaced with the `${TIER_NAME}` placeholder,
+// - the name of the users in the subjects of the RoleBindings (and in the name of these RoleBindings) is replaced with the
+// `${USERNAME}` placeholder, even if it is the same as the name of the Space,
+// - all other occurrences of the name of the Space are replaced with the `${SPACE_NAME}` placeholder.
+func RenderSnapshot(objs []unstructured.Unstructured, spaceName string) (string, error) {
+	normalized := make([]map[string]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		normalized = append(normalized, normalize(obj.DeepCopy(), spaceName))
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		ki, kj := normalized[i]["kind"].(string), normalized[j]["kind"].(string)
+		if ki != kj {
+			return ki < kj
+		}
+		return nameOf(normalized[i]) < nameOf(normalized[j])
+	})
+	docs := make([]string, 0, len(normalized))
+	for _, obj := range normalized {
+		doc, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, string(doc))
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// SnapshotDiff returns a description of the differences between the given expected and actual snapshots (see `RenderSnapshot`),
+// or an empty string if they contain resources of the same kinds and names, and if each actual resource contains
+// the content of the expected one (see `ContentDiff`), so that the fields defaulted by the cluster can be omitted in the golden files
+func SnapshotDiff(expected, actual string) (string, error) {
+	expectedObjs, err := parseSnapshot(expected)
+	if err != nil {
+		return "", err
+	}
+	actualObjs, err := parseSnapshot(actual)
+	if err != nil {
+		return "", err
+	}
+	var diffs []string
+	for key, expectedObj := range expectedObjs {
+		actualObj, found := actualObjs[key]
+		if !found {
+			diffs = append(diffs, fmt.Sprintf("%s: not found", key))
+			continue
+		}
+		if diff := ContentDiff(expectedObj, actualObj); diff != "" {
+			diffs = append(diffs, fmt.Sprintf("%s: %s", key, diff))
+		}
+	}
+	for key := range actualObjs {
+		if _, found := expectedObjs[key]; !found {
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected", key))
+		}
+	}
+	sort.Strings(diffs)
+	return strings.Join(diffs, "; "), nil
+}
+
+// parseSnapshot parses the given snapshot and returns its resources indexed by `<kind>/<name>`
+func parseSnapshot(snapshot string) (map[string]map[string]interface{}, error) {
+	objs := map[string]map[string]interface{}{}
+	for _, doc := range strings.Split(snapshot, "---\n") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, err
+		}
+		objs[fmt.Sprintf("%s/%s", obj["kind"], nameOf(obj))] = obj
+	}
+	return objs, nil
+}
+
+func normalize(obj *unstructured.Unstructured, spaceName string) map[string]interface{} {
+	delete(obj.Object, "status")
+	if metadata, ok := obj.Object["metadata"].(map[string]interface{}); ok {
+		for _, field := range volatileMetadataFields {
+			delete(metadata, field)
+		}
+	}
+	labels := obj.GetLabels()
+	delete(labels, toolchainv1alpha1.TemplateRefLabelKey)
+	if _, found := labels[toolchainv1alpha1.TierLabelKey]; found {
+		labels[toolchainv1alpha1.TierLabelKey] = TierNamePlaceholder
+	}
+	obj.SetLabels(labels)
+	annotations := obj.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	obj.SetAnnotations(annotations)
+	if obj.GetKind() == "RoleBinding" {
+		// before the name of the Space, since the user may have the same name
+		replaceUsernames(obj)
+	}
+	return replaceStrings(obj.Object, spaceName, SpaceNamePlaceholder).(map[string]interface{})
+}
+
+// replaceUsernames replaces the name of the User subjects of the given RoleBinding with the `${USERNAME}` placeholder,
+// as well as the name of the RoleBinding itself when it is prefixed with the name of one of its users (eg, `<username>-edit`)
+func replaceUsernames(roleBinding *unstructured.Unstructured) {
+	subjects, found, err := unstructured.NestedSlice(roleBinding.Object, "subjects")
+	if !found || err != nil {
+		return
+	}
+	for _, s := range subjects {
+		subject, ok := s.(map[string]interface{})
+		if !ok || subject["kind"] != "User" {
+			continue
+		}
+		username, _ := subject["name"].(string)
+		if username == "" {
+			continue
+		}
+		subject["name"] = UsernamePlaceholder
+		if strings.HasPrefix(roleBinding.GetName(), username+"-") {
+			roleBinding.SetName(UsernamePlaceholder + strings.TrimPrefix(roleBinding.GetName(), username))
+		}
+	}
+	_ = unstructured.SetNestedSlice(roleBinding.Object, subjects, "subjects")
+}
+
+// replaceStrings replaces all the occurrences of `from` with `to` in the string values of the given object
+func replaceStrings(value interface{}, from, to string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.ReplaceAll(v, from, to)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = replaceStrings(item, from, to)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = replaceStrings(item, from, to)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func nameOf(obj map[string]interface{}) string {
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		if name, ok := metadata["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
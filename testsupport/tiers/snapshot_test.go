@@ -0,0 +1,334 @@
+package tiers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestRenderSnapshot(t *testing.T) {
+	// given
+	roleBinding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind":       "RoleBinding",
+		"metadata": map[string]interface{}{
+			"name":              "johnsmith-edit",
+			"namespace":         "johnsmith-dev",
+			"uid":               "0f1a2b3c",
+			"resourceVersion":   "12345",
+			"creationTimestamp": "2024-01-01T00:00:00Z",
+			"labels": map[string]interface{}{
+				toolchainv1alpha1.SpaceLabelKey:       "johnsmith",
+				toolchainv1alpha1.ProviderLabelKey:    toolchainv1alpha1.ProviderLabelValue,
+				toolchainv1alpha1.TierLabelKey:        "base1ns",
+				toolchainv1alpha1.TemplateRefLabelKey: "base1ns-dev-abcd123",
+			},
+		},
+		"subjects": []interface{}{
+			map[string]interface{}{"kind": "User", "name": "johnsmith"},
+		},
+		"roleRef": map[string]interface{}{"kind": "ClusterRole", "name": "edit"},
+	}}
+	limitRange := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "LimitRange",
+		"metadata": map[string]interface{}{
+			"name":      "resource-limits",
+			"namespace": "johnsmith-dev",
+		},
+		"status": map[string]interface{}{"foo": "bar"},
+	}}
+
+	// when
+	snapshot, err := RenderSnapshot([]unstructured.Unstructured{*roleBinding, *limitRange}, "johnsmith")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: LimitRange
+metadata:
+  name: resource-limits
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  labels:
+    toolchain.dev.openshift.com/provider: codeready-toolchain
+    toolchain.dev.openshift.com/space: ${SPACE_NAME}
+    toolchain.dev.openshift.com/tier: ${TIER_NAME}
+  name: ${USERNAME}-edit
+roleRef:
+  kind: ClusterRole
+  name: edit
+subjects:
+- kind: User
+  name: ${USERNAME}
+`, snapshot)
+
+	t.Run("original objects are not modified", func(t *testing.T) {
+		assert.Equal(t, "johnsmith-edit", roleBinding.GetName())
+		assert.Equal(t, "12345", roleBinding.GetResourceVersion())
+		assert.Contains(t, limitRange.Object, "status")
+	})
+
+	t.Run("user with another name than the space", func(t *testing.T) {
+		// given
+		other := roleBinding.DeepCopy()
+		other.SetName("janedoe-edit")
+		other.Object["subjects"] = []interface{}{
+			map[string]interface{}{"kind": "User", "name": "janedoe"},
+			map[string]interface{}{"kind": "ServiceAccount", "name": "johnsmith-sa"},
+		}
+
+		// when
+		snapshot, err := RenderSnapshot([]unstructured.Unstructured{*other}, "johnsmith")
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, snapshot, `  name: ${USERNAME}-edit
+`)
+		assert.Contains(t, snapshot, `subjects:
+- kind: User
+  name: ${USERNAME}
+- kind: ServiceAccount
+  name: ${SPACE_NAME}-sa
+`)
+	})
+
+	t.Run("snapshot is stable", func(t *testing.T) {
+		// when
+		again, err := RenderSnapshot([]unstructured.Unstructured{*limitRange, *roleBinding}, "johnsmith")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, snapshot, again)
+	})
+}
+
+func TestGoldenFiles(t *testing.T) {
+	t.Run("from env var", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		t.Setenv(GoldenFilesDirVar, dir)
+
+		// when
+		tierDir, err := goldenFilesDir("mytier")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "mytier"), tierDir)
+	})
+
+	t.Run("at the root of the repository", func(t *testing.T) {
+		// when
+		tierDir, err := goldenFilesDir("mytier")
+
+		// then
+		require.NoError(t, err)
+		root, err := filepath.Abs(filepath.Join("..", ".."))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "testdata", "tiers", "mytier"), tierDir)
+	})
+}
+
+func TestGoldenFilesOfSnapshotVerifiedTiers(t *testing.T) {
+	for _, tierName := range snapshotVerifiedTiers {
+		t.Run(tierName, func(t *testing.T) {
+			// given
+			dir, err := goldenFilesDir(tierName)
+			require.NoError(t, err)
+			tier, tierTemplates := loadGoTemplateTier(t, filepath.Join("..", "..", "deploy", tierName))
+
+			// when
+			snapshots := renderSnapshots(t, tier, tierTemplates, "johnsmith")
+
+			// then
+			require.NotEmpty(t, snapshots)
+			for filename, snapshot := range snapshots {
+				path := filepath.Join(dir, filename)
+				if updateGoldenFiles() {
+					require.NoError(t, os.MkdirAll(dir, 0o755))
+					require.NoError(t, os.WriteFile(path, []byte(snapshot), 0o600))
+					continue
+				}
+				golden, err := os.ReadFile(path)
+				require.NoError(t, err, "missing golden file (set the %s env var to 'true' to generate it)", UpdateGoldenFilesVar)
+				diff, err := SnapshotDiff(snapshot, string(golden))
+				require.NoError(t, err)
+				assert.Empty(t, diff, "the golden file '%s' does not match the TierTemplates of the tier", path)
+			}
+		})
+	}
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	// given
+	expected := `apiVersion: v1
+kind: LimitRange
+metadata:
+  name: resource-limits
+spec:
+  limits:
+  - default:
+      cpu: "1"
+    type: Container
+---
+apiVersion: v1
+kind: ResourceQuota
+metadata:
+  name: compute
+`
+
+	t.Run("match with defaulted fields", func(t *testing.T) {
+		// given
+		actual := `apiVersion: v1
+kind: LimitRange
+metadata:
+  labels:
+    toolchain.dev.openshift.com/tier: ${TIER_NAME}
+  name: resource-limits
+spec:
+  limits:
+  - default:
+      cpu: 1000m
+    defaultRequest:
+      cpu: 100m
+    type: Container
+---
+apiVersion: v1
+kind: ResourceQuota
+metadata:
+  name: compute
+`
+
+		// when
+		diff, err := SnapshotDiff(expected, actual)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		// given
+		actual := `apiVersion: v1
+kind: LimitRange
+metadata:
+  name: resource-limits
+spec:
+  limits:
+  - default:
+      cpu: "2"
+    type: Container
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: extra
+`
+
+		// when
+		diff, err := SnapshotDiff(expected, actual)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "ConfigMap/extra: unexpected; LimitRange/resource-limits: spec.limits[0].default.cpu: expected '1' but got '2'; ResourceQuota/compute: not found", diff)
+	})
+}
+
+// loadGoTemplateTier loads the NSTemplateTier and the TierTemplates with Go templates in the given directory
+func loadGoTemplateTier(t *testing.T, dir string) (*toolchainv1alpha1.NSTemplateTier, map[string]*toolchainv1alpha1.TierTemplate) {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var tier *toolchainv1alpha1.NSTemplateTier
+	tierTemplates := map[string]*toolchainv1alpha1.TierTemplate{}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		require.NoError(t, err)
+		if entry.Name() == "tier.yaml" {
+			tier = &toolchainv1alpha1.NSTemplateTier{}
+			require.NoError(t, yaml.Unmarshal(content, tier))
+			continue
+		}
+		tierTemplate := &toolchainv1alpha1.TierTemplate{}
+		require.NoError(t, yaml.Unmarshal(content, tierTemplate))
+		tierTemplates[tierTemplate.Name] = tierTemplate
+	}
+	require.NotNil(t, tier, "no NSTemplateTier in '%s'", dir)
+	return tier, tierTemplates
+}
+
+// renderSnapshots renders locally the snapshots of the resources provisioned by the given tier for the given Space,
+// indexed by the name of their golden file
+func renderSnapshots(t *testing.T, tier *toolchainv1alpha1.NSTemplateTier, tierTemplates map[string]*toolchainv1alpha1.TierTemplate, spaceName string) map[string]string {
+	render := func(templateRef string, params map[string]string) []*unstructured.Unstructured {
+		tierTemplate, found := tierTemplates[templateRef]
+		require.True(t, found, "no TierTemplate '%s'", templateRef)
+		objs, err := RenderTierTemplate(scheme.Scheme, tier, tierTemplate, params)
+		require.NoError(t, err)
+		for _, obj := range objs {
+			// the labels set by the member operator
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			for key, value := range toolchainLabels(spaceName) {
+				labels[key] = value
+			}
+			labels[toolchainv1alpha1.TierLabelKey] = tier.Name
+			obj.SetLabels(labels)
+		}
+		return objs
+	}
+	snapshots := map[string]string{}
+	for _, ns := range tier.Spec.Namespaces {
+		var nsObjs []*unstructured.Unstructured
+		objs := render(ns.TemplateRef, map[string]string{"SPACE_NAME": spaceName})
+		for _, obj := range objs {
+			if obj.GetKind() == "Namespace" {
+				nsObjs = append(nsObjs, obj)
+			}
+		}
+		for _, namespace := range nsObjs {
+			content := filterSnapshotKinds(objs, namespaceSnapshotKinds)
+			for _, spaceRole := range tier.Spec.SpaceRoles {
+				content = append(content, filterSnapshotKinds(render(spaceRole.TemplateRef, map[string]string{
+					"SPACE_NAME": spaceName,
+					"NAMESPACE":  namespace.GetName(),
+					"USERNAME":   "janedoe",
+				}), namespaceSnapshotKinds)...)
+			}
+			snapshot, err := RenderSnapshot(content, spaceName)
+			require.NoError(t, err)
+			snapshots[namespaceGoldenFile(namespace.GetName(), spaceName)] = snapshot
+		}
+	}
+	if tier.Spec.ClusterResources != nil {
+		content := filterSnapshotKinds(render(tier.Spec.ClusterResources.TemplateRef, map[string]string{"SPACE_NAME": spaceName}), clusterSnapshotKinds)
+		snapshot, err := RenderSnapshot(content, spaceName)
+		require.NoError(t, err)
+		snapshots["cluster.yaml"] = snapshot
+	}
+	return snapshots
+}
+
+func filterSnapshotKinds(objs []*unstructured.Unstructured, kinds []schema.GroupVersionKind) []unstructured.Unstructured {
+	var filtered []unstructured.Unstructured
+	for _, obj := range objs {
+		for _, kind := range kinds {
+			if obj.GroupVersionKind() == kind {
+				filtered = append(filtered, *obj)
+			}
+		}
+	}
+	return filtered
+}
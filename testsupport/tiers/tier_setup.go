@@ -1,7 +1,10 @@
 package tiers
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"testing"
 	"time"
@@ -11,9 +14,14 @@ import (
 	"github.com/codeready-toolchain/toolchain-common/pkg/test"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
 
+	templatev1 "github.com/openshift/api/template/v1"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 type TierModifier func(tier *toolchainv1alpha1.NSTemplateTier) error
@@ -106,6 +114,99 @@ func WithParameter(name, value string) CustomNSTemplateTierModifier {
 	}
 }
 
+// WithParametersOf sets the parameters of the given tier in the custom tier, which is needed when duplicating
+// the Go-template TierTemplates of the given tier, since their parameters are processed with the ones of the custom tier
+func WithParametersOf(otherTier *toolchainv1alpha1.NSTemplateTier) CustomNSTemplateTierModifier {
+	return func(hostAwait *wait.HostAwaitility, tier *CustomNSTemplateTier) error {
+		for _, param := range otherTier.Spec.Parameters {
+			if err := WithParameter(param.Name, param.Value)(hostAwait, tier); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// WithGoTemplateClusterResources sets the cluster resources of the custom tier with a new TierTemplate whose `templateObjects`
+// are the given objects, in which the parameters are set as Go templates (eg, `{{.SPACE_NAME}}`)
+func WithGoTemplateClusterResources(t *testing.T, objects ...client.Object) CustomNSTemplateTierModifier {
+	return func(hostAwait *wait.HostAwaitility, tier *CustomNSTemplateTier) error {
+		tier.ClusterResourcesTier = nil
+		tmplRef, err := createGoTemplateTierTemplate(t, hostAwait, tier.Namespace, tier.Name, toolchainv1alpha1.ClusterResourcesTemplateType, objects...)
+		if err != nil {
+			return err
+		}
+		tier.Spec.ClusterResources = &toolchainv1alpha1.NSTemplateTierClusterResources{
+			TemplateRef: tmplRef,
+		}
+		return nil
+	}
+}
+
+// WithGoTemplateNamespace adds a namespace of the given type to the custom tier, with a new TierTemplate whose `templateObjects`
+// are the given objects (including the Namespace itself), in which the parameters are set as Go templates (eg, `{{.SPACE_NAME}}`)
+func WithGoTemplateNamespace(t *testing.T, nsType string, objects ...client.Object) CustomNSTemplateTierModifier {
+	return func(hostAwait *wait.HostAwaitility, tier *CustomNSTemplateTier) error {
+		tier.NamespaceResourcesTier = nil
+		tmplRef, err := createGoTemplateTierTemplate(t, hostAwait, tier.Namespace, tier.Name, nsType, objects...)
+		if err != nil {
+			return err
+		}
+		tier.Spec.Namespaces = append(tier.Spec.Namespaces, toolchainv1alpha1.NSTemplateTierNamespace{
+			TemplateRef: tmplRef,
+		})
+		return nil
+	}
+}
+
+// WithGoTemplateSpaceRole sets the given space role of the custom tier with a new TierTemplate whose `templateObjects`
+// are the given objects, in which the parameters are set as Go templates (eg, `{{.NAMESPACE}}` or `{{.USERNAME}}`)
+func WithGoTemplateSpaceRole(t *testing.T, role string, objects ...client.Object) CustomNSTemplateTierModifier {
+	return func(hostAwait *wait.HostAwaitility, tier *CustomNSTemplateTier) error {
+		tier.SpaceRolesTier = nil
+		tmplRef, err := createGoTemplateTierTemplate(t, hostAwait, tier.Namespace, tier.Name, role, objects...)
+		if err != nil {
+			return err
+		}
+		if tier.Spec.SpaceRoles == nil {
+			tier.Spec.SpaceRoles = map[string]toolchainv1alpha1.NSTemplateTierSpaceRole{}
+		}
+		tier.Spec.SpaceRoles[role] = toolchainv1alpha1.NSTemplateTierSpaceRole{
+			TemplateRef: tmplRef,
+		}
+		return nil
+	}
+}
+
+// WithTemplateObjects replaces the `templateObjects` of the duplicated TierTemplate with the given objects,
+// in which the parameters are set as Go templates (eg, `{{.SPACE_NAME}}`)
+func WithTemplateObjects(s *runtime.Scheme, objects ...client.Object) TierTemplateModifier {
+	return func(tierTemplate *toolchainv1alpha1.TierTemplate) error {
+		templateObjects, err := toTemplateObjects(s, objects...)
+		if err != nil {
+			return err
+		}
+		tierTemplate.Spec.TemplateObjects = templateObjects
+		return nil
+	}
+}
+
+// ParameterizeTemplateObjects replaces all the occurrences of the given value in the `templateObjects` of the duplicated
+// TierTemplate with the `{{.<param>}}` Go template, so that the value can be set with `WithParameter(param, ...)`
+func ParameterizeTemplateObjects(value, param string) TierTemplateModifier {
+	return func(tierTemplate *toolchainv1alpha1.TierTemplate) error {
+		if len(tierTemplate.Spec.TemplateObjects) == 0 {
+			return fmt.Errorf("TierTemplate '%s' has no templateObjects", tierTemplate.Name)
+		}
+		for i, obj := range tierTemplate.Spec.TemplateObjects {
+			tierTemplate.Spec.TemplateObjects[i] = runtime.RawExtension{
+				Raw: bytes.ReplaceAll(obj.Raw, []byte(value), []byte(fmt.Sprintf("{{.%s}}", param))),
+			}
+		}
+		return nil
+	}
+}
+
 // CreateCustomNSTemplateTier creates a custom tier.
 // If no modifiers provided then the new tier will use copies of the baseTier cluster, namespace and space roles templates
 // without any modifications.
@@ -197,6 +298,74 @@ func duplicateTierTemplate(t *testing.T, hostAwait *wait.HostAwaitility, namespa
 	return newTierTemplate.Name, nil
 }
 
+func createGoTemplateTierTemplate(t *testing.T, hostAwait *wait.HostAwaitility, namespace, tierName, tmplType string, objects ...client.Object) (string, error) {
+	tierTemplate, err := newGoTemplateTierTemplate(hostAwait.Client.Scheme(), namespace, tierName, tmplType, objects...)
+	if err != nil {
+		return "", err
+	}
+	if err := hostAwait.CreateWithCleanup(t, tierTemplate); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return "", err
+		}
+	}
+	return tierTemplate.Name, nil
+}
+
+// newGoTemplateTierTemplate returns a new TierTemplate with the given objects as `templateObjects`.
+// Its name follows the `<tier>-<type>-<revision>-<revision>` format of the generated TierTemplates, in which
+// the revision is computed from the content of the objects
+func newGoTemplateTierTemplate(s *runtime.Scheme, namespace, tierName, tmplType string, objects ...client.Object) (*toolchainv1alpha1.TierTemplate, error) {
+	templateObjects, err := toTemplateObjects(s, objects...)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	for _, obj := range templateObjects {
+		hash.Write(obj.Raw)
+	}
+	revision := hex.EncodeToString(hash.Sum(nil))[:7]
+	return &toolchainv1alpha1.TierTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s-%s-%s-%s", tierName, tmplType, revision, revision),
+			Labels:    map[string]string{"producer": "toolchain-e2e"},
+		},
+		Spec: toolchainv1alpha1.TierTemplateSpec{
+			TierName:        tierName,
+			Type:            tmplType,
+			Revision:        fmt.Sprintf("%s-%s", revision, revision),
+			Template:        templatev1.Template{Objects: []runtime.RawExtension{}},
+			TemplateObjects: templateObjects,
+		},
+	}, nil
+}
+
+// toTemplateObjects converts the given objects in raw `templateObjects`, with their apiVersion and kind
+// and without the fields which are set by the cluster (ie, the creation timestamp and the status)
+func toTemplateObjects(s *runtime.Scheme, objects ...client.Object) ([]runtime.RawExtension, error) {
+	templateObjects := make([]runtime.RawExtension, 0, len(objects))
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, s)
+		if err != nil {
+			return nil, err
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		u := &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(gvk)
+		unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(u.Object, "status")
+		raw, err := u.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		templateObjects = append(templateObjects, runtime.RawExtension{Raw: raw})
+	}
+	return templateObjects, nil
+}
+
 func DuplicatedTierName(tierName, origTierTemplateName string) string {
 	return fmt.Sprintf("%sfrom%s", tierName, origTierTemplateName)
 }
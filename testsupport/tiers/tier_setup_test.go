@@ -0,0 +1,156 @@
+package tiers
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestGoTemplateTierTemplate(t *testing.T) {
+	// given
+	tier := &toolchainv1alpha1.NSTemplateTier{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gotmpl",
+		},
+		Spec: toolchainv1alpha1.NSTemplateTierSpec{
+			Parameters: []toolchainv1alpha1.Parameter{
+				{Name: "GREETING", Value: "hello"},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "greeting",
+			Namespace: "{{.SPACE_NAME}}-dev",
+		},
+		Data: map[string]string{
+			"greeting": "{{.GREETING}}",
+			"role":     "rbac-edit",
+		},
+	}
+
+	// when
+	tierTemplate, err := newGoTemplateTierTemplate(scheme.Scheme, "toolchain-host-operator", tier.Name, "dev", configMap)
+
+	// then
+	require.NoError(t, err)
+	assert.Regexp(t, `^gotmpl-dev-[0-9a-f]{7}-[0-9a-f]{7}$`, tierTemplate.Name)
+	assert.Equal(t, "gotmpl", tierTemplate.Spec.TierName)
+	assert.Equal(t, "dev", tierTemplate.Spec.Type)
+	require.Len(t, tierTemplate.Spec.TemplateObjects, 1)
+	assert.JSONEq(t, `{
+		"apiVersion": "v1",
+		"kind": "ConfigMap",
+		"metadata": {"name": "greeting", "namespace": "{{.SPACE_NAME}}-dev"},
+		"data": {"greeting": "{{.GREETING}}", "role": "rbac-edit"}
+	}`, string(tierTemplate.Spec.TemplateObjects[0].Raw))
+	assert.Equal(t, "{{.GREETING}}", configMap.Data["greeting"], "the original object is not modified")
+
+	t.Run("same objects have the same name", func(t *testing.T) {
+		// when
+		other, err := newGoTemplateTierTemplate(scheme.Scheme, "toolchain-host-operator", tier.Name, "dev", configMap)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, tierTemplate.Name, other.Name)
+	})
+
+	t.Run("render", func(t *testing.T) {
+		// when
+		objs, err := RenderTierTemplate(scheme.Scheme, tier, tierTemplate, map[string]string{"SPACE_NAME": "johnsmith"})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, objs, 1)
+		assert.Equal(t, "johnsmith-dev", objs[0].GetNamespace())
+		assert.Equal(t, map[string]interface{}{"greeting": "hello", "role": "rbac-edit"}, objs[0].Object["data"])
+	})
+
+	t.Run("parameterize and render", func(t *testing.T) {
+		// given
+		parameterized := tierTemplate.DeepCopy()
+		tier := tier.DeepCopy()
+		tier.Spec.Parameters = append(tier.Spec.Parameters, toolchainv1alpha1.Parameter{Name: "EDIT_ROLE", Value: "rbac-edit-custom"})
+
+		// when
+		err := ParameterizeTemplateObjects("rbac-edit", "EDIT_ROLE")(parameterized)
+
+		// then
+		require.NoError(t, err)
+		objs, err := RenderTierTemplate(scheme.Scheme, tier, parameterized, map[string]string{"SPACE_NAME": "johnsmith"})
+		require.NoError(t, err)
+		require.Len(t, objs, 1)
+		assert.Equal(t, map[string]interface{}{"greeting": "hello", "role": "rbac-edit-custom"}, objs[0].Object["data"])
+	})
+
+	t.Run("missing parameter", func(t *testing.T) {
+		// when
+		_, err := RenderTierTemplate(scheme.Scheme, &toolchainv1alpha1.NSTemplateTier{}, tierTemplate, map[string]string{"SPACE_NAME": "johnsmith"})
+
+		// then
+		require.ErrorContains(t, err, `map has no entry for key "GREETING"`)
+	})
+}
+
+func TestParameterizeTemplateObjectsWithoutTemplateObjects(t *testing.T) {
+	// given
+	tierTemplate := &toolchainv1alpha1.TierTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "base1ns-dev-123abc-123abc",
+		},
+	}
+
+	// when
+	err := ParameterizeTemplateObjects("rbac-edit", "EDIT_ROLE")(tierTemplate)
+
+	// then
+	require.EqualError(t, err, "TierTemplate 'base1ns-dev-123abc-123abc' has no templateObjects")
+}
+
+func TestRenderOpenShiftTierTemplate(t *testing.T) {
+	// given
+	s := runtime.NewScheme()
+	require.NoError(t, templatev1.Install(s))
+	require.NoError(t, corev1.AddToScheme(s))
+	tier := &toolchainv1alpha1.NSTemplateTier{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mytier",
+		},
+		Spec: toolchainv1alpha1.NSTemplateTierSpec{
+			Parameters: []toolchainv1alpha1.Parameter{
+				{Name: "GREETING", Value: "hello"},
+				{Name: "SPACE_NAME", Value: "ignored"},
+			},
+		},
+	}
+	tierTemplate := &toolchainv1alpha1.TierTemplate{
+		Spec: toolchainv1alpha1.TierTemplateSpec{
+			Template: templatev1.Template{
+				Objects: []runtime.RawExtension{
+					{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"greeting","namespace":"${SPACE_NAME}-dev"},"data":{"greeting":"${GREETING}"}}`)},
+				},
+				Parameters: []templatev1.Parameter{
+					{Name: "SPACE_NAME", Required: true},
+					{Name: "GREETING", Value: "hi"},
+				},
+			},
+		},
+	}
+
+	// when
+	objs, err := RenderTierTemplate(s, tier, tierTemplate, map[string]string{"SPACE_NAME": "johnsmith"})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, "johnsmith-dev", objs[0].GetNamespace())
+	assert.Equal(t, map[string]interface{}{"greeting": "hello"}, objs[0].Object["data"])
+}
@@ -0,0 +1,271 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	commontemplate "github.com/codeready-toolchain/toolchain-common/pkg/template"
+	"github.com/codeready-toolchain/toolchain-common/pkg/template/nstemplatetiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the namespace in which the NSTemplateTiers and TierTemplates are generated
+	hostNamespace = "toolchain-host-operator"
+	// the (fake) git revision of all the template files
+	revision = "abcdef0"
+)
+
+// RepresentativeParameters the values of the parameters which are set by the host and member operators when
+// processing the templates, and which are used to validate the templates
+var RepresentativeParameters = map[string]string{
+	"SPACE_NAME": "validation",
+	"NAMESPACE":  "validation-dev",
+	"USERNAME":   "validation",
+}
+
+// ValidateNSTemplateTiers validates the tiers in the given directory, which are generated with `ksctl generate nstemplatetiers`
+// (ie, one sub-directory per tier, containing a `tier.yaml` file and the `cluster.yaml`, `ns_<type>.yaml` and `spacerole_<role>.yaml`
+// templates, or a `based_on_tier.yaml` file). It verifies that:
+// - the NSTemplateTiers and TierTemplates can be generated,
+// - the templateRefs of the NSTemplateTiers are well formed and refer to existing TierTemplates of the right tier and type,
+// - the templates can be processed with the `RepresentativeParameters`, and all the resulting objects can be decoded with the given scheme.
+//
+// It returns all the problems that were found.
+func ValidateNSTemplateTiers(s *runtime.Scheme, dir string) []error {
+	files, metadata, err := readTierFiles(dir)
+	if err != nil {
+		return []error{err}
+	}
+	tierTemplates := map[string]*toolchainv1alpha1.TierTemplate{}
+	var nsTemplateTiers []*toolchainv1alpha1.NSTemplateTier
+	err = nstemplatetiers.GenerateTiers(s, func(obj client.Object, _ string) error {
+		switch obj := obj.(type) {
+		case *toolchainv1alpha1.TierTemplate:
+			tierTemplates[obj.Name] = obj
+		case *toolchainv1alpha1.NSTemplateTier:
+			nsTemplateTiers = append(nsTemplateTiers, obj)
+		default:
+			return fmt.Errorf("unexpected object of type %T", obj)
+		}
+		return nil
+	}, hostNamespace, metadata, files)
+	if err != nil {
+		return []error{fmt.Errorf("unable to generate the tiers from '%s': %w", dir, err)}
+	}
+
+	var errs []error
+	for _, tier := range sortedTiers(nsTemplateTiers) {
+		errs = append(errs, checkTemplateRefs(tier, tierTemplates, true)...)
+	}
+	processor := commontemplate.NewProcessor(s)
+	for _, name := range sortedKeys(tierTemplates) {
+		tierTemplate := tierTemplates[name]
+		objs, err := processor.Process(tierTemplate.Spec.Template.DeepCopy(), RepresentativeParameters)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("TierTemplate '%s': %w", name, err))
+			continue
+		}
+		for _, obj := range objs {
+			if err := decode(s, obj); err != nil {
+				errs = append(errs, fmt.Errorf("TierTemplate '%s': %w", name, err))
+			}
+		}
+	}
+	return errs
+}
+
+// ValidateGoTemplateTier validates the tier in the given directory, which contains the NSTemplateTier and the TierTemplates
+// with Go templates in their `templateObjects`. It verifies that:
+// - all the files can be decoded with the given scheme,
+// - the templateRefs of the NSTemplateTier refer to existing TierTemplates,
+// - the `templateObjects` can be processed with the parameters of the NSTemplateTier and the `RepresentativeParameters`,
+// and all the resulting objects can be decoded with the given scheme.
+//
+// It returns all the problems that were found.
+func ValidateGoTemplateTier(s *runtime.Scheme, dir string) []error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []error{err}
+	}
+	decoder := serializer.NewCodecFactory(s, serializer.EnableStrict).UniversalDeserializer()
+	tierTemplates := map[string]*toolchainv1alpha1.TierTemplate{}
+	var nsTemplateTiers []*toolchainv1alpha1.NSTemplateTier
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return []error{err}
+		}
+		obj, _, err := decoder.Decode(content, nil, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to decode '%s': %w", path, err))
+			continue
+		}
+		switch obj := obj.(type) {
+		case *toolchainv1alpha1.TierTemplate:
+			tierTemplates[obj.Name] = obj
+		case *toolchainv1alpha1.NSTemplateTier:
+			nsTemplateTiers = append(nsTemplateTiers, obj)
+		default:
+			errs = append(errs, fmt.Errorf("unexpected object of type %T in '%s'", obj, path))
+		}
+	}
+	if len(nsTemplateTiers) != 1 {
+		return append(errs, fmt.Errorf("expected exactly one NSTemplateTier in '%s', found %d", dir, len(nsTemplateTiers)))
+	}
+	tier := nsTemplateTiers[0]
+	errs = append(errs, checkTemplateRefs(tier, tierTemplates, false)...)
+
+	params := map[string]string{}
+	for _, p := range tier.Spec.Parameters {
+		params[p.Name] = p.Value
+	}
+	for k, v := range RepresentativeParameters {
+		params[k] = v
+	}
+	for _, name := range sortedKeys(tierTemplates) {
+		for i, raw := range tierTemplates[name].Spec.TemplateObjects {
+			obj, err := tiers.ProcessGoTemplate(fmt.Sprintf("%s[%d]", name, i), raw.Raw, params)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("TierTemplate '%s': %w", name, err))
+				continue
+			}
+			if err := decode(s, obj); err != nil {
+				errs = append(errs, fmt.Errorf("TierTemplate '%s': %w", name, err))
+			}
+		}
+	}
+	return errs
+}
+
+// readTierFiles reads the files of all the tiers in the given directory, indexed by `<tier>/<filename>`,
+// along with the metadata which contains their (fake) revision
+func readTierFiles(dir string) (map[string][]byte, map[string]string, error) {
+	files := map[string][]byte{}
+	metadata := map[string]string{}
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*.yaml"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil, fmt.Errorf("no tier found in '%s'", dir)
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		name := filepath.Base(filepath.Dir(path)) + "/" + filepath.Base(path)
+		files[name] = content
+		metadata[strings.TrimSuffix(name, ".yaml")] = revision
+	}
+	return files, metadata, nil
+}
+
+// checkTemplateRefs verifies that all the templateRefs of the given tier refer to existing TierTemplates, and (optionally)
+// that they are well formed, ie, `<tier>-<type>-<based-on-tier-revision>-<template-revision>` with the expected tier and type
+func checkTemplateRefs(tier *toolchainv1alpha1.NSTemplateTier, tierTemplates map[string]*toolchainv1alpha1.TierTemplate, wellFormed bool) []error {
+	var errs []error
+	check := func(templateRef, expectedType string) {
+		if templateRef == "" {
+			errs = append(errs, fmt.Errorf("NSTemplateTier '%s': missing templateRef for type '%s'", tier.Name, expectedType))
+			return
+		}
+		tierTemplate, found := tierTemplates[templateRef]
+		if !found {
+			errs = append(errs, fmt.Errorf("NSTemplateTier '%s': TierTemplate '%s' does not exist", tier.Name, templateRef))
+			return
+		}
+		if !wellFormed {
+			return
+		}
+		tierName, tmplType, err := tierAndType(templateRef)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("NSTemplateTier '%s': %w", tier.Name, err))
+			return
+		}
+		if tierName != tier.Name || tierName != tierTemplate.Spec.TierName {
+			errs = append(errs, fmt.Errorf("NSTemplateTier '%s': templateRef '%s' refers to the tier '%s'", tier.Name, templateRef, tierName))
+		}
+		if expectedType != "" && tmplType != expectedType {
+			errs = append(errs, fmt.Errorf("NSTemplateTier '%s': templateRef '%s' is of type '%s' instead of '%s'", tier.Name, templateRef, tmplType, expectedType))
+		}
+	}
+	if tier.Spec.ClusterResources != nil {
+		check(tier.Spec.ClusterResources.TemplateRef, toolchainv1alpha1.ClusterResourcesTemplateType)
+	}
+	for _, ns := range tier.Spec.Namespaces {
+		check(ns.TemplateRef, "")
+	}
+	roles := make([]string, 0, len(tier.Spec.SpaceRoles))
+	for role := range tier.Spec.SpaceRoles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		check(tier.Spec.SpaceRoles[role].TemplateRef, role)
+	}
+	return errs
+}
+
+// tierAndType is a safe wrapper of `wait.TierAndType` which does not panic when the given templateRef has not enough segments
+func tierAndType(templateRef string) (string, string, error) {
+	if strings.Count(templateRef, "-") < 3 {
+		return "", "", fmt.Errorf("invalid templateref: '%s'", templateRef)
+	}
+	return wait.TierAndType(templateRef)
+}
+
+// decode verifies that the given object is of a kind registered in the given scheme, and that it can be decoded
+// without unknown fields
+func decode(s *runtime.Scheme, obj runtime.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	typed, err := s.New(gvk)
+	if err != nil {
+		return err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(u, typed, true); err != nil {
+		return fmt.Errorf("unable to decode the %s '%s': %w", gvk.Kind, nameOf(u), err)
+	}
+	return nil
+}
+
+func nameOf(obj map[string]interface{}) string {
+	name, _, _ := unstructured.NestedString(obj, "metadata", "name")
+	return name
+}
+
+func sortedTiers(items []*toolchainv1alpha1.NSTemplateTier) []*toolchainv1alpha1.NSTemplateTier {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+func sortedKeys(tierTemplates map[string]*toolchainv1alpha1.TierTemplate) []string {
+	keys := make([]string, 0, len(tierTemplates))
+	for k := range tierTemplates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,231 @@
+package validation_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/tiers/validation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDeployedTiers(t *testing.T) {
+	s := testsupport.SchemeWithAllAPIs(t)
+
+	t.Run("nstemplatetiers", func(t *testing.T) {
+		// when
+		errs := validation.ValidateNSTemplateTiers(s, filepath.Join("..", "..", "..", "deploy", "nstemplatetiers"))
+
+		// then
+		assert.Empty(t, errs)
+	})
+
+	t.Run("base1ns-gotemplate", func(t *testing.T) {
+		// when
+		errs := validation.ValidateGoTemplateTier(s, filepath.Join("..", "..", "..", "deploy", "base1ns-gotemplate"))
+
+		// then
+		assert.Empty(t, errs)
+	})
+}
+
+func TestValidateNSTemplateTiers(t *testing.T) {
+	s := testsupport.SchemeWithAllAPIs(t)
+
+	t.Run("unknown kind and field", func(t *testing.T) {
+		// given
+		dir := newTier(t, "mytier", map[string]string{
+			"tier.yaml": tierYAML,
+			"ns_dev.yaml": namespaceTemplateYAML(`
+- apiVersion: v1
+  kind: LimitRange
+  metadata:
+    name: resource-limits
+    namespace: ${SPACE_NAME}-dev
+  spec:
+    unknownField: true
+- apiVersion: unknown.io/v1
+  kind: Unknown
+  metadata:
+    name: unknown
+`),
+			"spacerole_admin.yaml": spaceRoleTemplateYAML,
+		})
+
+		// when
+		errs := validation.ValidateNSTemplateTiers(s, dir)
+
+		// then
+		require.Len(t, errs, 2)
+		assert.ErrorContains(t, errs[0], `unable to decode the LimitRange 'resource-limits'`)
+		assert.ErrorContains(t, errs[0], `unknownField`)
+		assert.ErrorContains(t, errs[1], `no kind "Unknown" is registered`)
+	})
+
+	t.Run("missing space role template", func(t *testing.T) {
+		// given
+		dir := newTier(t, "mytier", map[string]string{
+			"tier.yaml":   tierYAML,
+			"ns_dev.yaml": namespaceTemplateYAML(""),
+		})
+
+		// when
+		errs := validation.ValidateNSTemplateTiers(s, dir)
+
+		// then
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "NSTemplateTier 'mytier': missing templateRef for type 'admin'")
+	})
+
+	t.Run("missing required parameter", func(t *testing.T) {
+		// given
+		dir := newTier(t, "mytier", map[string]string{
+			"tier.yaml": tierYAML,
+			"ns_dev.yaml": `apiVersion: template.openshift.io/v1
+kind: Template
+metadata:
+  name: mytier-dev
+objects:
+- apiVersion: v1
+  kind: Namespace
+  metadata:
+    name: ${SPACE_NAME}-${ENV}
+parameters:
+- name: SPACE_NAME
+  required: true
+- name: ENV
+  required: true
+`,
+			"spacerole_admin.yaml": spaceRoleTemplateYAML,
+		})
+
+		// when
+		errs := validation.ValidateNSTemplateTiers(s, dir)
+
+		// then
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "TierTemplate 'mytier-dev-abcdef0-abcdef0'")
+		assert.ErrorContains(t, errs[0], "ENV")
+	})
+}
+
+func TestValidateGoTemplateTier(t *testing.T) {
+	s := testsupport.SchemeWithAllAPIs(t)
+
+	t.Run("missing template and parameter", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "tier.yaml"), `apiVersion: toolchain.dev.openshift.com/v1alpha1
+kind: NSTemplateTier
+metadata:
+  name: mytier
+spec:
+  namespaces:
+  - templateRef: dev-go
+  spaceRoles:
+    admin:
+      templateRef: spacerole-admin-go
+`)
+		writeFile(t, filepath.Join(dir, "ns_dev.yaml"), `apiVersion: toolchain.dev.openshift.com/v1alpha1
+kind: TierTemplate
+metadata:
+  name: dev-go
+spec:
+  revision: abcdef0-abcdef0
+  tierName: mytier
+  type: dev
+  template: {}
+  templateObjects:
+  - apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: '{{.SPACE_NAME}}-{{.ENV}}'
+`)
+
+		// when
+		errs := validation.ValidateGoTemplateTier(s, dir)
+
+		// then
+		require.Len(t, errs, 2)
+		assert.EqualError(t, errs[0], "NSTemplateTier 'mytier': TierTemplate 'spacerole-admin-go' does not exist")
+		assert.ErrorContains(t, errs[1], `map has no entry for key "ENV"`)
+	})
+}
+
+const tierYAML = `apiVersion: template.openshift.io/v1
+kind: Template
+metadata:
+  name: mytier-tier
+objects:
+- kind: NSTemplateTier
+  apiVersion: toolchain.dev.openshift.com/v1alpha1
+  metadata:
+    name: mytier
+    namespace: ${NAMESPACE}
+  spec:
+    namespaces:
+    - templateRef: ${DEV_TEMPL_REF}
+    spaceRoles:
+      admin:
+        templateRef: ${ADMIN_TEMPL_REF}
+parameters:
+- name: NAMESPACE
+- name: DEV_TEMPL_REF
+- name: ADMIN_TEMPL_REF
+`
+
+const spaceRoleTemplateYAML = `apiVersion: template.openshift.io/v1
+kind: Template
+metadata:
+  name: mytier-spacerole-admin
+objects:
+- apiVersion: rbac.authorization.k8s.io/v1
+  kind: RoleBinding
+  metadata:
+    name: ${USERNAME}-admin
+    namespace: ${NAMESPACE}
+  roleRef:
+    apiGroup: rbac.authorization.k8s.io
+    kind: ClusterRole
+    name: admin
+  subjects:
+  - kind: User
+    name: ${USERNAME}
+parameters:
+- name: NAMESPACE
+  required: true
+- name: USERNAME
+  required: true
+`
+
+func namespaceTemplateYAML(objects string) string {
+	return `apiVersion: template.openshift.io/v1
+kind: Template
+metadata:
+  name: mytier-dev
+objects:
+- apiVersion: v1
+  kind: Namespace
+  metadata:
+    name: ${SPACE_NAME}-dev` + objects + `
+parameters:
+- name: SPACE_NAME
+  required: true
+`
+}
+
+func newTier(t *testing.T, name string, files map[string]string) string {
+	dir := t.TempDir()
+	for filename, content := range files {
+		writeFile(t, filepath.Join(dir, name, filename), content)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
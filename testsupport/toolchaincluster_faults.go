@@ -0,0 +1,289 @@
+package testsupport
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test/assertions"
+	testSpc "github.com/codeready-toolchain/toolchain-common/pkg/test/spaceprovisionerconfig"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/kubeconfig"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/slowproxy"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/spaceprovisionerconfig"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ToolchainClusterFault a fault which is injected in the kubeconfig of a ToolchainCluster, to simulate the loss of
+// connectivity with the cluster
+type ToolchainClusterFault struct {
+	name string
+	// reason the expected reason of the (false) ready condition of the ToolchainCluster while the fault is injected
+	reason string
+	// modifiers returns the modifiers of the kubeconfig of the ToolchainCluster, given the current API endpoint of the cluster
+	modifiers func(t *testing.T, hostAwait *wait.HostAwaitility, apiEndpoint string) []func(*clientcmdapi.Config)
+}
+
+// Name returns the name of the fault
+func (f ToolchainClusterFault) Name() string {
+	return f.name
+}
+
+// UnreachableCluster a fault where the API endpoint of the cluster does not respond
+func UnreachableCluster() ToolchainClusterFault {
+	return ToolchainClusterFault{
+		name:   "unreachable cluster",
+		reason: toolchainv1alpha1.ToolchainClusterClusterNotReachableReason,
+		modifiers: func(_ *testing.T, _ *wait.HostAwaitility, _ string) []func(*clientcmdapi.Config) {
+			return []func(*clientcmdapi.Config){kubeconfig.ApiEndpoint("https://1.2.3.4:8443")}
+		},
+	}
+}
+
+// ExpiredToken a fault where the token of the service account used to connect to the cluster is rejected
+func ExpiredToken() ToolchainClusterFault {
+	return ToolchainClusterFault{
+		name:   "expired token",
+		reason: toolchainv1alpha1.ToolchainClusterClusterNotReachableReason,
+		modifiers: func(_ *testing.T, _ *wait.HostAwaitility, _ string) []func(*clientcmdapi.Config) {
+			return []func(*clientcmdapi.Config){kubeconfig.Token("sha256~expired-e2e-token")}
+		},
+	}
+}
+
+// CAMismatch a fault where the certificate of the API endpoint of the cluster is not signed by the trusted certificate authority
+func CAMismatch() ToolchainClusterFault {
+	return ToolchainClusterFault{
+		name:   "CA mismatch",
+		reason: toolchainv1alpha1.ToolchainClusterClusterNotReachableReason,
+		modifiers: func(t *testing.T, _ *wait.HostAwaitility, _ string) []func(*clientcmdapi.Config) {
+			return []func(*clientcmdapi.Config){kubeconfig.CertificateAuthority(newCertificateAuthority(t))}
+		},
+	}
+}
+
+// SlowResponses a fault where the responses of the API server of the cluster are delayed by the given duration, which should
+// be longer than the timeout of the clients of the host operator. The connections go through the slow proxy which is deployed
+// next to the host operator (see `make deploy-slow-proxy`), so the test is skipped if the proxy is not deployed.
+func SlowResponses(delay time.Duration) ToolchainClusterFault {
+	return ToolchainClusterFault{
+		name:   fmt.Sprintf("responses delayed by %s", delay),
+		reason: toolchainv1alpha1.ToolchainClusterClusterNotReachableReason,
+		modifiers: func(t *testing.T, hostAwait *wait.HostAwaitility, apiEndpoint string) []func(*clientcmdapi.Config) {
+			proxy := SlowProxy(t, hostAwait)
+			if proxy == nil {
+				t.Skip("the slow proxy is not deployed")
+			}
+			// the proxy forwards the connections to the API endpoint of the cluster, so that it also works when the
+			// member cluster is not the cluster of the host operator
+			endpoint, err := url.Parse(apiEndpoint)
+			require.NoError(t, err)
+			port := endpoint.Port()
+			if port == "" {
+				port = "443"
+			}
+			require.NoError(t, proxy.SetUpstream(net.JoinHostPort(endpoint.Hostname(), port)))
+			require.NoError(t, proxy.SetDelay(delay))
+			t.Cleanup(func() {
+				require.NoError(t, proxy.Reset())
+			})
+			return []func(*clientcmdapi.Config){
+				kubeconfig.ApiEndpoint(fmt.Sprintf("https://slow-proxy.%s.svc:6443", hostAwait.Namespace)),
+				// the certificate of the API server is still verified against its original hostname
+				kubeconfig.TLSServerName(endpoint.Hostname()),
+			}
+		},
+	}
+}
+
+// SlowProxy returns a client of the control API of the slow proxy which is deployed next to the host operator (see
+// `make deploy-slow-proxy`), or `nil` if there is none
+func SlowProxy(t *testing.T, hostAwait *wait.HostAwaitility) *slowproxy.Client {
+	serviceURL := fakeServiceURL(t, hostAwait, hostAwait.Namespace, "slow-proxy")
+	if serviceURL == "" {
+		return nil
+	}
+	return slowproxy.NewClient(serviceURL)
+}
+
+// ToolchainClusterFaultInjection a fault which is injected in the ToolchainCluster of a member cluster in the host
+// namespace, along with the assertions on how the host reacts to it. For example:
+//
+//	injection := InjectToolchainClusterFault(t, awaitilities, awaitilities.Member2(), UnreachableCluster())
+//	injection.WaitUntilToolchainClusterNotReady()
+//	injection.WaitUntilMemberNotReadyInToolchainStatus()
+//	injection.WaitUntilSpaceProvisionerConfigNotReady()
+//	injection.VerifySignupsRoutedAway("faultyuser")
+//	injection.Remove()
+//	injection.VerifyRecovered()
+//
+// Since the member cluster cannot be used while the fault is injected, the injection must not be used in the parallel tests.
+// The original kubeconfig of the ToolchainCluster is restored at the end of the test if the fault was not removed before.
+type ToolchainClusterFaultInjection struct {
+	t                  *testing.T
+	awaitilities       wait.Awaitilities
+	fault              ToolchainClusterFault
+	clusterName        string
+	secretName         string
+	originalKubeconfig []byte
+	removed            bool
+}
+
+// InjectToolchainClusterFault injects the given fault in the kubeconfig of the ToolchainCluster of the given member
+// cluster in the host namespace
+func InjectToolchainClusterFault(t *testing.T, awaitilities wait.Awaitilities, memberAwait *wait.MemberAwaitility, fault ToolchainClusterFault) *ToolchainClusterFaultInjection {
+	hostAwait := awaitilities.Host()
+	toolchainCluster, err := hostAwait.WaitForToolchainCluster(t,
+		wait.UntilToolchainClusterHasName(memberAwait.ClusterName),
+		wait.UntilToolchainClusterHasCondition(toolchainv1alpha1.ConditionReady))
+	require.NoError(t, err)
+
+	secret := &corev1.Secret{}
+	err = hostAwait.Client.Get(context.TODO(), types.NamespacedName{Namespace: hostAwait.Namespace, Name: toolchainCluster.Spec.SecretRef.Name}, secret)
+	require.NoError(t, err)
+	injection := &ToolchainClusterFaultInjection{
+		t:                  t,
+		awaitilities:       awaitilities,
+		fault:              fault,
+		clusterName:        toolchainCluster.Name,
+		secretName:         secret.Name,
+		originalKubeconfig: secret.Data["kubeconfig"],
+	}
+	modifiers := fault.modifiers(t, hostAwait, kubeconfig.CurrentServer(t, secret))
+
+	t.Logf("injecting fault '%s' in the ToolchainCluster '%s'", fault.name, injection.clusterName)
+	_, err = wait.For(t, hostAwait.Awaitility, &corev1.Secret{}).
+		Update(injection.secretName, hostAwait.Namespace, kubeconfig.Modify(t, modifiers...))
+	require.NoError(t, err)
+	t.Cleanup(injection.Remove)
+	return injection
+}
+
+// WaitUntilToolchainClusterNotReady waits until the ToolchainCluster is not ready, with the reason which is expected for the fault
+func (i *ToolchainClusterFaultInjection) WaitUntilToolchainClusterNotReady() *toolchainv1alpha1.ToolchainCluster {
+	toolchainCluster, err := i.awaitilities.Host().WaitForToolchainCluster(i.t,
+		wait.UntilToolchainClusterHasName(i.clusterName),
+		wait.UntilToolchainClusterHasConditionFalseStatusAndReason(toolchainv1alpha1.ConditionReady, i.fault.reason))
+	require.NoError(i.t, err)
+	return toolchainCluster
+}
+
+// WaitUntilMemberNotReadyInToolchainStatus waits until the ToolchainStatus reports the member cluster as not ready, and
+// the whole toolchain as not ready either
+func (i *ToolchainClusterFaultInjection) WaitUntilMemberNotReadyInToolchainStatus() *toolchainv1alpha1.ToolchainStatus {
+	toolchainStatus, err := i.awaitilities.Host().WaitForToolchainStatus(i.t,
+		wait.UntilMemberHasReadyStatus(i.clusterName, corev1.ConditionFalse),
+		untilToolchainStatusHasReadyStatus(corev1.ConditionFalse))
+	require.NoError(i.t, err)
+	return toolchainStatus
+}
+
+// WaitUntilSpaceProvisionerConfigNotReady waits until the SpaceProvisionerConfig of the member cluster is not ready,
+// because its ToolchainCluster is not ready
+func (i *ToolchainClusterFaultInjection) WaitUntilSpaceProvisionerConfigNotReady() *toolchainv1alpha1.SpaceProvisionerConfig {
+	spc, err := wait.For(i.t, i.awaitilities.Host().Awaitility, &toolchainv1alpha1.SpaceProvisionerConfig{}).FirstThat(
+		assertions.Has(spaceprovisionerconfig.ReferenceToToolchainCluster(i.clusterName)),
+		assertions.Is(testSpc.NotReadyWithReason(toolchainv1alpha1.SpaceProvisionerConfigToolchainClusterNotReadyReason)))
+	require.NoError(i.t, err)
+	return spc
+}
+
+// VerifySignupsRoutedAway signs up a new user (without any target cluster) and verifies that its Space is provisioned
+// in another member cluster than the faulty one
+func (i *ToolchainClusterFaultInjection) VerifySignupsRoutedAway(username string) *toolchainv1alpha1.Space {
+	user := NewSignupRequest(i.awaitilities).
+		Username(username).
+		Email(username + "@redhat.com").
+		ManuallyApprove().
+		EnsureMUR().
+		RequireConditions(wait.ConditionSet(wait.Default(), wait.ApprovedByAdmin())...).
+		Execute(i.t)
+	space, err := i.awaitilities.Host().WaitForSpace(i.t, user.UserSignup.Status.CompliantUsername, wait.UntilSpaceHasAnyTargetClusterSet())
+	require.NoError(i.t, err)
+	require.NotEqual(i.t, i.clusterName, space.Spec.TargetCluster, "the Space '%s' should not be provisioned in the faulty cluster", space.Name)
+	return space
+}
+
+// Remove restores the original kubeconfig of the ToolchainCluster (if it was not restored yet)
+func (i *ToolchainClusterFaultInjection) Remove() {
+	if i.removed {
+		return
+	}
+	i.t.Logf("removing fault '%s' from the ToolchainCluster '%s'", i.fault.name, i.clusterName)
+	_, err := wait.For(i.t, i.awaitilities.Host().Awaitility, &corev1.Secret{}).
+		Update(i.secretName, i.awaitilities.Host().Namespace, func(secret *corev1.Secret) {
+			secret.Data["kubeconfig"] = i.originalKubeconfig
+		})
+	require.NoError(i.t, err)
+	i.removed = true
+}
+
+// VerifyRecovered verifies that the host recovers once the fault is removed: the ToolchainCluster, the member cluster in
+// the ToolchainStatus, and the SpaceProvisionerConfig of the member cluster are all ready again
+func (i *ToolchainClusterFaultInjection) VerifyRecovered() {
+	require.True(i.t, i.removed, "the fault must be removed first")
+	hostAwait := i.awaitilities.Host()
+	_, err := hostAwait.WaitForToolchainCluster(i.t,
+		wait.UntilToolchainClusterHasName(i.clusterName),
+		wait.UntilToolchainClusterHasCondition(toolchainv1alpha1.ConditionReady))
+	require.NoError(i.t, err)
+	_, err = hostAwait.WaitForToolchainStatus(i.t,
+		wait.UntilMemberHasReadyStatus(i.clusterName, corev1.ConditionTrue),
+		untilToolchainStatusHasReadyStatus(corev1.ConditionTrue))
+	require.NoError(i.t, err)
+	_, err = wait.For(i.t, hostAwait.Awaitility, &toolchainv1alpha1.SpaceProvisionerConfig{}).FirstThat(
+		assertions.Has(spaceprovisionerconfig.ReferenceToToolchainCluster(i.clusterName)),
+		assertions.Is(testSpc.Ready()))
+	require.NoError(i.t, err)
+}
+
+// untilToolchainStatusHasReadyStatus checks that the ready condition of the ToolchainStatus has the given status, regardless
+// of its reason and of the components which are listed in its message (the other member clusters may not be ready either
+// in the meantime)
+func untilToolchainStatusHasReadyStatus(expected corev1.ConditionStatus) wait.ToolchainStatusWaitCriterion {
+	return wait.ToolchainStatusWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.ToolchainStatus) bool {
+			c, found := condition.FindConditionByType(actual.Status.Conditions, toolchainv1alpha1.ConditionReady)
+			return found && c.Status == expected
+		},
+		Diff: func(actual *toolchainv1alpha1.ToolchainStatus) string {
+			return fmt.Sprintf("expected the ready condition of the ToolchainStatus to have status '%s'. Actual conditions: %v", expected, actual.Status.Conditions)
+		},
+	}
+}
+
+// newCertificateAuthority returns a new (PEM-encoded) self-signed certificate authority, which did not sign the certificate of any cluster
+func newCertificateAuthority(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "e2e-unknown-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, pem.Encode(buf, &pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	return buf.Bytes()
+}
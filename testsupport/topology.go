@@ -0,0 +1,111 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Topology the topology of the member clusters used by the e2e tests. It can be configured
+// in a YAML file referenced by the `TOPOLOGY_FILE` env var, eg:
+//
+//	members:
+//	- namespace: toolchain-member-operator
+//	- context: member-cluster-2
+//	  namespace: toolchain-member-operator
+type Topology struct {
+	Members []MemberLocation `json:"members"`
+}
+
+// MemberLocation the location of a member operator
+type MemberLocation struct {
+	// Context the name of the kubeconfig context of the cluster in which the member operator is running.
+	// If empty, then the current context (ie, the cluster of the host operator) is used.
+	Context string `json:"context,omitempty"`
+	// Namespace the namespace in which the member operator is running
+	Namespace string `json:"namespace"`
+}
+
+// LoadTopology loads the topology from the given YAML file
+func LoadTopology(path string) (Topology, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Topology{}, err
+	}
+	topology := Topology{}
+	if err := yaml.Unmarshal(content, &topology); err != nil {
+		return Topology{}, fmt.Errorf("failed to parse the topology file '%s': %w", path, err)
+	}
+	if len(topology.Members) == 0 {
+		return Topology{}, fmt.Errorf("no member configured in the topology file '%s'", path)
+	}
+	for i, m := range topology.Members {
+		if m.Namespace == "" {
+			return Topology{}, fmt.Errorf("missing namespace of member #%d in the topology file '%s'", i+1, path)
+		}
+	}
+	return topology, nil
+}
+
+// memberLocations returns the locations of the member operators (see MemberLocations)
+func memberLocations(t *testing.T, hostAwait *wait.HostAwaitility) []MemberLocation {
+	locations, err := MemberLocations(context.TODO(), hostAwait.Client, hostAwait.Namespace)
+	require.NoError(t, err)
+	return locations
+}
+
+// MemberLocations returns the locations of the member operators, which are:
+// - loaded from the topology file referenced by the `TOPOLOGY_FILE` env var, if set,
+// - or taken from the `MEMBER_NS` and `MEMBER_NS_2` env vars (the latter only if `SECOND_MEMBER_MODE` is `true`), if set,
+// - or discovered from the ToolchainClusters in the host namespace.
+func MemberLocations(ctx context.Context, cl client.Client, hostNs string) ([]MemberLocation, error) {
+	if path := os.Getenv(wait.TopologyFileVar); path != "" {
+		topology, err := LoadTopology(path)
+		if err != nil {
+			return nil, err
+		}
+		return topology.Members, nil
+	}
+
+	if memberNs := os.Getenv(wait.MemberNsVar); memberNs != "" {
+		secondMemberMode := os.Getenv(wait.SecondMemberModeVar)
+		if secondMemberMode == "" {
+			return nil, fmt.Errorf("the '%s' env var must be set along with the '%s' env var", wait.SecondMemberModeVar, wait.MemberNsVar)
+		}
+		locations := []MemberLocation{{Namespace: memberNs}}
+		if secondMemberMode == "true" {
+			locations = append(locations, MemberLocation{Namespace: os.Getenv(wait.MemberNsVar2)})
+		}
+		return locations, nil
+	}
+
+	return discoverMemberLocations(ctx, cl, hostNs)
+}
+
+// discoverMemberLocations returns the locations of the member operators based on the ToolchainClusters in the host namespace
+// which have an operator namespace, regardless of their readiness (which is verified later on, when waiting for the ToolchainClusters).
+// Note that the discovered member operators are expected to run in the same cluster as the host operator.
+func discoverMemberLocations(ctx context.Context, cl client.Client, hostNs string) ([]MemberLocation, error) {
+	toolchainClusters := &toolchainv1alpha1.ToolchainClusterList{}
+	if err := cl.List(ctx, toolchainClusters, client.InNamespace(hostNs)); err != nil {
+		return nil, err
+	}
+
+	var locations []MemberLocation
+	for _, tc := range toolchainClusters.Items {
+		if tc.Status.OperatorNamespace != "" {
+			locations = append(locations, MemberLocation{Namespace: tc.Status.OperatorNamespace})
+		}
+	}
+	if len(locations) == 0 {
+		return nil, fmt.Errorf("no ToolchainCluster with an operator namespace found in namespace '%s'", hostNs)
+	}
+	return locations, nil
+}
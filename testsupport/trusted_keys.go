@@ -0,0 +1,185 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	testconfig "github.com/codeready-toolchain/toolchain-common/pkg/test/config"
+	authsupport "github.com/codeready-toolchain/toolchain-e2e/testsupport/auth"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	trustedKeysName  = "e2e-trusted-keys"
+	trustedKeysImage = "registry.access.redhat.com/ubi9/nginx-124:latest"
+)
+
+// TrustKeys rotates the set of keys which are trusted by the registration service, so that it accepts the tokens signed
+// by the given keys (in addition to the e2e key). The key set is served next to the registration service, and the
+// `authClientPublicKeysURL` of the ToolchainConfig is set to its URL. Since the registration service only loads its keys at
+// startup, its pods are restarted, and they are restarted again at the end of the test, when the ToolchainConfig and
+// the previous key set (if any) are restored.
+// Restarting the registration service disrupts the other tests, so this must not be used in the parallel tests.
+func TrustKeys(t *testing.T, hostAwait *wait.HostAwaitility, keys ...*authsupport.Key) {
+	jwks, err := authsupport.TrustedKeySet(keys...)
+	require.NoError(t, err)
+	ns := hostAwait.RegistrationServiceNs
+
+	// the registration service is restarted at the very end of the test, ie, once the original config and key set are restored
+	t.Cleanup(func() {
+		restartRegistrationService(t, hostAwait)
+	})
+
+	// serve the key set
+	keySet := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      trustedKeysName,
+			Namespace: ns,
+		},
+	}
+	var previousKeys map[string]string
+	op, err := controllerutil.CreateOrUpdate(context.TODO(), hostAwait.Client, keySet, func() error {
+		previousKeys = keySet.Data
+		keySet.Data = map[string]string{
+			"jwks.json": string(jwks),
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	if op == controllerutil.OperationResultCreated {
+		cleanup.AddCleanTasks(t, hostAwait.Client, keySet)
+	} else {
+		// the keys were already rotated earlier in the test (eg, in a parent test), so restore them at the end of this one
+		t.Cleanup(func() {
+			_, err := controllerutil.CreateOrUpdate(context.TODO(), hostAwait.Client, keySet, func() error {
+				keySet.Data = previousKeys
+				return nil
+			})
+			require.NoError(t, err)
+			restartPods(t, hostAwait, trustedKeysName, client.MatchingLabels{"app": trustedKeysName})
+		})
+	}
+	ensureTrustedKeysServer(t, hostAwait)
+
+	// then configure the registration service to use the key set
+	hostAwait.UpdateToolchainConfig(t, testconfig.RegistrationService().Auth().
+		AuthClientPublicKeysURL(fmt.Sprintf("http://%s.%s.svc:8080/jwks.json", trustedKeysName, ns)))
+	restartRegistrationService(t, hostAwait)
+}
+
+// ensureTrustedKeysServer creates (if needed) the deployment and the service which serve the trusted key set, and waits until it is ready
+func ensureTrustedKeysServer(t *testing.T, hostAwait *wait.HostAwaitility) {
+	ns := hostAwait.RegistrationServiceNs
+	labels := map[string]string{"app": trustedKeysName}
+	deployment := &appsv1.Deployment{}
+	err := hostAwait.Client.Get(context.TODO(), client.ObjectKey{Namespace: ns, Name: trustedKeysName}, deployment)
+	if err == nil {
+		// the key set was rotated in the same test: restart the server so that it serves the new keys right away
+		// (instead of waiting until the kubelet refreshes the mounted ConfigMap)
+		restartPods(t, hostAwait, trustedKeysName, client.MatchingLabels(labels))
+		return
+	}
+	require.True(t, apierrors.IsNotFound(err), "failed to get the deployment of the trusted keys: %s", err)
+
+	deployment = &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      trustedKeysName,
+			Namespace: ns,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    "nginx",
+						Image:   trustedKeysImage,
+						Command: []string{"nginx", "-g", "daemon off;"},
+						Ports:   []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/jwks.json", Port: intstr.FromString("http")},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "keys", MountPath: "/opt/app-root/src"}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "keys",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: trustedKeysName},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	require.NoError(t, hostAwait.CreateWithCleanup(t, deployment))
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      trustedKeysName,
+			Namespace: ns,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 8080, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	require.NoError(t, hostAwait.CreateWithCleanup(t, service))
+	hostAwait.WaitForDeploymentToGetReady(t, trustedKeysName, 1)
+}
+
+// restartRegistrationService restarts the pods of the registration service, so that they load the current configuration
+func restartRegistrationService(t *testing.T, hostAwait *wait.HostAwaitility) {
+	t.Log("restarting the registration service")
+	restartPods(t, hostAwait, "registration-service", client.MatchingLabels{"name": "registration-service"})
+}
+
+// restartPods deletes the pods of the given deployment (in the namespace of the registration service) and waits until
+// they are all replaced by new, ready pods
+func restartPods(t *testing.T, hostAwait *wait.HostAwaitility, deploymentName string, selector client.MatchingLabels) {
+	ns := hostAwait.RegistrationServiceNs
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, hostAwait.Client.Get(context.TODO(), client.ObjectKey{Namespace: ns, Name: deploymentName}, deployment))
+	previous := &corev1.PodList{}
+	require.NoError(t, hostAwait.Client.List(context.TODO(), previous, client.InNamespace(ns), selector))
+	require.NoError(t, hostAwait.Client.DeleteAllOf(context.TODO(), &corev1.Pod{}, client.InNamespace(ns), selector))
+
+	err := k8swait.PollUntilContextTimeout(context.TODO(), hostAwait.RetryInterval, hostAwait.Timeout, true, func(ctx context.Context) (done bool, err error) {
+		pods := &corev1.PodList{}
+		if err := hostAwait.Client.List(ctx, pods, client.InNamespace(ns), selector); err != nil {
+			return false, err
+		}
+		ready := 0
+		for _, pod := range pods.Items {
+			for _, old := range previous.Items {
+				if pod.UID == old.UID {
+					return false, nil // the previous pods are still terminating
+				}
+			}
+			for _, cond := range pod.Status.Conditions {
+				if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+					ready++
+				}
+			}
+		}
+		return deployment.Spec.Replicas != nil && ready == int(*deployment.Spec.Replicas), nil
+	})
+	require.NoError(t, err, "the pods of the '%s' deployment were not restarted", deploymentName)
+}
@@ -265,8 +265,7 @@ func VerifySpaceRelatedResources(t *testing.T, awaitilities wait.Awaitilities, u
 		wait.UntilNSTemplateSetHasSpaceRolesFromBindings(tier, bindings),
 	)
 	require.NoError(t, err)
-	tierChecks, err := tiers.NewChecksForTier(tier)
-	require.NoError(t, err)
+	tierChecks := tiers.ChecksForTier(t, hostAwait, tier)
 	tiers.VerifyNSTemplateSet(t, hostAwait, memberAwait, nsTemplateSet, space, tierChecks)
 
 	require.Equal(t, space.Name, userSignup.Status.HomeSpace)
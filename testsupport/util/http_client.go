@@ -0,0 +1,21 @@
+package util
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// NewInsecureHTTPClient returns an HTTP client for the services which are deployed for the tests (eg, the fake mail
+// delivery service or the slow proxy). The client skips the verification of the certificates, since the services are
+// exposed via routes which may use self-signed certificates.
+func NewInsecureHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: time.Second * 10,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, // nolint:gosec
+			},
+		},
+	}
+}
@@ -1,6 +1,16 @@
 package wait
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
 
 func NewAwaitilities(hostAwait *HostAwaitility, memberAwaitilities ...*MemberAwaitility) Awaitilities {
 	return Awaitilities{
@@ -38,3 +48,41 @@ func (a Awaitilities) Member(name string) (*MemberAwaitility, error) {
 func (a Awaitilities) AllMembers() []*MemberAwaitility {
 	return a.memberAwaitilities
 }
+
+// MembersExcept returns the awaitilities of all the members except the ones with the given cluster names
+func (a Awaitilities) MembersExcept(clusterNames ...string) []*MemberAwaitility {
+	members := []*MemberAwaitility{}
+	for _, m := range a.memberAwaitilities {
+		if !slices.Contains(clusterNames, m.ClusterName) {
+			members = append(members, m)
+		}
+	}
+	return members
+}
+
+// AnyMemberExcept returns the awaitility of the first member whose cluster name is not one of the given names
+func (a Awaitilities) AnyMemberExcept(clusterNames ...string) (*MemberAwaitility, error) {
+	if members := a.MembersExcept(clusterNames...); len(members) > 0 {
+		return members[0], nil
+	}
+	return nil, fmt.Errorf("could not find awaitility for any member other than %v", clusterNames)
+}
+
+// MembersWithClusterRole returns the awaitilities of all the members whose ToolchainCluster (in the host namespace)
+// has the given cluster role, eg: `cluster.Tenant`
+func (a Awaitilities) MembersWithClusterRole(t *testing.T, role cluster.Role) []*MemberAwaitility {
+	toolchainClusters := &toolchainv1alpha1.ToolchainClusterList{}
+	err := a.hostAwaitility.Client.List(context.TODO(), toolchainClusters, client.InNamespace(a.hostAwaitility.Namespace), client.HasLabels{cluster.RoleLabel(role)})
+	require.NoError(t, err)
+
+	members := []*MemberAwaitility{}
+	for _, m := range a.memberAwaitilities {
+		for _, tc := range toolchainClusters.Items {
+			if tc.Name == m.ClusterName {
+				members = append(members, m)
+				break
+			}
+		}
+	}
+	return members
+}
@@ -0,0 +1,66 @@
+package wait_test
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAwaitilities(t *testing.T) {
+	// given
+	host := &wait.HostAwaitility{
+		Awaitility: newAwaitility(t,
+			newToolchainCluster("member-1", cluster.Tenant),
+			newToolchainCluster("member-2"),
+			newToolchainCluster("member-3", cluster.Tenant)),
+	}
+	member1 := wait.NewMemberAwaitility(nil, nil, "member-ns-1", "member-1")
+	member2 := wait.NewMemberAwaitility(nil, nil, "member-ns-2", "member-2")
+	member3 := wait.NewMemberAwaitility(nil, nil, "member-ns-3", "member-3")
+	awaitilities := wait.NewAwaitilities(host, member1, member2, member3)
+
+	t.Run("members except", func(t *testing.T) {
+		assert.Equal(t, []*wait.MemberAwaitility{member2, member3}, awaitilities.MembersExcept("member-1"))
+		assert.Equal(t, []*wait.MemberAwaitility{member2}, awaitilities.MembersExcept("member-1", "member-3"))
+		assert.Empty(t, awaitilities.MembersExcept("member-1", "member-2", "member-3"))
+	})
+
+	t.Run("any member except", func(t *testing.T) {
+		// when
+		member, err := awaitilities.AnyMemberExcept("member-1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, member2, member)
+
+		// when
+		_, err = awaitilities.AnyMemberExcept("member-1", "member-2", "member-3")
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("members with cluster role", func(t *testing.T) {
+		assert.Equal(t, []*wait.MemberAwaitility{member1, member3}, awaitilities.MembersWithClusterRole(t, cluster.Tenant))
+		assert.Empty(t, awaitilities.MembersWithClusterRole(t, cluster.Role("unknown")))
+	})
+}
+
+func newToolchainCluster(name string, roles ...cluster.Role) *toolchainv1alpha1.ToolchainCluster {
+	labels := map[string]string{}
+	for _, role := range roles {
+		labels[cluster.RoleLabel(role)] = ""
+	}
+	return &toolchainv1alpha1.ToolchainCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "host",
+			Name:      name,
+			Labels:    labels,
+		},
+	}
+}
@@ -2,13 +2,10 @@ package wait
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"math"
-	"net/http"
-	"net/url"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -16,13 +13,12 @@ import (
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/cluster"
 	cd "github.com/codeready-toolchain/toolchain-common/pkg/condition"
-	"github.com/codeready-toolchain/toolchain-common/pkg/status"
 	"github.com/codeready-toolchain/toolchain-common/pkg/test/assertions"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/cleanup"
 	"github.com/codeready-toolchain/toolchain-e2e/testsupport/metrics"
 
 	routev1 "github.com/openshift/api/route/v1"
-	"github.com/redhat-cop/operator-utils/pkg/util"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -35,7 +31,6 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
-	"k8s.io/kubectl/pkg/util/podutils"
 	k8smetrics "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -48,6 +43,7 @@ const (
 	SecondMemberModeVar              = "SECOND_MEMBER_MODE"
 	HostNsVar                        = "HOST_NS"
 	RegistrationServiceVar           = "REGISTRATION_SERVICE_NS"
+	TopologyFileVar                  = "TOPOLOGY_FILE"
 	ToolchainClusterConditionTimeout = 180 * time.Second
 )
 
@@ -59,6 +55,7 @@ type Awaitility struct {
 	RetryInterval           time.Duration
 	Timeout                 time.Duration
 	MetricsURL              string
+	metricsURLs             func(ctx context.Context) ([]string, error)
 	baselineValues          map[string]float64
 	baselineHistogramValues map[string]map[float64]uint64
 }
@@ -123,7 +120,7 @@ func (a *Awaitility) WaitForHistogramInfBucketDelta(t *testing.T, family string,
 	expectedValue := baseline + delta
 	t.Logf("waiting for the +Inf bucket in histogram '%s{%v}' to reach '%v'", family, labels, expectedValue)
 	var actualValues map[float64]uint64
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		actualValues = a.GetHistogramValues(t, family, labels...)
 		return actualValues[math.Inf(1)] == expectedValue, nil
 	})
@@ -131,6 +128,46 @@ func (a *Awaitility) WaitForHistogramInfBucketDelta(t *testing.T, family string,
 		family, labels, expectedValue, actualValues[math.Inf(1)], actualValues)
 }
 
+// WaitForMetricDeltaOrMore waits for the metric value to reach the adjusted value (or more), i.e, the delta value combined with
+// the baseline value. To be used for the metrics which may also be changed by the tests running in parallel (eg, the number of requests)
+func (a *Awaitility) WaitForMetricDeltaOrMore(t *testing.T, family string, delta float64, labels ...string) {
+	key := a.baselineKey(t, family, labels...)
+	adjustedValue := a.baselineValues[key] + delta
+	err := a.WaitUntilMetricHasValueOrMore(t, family, adjustedValue, labels...)
+	require.NoError(t, err, "waited for metric '%s{%v}' to reach '%v' or more", family, labels, adjustedValue)
+}
+
+// WaitForHistogramInfBucketDeltaOrMore waits for the histogram +Inf bucket value to reach the adjusted value (or more), i.e, the delta
+// value combined with the baseline value of the +Inf bucket. To be used for the histograms which may also be changed by the tests
+// running in parallel (eg, the latencies of the requests)
+func (a *Awaitility) WaitForHistogramInfBucketDeltaOrMore(t *testing.T, family string, delta uint64, labels ...string) {
+	key := a.baselineKey(t, family, labels...)
+
+	baseline := a.baselineHistogramValues[key][math.Inf(1)]
+	expectedValue := baseline + delta
+	t.Logf("waiting for the +Inf bucket in histogram '%s{%v}' to reach '%v' or more", family, labels, expectedValue)
+	var actualValues map[float64]uint64
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
+		families, err := a.scrapeMetrics()
+		if err != nil {
+			// ignore and keep waiting (may be due to endpoint temporarily unavailable)
+			return false, nil
+		}
+		buckets, err := metrics.HistogramBuckets(families, family, labels)
+		if err != nil {
+			// the histogram may not be exposed yet
+			return false, nil
+		}
+		actualValues = make(map[float64]uint64, len(buckets))
+		for _, bucket := range buckets {
+			actualValues[bucket.GetUpperBound()] = bucket.GetCumulativeCount()
+		}
+		return actualValues[math.Inf(1)] >= expectedValue, nil
+	})
+	require.NoError(t, err, "waited for the +Inf bucket in histogram '%s{%v}' to reach '%v' or more. Current value: %v. Whole histogram: %v",
+		family, labels, expectedValue, actualValues[math.Inf(1)], actualValues)
+}
+
 // WaitForMetricBaseline waits for the metric value to reach the baseline value back (to be used during the cleanup)
 func (a *Awaitility) WaitForMetricBaseline(t *testing.T, family string, labels ...string) {
 	t.Log("waiting until host metrics reached their baseline again...")
@@ -138,6 +175,63 @@ func (a *Awaitility) WaitForMetricBaseline(t *testing.T, family string, labels .
 	a.WaitUntiltMetricHasValue(t, family, a.baselineValues[key], labels...)
 }
 
+// TakeMetricsSnapshot scrapes all the metrics exposed at the MetricsURL at once, so that the changes of several metrics
+// can later be verified atomically with `WaitForMetricsDeltas`
+func (a *Awaitility) TakeMetricsSnapshot(t *testing.T) metrics.Snapshot {
+	families, err := a.scrapeMetrics()
+	require.NoError(t, err)
+	return metrics.NewSnapshot(families)
+}
+
+// scrapeMetrics scrapes all the metrics exposed at the MetricsURL, or the metrics exposed by all the replicas
+// of a component (summed) when this Awaitility was configured to do so, eg. by `HostAwaitility.RegistrationServiceMetrics`
+func (a *Awaitility) scrapeMetrics() (map[string]*dto.MetricFamily, error) {
+	if a.metricsURLs == nil {
+		return metrics.Scrape(a.RestConfig, a.MetricsURL)
+	}
+	urls, err := a.metricsURLs(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	return metrics.Scrape(a.RestConfig, urls...)
+}
+
+// metricValue returns the value of the metric with the given family and labels
+func (a *Awaitility) metricValue(family string, labelAndValues []string) (float64, error) {
+	families, err := a.scrapeMetrics()
+	if err != nil {
+		return -1, err
+	}
+	return metrics.MetricValue(families, family, labelAndValues)
+}
+
+// WaitForMetricsDeltas waits until the changes of the metrics since the given snapshot match the given expectation, eg:
+//
+//	before := hostAwait.TakeMetricsSnapshot(t)
+//	// ... sign up a user
+//	hostAwait.WaitForMetricsDeltas(t, before, metrics.ExpectDeltas(
+//		metrics.Family(UserSignupsMetric).Delta(1),
+//		metrics.Family(UserSignupsApprovedMetric).Delta(1),
+//	).AndNoOtherChangeIn(metrics.Family(UserSignupsBannedMetric)))
+func (a *Awaitility) WaitForMetricsDeltas(t *testing.T, before metrics.Snapshot, expectation *metrics.DeltaExpectation) metrics.Deltas {
+	t.Logf("waiting for the metrics to change since %s", before.Time.Format(time.RFC3339))
+	var deltas metrics.Deltas
+	var mismatch error
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
+		families, err := a.scrapeMetrics()
+		if err != nil {
+			// ignore and keep waiting (may be due to endpoint temporarily unavailable)
+			mismatch = err
+			return false, nil
+		}
+		deltas = metrics.NewSnapshot(families).DeltasSince(before)
+		mismatch = expectation.Check(deltas)
+		return mismatch == nil, nil
+	})
+	require.NoError(t, err, "waited for the metrics to match the expected deltas: %v\nactual deltas:\n%s", mismatch, deltas)
+	return deltas
+}
+
 // generates a key to retain the baseline metric value, by joining the metric name and its labels.
 // Note: there are probably more sophisticated ways to combine the name and the labels, but for now
 // this simple concatenation should be enough to make the keys unique
@@ -145,14 +239,28 @@ func (a *Awaitility) baselineKey(t *testing.T, name string, labelAndValues ...st
 	if len(labelAndValues)%2 != 0 {
 		t.Fatal("`labelAndValues` must be pairs of labels and values")
 	}
-	return strings.Join(append([]string{name}, labelAndValues...), ",")
+	// sort the labels by name, so that the key does not depend on the order in which they were given
+	pairs := make([]string, 0, len(labelAndValues)/2)
+	for i := 0; i < len(labelAndValues); i += 2 {
+		pairs = append(pairs, labelAndValues[i]+","+labelAndValues[i+1])
+	}
+	sort.Strings(pairs)
+	return strings.Join(append([]string{name}, pairs...), ",")
+}
+
+// WaitUntil waits until the given condition is met, using the retry interval and timeout of this Awaitility.
+// The given description of what is awaited is recorded in the wait report.
+func (a *Awaitility) WaitUntil(t *testing.T, description string, condition func() (bool, error)) error {
+	return pollFor(t, description, nil, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
+		return condition()
+	})
 }
 
 // WaitForService waits until there's a service with the given name in the current namespace
 func (a *Awaitility) WaitForService(t *testing.T, name string) (corev1.Service, error) {
 	t.Logf("waiting for Service '%s' in namespace '%s'", name, a.Namespace)
 	var metricsSvc *corev1.Service
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		metricsSvc = &corev1.Service{}
 		// retrieve the metrics service from the namespace
 		err = a.Client.Get(context.TODO(),
@@ -178,7 +286,7 @@ func (a *Awaitility) WaitForToolchainClusterWithCondition(t *testing.T, namespac
 	t.Logf("waiting for ToolchainCluster in namespace '%s'", namespace)
 
 	var c toolchainv1alpha1.ToolchainCluster
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		var ready bool
 		if c, ready, err = a.GetToolchainCluster(t, namespace, cdtype); ready {
 			return true, nil
@@ -191,20 +299,18 @@ func (a *Awaitility) WaitForToolchainClusterWithCondition(t *testing.T, namespac
 // GetToolchainCluster retrieves and returns a ToolchainCluster representing a operator of the given type
 // and running in the given expected namespace. It also checks if the CR has the ClusterConditionType
 func (a *Awaitility) GetToolchainCluster(t *testing.T, namespace string, cdtype toolchainv1alpha1.ConditionType) (toolchainv1alpha1.ToolchainCluster, bool, error) {
-	clusters := &toolchainv1alpha1.ToolchainClusterList{}
-	if err := a.Client.List(context.TODO(), clusters, client.InNamespace(a.Namespace)); err != nil {
+	cl, err := FindToolchainCluster(context.TODO(), a.Client, a.Namespace, namespace)
+	if err != nil {
 		return toolchainv1alpha1.ToolchainCluster{}, false, err
 	}
-	if len(clusters.Items) == 0 {
+	if cl == nil {
 		t.Logf("no toolchaincluster resource with the expected operator namespace (.status.operatorNamespace): '%s'", namespace)
+		return toolchainv1alpha1.ToolchainCluster{}, false, nil
 	}
-	// assume there is zero or 1 match only
-	for _, cl := range clusters.Items {
-		if cl.Status.OperatorNamespace == namespace && cd.IsTrue(cl.Status.Conditions, cdtype) {
-			return cl, true, nil
-		}
+	if !cd.IsTrue(cl.Status.Conditions, cdtype) {
+		return toolchainv1alpha1.ToolchainCluster{}, false, nil
 	}
-	return toolchainv1alpha1.ToolchainCluster{}, false, nil
+	return *cl, true, nil
 }
 
 // SetupRouteForService if needed, creates a route for the given service (with the same namespace/name)
@@ -255,60 +361,12 @@ func (a *Awaitility) WaitForRouteToBeAvailable(t *testing.T, ns, name, endpoint
 	t.Logf("waiting for route '%s' in namespace '%s'", name, ns)
 	route := routev1.Route{}
 	// retrieve the route for the registration service
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		if err = a.Client.Get(context.TODO(),
-			types.NamespacedName{
-				Namespace: ns,
-				Name:      name,
-			}, &route); err != nil {
-			if apierrors.IsNotFound(err) {
-				return false, nil
-			}
-			return false, err
-		}
-		// assume there's a single Ingress and that its host will not be empty when the route is ready
-		if len(route.Status.Ingress) == 0 || route.Status.Ingress[0].Host == "" {
-			return false, nil
-		}
-		// verify that the endpoint gives a `200 OK` response on a GET request
-		client := http.Client{
-			Timeout: time.Duration(5 * time.Second), // because sometimes the network connection may be a bit slow
-		}
-		var request *http.Request
-
-		if route.Spec.TLS != nil {
-			client.Transport = &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true, // nolint:gosec
-				},
-			}
-			request, err = http.NewRequest("GET", "https://"+route.Status.Ingress[0].Host+endpoint, nil)
-			if err != nil {
-				return false, err
-			}
-			request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", a.RestConfig.BearerToken))
-		} else {
-			request, err = http.NewRequest("GET", "http://"+route.Status.Ingress[0].Host+endpoint, nil)
-			if err != nil {
-				return false, err
-			}
-		}
-		resp, err := client.Do(request)
-		urlError := &url.Error{}
-		if errors.As(err, &urlError) && urlError.Timeout() {
-			// keep waiting if there was a timeout: the endpoint is not available yet (pod is still re-starting)
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
+		route, err = CheckRouteAvailable(ctx, a.Client, a.RestConfig.BearerToken, ns, name, endpoint)
+		if IsNotReady(err) {
 			return false, nil
-		} else if err != nil {
-			return false, err
 		}
-		defer func() {
-			_ = resp.Body.Close()
-		}()
-
-		if resp.StatusCode != http.StatusOK {
-			return false, nil
-		}
-		return true, nil
+		return err == nil, err
 	})
 	return route, err
 }
@@ -316,7 +374,7 @@ func (a *Awaitility) WaitForRouteToBeAvailable(t *testing.T, ns, name, endpoint
 // GetMetricValue gets the value of the metric with the given family and label key-value pair
 // fails if the metric with the given labelAndValues does not exist
 func (a *Awaitility) GetMetricValue(t *testing.T, family string, labelAndValues ...string) float64 {
-	value, err := metrics.GetMetricValue(a.RestConfig, a.MetricsURL, family, labelAndValues)
+	value, err := a.metricValue(family, labelAndValues)
 	require.NoError(t, err)
 	return value
 }
@@ -324,7 +382,9 @@ func (a *Awaitility) GetMetricValue(t *testing.T, family string, labelAndValues
 // GetHistogramValues gets the value of the histogram with the given family and label key-value pair
 // fails if the histogram with the given labelAndValues does not exist
 func (a *Awaitility) GetHistogramValues(t *testing.T, family string, labelAndValues ...string) map[float64]uint64 {
-	buckets, err := metrics.GetHistogramBuckets(a.RestConfig, a.MetricsURL, family, labelAndValues)
+	families, err := a.scrapeMetrics()
+	require.NoError(t, err)
+	buckets, err := metrics.HistogramBuckets(families, family, labelAndValues)
 	require.NoError(t, err)
 	values := make(map[float64]uint64, len(buckets))
 	for _, bucket := range buckets {
@@ -336,9 +396,9 @@ func (a *Awaitility) GetHistogramValues(t *testing.T, family string, labelAndVal
 // GetMetricValue gets the value of the metric with the given family and label key-value pair
 // fails if the metric with the given labelAndValues does not exist
 func (a *Awaitility) GetMetricLabels(t *testing.T, family string) []map[string]string {
-	labels, err := metrics.GetMetricLabels(a.RestConfig, a.MetricsURL, family)
+	families, err := a.scrapeMetrics()
 	require.NoError(t, err)
-	return labels
+	return metrics.MetricLabels(families, family)
 }
 
 // GetMetricValue gets the value of the metric with the given family and label key-value pair
@@ -347,7 +407,7 @@ func (a *Awaitility) GetMetricValueOrZero(t *testing.T, family string, labelAndV
 	if len(labelAndValues)%2 != 0 {
 		t.Fatal("`labelAndValues` must be pairs of labels and values")
 	}
-	if value, err := metrics.GetMetricValue(a.RestConfig, a.MetricsURL, family, labelAndValues); err == nil {
+	if value, err := a.metricValue(family, labelAndValues); err == nil {
 		return value
 	}
 	return 0
@@ -358,8 +418,8 @@ func (a *Awaitility) GetMetricValueOrZero(t *testing.T, family string, labelAndV
 func (a *Awaitility) WaitUntiltMetricHasValue(t *testing.T, family string, expectedValue float64, labels ...string) {
 	t.Logf("waiting for metric '%s{%v}' to reach '%v'", family, labels, expectedValue)
 	var value float64
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		value, err = metrics.GetMetricValue(a.RestConfig, a.MetricsURL, family, labels)
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
+		value, err = a.metricValue(family, labels)
 		// if error occurred, ignore and return `false` to keep waiting (may be due to endpoint temporarily unavailable)
 		// unless the expected value is `0`, in which case the metric is bot exposed (value==0 and err!= nil), but it's fine too.
 		return (value == expectedValue && err == nil) || (expectedValue == 0 && value == 0), nil
@@ -372,8 +432,8 @@ func (a *Awaitility) WaitUntiltMetricHasValue(t *testing.T, family string, expec
 func (a *Awaitility) WaitUntilMetricHasValueOrMore(t *testing.T, family string, expectedValue float64, labels ...string) error {
 	t.Logf("waiting for metric '%s{%v}' to reach '%v' or more", family, labels, expectedValue)
 	var value float64
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		value, err = metrics.GetMetricValue(a.RestConfig, a.MetricsURL, family, labels)
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
+		value, err = a.metricValue(family, labels)
 		// if error occurred, return `false` to keep waiting (may be due to endpoint temporarily unavailable)
 		return value >= expectedValue && err == nil, nil
 	})
@@ -388,8 +448,8 @@ func (a *Awaitility) WaitUntilMetricHasValueOrMore(t *testing.T, family string,
 func (a *Awaitility) WaitUntilMetricHasValueOrLess(t *testing.T, family string, expectedValue float64, labels ...string) error {
 	t.Logf("waiting for metric '%s{%v}' to reach '%v' or less", family, labels, expectedValue)
 	var value float64
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		value, err = metrics.GetMetricValue(a.RestConfig, a.MetricsURL, family, labels)
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
+		value, err = a.metricValue(family, labels)
 		// if error occurred, return `false` to keep waiting (may be due to endpoint temporarily unavailable)
 		return value <= expectedValue && err == nil, nil
 	})
@@ -417,7 +477,7 @@ func (a *Awaitility) DeletePods(criteria ...client.ListOption) error {
 // GetMemoryUsage retrieves the memory usage (in KB) of a given the pod
 func (a *Awaitility) GetMemoryUsage(podname, ns string) (int64, error) {
 	var containerMetrics k8smetrics.ContainerMetrics
-	if err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	if err := poll(nil, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		podMetrics := k8smetrics.PodMetrics{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: ns,
@@ -459,30 +519,12 @@ func (a *Awaitility) CreateNamespace(t *testing.T, name string) {
 func (a *Awaitility) WaitForDeploymentToGetReady(t *testing.T, name string, replicas int, criteria ...DeploymentCriteria) *appsv1.Deployment {
 	t.Logf("waiting until deployment '%s' in namespace '%s' is ready", name, a.Namespace)
 	deployment := &appsv1.Deployment{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 6*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		obj := &appsv1.Deployment{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
-			if apierrors.IsNotFound(err) {
-				return false, nil
-			}
-			return false, err
-		}
-		deploymentConditions := status.GetDeploymentStatusConditions(context.TODO(), a.Client, name, a.Namespace)
-		if err := status.ValidateComponentConditionReady(deploymentConditions...); err != nil {
-			return false, nil // nolint:nilerr
-		}
-		if int(obj.Status.AvailableReplicas) != replicas {
+	err := poll(t, a.RetryInterval, 6*a.Timeout, func(ctx context.Context) (done bool, err error) {
+		obj, err := CheckDeploymentReady(ctx, a.Client, a.Namespace, name, replicas)
+		if IsNotReady(err) {
 			return false, nil
-		}
-		pods := &corev1.PodList{}
-		require.NoError(t, a.Client.List(context.TODO(), pods, client.InNamespace(a.Namespace), client.MatchingLabels(obj.Spec.Selector.MatchLabels)))
-		if len(pods.Items) != replicas {
-			return false, nil
-		}
-		for _, pod := range pods.Items { // nolint
-			if util.IsBeingDeleted(&pod) || !podutils.IsPodReady(&pod) {
-				return false, nil
-			}
+		} else if err != nil {
+			return false, err
 		}
 		for _, criteriaMatch := range criteria {
 			if !criteriaMatch(obj) {
@@ -513,23 +555,21 @@ func DeploymentHasContainerWithImage(containerName, image string) DeploymentCrit
 }
 
 // ToolchainClusterWaitCriterion a struct to compare with an expected ToolchainCluster CR
-type ToolchainClusterWaitCriterion struct {
-	Match func(toolchainCluster *toolchainv1alpha1.ToolchainCluster) bool
-}
+type ToolchainClusterWaitCriterion = WaitCriterion[*toolchainv1alpha1.ToolchainCluster]
 
 // WaitForToolchainCluster waits until there is a ToolchainCluster CR available with the given list of criteria
 func (a *Awaitility) WaitForToolchainCluster(t *testing.T, criteria ...ToolchainClusterWaitCriterion) (*toolchainv1alpha1.ToolchainCluster, error) {
 	t.Logf("waiting for toolchaincluster in namespace '%s' to match criteria", a.Namespace)
 	var clusters *toolchainv1alpha1.ToolchainClusterList
 	var cl *toolchainv1alpha1.ToolchainCluster
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, "", criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		clusters = &toolchainv1alpha1.ToolchainClusterList{}
 		if err := a.Client.List(context.TODO(), clusters, client.InNamespace(a.Namespace)); err != nil {
 			return false, err
 		}
 		for _, obj := range clusters.Items {
 			cpObj := obj
-			if matchesAllCriteria := matchToolchainClusterWaitCriterion(&cpObj, criteria...); matchesAllCriteria {
+			if matchesAllCriteria := matchCriteria(&cpObj, criteria...); matchesAllCriteria {
 				cl = &cpObj
 				return true, nil
 			}
@@ -539,15 +579,6 @@ func (a *Awaitility) WaitForToolchainCluster(t *testing.T, criteria ...Toolchain
 	return cl, err
 }
 
-func matchToolchainClusterWaitCriterion(actual *toolchainv1alpha1.ToolchainCluster, criteria ...ToolchainClusterWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
-
 // UntilToolchainClusterHasName checks if ToolchainCluster has given name
 func UntilToolchainClusterHasName(expectedName string) ToolchainClusterWaitCriterion {
 	return ToolchainClusterWaitCriterion{
@@ -678,32 +709,33 @@ type Waiter[T client.Object] struct {
 	await *Awaitility
 	t     *testing.T
 	gvk   schema.GroupVersionKind
+	// namespace in which the objects are looked up. Empty for cluster-scoped objects.
+	namespace string
+}
+
+// InNamespace returns a copy of the Waiter that looks up the objects in the given namespace instead of
+// the namespace of the awaitility.
+func (w *Waiter[T]) InNamespace(namespace string) *Waiter[T] {
+	result := *w
+	result.namespace = namespace
+	return &result
 }
 
 // FirstThat uses the provided predicates to filter the objects of the type provided to `wait.For()` and
 // repeatedly tries to find the first one that satisfies all the predicates.
 func (w *Waiter[T]) FirstThat(predicates ...assertions.Predicate[client.Object]) (T, error) {
-	w.t.Logf("waiting for objects of GVK '%s' in namespace '%s' to match criteria", w.gvk, w.await.Namespace)
+	w.t.Logf("waiting for objects of GVK '%s' %s to match criteria", w.gvk, w.scope())
 
 	var returnedObject T
 	// match status of each predicate per object
 	latestResults := map[client.ObjectKey][]bool{}
 
-	err := wait.PollUntilContextTimeout(context.TODO(), w.await.RetryInterval, w.await.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		// because there is no generic way of figuring out the list type for some client.Object type, we need to go
-		// down the low level route and use unstructured to get the list generically and unmarshal and cast the list
-		// items.
-		list := &unstructured.UnstructuredList{}
-		list.SetGroupVersionKind(w.gvk)
-		if err := w.await.Client.List(context.TODO(), list, client.InNamespace(w.await.Namespace)); err != nil {
+	err := poll(w.t, w.await.RetryInterval, w.await.Timeout, func(ctx context.Context) (done bool, err error) {
+		objects, err := w.list()
+		if err != nil {
 			return false, err
 		}
-		for _, obj := range list.Items {
-			object, err := w.cast(&obj)
-			if err != nil {
-				return false, fmt.Errorf("failed to cast the object to GVK %v: %w", w.gvk, err)
-			}
-
+		for _, object := range objects {
 			matches, results := w.matches(object, predicates)
 			latestResults[client.ObjectKeyFromObject(object)] = results
 			if matches {
@@ -715,49 +747,195 @@ func (w *Waiter[T]) FirstThat(predicates ...assertions.Predicate[client.Object])
 	})
 	if err != nil {
 		sb := strings.Builder{}
-		sb.WriteString("failed to find objects (of GVK '%s') in namespace '%s' matching the criteria: %s")
-		args := []any{w.gvk, w.await.Namespace, err.Error()}
-		list := &unstructured.UnstructuredList{}
-		list.SetGroupVersionKind(w.gvk)
-		if err := w.await.Client.List(context.TODO(), list, client.InNamespace(w.await.Namespace)); err != nil {
-			sb.WriteString(" and also failed to retrieve the object at all with error: %s")
-			args = append(args, err)
-		} else {
-			sb.WriteString("\nlisting the objects found in cluster with the differences from the expected state for each:")
-			for _, o := range list.Items {
-				obj, _ := w.cast(&o)
-				key := client.ObjectKeyFromObject(obj)
-
-				matches := true
-				objectResults := latestResults[key]
-				for _, res := range objectResults {
-					if !res {
-						matches = false
-						break
-					}
-				}
+		sb.WriteString("failed to find objects (of GVK '%s') %s matching the criteria: %s")
+		args := []any{w.gvk, w.scope(), err.Error()}
+		w.writeListDiffs(&sb, &args, latestResults, predicates, false)
+		w.t.Logf(sb.String(), args...)
+	}
+	return returnedObject, err
+}
 
-				sb.WriteRune('\n')
-				sb.WriteString("object ")
-				sb.WriteString(key.String())
-				if matches {
-					sb.WriteString("matches all predicates")
-				} else {
-					sb.WriteString("was found to have the following differences:")
-					for i, res := range objectResults {
-						if !res {
-							sb.WriteRune('\n')
-							sb.WriteString(assertions.Explain(predicates[i], obj.DeepCopyObject().(T)))
-						}
-					}
-				}
+// AllThat waits until there is at least one object of the type provided to `wait.For()` and all the existing
+// objects satisfy all the provided predicates. Returns all the objects.
+func (w *Waiter[T]) AllThat(predicates ...assertions.Predicate[client.Object]) ([]T, error) {
+	w.t.Logf("waiting for all objects of GVK '%s' %s to match criteria", w.gvk, w.scope())
+
+	var returnedObjects []T
+	latestResults := map[client.ObjectKey][]bool{}
+
+	err := poll(w.t, w.await.RetryInterval, w.await.Timeout, func(ctx context.Context) (done bool, err error) {
+		objects, err := w.list()
+		if err != nil {
+			return false, err
+		}
+		latestResults = map[client.ObjectKey][]bool{}
+		allMatch := true
+		for _, object := range objects {
+			matches, results := w.matches(object, predicates)
+			latestResults[client.ObjectKeyFromObject(object)] = results
+			allMatch = allMatch && matches
+		}
+		if len(objects) == 0 || !allMatch {
+			return false, nil
+		}
+		returnedObjects = objects
+		return true, nil
+	})
+	if err != nil {
+		sb := strings.Builder{}
+		sb.WriteString("failed to wait for all objects (of GVK '%s') %s to match the criteria: %s")
+		args := []any{w.gvk, w.scope(), err.Error()}
+		w.writeListDiffs(&sb, &args, latestResults, predicates, false)
+		w.t.Logf(sb.String(), args...)
+	}
+	return returnedObjects, err
+}
+
+// ExactlyN waits until there are exactly `n` objects of the type provided to `wait.For()` that satisfy all
+// the provided predicates. Returns the matching objects.
+func (w *Waiter[T]) ExactlyN(n int, predicates ...assertions.Predicate[client.Object]) ([]T, error) {
+	w.t.Logf("waiting for exactly %d objects of GVK '%s' %s to match criteria", n, w.gvk, w.scope())
+
+	var matching []T
+	latestResults := map[client.ObjectKey][]bool{}
+
+	err := poll(w.t, w.await.RetryInterval, w.await.Timeout, func(ctx context.Context) (done bool, err error) {
+		objects, err := w.list()
+		if err != nil {
+			return false, err
+		}
+		latestResults = map[client.ObjectKey][]bool{}
+		matching = nil
+		for _, object := range objects {
+			matches, results := w.matches(object, predicates)
+			latestResults[client.ObjectKeyFromObject(object)] = results
+			if matches {
+				matching = append(matching, object)
 			}
 		}
+		return len(matching) == n, nil
+	})
+	if err != nil {
+		sb := strings.Builder{}
+		sb.WriteString("failed to find exactly %d objects (of GVK '%s') %s matching the criteria, found %d: %s")
+		args := []any{n, w.gvk, w.scope(), len(matching), err.Error()}
+		w.writeListDiffs(&sb, &args, latestResults, predicates, false)
 		w.t.Logf(sb.String(), args...)
+		return nil, err
 	}
+	return matching, nil
+}
+
+// NoneThat waits until there is no object of the type provided to `wait.For()` that satisfies all the provided
+// predicates (ie, all matching objects are gone, or none of them matches anymore).
+func (w *Waiter[T]) NoneThat(predicates ...assertions.Predicate[client.Object]) error {
+	w.t.Logf("waiting for no object of GVK '%s' %s to match criteria", w.gvk, w.scope())
+
+	latestResults := map[client.ObjectKey][]bool{}
+
+	err := poll(w.t, w.await.RetryInterval, w.await.Timeout, func(ctx context.Context) (done bool, err error) {
+		objects, err := w.list()
+		if err != nil {
+			return false, err
+		}
+		latestResults = map[client.ObjectKey][]bool{}
+		found := false
+		for _, object := range objects {
+			matches, results := w.matches(object, predicates)
+			latestResults[client.ObjectKeyFromObject(object)] = results
+			found = found || matches
+		}
+		return !found, nil
+	})
+	if err != nil {
+		sb := strings.Builder{}
+		sb.WriteString("failed to wait for no object (of GVK '%s') %s to match the criteria: %s")
+		args := []any{w.gvk, w.scope(), err.Error()}
+		w.writeListDiffs(&sb, &args, latestResults, predicates, true)
+		w.t.Logf(sb.String(), args...)
+	}
+	return err
+}
+
+// StaysThat verifies that the object with the provided name exists and keeps satisfying all the provided
+// predicates during the whole given duration. It returns an error as soon as the object is not found or
+// doesn't match the predicates anymore. Returns the latest version of the object.
+func (w *Waiter[T]) StaysThat(name string, duration time.Duration, predicates ...assertions.Predicate[client.Object]) (T, error) {
+	w.t.Logf("verifying that object of GVK '%s' with name '%s' %s keeps matching the criteria for %s", w.gvk, name, w.scope(), duration)
+
+	var returnedObject T
+	var violation T
+	var violationResults []bool
+	start := time.Now()
+
+	err := pollWhile(w.t, name, w.await.RetryInterval, duration, func(ctx context.Context) (done bool, err error) {
+		object, err := w.get(name)
+		if err != nil {
+			return false, err
+		}
+		if matches, results := w.matches(object, predicates); !matches {
+			violation = object
+			violationResults = results
+			return false, fmt.Errorf("the object stopped matching the criteria after %s", time.Since(start))
+		}
+		returnedObject = object
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		// the duration elapsed without any violation
+		return returnedObject, nil
+	}
+	sb := strings.Builder{}
+	sb.WriteString("the object (GVK '%s') called '%s' %s didn't keep matching the criteria: %s")
+	args := []any{w.gvk, name, w.scope(), err.Error()}
+	if violationResults != nil {
+		sb.WriteString("\nthe object was found to have the following differences:")
+		for i, p := range predicates {
+			if !violationResults[i] {
+				sb.WriteRune('\n')
+				sb.WriteString(explain(p, violation))
+			}
+		}
+	}
+	w.t.Logf(sb.String(), args...)
 	return returnedObject, err
 }
 
+// StaysAbsent verifies that there is no object with the provided name during the whole given duration.
+// It returns an error as soon as the object is found.
+func (w *Waiter[T]) StaysAbsent(name string, duration time.Duration) error {
+	w.t.Logf("verifying that object of GVK '%s' with name '%s' %s is not created for %s", w.gvk, name, w.scope(), duration)
+
+	var found *T
+	start := time.Now()
+
+	err := pollWhile(w.t, name, w.await.RetryInterval, duration, func(ctx context.Context) (done bool, err error) {
+		object, err := w.get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		found = &object
+		return false, fmt.Errorf("the object was found after %s", time.Since(start))
+	})
+	if wait.Interrupted(err) {
+		// the duration elapsed without the object being found
+		return nil
+	}
+	sb := strings.Builder{}
+	sb.WriteString("the object (GVK '%s') called '%s' %s was not expected to exist: %s")
+	args := []any{w.gvk, name, w.scope(), err.Error()}
+	if found != nil {
+		content, _ := StringifyObject(*found)
+		sb.WriteRune('\n')
+		sb.Write(content)
+	}
+	w.t.Logf(sb.String(), args...)
+	return err
+}
+
 // WithNameMatching waits for a single object with the provided name in the namespace of the awaitality that additionally
 // matches the provided predicate function.
 func (w *Waiter[T]) WithNameMatching(name string, predicate func(T) bool) (T, error) {
@@ -777,24 +955,19 @@ func (p *customPredicate[T]) Matches(object client.Object) bool {
 // WithNameThat waits for a single object with the provided name in the namespace of the awaitality that additionally
 // matches the provided predicates.
 func (w *Waiter[T]) WithNameThat(name string, predicates ...assertions.Predicate[client.Object]) (T, error) {
-	w.t.Logf("waiting for object of GVK '%s' with name '%s' in namespace '%s' to match additional criteria", w.gvk, name, w.await.Namespace)
+	w.t.Logf("waiting for object of GVK '%s' with name '%s' %s to match additional criteria", w.gvk, name, w.scope())
 
 	var returnedObject T
 	latestResults := []bool{}
 
-	err := wait.PollUntilContextTimeout(context.TODO(), w.await.RetryInterval, w.await.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		obj := &unstructured.Unstructured{}
-		obj.SetGroupVersionKind(w.gvk)
-		if err := w.await.Client.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: w.await.Namespace}, obj); err != nil {
+	err := pollFor(w.t, name, nil, w.await.RetryInterval, w.await.Timeout, func(ctx context.Context) (done bool, err error) {
+		object, err := w.get(name)
+		if err != nil {
 			if apierrors.IsNotFound(err) {
 				return false, nil
 			}
 			return false, err
 		}
-		object, err := w.cast(obj)
-		if err != nil {
-			return false, fmt.Errorf("failed to cast the object to GVK %v: %w", w.gvk, err)
-		}
 
 		matches, results := w.matches(object, predicates)
 		latestResults = results
@@ -807,21 +980,17 @@ func (w *Waiter[T]) WithNameThat(name string, predicates ...assertions.Predicate
 	})
 	if err != nil {
 		sb := strings.Builder{}
-		sb.WriteString("couldn't match the object (GVK '%s') called '%s' in namespace '%s' with the criteria")
-		args := []any{w.gvk, name, w.await.Namespace}
-		obj := &unstructured.Unstructured{}
-		obj.SetGroupVersionKind(w.gvk)
-		if err := w.await.Client.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: w.await.Namespace}, obj); err != nil {
+		sb.WriteString("couldn't match the object (GVK '%s') called '%s' %s with the criteria")
+		args := []any{w.gvk, name, w.scope()}
+		if o, err := w.get(name); err != nil {
 			sb.WriteString(" and also failed to retrieve the object at all with error: %s")
 			args = append(args, err)
 		} else {
-			o, _ := w.cast(obj)
 			sb.WriteString(" but the object exists in the cluster with the following differences:")
 			for i, p := range predicates {
 				if !latestResults[i] {
-					expl := assertions.Explain(p, o.DeepCopyObject().(T))
 					sb.WriteRune('\n')
-					sb.WriteString(expl)
+					sb.WriteString(explain(p, o))
 				}
 			}
 		}
@@ -832,11 +1001,9 @@ func (w *Waiter[T]) WithNameThat(name string, predicates ...assertions.Predicate
 
 // WithNameDeleted waits for a single object with the provided name in the namespace of the awaitility to get deleted
 func (w *Waiter[T]) WithNameDeleted(name string) error {
-	w.t.Logf("waiting for object of GVK '%s' with name '%s' in namespace '%s' to be deleted", w.gvk, name, w.await.Namespace)
-	err := wait.PollUntilContextTimeout(context.TODO(), w.await.RetryInterval, w.await.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		obj := &unstructured.Unstructured{}
-		obj.SetGroupVersionKind(w.gvk)
-		if err := w.await.Client.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: w.await.Namespace}, obj); err != nil {
+	w.t.Logf("waiting for object of GVK '%s' with name '%s' %s to be deleted", w.gvk, name, w.scope())
+	err := pollFor(w.t, name, nil, w.await.RetryInterval, w.await.Timeout, func(ctx context.Context) (done bool, err error) {
+		if _, err := w.get(name); err != nil {
 			if apierrors.IsNotFound(err) {
 				return true, nil
 			}
@@ -846,15 +1013,12 @@ func (w *Waiter[T]) WithNameDeleted(name string) error {
 	})
 	if err != nil {
 		sb := strings.Builder{}
-		sb.WriteString("failed to wait for the the object (GVK '%s') called '%s' in namespace '%s' to be deleted")
-		args := []any{w.gvk, name, w.await.Namespace}
-		obj := &unstructured.Unstructured{}
-		obj.SetGroupVersionKind(w.gvk)
-		if err := w.await.Client.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: w.await.Namespace}, obj); err != nil {
+		sb.WriteString("failed to wait for the the object (GVK '%s') called '%s' %s to be deleted")
+		args := []any{w.gvk, name, w.scope()}
+		if o, err := w.get(name); err != nil {
 			sb.WriteString(" and also failed to retrieve the object at all with error: %s")
 			args = append(args, err)
 		} else {
-			o, _ := w.cast(obj)
 			sb.WriteString(" and the object exists in the cluster:")
 			content, _ := StringifyObject(o)
 			sb.WriteRune('\n')
@@ -865,6 +1029,98 @@ func (w *Waiter[T]) WithNameDeleted(name string) error {
 	return err
 }
 
+// writeListDiffs lists the objects currently present in the cluster and writes the differences from the expected state
+// for each of them, based on the latest predicate results. If `matchingOnly` is true, then only the objects that
+// matched all the predicates are written.
+func (w *Waiter[T]) writeListDiffs(sb *strings.Builder, args *[]any, latestResults map[client.ObjectKey][]bool, predicates []assertions.Predicate[client.Object], matchingOnly bool) {
+	objects, err := w.list()
+	if err != nil {
+		sb.WriteString(" and also failed to retrieve the objects at all with error: %s")
+		*args = append(*args, err)
+		return
+	}
+	sb.WriteString("\nlisting the objects found in cluster with the differences from the expected state for each:")
+	for _, obj := range objects {
+		key := client.ObjectKeyFromObject(obj)
+
+		matches := true
+		objectResults := latestResults[key]
+		for _, res := range objectResults {
+			if !res {
+				matches = false
+				break
+			}
+		}
+		if matchingOnly && !matches {
+			continue
+		}
+
+		sb.WriteRune('\n')
+		sb.WriteString("object ")
+		sb.WriteString(key.String())
+		if matches {
+			sb.WriteString(" matches all predicates")
+		} else {
+			sb.WriteString(" was found to have the following differences:")
+			for i, res := range objectResults {
+				if !res {
+					sb.WriteRune('\n')
+					sb.WriteString(explain(predicates[i], obj))
+				}
+			}
+		}
+	}
+}
+
+// scope returns a human-readable description of where the objects are looked up
+func (w *Waiter[T]) scope() string {
+	if w.namespace == "" {
+		return "at the cluster scope"
+	}
+	return fmt.Sprintf("in namespace '%s'", w.namespace)
+}
+
+// list returns all the objects of the type provided to `wait.For()` in the namespace of the waiter
+// (or in the whole cluster for cluster-scoped objects)
+func (w *Waiter[T]) list() ([]T, error) {
+	// because there is no generic way of figuring out the list type for some client.Object type, we need to go
+	// down the low level route and use unstructured to get the list generically and unmarshal and cast the list
+	// items.
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(w.gvk)
+	var opts []client.ListOption
+	if w.namespace != "" {
+		opts = append(opts, client.InNamespace(w.namespace))
+	}
+	if err := w.await.Client.List(context.TODO(), list, opts...); err != nil {
+		return nil, err
+	}
+	objects := make([]T, 0, len(list.Items))
+	for i := range list.Items {
+		object, err := w.cast(&list.Items[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to cast the object to GVK %v: %w", w.gvk, err)
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+// get returns the object of the type provided to `wait.For()` with the given name in the namespace of the waiter
+func (w *Waiter[T]) get(name string) (T, error) {
+	var empty T
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(w.gvk)
+	if err := w.await.Client.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: w.namespace}, obj); err != nil {
+		return empty, err
+	}
+	object, err := w.cast(obj)
+	if err != nil {
+		return empty, fmt.Errorf("failed to cast the object to GVK %v: %w", w.gvk, err)
+	}
+	return object, nil
+}
+
 func (w *Waiter[T]) cast(obj *unstructured.Unstructured) (T, error) {
 	var empty T
 	raw, err := obj.MarshalJSON()
@@ -900,16 +1156,27 @@ func (w *Waiter[T]) matches(obj T, predicates []assertions.Predicate[client.Obje
 //
 // Note that this is a recent addition to the utility functions and therefore is not used much. It could
 // be used to cut down the line count of the new utility functions dramatically though.
+//
+// The objects are looked up in the namespace of the given awaitility, unless they are cluster-scoped
+// (eg. Namespaces, Users or Identities). Use `InNamespace()` to look them up in another namespace.
+// The existing wait criteria can be used as predicates thanks to the `Criterion()` function.
 func For[T client.Object](t *testing.T, a *Awaitility, obj T) *Waiter[T] {
 	gvks, _, err := a.Client.Scheme().ObjectKinds(obj)
 	require.NoError(t, err, "failed to get the GVK of object %v", obj)
 
 	require.Len(t, gvks, 1, "multiple versions of a single GK not supported but found multiple for object %v", obj)
 
+	namespace := a.Namespace
+	// cluster-scoped objects (such as Namespaces, Users, Identities or ClusterResourceQuotas) are not looked up in any namespace
+	if namespaced, err := a.Client.IsObjectNamespaced(obj); err == nil && !namespaced {
+		namespace = ""
+	}
+
 	return &Waiter[T]{
-		await: a,
-		t:     t,
-		gvk:   gvks[0],
+		await:     a,
+		t:         t,
+		gvk:       gvks[0],
+		namespace: namespace,
 	}
 }
 
@@ -918,7 +1185,7 @@ func For[T client.Object](t *testing.T, a *Awaitility, obj T) *Waiter[T] {
 // Returns the updated object
 func (w *Waiter[T]) doUpdate(status bool, objectName, objectNamespace string, modify func(T)) (T, error) {
 	var objectToReturn T
-	err := wait.PollUntilContextTimeout(context.TODO(), w.await.RetryInterval, w.await.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(w.t, w.await.RetryInterval, w.await.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &unstructured.Unstructured{}
 		obj.SetGroupVersionKind(w.gvk)
 		if err := w.await.Client.Get(context.TODO(), types.NamespacedName{Namespace: objectNamespace, Name: objectName}, obj); err != nil {
@@ -0,0 +1,203 @@
+package wait_test
+
+import (
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test/assertions"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWaiter(t *testing.T) {
+	t.Run("first that", func(t *testing.T) {
+		// given
+		a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"), newSpace("host", "oddity-2", "appstudio"))
+
+		// when
+		space, err := wait.For(t, a, &toolchainv1alpha1.Space{}).FirstThat(wait.Criterion(wait.UntilSpaceHasTier("appstudio")))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "oddity-2", space.Name)
+	})
+
+	t.Run("in namespace", func(t *testing.T) {
+		// given
+		a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"), newSpace("other", "oddity-2", "base1ns"))
+
+		// when
+		space, err := wait.For(t, a, &toolchainv1alpha1.Space{}).InNamespace("other").WithNameThat("oddity-2")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "other", space.Namespace)
+	})
+
+	t.Run("cluster-scoped", func(t *testing.T) {
+		// given
+		a := newAwaitility(t, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "oddity-dev"}})
+
+		// when
+		ns, err := wait.For(t, a, &corev1.Namespace{}).WithNameThat("oddity-dev")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "oddity-dev", ns.Name)
+	})
+
+	t.Run("all that", func(t *testing.T) {
+		t.Run("all match", func(t *testing.T) {
+			// given
+			a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"), newSpace("host", "oddity-2", "base1ns"))
+
+			// when
+			spaces, err := wait.For(t, a, &toolchainv1alpha1.Space{}).AllThat(wait.Criterion(wait.UntilSpaceHasTier("base1ns")))
+
+			// then
+			require.NoError(t, err)
+			assert.Len(t, spaces, 2)
+		})
+
+		t.Run("one does not match", func(t *testing.T) {
+			// given
+			a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"), newSpace("host", "oddity-2", "appstudio"))
+
+			// when
+			_, err := wait.For(t, a, &toolchainv1alpha1.Space{}).AllThat(wait.Criterion(wait.UntilSpaceHasTier("base1ns")))
+
+			// then
+			require.Error(t, err)
+		})
+
+		t.Run("none found", func(t *testing.T) {
+			// given
+			a := newAwaitility(t)
+
+			// when
+			_, err := wait.For(t, a, &toolchainv1alpha1.Space{}).AllThat()
+
+			// then
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("exactly n", func(t *testing.T) {
+		// given
+		a := newAwaitility(t,
+			newSpace("host", "oddity-1", "base1ns"),
+			newSpace("host", "oddity-2", "base1ns"),
+			newSpace("host", "oddity-3", "appstudio"))
+
+		t.Run("match", func(t *testing.T) {
+			// when
+			spaces, err := wait.For(t, a, &toolchainv1alpha1.Space{}).ExactlyN(2, wait.Criterion(wait.UntilSpaceHasTier("base1ns")))
+
+			// then
+			require.NoError(t, err)
+			assert.Len(t, spaces, 2)
+		})
+
+		t.Run("mismatch", func(t *testing.T) {
+			// when
+			_, err := wait.For(t, a, &toolchainv1alpha1.Space{}).ExactlyN(1, wait.Criterion(wait.UntilSpaceHasTier("base1ns")))
+
+			// then
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("none that", func(t *testing.T) {
+		// given
+		a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"))
+
+		t.Run("no match", func(t *testing.T) {
+			// when
+			err := wait.For(t, a, &toolchainv1alpha1.Space{}).NoneThat(wait.Criterion(wait.UntilSpaceHasTier("appstudio")))
+
+			// then
+			require.NoError(t, err)
+		})
+
+		t.Run("match", func(t *testing.T) {
+			// when
+			err := wait.For(t, a, &toolchainv1alpha1.Space{}).NoneThat(assertions.Name("oddity-1"))
+
+			// then
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("stays that", func(t *testing.T) {
+		// given
+		a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"))
+
+		t.Run("keeps matching", func(t *testing.T) {
+			// when
+			space, err := wait.For(t, a, &toolchainv1alpha1.Space{}).StaysThat("oddity-1", 100*time.Millisecond, wait.Criterion(wait.UntilSpaceHasTier("base1ns")))
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, "oddity-1", space.Name)
+		})
+
+		t.Run("does not match", func(t *testing.T) {
+			// when
+			_, err := wait.For(t, a, &toolchainv1alpha1.Space{}).StaysThat("oddity-1", 100*time.Millisecond, wait.Criterion(wait.UntilSpaceHasTier("appstudio")))
+
+			// then
+			require.Error(t, err)
+		})
+
+		t.Run("not found", func(t *testing.T) {
+			// when
+			_, err := wait.For(t, a, &toolchainv1alpha1.Space{}).StaysThat("unknown", 100*time.Millisecond)
+
+			// then
+			require.Error(t, err)
+		})
+	})
+}
+
+func newAwaitility(t *testing.T, initObjs ...client.Object) *wait.Awaitility {
+	s := runtime.NewScheme()
+	require.NoError(t, scheme.AddToScheme(s))
+	require.NoError(t, toolchainv1alpha1.AddToScheme(s))
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(toolchainv1alpha1.GroupVersion.WithKind("Space"), meta.RESTScopeNamespace)
+	mapper.Add(toolchainv1alpha1.GroupVersion.WithKind("ToolchainCluster"), meta.RESTScopeNamespace)
+	mapper.Add(toolchainv1alpha1.GroupVersion.WithKind("Notification"), meta.RESTScopeNamespace)
+	cl := fake.NewClientBuilder().
+		WithScheme(s).
+		WithRESTMapper(mapper).
+		WithObjects(initObjs...).
+		Build()
+	return &wait.Awaitility{
+		Client:        cl,
+		Namespace:     "host",
+		RetryInterval: 10 * time.Millisecond,
+		Timeout:       100 * time.Millisecond,
+	}
+}
+
+func newSpace(namespace, name, tierName string) *toolchainv1alpha1.Space {
+	return &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: toolchainv1alpha1.SpaceSpec{
+			TierName: tierName,
+		},
+	}
+}
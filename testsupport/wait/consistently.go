@@ -0,0 +1,107 @@
+package wait
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test/assertions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Consistently verifies that the object of type T with the given name keeps matching all the given criteria
+// during the whole duration (as opposed to the `WaitForX` functions which wait until the criteria are eventually matched).
+// The object is looked up in the namespace of the given awaitility, unless it is cluster-scoped.
+//
+// As soon as the object is not found or doesn't match one of the criteria anymore, the verification stops
+// and the first violation is logged along with the diffs of the criteria that were not matched.
+// Returns the latest version of the object that matched all the criteria.
+//
+// Any existing criterion type can be used, eg:
+//
+//	space, err := wait.Consistently(t, hostAwait.Awaitility, spaceName, 10*time.Second,
+//		wait.UntilSpaceHasConditions(wait.Provisioned()))
+//
+// The criteria which do not apply to a pointer to the object must be converted first, with `NotificationCriterion`
+// for the `NotificationWaitCriterion` and with `LabelCriterion` for the `LabelWaitCriterion`.
+// The `TierTemplateRevisionWaitCriterion` apply to the list of all the TierTemplateRevisions of a tier instead of
+// a single named object, hence they cannot be used here.
+func Consistently[T client.Object](t *testing.T, a *Awaitility, name string, duration time.Duration, criteria ...WaitCriterion[T]) (T, error) {
+	predicates := make([]assertions.Predicate[client.Object], len(criteria))
+	for i, c := range criteria {
+		predicates[i] = Criterion(c)
+	}
+	return For(t, a, newObject[T]()).StaysThat(name, duration, predicates...)
+}
+
+// ConsistentlyAbsent verifies that there is no object of type T with the given name during the whole duration,
+// eg. to verify that a deleted object is not recreated:
+//
+//	err := wait.ConsistentlyAbsent[*toolchainv1alpha1.UserAccount](t, memberAwait.Awaitility, username, 10*time.Second)
+func ConsistentlyAbsent[T client.Object](t *testing.T, a *Awaitility, name string, duration time.Duration) error {
+	return For(t, a, newObject[T]()).StaysAbsent(name, duration)
+}
+
+// NotificationCriterion converts the given NotificationWaitCriterion (which applies to a Notification value)
+// to a criterion which applies to a pointer to a Notification, so that it can be used with `Consistently`, eg:
+//
+//	_, err := wait.Consistently(t, hostAwait.Awaitility, notificationName, 10*time.Second,
+//		wait.NotificationCriterion(wait.UntilNotificationHasConditions(wait.Sent())))
+func NotificationCriterion(criterion NotificationWaitCriterion) WaitCriterion[*toolchainv1alpha1.Notification] {
+	return WaitCriterion[*toolchainv1alpha1.Notification]{
+		Match: func(actual *toolchainv1alpha1.Notification) bool {
+			return criterion.Match(*actual)
+		},
+		Diff: func(actual *toolchainv1alpha1.Notification) string {
+			if criterion.Diff == nil {
+				return fmt.Sprintf("criterion didn't match the Notification '%s'", actual.Name)
+			}
+			return criterion.Diff(*actual)
+		},
+	}
+}
+
+// LabelCriterion converts the given LabelWaitCriterion (which applies to the ObjectMeta of an object)
+// to a criterion which applies to the object of type T, so that it can be used with `Consistently`, eg:
+//
+//	_, err := wait.Consistently(t, memberAwait.Awaitility, nsName, 10*time.Second,
+//		wait.LabelCriterion[*corev1.Namespace](wait.UntilObjectHasLabel(toolchainv1alpha1.TierLabelKey, "base1ns")))
+func LabelCriterion[T client.Object](criterion LabelWaitCriterion) WaitCriterion[T] {
+	return WaitCriterion[T]{
+		Match: func(actual T) bool {
+			return criterion.Match(objectMeta(actual))
+		},
+		Diff: func(actual T) string {
+			if criterion.Diff == nil {
+				return fmt.Sprintf("criterion didn't match the object '%s'", client.ObjectKeyFromObject(actual))
+			}
+			return criterion.Diff(objectMeta(actual))
+		},
+	}
+}
+
+// objectMeta returns a copy of the metadata of the given object
+func objectMeta(obj client.Object) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              obj.GetName(),
+		Namespace:         obj.GetNamespace(),
+		UID:               obj.GetUID(),
+		ResourceVersion:   obj.GetResourceVersion(),
+		Generation:        obj.GetGeneration(),
+		CreationTimestamp: obj.GetCreationTimestamp(),
+		DeletionTimestamp: obj.GetDeletionTimestamp(),
+		Labels:            obj.GetLabels(),
+		Annotations:       obj.GetAnnotations(),
+		OwnerReferences:   obj.GetOwnerReferences(),
+		Finalizers:        obj.GetFinalizers(),
+	}
+}
+
+// newObject returns a new empty object of type T (which is expected to be a pointer to a struct)
+func newObject[T client.Object]() T {
+	var obj T
+	return reflect.New(reflect.TypeOf(obj).Elem()).Interface().(T)
+}
@@ -0,0 +1,115 @@
+package wait_test
+
+import (
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConsistently(t *testing.T) {
+	// given
+	a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"))
+
+	t.Run("criteria hold for the whole duration", func(t *testing.T) {
+		// when
+		space, err := wait.Consistently(t, a, "oddity-1", 100*time.Millisecond, wait.UntilSpaceHasTier("base1ns"))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "oddity-1", space.Name)
+	})
+
+	t.Run("criteria do not hold", func(t *testing.T) {
+		// when
+		_, err := wait.Consistently(t, a, "oddity-1", 100*time.Millisecond, wait.UntilSpaceHasTier("base1ns"), wait.UntilSpaceHasTier("appstudio"))
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		// when
+		_, err := wait.Consistently(t, a, "unknown", 100*time.Millisecond, wait.UntilSpaceHasTier("base1ns"))
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestConsistentlyWithConvertedCriteria(t *testing.T) {
+	// given
+	space := newSpace("host", "oddity-1", "base1ns")
+	space.Labels = map[string]string{toolchainv1alpha1.SpaceCreatorLabelKey: "oddity"}
+	notification := &toolchainv1alpha1.Notification{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "host",
+			Name:      "oddity-provisioned",
+		},
+		Status: toolchainv1alpha1.NotificationStatus{
+			Conditions: []toolchainv1alpha1.Condition{wait.Sent()},
+		},
+	}
+	a := newAwaitility(t, space, notification)
+
+	t.Run("label criterion holds", func(t *testing.T) {
+		// when
+		_, err := wait.Consistently(t, a, "oddity-1", 100*time.Millisecond,
+			wait.LabelCriterion[*toolchainv1alpha1.Space](wait.UntilObjectHasLabel(toolchainv1alpha1.SpaceCreatorLabelKey, "oddity")))
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("label criterion does not hold", func(t *testing.T) {
+		// when
+		_, err := wait.Consistently(t, a, "oddity-1", 100*time.Millisecond,
+			wait.LabelCriterion[*toolchainv1alpha1.Space](wait.UntilObjectHasLabel(toolchainv1alpha1.SpaceCreatorLabelKey, "other")))
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("notification criterion holds", func(t *testing.T) {
+		// when
+		_, err := wait.Consistently(t, a, "oddity-provisioned", 100*time.Millisecond,
+			wait.NotificationCriterion(wait.UntilNotificationHasConditions(wait.Sent())))
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("notification criterion does not hold", func(t *testing.T) {
+		// when
+		_, err := wait.Consistently(t, a, "oddity-provisioned", 100*time.Millisecond,
+			wait.NotificationCriterion(wait.UntilNotificationHasConditions()))
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestConsistentlyAbsent(t *testing.T) {
+	// given
+	a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"))
+
+	t.Run("object never found", func(t *testing.T) {
+		// when
+		err := wait.ConsistentlyAbsent[*toolchainv1alpha1.Space](t, a, "unknown", 100*time.Millisecond)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("object found", func(t *testing.T) {
+		// when
+		err := wait.ConsistentlyAbsent[*toolchainv1alpha1.Space](t, a, "oddity-1", 100*time.Millisecond)
+
+		// then
+		require.Error(t, err)
+	})
+}
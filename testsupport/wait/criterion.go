@@ -0,0 +1,80 @@
+package wait
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/codeready-toolchain/toolchain-common/pkg/test/assertions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WaitCriterion a struct to compare with an expected object (or list of objects) of type T.
+// All the `XWaitCriterion` types of this package are instances of this generic type.
+type WaitCriterion[T any] struct {
+	Match func(T) bool
+	Diff  func(T) string
+}
+
+// matchCriteria returns `true` if the given actual value matches all the given criteria
+func matchCriteria[T any](actual T, criteria ...WaitCriterion[T]) bool {
+	for _, c := range criteria {
+		// if at least one criteria does not match, keep waiting
+		if !c.Match(actual) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeCriteriaDiffs writes the given actual object along with the diffs of all the criteria that it does not match
+func writeCriteriaDiffs[T client.Object](w io.Writer, actual T, criteria ...WaitCriterion[T]) {
+	fmt.Fprintln(w, "actual:")
+	y, _ := StringifyObject(actual)
+	fmt.Fprintln(w, string(y))
+	fmt.Fprintln(w, "----")
+	fmt.Fprintln(w, "diffs:")
+	for _, c := range criteria {
+		if !c.Match(actual) && c.Diff != nil {
+			fmt.Fprintln(w, c.Diff(actual))
+		}
+	}
+}
+
+// Criterion converts the given wait criterion to a predicate, so that the existing criteria can also
+// be used with `wait.For(...)`, eg:
+//
+//	wait.For(t, hostAwait.Awaitility, &toolchainv1alpha1.Space{}).
+//		WithNameThat(name, wait.Criterion(wait.UntilSpaceHasTier("base1ns")))
+func Criterion[T client.Object](criterion WaitCriterion[T]) assertions.Predicate[client.Object] {
+	return &criterionPredicate[T]{criterion: criterion}
+}
+
+type criterionPredicate[T client.Object] struct {
+	criterion WaitCriterion[T]
+}
+
+var _ predicateDiffer = &criterionPredicate[client.Object]{}
+
+func (p *criterionPredicate[T]) Matches(obj client.Object) bool {
+	return p.criterion.Match(obj.(T))
+}
+
+func (p *criterionPredicate[T]) Diff(obj client.Object) string {
+	if p.criterion.Diff == nil {
+		return fmt.Sprintf("criterion didn't match the object '%s'", client.ObjectKeyFromObject(obj))
+	}
+	return p.criterion.Diff(obj.(T))
+}
+
+// predicateDiffer is implemented by the predicates which can explain themselves why they didn't match a given object
+type predicateDiffer interface {
+	Diff(obj client.Object) string
+}
+
+// explain returns the explanation why the given object doesn't match the given predicate
+func explain[T client.Object](p assertions.Predicate[client.Object], obj T) string {
+	if d, ok := p.(predicateDiffer); ok {
+		return d.Diff(obj.DeepCopyObject().(T))
+	}
+	return assertions.Explain(p, obj.DeepCopyObject().(T))
+}
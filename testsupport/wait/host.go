@@ -42,7 +42,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/metrics/pkg/apis/metrics"
@@ -170,7 +169,7 @@ func (a *HostAwaitility) InitMetrics(t *testing.T, memberClusterNames ...string)
 func (a *HostAwaitility) WaitForMasterUserRecord(t *testing.T, name string, criteria ...MasterUserRecordWaitCriterion) (*toolchainv1alpha1.MasterUserRecord, error) {
 	t.Logf("waiting for MasterUserRecord '%s' in namespace '%s' to match criteria", name, a.Namespace)
 	var mur *toolchainv1alpha1.MasterUserRecord
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.MasterUserRecord{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -179,7 +178,7 @@ func (a *HostAwaitility) WaitForMasterUserRecord(t *testing.T, name string, crit
 			return false, err
 		}
 		mur = obj
-		return matchMasterUserRecordWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -197,19 +196,7 @@ func (a *HostAwaitility) GetMasterUserRecord(name string) (*toolchainv1alpha1.Ma
 }
 
 // MasterUserRecordWaitCriterion a struct to compare with an expected MasterUserRecord
-type MasterUserRecordWaitCriterion struct {
-	Match func(*toolchainv1alpha1.MasterUserRecord) bool
-	Diff  func(*toolchainv1alpha1.MasterUserRecord) string
-}
-
-func matchMasterUserRecordWaitCriterion(actual *toolchainv1alpha1.MasterUserRecord, criteria ...MasterUserRecordWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type MasterUserRecordWaitCriterion = WaitCriterion[*toolchainv1alpha1.MasterUserRecord]
 
 func (a *HostAwaitility) printMasterUserRecordWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.MasterUserRecord, criteria ...MasterUserRecordWaitCriterion) {
 	buf := &strings.Builder{}
@@ -218,16 +205,7 @@ func (a *HostAwaitility) printMasterUserRecordWaitCriterionDiffs(t *testing.T, a
 	} else {
 		fmt.Fprintln(buf, "failed to find MasterUserRecord with matching criteria:")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) && c.Diff != nil {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	// also include other resources relevant in the host namespace, to help troubleshooting
 	a.listAndPrint(t, "UserSignups", a.Namespace, &toolchainv1alpha1.UserSignupList{})
@@ -368,19 +346,7 @@ func UntilMasterUserRecordHasNoTierHashLabel() MasterUserRecordWaitCriterion {
 }
 
 // UserSignupWaitCriterion a struct to compare with an expected UserSignup
-type UserSignupWaitCriterion struct {
-	Match func(*toolchainv1alpha1.UserSignup) bool
-	Diff  func(*toolchainv1alpha1.UserSignup) string
-}
-
-func matchUserSignupWaitCriterion(actual *toolchainv1alpha1.UserSignup, criteria ...UserSignupWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type UserSignupWaitCriterion = WaitCriterion[*toolchainv1alpha1.UserSignup]
 
 func (a *HostAwaitility) printUserSignupWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.UserSignup, criteria ...UserSignupWaitCriterion) {
 	buf := &strings.Builder{}
@@ -388,16 +354,7 @@ func (a *HostAwaitility) printUserSignupWaitCriterionDiffs(t *testing.T, actual
 		buf.WriteString("failed to find UserSignup\n")
 	} else {
 		buf.WriteString("failed to find UserSignup with matching criteria:\n")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) && c.Diff != nil {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	// include also all UserSignups in the host namespace, to help troubleshooting
 	a.listAndPrint(t, "UserSignups", a.Namespace, &toolchainv1alpha1.UserSignupList{})
@@ -555,6 +512,27 @@ func UntilUserSignupHasNilScheduledDeactivationTime() UserSignupWaitCriterion {
 	}
 }
 
+// UntilUserSignupHasScheduledDeactivationTimeAround returns a `UserSignupWaitCriterion` which checks that the given
+// UserSignup has a `.Status.ScheduledDeactivationTimestamp` within the given tolerance of the expected time
+func UntilUserSignupHasScheduledDeactivationTimeAround(expected time.Time, tolerance time.Duration) UserSignupWaitCriterion {
+	return UserSignupWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.UserSignup) bool {
+			if actual.Status.ScheduledDeactivationTimestamp == nil {
+				return false
+			}
+			delta := actual.Status.ScheduledDeactivationTimestamp.Sub(expected)
+			return delta >= -tolerance && delta <= tolerance
+		},
+		Diff: func(actual *toolchainv1alpha1.UserSignup) string {
+			if actual.Status.ScheduledDeactivationTimestamp == nil {
+				return fmt.Sprintf("expected '.Status.ScheduledDeactivationTimestamp' to be around %s, but it is nil", expected.Format(time.RFC3339))
+			}
+			return fmt.Sprintf("expected '.Status.ScheduledDeactivationTimestamp' to be within %s of %s, actual: %s",
+				tolerance, expected.Format(time.RFC3339), actual.Status.ScheduledDeactivationTimestamp.Format(time.RFC3339))
+		},
+	}
+}
+
 // UntilUserSignupHasCompliantUsername returns a `UserSignupWaitCriterion` which checks that the given
 // UserSignup has a `.Status.CompliantUsername` value
 func UntilUserSignupHasCompliantUsername() UserSignupWaitCriterion {
@@ -572,7 +550,7 @@ func UntilUserSignupHasCompliantUsername() UserSignupWaitCriterion {
 func (a *HostAwaitility) WaitForTestResourcesCleanup(t *testing.T, initialDelay time.Duration) error {
 	t.Logf("waiting for resource cleanup")
 	time.Sleep(initialDelay)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		usList := &toolchainv1alpha1.UserSignupList{}
 		if err := a.Client.List(context.TODO(), usList, client.InNamespace(a.Namespace)); err != nil {
 			return false, err
@@ -640,7 +618,7 @@ func (a *HostAwaitility) WaitForTestResourcesCleanup(t *testing.T, initialDelay
 func (a *HostAwaitility) WaitForUserSignup(t *testing.T, name string, criteria ...UserSignupWaitCriterion) (*toolchainv1alpha1.UserSignup, error) {
 	t.Logf("waiting for UserSignup '%s' in namespace '%s' to match criteria", name, a.Namespace)
 	var userSignup *toolchainv1alpha1.UserSignup
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.UserSignup{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -649,7 +627,7 @@ func (a *HostAwaitility) WaitForUserSignup(t *testing.T, name string, criteria .
 			return false, err
 		}
 		userSignup = obj
-		return matchUserSignupWaitCriterion(userSignup, criteria...), nil
+		return matchCriteria(userSignup, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -663,7 +641,7 @@ func (a *HostAwaitility) WaitForUserSignupByUserIDAndUsername(t *testing.T, user
 	t.Logf("waiting for UserSignup '%s' or '%s' in namespace '%s' to match criteria", userID, username, a.Namespace)
 	encodedUsername := EncodeUserIdentifier(username)
 	var userSignup *toolchainv1alpha1.UserSignup
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, username, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.UserSignup{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: userID}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -678,7 +656,7 @@ func (a *HostAwaitility) WaitForUserSignupByUserIDAndUsername(t *testing.T, user
 			}
 		}
 		userSignup = obj
-		return matchUserSignupWaitCriterion(userSignup, criteria...), nil
+		return matchCriteria(userSignup, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -689,22 +667,8 @@ func (a *HostAwaitility) WaitForUserSignupByUserIDAndUsername(t *testing.T, user
 
 // WaitAndVerifyThatUserSignupIsNotCreated waits and checks that the UserSignup is not created
 func (a *HostAwaitility) WaitAndVerifyThatUserSignupIsNotCreated(t *testing.T, name string) {
-	t.Logf("waiting and verifying that UserSignup '%s' in namespace '%s' is not created", name, a.Namespace)
-	var userSignup *toolchainv1alpha1.UserSignup
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
-		obj := &toolchainv1alpha1.UserSignup{}
-		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
-			if errors.IsNotFound(err) {
-				return false, nil
-			}
-			return false, err
-		}
-		userSignup = obj
-		return true, nil
-	})
-	if err == nil {
-		require.Fail(t, fmt.Sprintf("UserSignup '%s' should not be created, but it was found: %v", name, userSignup))
-	}
+	err := For(t, a.Awaitility, &toolchainv1alpha1.UserSignup{}).StaysAbsent(name, a.Timeout)
+	require.NoError(t, err, "UserSignup '%s' should not be created", name)
 }
 
 // WaitForBannedUser waits until there is a BannedUser available with the given email hash
@@ -715,7 +679,7 @@ func (a *HostAwaitility) WaitForBannedUser(t *testing.T, userEmailHash string) (
 	emailHashLabelMatch := client.MatchingLabels(map[string]string{
 		toolchainv1alpha1.BannedUserEmailHashLabelKey: userEmailHash,
 	})
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		bannedUserList := &toolchainv1alpha1.BannedUserList{}
 		if err := a.Client.List(ctx, bannedUserList, emailHashLabelMatch, client.InNamespace(a.Namespace)); err != nil {
 			return false, err
@@ -749,7 +713,7 @@ func (a *HostAwaitility) DeleteToolchainStatus(t *testing.T, name string) error
 // WaitUntilBannedUserDeleted waits until the BannedUser with the given name is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilBannedUserDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until BannedUser '%s' in namespace '%s' is deleted", name, a.Namespace)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		user := &toolchainv1alpha1.BannedUser{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, user); err != nil {
 			if errors.IsNotFound(err) {
@@ -764,7 +728,7 @@ func (a *HostAwaitility) WaitUntilBannedUserDeleted(t *testing.T, name string) e
 // WaitUntilUserSignupDeleted waits until the UserSignup with the given name is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilUserSignupDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until UserSignup '%s' in namespace '%s is deleted", name, a.Namespace)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		userSignup := &toolchainv1alpha1.UserSignup{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, userSignup); err != nil {
 			if errors.IsNotFound(err) {
@@ -779,7 +743,7 @@ func (a *HostAwaitility) WaitUntilUserSignupDeleted(t *testing.T, name string) e
 // WaitUntilMasterUserRecordAndSpaceBindingsDeleted waits until the MUR with the given name and its associated SpaceBindings are deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until MasterUserRecord '%s' in namespace '%s' is deleted", name, a.Namespace)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		mur := &toolchainv1alpha1.MasterUserRecord{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, mur); err != nil {
 			if errors.IsNotFound(err) {
@@ -798,7 +762,7 @@ func (a *HostAwaitility) WaitUntilMasterUserRecordAndSpaceBindingsDeleted(t *tes
 // CheckMasterUserRecordIsDeleted checks that the MUR with the given name is not present and won't be created in the next 2 seconds
 func (a *HostAwaitility) CheckMasterUserRecordIsDeleted(t *testing.T, name string) {
 	t.Logf("checking that MasterUserRecord '%s' in namespace '%s' is deleted", name, a.Namespace)
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*time.Second, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, 2*time.Second, func(ctx context.Context) (done bool, err error) {
 		mur := &toolchainv1alpha1.MasterUserRecord{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, mur); err != nil {
 			if errors.IsNotFound(err) {
@@ -825,7 +789,7 @@ func containsUserAccountStatus(uaStatuses []toolchainv1alpha1.UserAccountStatusE
 func (a *HostAwaitility) WaitForUserTier(t *testing.T, name string, criteria ...UserTierWaitCriterion) (*toolchainv1alpha1.UserTier, error) {
 	t.Logf("waiting until UserTier '%s' in namespace '%s' matches criteria", name, a.Namespace)
 	tier := &toolchainv1alpha1.UserTier{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.UserTier{}
 		err = a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj)
 		if err != nil && !errors.IsNotFound(err) {
@@ -836,7 +800,7 @@ func (a *HostAwaitility) WaitForUserTier(t *testing.T, name string, criteria ...
 			return false, nil
 		}
 		tier = obj
-		return matchUserTierWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -846,20 +810,7 @@ func (a *HostAwaitility) WaitForUserTier(t *testing.T, name string, criteria ...
 }
 
 // UserTierWaitCriterion a struct to compare with an expected UserTier
-type UserTierWaitCriterion struct {
-	Match func(*toolchainv1alpha1.UserTier) bool
-	Diff  func(*toolchainv1alpha1.UserTier) string
-}
-
-func matchUserTierWaitCriterion(actual *toolchainv1alpha1.UserTier, criteria ...UserTierWaitCriterion) bool {
-	for _, c := range criteria {
-		// if at least one criteria does not match, keep waiting
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type UserTierWaitCriterion = WaitCriterion[*toolchainv1alpha1.UserTier]
 
 func (a *HostAwaitility) printUserTierWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.UserTier, criteria ...UserTierWaitCriterion) {
 	buf := &strings.Builder{}
@@ -867,16 +818,7 @@ func (a *HostAwaitility) printUserTierWaitCriterionDiffs(t *testing.T, actual *t
 		buf.WriteString("failed to find UserTier\n")
 	} else {
 		buf.WriteString("failed to find UserTier with matching criteria:\n")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 
 	t.Log(buf.String())
@@ -908,7 +850,7 @@ func (a *HostAwaitility) WaitUntilBaseNSTemplateTierIsUpdated(t *testing.T) erro
 func (a *HostAwaitility) WaitForNSTemplateTier(t *testing.T, name string, criteria ...NSTemplateTierWaitCriterion) (*toolchainv1alpha1.NSTemplateTier, error) {
 	t.Logf("waiting until NSTemplateTier '%s' in namespace '%s' matches criteria", name, a.Namespace)
 	tier := &toolchainv1alpha1.NSTemplateTier{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.NSTemplateTier{}
 		err = a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj)
 		if err != nil && !errors.IsNotFound(err) {
@@ -919,7 +861,7 @@ func (a *HostAwaitility) WaitForNSTemplateTier(t *testing.T, name string, criter
 			return false, nil
 		}
 		tier = obj
-		return matchNSTemplateTierWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -998,7 +940,7 @@ func (a *HostAwaitility) checkTTR(t *testing.T, tier *toolchainv1alpha1.NSTempla
 func (a *HostAwaitility) WaitForTierTemplate(t *testing.T, name string) (*toolchainv1alpha1.TierTemplate, error) { // nolint:unparam
 	tierTemplate := &toolchainv1alpha1.TierTemplate{}
 	t.Logf("waiting until TierTemplate '%s' exists in namespace '%s'...", name, a.Namespace)
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.TierTemplate{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1017,20 +959,7 @@ func (a *HostAwaitility) WaitForTierTemplate(t *testing.T, name string) (*toolch
 }
 
 // TierTemplateRevisionWaitCriterion a struct to compare with an expected TierTemplateRevision
-type TierTemplateRevisionWaitCriterion struct {
-	Match func([]toolchainv1alpha1.TierTemplateRevision) bool
-	Diff  func([]toolchainv1alpha1.TierTemplateRevision) string
-}
-
-func matchTierTemplateRevisionWaitCriterion(actual []toolchainv1alpha1.TierTemplateRevision, criteria ...TierTemplateRevisionWaitCriterion) bool {
-	for _, c := range criteria {
-		// if at least one criteria does not match, keep waiting
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type TierTemplateRevisionWaitCriterion = WaitCriterion[[]toolchainv1alpha1.TierTemplateRevision]
 
 func (a *HostAwaitility) printTierTemplateRevisionWaitCriterionDiffs(t *testing.T, actual []toolchainv1alpha1.TierTemplateRevision, tierName string, criteria ...TierTemplateRevisionWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1091,7 +1020,7 @@ func LessOrEqual(count int) TierTemplateRevisionWaitCriterion {
 func (a *HostAwaitility) WaitForTTRs(t *testing.T, tierName string, criteria ...TierTemplateRevisionWaitCriterion) ([]toolchainv1alpha1.TierTemplateRevision, error) {
 	t.Logf("waiting for ttrs to match criteria for tier '%s'", tierName)
 	var ttrs []toolchainv1alpha1.TierTemplateRevision
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, tierName, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		objs := &toolchainv1alpha1.TierTemplateRevisionList{}
 		if err := a.Client.List(ctx, objs, client.InNamespace(a.Namespace), client.MatchingLabels{toolchainv1alpha1.TierLabelKey: tierName}); err != nil {
 			return false, err
@@ -1100,7 +1029,7 @@ func (a *HostAwaitility) WaitForTTRs(t *testing.T, tierName string, criteria ...
 			return false, nil
 		}
 		ttrs = objs.Items
-		return matchTierTemplateRevisionWaitCriterion(ttrs, criteria...), nil
+		return matchCriteria(ttrs, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -1110,20 +1039,7 @@ func (a *HostAwaitility) WaitForTTRs(t *testing.T, tierName string, criteria ...
 }
 
 // NSTemplateTierWaitCriterion a struct to compare with an expected NSTemplateTier
-type NSTemplateTierWaitCriterion struct {
-	Match func(*toolchainv1alpha1.NSTemplateTier) bool
-	Diff  func(*toolchainv1alpha1.NSTemplateTier) string
-}
-
-func matchNSTemplateTierWaitCriterion(actual *toolchainv1alpha1.NSTemplateTier, criteria ...NSTemplateTierWaitCriterion) bool {
-	for _, c := range criteria {
-		// if at least one criteria does not match, keep waiting
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type NSTemplateTierWaitCriterion = WaitCriterion[*toolchainv1alpha1.NSTemplateTier]
 
 func (a *HostAwaitility) printNSTemplateTierWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.NSTemplateTier, criteria ...NSTemplateTierWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1131,16 +1047,7 @@ func (a *HostAwaitility) printNSTemplateTierWaitCriterionDiffs(t *testing.T, act
 		buf.WriteString("failed to find NSTemplateTier\n")
 	} else {
 		buf.WriteString("failed to find NSTemplateTier with matching criteria:\n")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	// include also all NSTemplateTiers in the host namespace, to help troubleshooting
 	a.listAndPrint(t, "NSTemplateTiers", a.Namespace, &toolchainv1alpha1.NSTemplateTierList{})
@@ -1249,17 +1156,12 @@ func HasClusterResourcesTemplateRef(expected string) NSTemplateTierSpecMatcher {
 }
 
 // NotificationWaitCriterion a struct to compare with an expected Notification
-type NotificationWaitCriterion struct {
-	Match func(toolchainv1alpha1.Notification) bool
-	Diff  func(toolchainv1alpha1.Notification) string
-}
+type NotificationWaitCriterion = WaitCriterion[toolchainv1alpha1.Notification]
 
 func matchNotificationWaitCriterion(actual []toolchainv1alpha1.Notification, criteria ...NotificationWaitCriterion) bool {
 	for _, n := range actual {
-		for _, c := range criteria {
-			if !c.Match(n) {
-				return false
-			}
+		if !matchCriteria(n, criteria...) {
+			return false
 		}
 	}
 	return true
@@ -1296,7 +1198,7 @@ func (a *HostAwaitility) printNotificationWaitCriterionDiffs(t *testing.T, actua
 func (a *HostAwaitility) WaitForNotifications(t *testing.T, username, notificationType string, numberOfNotifications int, criteria ...NotificationWaitCriterion) ([]toolchainv1alpha1.Notification, error) {
 	t.Logf("waiting for notifications to match criteria for user '%s'", username)
 	var notifications []toolchainv1alpha1.Notification
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, username, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		labels := map[string]string{toolchainv1alpha1.NotificationUserNameLabelKey: username, toolchainv1alpha1.NotificationTypeLabelKey: notificationType}
 		opts := client.MatchingLabels(labels)
 		notificationList := &toolchainv1alpha1.NotificationList{}
@@ -1320,7 +1222,7 @@ func (a *HostAwaitility) WaitForNotifications(t *testing.T, username, notificati
 func (a *HostAwaitility) WaitForNotificationWithName(t *testing.T, notificationName, notificationType string, criteria ...NotificationWaitCriterion) (toolchainv1alpha1.Notification, error) {
 	t.Logf("waiting for notification with name '%s'", notificationName)
 	notification := &toolchainv1alpha1.Notification{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, notificationName, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		notification = &toolchainv1alpha1.Notification{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: notificationName, Namespace: a.Namespace}, notification); err != nil {
 			if errors.IsNotFound(err) {
@@ -1347,7 +1249,7 @@ func (a *HostAwaitility) WaitForNotificationWithName(t *testing.T, notificationN
 func (a *HostAwaitility) WaitForNotificationToNotBeCreated(t *testing.T, notificationName string) error {
 	t.Logf("waiting to check notification with name '%s' is NOT created", notificationName)
 	notification := &toolchainv1alpha1.Notification{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 10*time.Second, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, 10*time.Second, func(ctx context.Context) (done bool, err error) {
 		notification = &toolchainv1alpha1.Notification{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: notificationName, Namespace: a.Namespace}, notification); err != nil {
 			if errors.IsNotFound(err) {
@@ -1369,7 +1271,7 @@ func (a *HostAwaitility) WaitForNotificationToNotBeCreated(t *testing.T, notific
 // WaitUntilNotificationsDeleted waits until the Notification for the given user is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilNotificationsDeleted(t *testing.T, username, notificationType string) error {
 	t.Logf("waiting until notifications have been deleted for user '%s'", username)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		labels := map[string]string{toolchainv1alpha1.NotificationUserNameLabelKey: username, toolchainv1alpha1.NotificationTypeLabelKey: notificationType}
 		opts := client.MatchingLabels(labels)
 		notificationList := &toolchainv1alpha1.NotificationList{}
@@ -1383,7 +1285,7 @@ func (a *HostAwaitility) WaitUntilNotificationsDeleted(t *testing.T, username, n
 // WaitUntilNotificationWithNameDeleted waits until the Notification with the given name is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilNotificationWithNameDeleted(t *testing.T, notificationName string) error {
 	t.Logf("waiting for notification with name '%s' to get deleted", notificationName)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		notification := &toolchainv1alpha1.Notification{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: notificationName, Namespace: a.Namespace}, notification); err != nil {
 			if errors.IsNotFound(err) {
@@ -1408,19 +1310,7 @@ func UntilNotificationHasConditions(expected ...toolchainv1alpha1.Condition) Not
 }
 
 // ToolchainStatusWaitCriterion a struct to compare with an expected ToolchainStatus
-type ToolchainStatusWaitCriterion struct {
-	Match func(*toolchainv1alpha1.ToolchainStatus) bool
-	Diff  func(*toolchainv1alpha1.ToolchainStatus) string
-}
-
-func matchToolchainStatusWaitCriterion(actual *toolchainv1alpha1.ToolchainStatus, criteria ...ToolchainStatusWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type ToolchainStatusWaitCriterion = WaitCriterion[*toolchainv1alpha1.ToolchainStatus]
 
 func (a *HostAwaitility) printToolchainStatusWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.ToolchainStatus, criteria ...ToolchainStatusWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1428,16 +1318,7 @@ func (a *HostAwaitility) printToolchainStatusWaitCriterionDiffs(t *testing.T, ac
 		buf.WriteString("failed to find Toolchainstatus\n")
 	} else {
 		buf.WriteString("failed to find ToolchainStatus with matching criteria:\n")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	// include also all ToolchainStatuses in the host namespace, to help troubleshooting
 	a.listAndPrint(t, "ToolchainStatuses", a.Namespace, &toolchainv1alpha1.ToolchainStatusList{})
@@ -1570,12 +1451,43 @@ func UntilHasSpaceCount(clusterName string, expectedCount int) ToolchainStatusWa
 	}
 }
 
+// UntilMemberHasReadyStatus returns a `ToolchainStatusWaitCriterion` which checks that the member cluster with the given
+// name is listed in the ToolchainStatus, with the given status of its ready condition
+func UntilMemberHasReadyStatus(clusterName string, expected corev1.ConditionStatus) ToolchainStatusWaitCriterion {
+	return ToolchainStatusWaitCriterion{
+		Match: func(actual *toolchainv1alpha1.ToolchainStatus) bool {
+			for _, m := range actual.Status.Members {
+				if m.ClusterName == clusterName {
+					return readyStatus(m.MemberStatus.Conditions) == expected
+				}
+			}
+			return false
+		},
+		Diff: func(actual *toolchainv1alpha1.ToolchainStatus) string {
+			for _, m := range actual.Status.Members {
+				if m.ClusterName == clusterName {
+					return fmt.Sprintf("expected the ready condition of member cluster '%s' to have status '%s'. Actual: %v", clusterName, expected, m.MemberStatus.Conditions)
+				}
+			}
+			return fmt.Sprintf("expected member cluster '%s' to be listed in the ToolchainStatus. Actual members: %v", clusterName, actual.Status.Members)
+		},
+	}
+}
+
+// readyStatus returns the status of the ready condition, or `Unknown` if there is none
+func readyStatus(conditions []toolchainv1alpha1.Condition) corev1.ConditionStatus {
+	if c, found := condition.FindConditionByType(conditions, toolchainv1alpha1.ConditionReady); found {
+		return c.Status
+	}
+	return corev1.ConditionUnknown
+}
+
 // WaitForToolchainStatus waits until the ToolchainStatus is available with the provided criteria, if any
 func (a *HostAwaitility) WaitForToolchainStatus(t *testing.T, criteria ...ToolchainStatusWaitCriterion) (*toolchainv1alpha1.ToolchainStatus, error) {
 	// there should only be one toolchain status with the name toolchain-status
 	name := "toolchain-status"
 	toolchainStatus := &toolchainv1alpha1.ToolchainStatus{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, "", criteriaNames(criteria), a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.ToolchainStatus{}
 		// retrieve the toolchainstatus from the host namespace
 		err = a.Client.Get(context.TODO(),
@@ -1591,7 +1503,7 @@ func (a *HostAwaitility) WaitForToolchainStatus(t *testing.T, criteria ...Toolch
 			return false, err
 		}
 		toolchainStatus = obj
-		return matchToolchainStatusWaitCriterion(toolchainStatus, criteria...), nil
+		return matchCriteria(toolchainStatus, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -1601,7 +1513,7 @@ func (a *HostAwaitility) WaitForToolchainStatus(t *testing.T, criteria ...Toolch
 }
 
 func (a *HostAwaitility) waitForResource(t *testing.T, namespace, name string, object client.Object) {
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		if err := a.Client.Get(context.TODO(), test.NamespacedName(namespace, name), object); err != nil {
 			if errors.IsNotFound(err) {
 				return false, nil
@@ -1659,19 +1571,7 @@ func (a *HostAwaitility) GetToolchainConfig(t *testing.T) *toolchainv1alpha1.Too
 }
 
 // ToolchainConfigWaitCriterion a struct to compare with an expected ToolchainConfig
-type ToolchainConfigWaitCriterion struct {
-	Match func(*toolchainv1alpha1.ToolchainConfig) bool
-	Diff  func(*toolchainv1alpha1.ToolchainConfig) string
-}
-
-func matchToolchainConfigWaitCriterion(actual *toolchainv1alpha1.ToolchainConfig, criteria ...ToolchainConfigWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type ToolchainConfigWaitCriterion = WaitCriterion[*toolchainv1alpha1.ToolchainConfig]
 
 func (a *HostAwaitility) printToolchainConfigWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.ToolchainConfig, criteria ...ToolchainConfigWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1679,16 +1579,7 @@ func (a *HostAwaitility) printToolchainConfigWaitCriterionDiffs(t *testing.T, ac
 		buf.WriteString("failed to find ToolchainConfig\n")
 	} else {
 		buf.WriteString("failed to find ToolchainConfig with matching criteria:\n")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	// include also all ToolchainConfigs in the host namespace, to help troubleshooting
 	a.listAndPrint(t, "ToolchainConfigs", a.Namespace, &toolchainv1alpha1.ToolchainConfigList{})
@@ -1740,7 +1631,7 @@ func (a *HostAwaitility) WaitForToolchainConfig(t *testing.T, criteria ...Toolch
 	// there should only be one ToolchainConfig with the name "config"
 	name := "config"
 	var toolchainConfig *toolchainv1alpha1.ToolchainConfig
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, "", criteriaNames(criteria), a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.ToolchainConfig{}
 		// retrieve the ToolchainConfig from the host namespace
 		if err := a.Client.Get(context.TODO(),
@@ -1755,7 +1646,7 @@ func (a *HostAwaitility) WaitForToolchainConfig(t *testing.T, criteria ...Toolch
 			return false, err
 		}
 		toolchainConfig = obj
-		return matchToolchainConfigWaitCriterion(toolchainConfig, criteria...), nil
+		return matchCriteria(toolchainConfig, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -1831,7 +1722,7 @@ func (a *HostAwaitility) UpdateToolchainConfig(t *testing.T, options ...testconf
 // resource periodically which can cause errors like `Operation cannot be fulfilled on toolchainconfigs.toolchain.dev.openshift.com "config": the object has been modified; please apply your changes to the latest version and try again`
 // in some cases. Retrying mitigates the potential for test flakiness due to this behaviour.
 func (a *HostAwaitility) updateToolchainConfigWithRetry(t *testing.T, updatedConfig *toolchainv1alpha1.ToolchainConfig) error {
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		config := a.GetToolchainConfig(t)
 		config.Spec = updatedConfig.Spec
 		if err := a.Client.Update(context.TODO(), config); err != nil {
@@ -1901,7 +1792,7 @@ func (a *HostAwaitility) CreateAPIProxyClient(t *testing.T, userToken, proxyURL
 	// updated yet and we try to create the client too quickly so retry to reduce flakiness.
 	var proxyCl client.Client
 	var initProxyClError error
-	waitErr := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	waitErr := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		proxyCl, initProxyClError = client.New(proxyKubeConfig, client.Options{Scheme: s})
 		return initProxyClError == nil, nil
 	})
@@ -1919,25 +1810,13 @@ func (a *HostAwaitility) PluginProxyURLWithWorkspaceContext(proxyPluginName, wor
 	return fmt.Sprintf("%s/plugins/%s/workspaces/%s", a.APIProxyURL, proxyPluginName, workspaceContext)
 }
 
-type SpaceWaitCriterion struct {
-	Match func(*toolchainv1alpha1.Space) bool
-	Diff  func(*toolchainv1alpha1.Space) string
-}
-
-func matchSpaceWaitCriterion(actual *toolchainv1alpha1.Space, criteria ...SpaceWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type SpaceWaitCriterion = WaitCriterion[*toolchainv1alpha1.Space]
 
 // WaitForSpace waits until the Space with the given name is available with the provided criteria, if any
 func (a *HostAwaitility) WaitForSpace(t *testing.T, name string, criteria ...SpaceWaitCriterion) (*toolchainv1alpha1.Space, error) {
 	t.Logf("waiting for Space '%s' with matching criteria", name)
 	var space *toolchainv1alpha1.Space
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.Space{}
 		// retrieve the Space from the host namespace
 		if err := a.Client.Get(context.TODO(),
@@ -1952,7 +1831,7 @@ func (a *HostAwaitility) WaitForSpace(t *testing.T, name string, criteria ...Spa
 			return false, err
 		}
 		space = obj
-		return matchSpaceWaitCriterion(space, criteria...), nil
+		return matchCriteria(space, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -1964,7 +1843,7 @@ func (a *HostAwaitility) WaitForSpace(t *testing.T, name string, criteria ...Spa
 func (a *HostAwaitility) WaitForProxyPlugin(t *testing.T, name string) (*toolchainv1alpha1.ProxyPlugin, error) {
 	t.Logf("waiting for ProxyPlugin %q", name)
 	var proxyPlugin *toolchainv1alpha1.ProxyPlugin
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.ProxyPlugin{}
 		if err = a.Client.Get(context.TODO(),
 			types.NamespacedName{
@@ -1989,16 +1868,7 @@ func (a *HostAwaitility) printSpaceWaitCriterionDiffs(t *testing.T, actual *tool
 	} else {
 		buf.WriteString("failed to find Space with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	// also include Spaces resources in the host namespace, to help troubleshooting
 	a.listAndPrint(t, "Spaces", a.Namespace, &toolchainv1alpha1.SpaceList{})
@@ -2218,7 +2088,7 @@ func UntilSpaceHasStatusTargetCluster(expected string) SpaceWaitCriterion {
 func (a *HostAwaitility) WaitUntilSpaceAndSpaceBindingsDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until Space '%s' in namespace '%s' is deleted", name, a.Namespace)
 	var s *toolchainv1alpha1.Space
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.Space{}
 		if err := a.Client.Get(context.TODO(),
 			types.NamespacedName{
@@ -2247,7 +2117,7 @@ func (a *HostAwaitility) WaitUntilSpaceAndSpaceBindingsDeleted(t *testing.T, nam
 
 // WaitUntilSpaceBindingDeleted waits until the SpaceBinding with the given name is deleted (ie, not found)
 func (a *HostAwaitility) WaitUntilSpaceBindingDeleted(name string) error {
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(nil, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		mur := &toolchainv1alpha1.SpaceBinding{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, mur); err != nil {
 			if errors.IsNotFound(err) {
@@ -2264,7 +2134,7 @@ func (a *HostAwaitility) WaitUntilSpaceBindingsWithLabelDeleted(t *testing.T, ke
 	labels := map[string]string{key: value}
 	t.Logf("waiting until SpaceBindings with labels '%v' in namespace '%s' are deleted", labels, a.Namespace)
 	var spaceBindingList *toolchainv1alpha1.SpaceBindingList
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		// retrieve the SpaceBinding from the host namespace
 		spaceBindingList = &toolchainv1alpha1.SpaceBindingList{}
 		if err = a.Client.List(context.TODO(), spaceBindingList, client.MatchingLabels(labels), client.InNamespace(a.Namespace)); err != nil {
@@ -2284,19 +2154,7 @@ func (a *HostAwaitility) WaitUntilSpaceBindingsWithLabelDeleted(t *testing.T, ke
 	return err
 }
 
-type SpaceBindingWaitCriterion struct {
-	Match func(*toolchainv1alpha1.SpaceBinding) bool
-	Diff  func(*toolchainv1alpha1.SpaceBinding) string
-}
-
-func matchSpaceBindingWaitCriterion(actual *toolchainv1alpha1.SpaceBinding, criteria ...SpaceBindingWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type SpaceBindingWaitCriterion = WaitCriterion[*toolchainv1alpha1.SpaceBinding]
 
 // WaitForSubSpace waits until the space provisioned by a SpaceRequest is available with the provided criteria, if any
 func (a *HostAwaitility) WaitForSubSpace(t *testing.T, spaceRequestName, spaceRequestNamespace, parentSpaceName string, criteria ...SpaceWaitCriterion) (*toolchainv1alpha1.Space, error) {
@@ -2307,7 +2165,7 @@ func (a *HostAwaitility) WaitForSubSpace(t *testing.T, spaceRequestName, spaceRe
 		toolchainv1alpha1.ParentSpaceLabelKey:           parentSpaceName,
 	}
 
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, spaceRequestNamespace+"/"+spaceRequestName, criteriaNames(criteria), a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		// retrieve the subSpace from the host namespace
 		spaceList := &toolchainv1alpha1.SpaceList{}
 		if err = a.Client.List(context.TODO(), spaceList, client.MatchingLabels(labels), client.InNamespace(a.Namespace)); err != nil {
@@ -2320,7 +2178,7 @@ func (a *HostAwaitility) WaitForSubSpace(t *testing.T, spaceRequestName, spaceRe
 			return false, fmt.Errorf("more than 1 subSpaces for SpaceRequest '%s'", spaceRequestName)
 		}
 		subSpace = &spaceList.Items[0]
-		return matchSpaceWaitCriterion(subSpace, criteria...), nil
+		return matchCriteria(subSpace, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -2333,7 +2191,7 @@ func (a *HostAwaitility) WaitForSubSpace(t *testing.T, spaceRequestName, spaceRe
 func (a *HostAwaitility) WaitForSpaceBinding(t *testing.T, murName, spaceName string, criteria ...SpaceBindingWaitCriterion) (*toolchainv1alpha1.SpaceBinding, error) {
 	var spaceBinding *toolchainv1alpha1.SpaceBinding
 
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (bool, error) {
+	err := pollFor(t, spaceName, criteriaNames(criteria), a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (bool, error) {
 		// retrieve the SpaceBinding from the host namespace
 		var err error
 		if spaceBinding, err = a.GetSpaceBindingByListing(murName, spaceName); err != nil {
@@ -2342,7 +2200,7 @@ func (a *HostAwaitility) WaitForSpaceBinding(t *testing.T, murName, spaceName st
 		if spaceBinding == nil {
 			return false, nil
 		}
-		return matchSpaceBindingWaitCriterion(spaceBinding, criteria...), nil
+		return matchCriteria(spaceBinding, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -2378,16 +2236,7 @@ func (a *HostAwaitility) printSpaceBindingWaitCriterionDiffs(t *testing.T, actua
 	} else {
 		buf.WriteString("failed to find SpaceBinding with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	// also include SpaceBindings resources in the host namespace, to help troubleshooting
 	a.listAndPrint(t, "SpaceBindings", a.Namespace, &toolchainv1alpha1.SpaceBindingList{})
@@ -2485,24 +2334,12 @@ func UntilSpaceBindingHasLabel(key, value string) SpaceBindingWaitCriterion {
 	}
 }
 
-type SocialEventWaitCriterion struct {
-	Match func(*toolchainv1alpha1.SocialEvent) bool
-	Diff  func(*toolchainv1alpha1.SocialEvent) string
-}
-
-func matchSocialEventWaitCriterion(actual *toolchainv1alpha1.SocialEvent, criteria ...SocialEventWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type SocialEventWaitCriterion = WaitCriterion[*toolchainv1alpha1.SocialEvent]
 
 func (a *HostAwaitility) WaitForSocialEvent(t *testing.T, name string, criteria ...SocialEventWaitCriterion) (*toolchainv1alpha1.SocialEvent, error) {
 	t.Logf("waiting for SocialEvent '%s' in namespace '%s' to match criteria", name, a.Namespace)
 	var event *toolchainv1alpha1.SocialEvent
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.SocialEvent{}
 		// retrieve the Space from the host namespace
 		if err := a.Client.Get(context.TODO(),
@@ -2517,7 +2354,7 @@ func (a *HostAwaitility) WaitForSocialEvent(t *testing.T, name string, criteria
 			return false, err
 		}
 		event = obj
-		return matchSocialEventWaitCriterion(event, criteria...), nil
+		return matchCriteria(event, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -2559,16 +2396,7 @@ func (a *HostAwaitility) printSocialEventWaitCriterionDiffs(t *testing.T, actual
 	} else {
 		fmt.Fprintln(buf, "failed to find SocialEvent with matching criteria:")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	// also include SocialEvents resources in the host namespace, to help troubleshooting
 	a.listAndPrint(t, "SocialEvents", a.Namespace, &toolchainv1alpha1.SocialEventList{})
@@ -2612,7 +2440,7 @@ func (a *HostAwaitility) CreateSpaceAndSpaceBinding(t *testing.T, mur *toolchain
 	var spaceBinding *toolchainv1alpha1.SpaceBinding
 	var spaceCreated *toolchainv1alpha1.Space
 	testutil.LogWithTimestamp(t, fmt.Sprintf("Creating Space %s (prefix: %s) and SpaceBinding with role %s for %s", space.Name, space.GenerateName, spaceRole, mur.Name))
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		// create the space
 		spaceToCreate := space.DeepCopy()
 		if err := a.Create(spaceToCreate); err != nil {
@@ -0,0 +1,83 @@
+package wait_test
+
+import (
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUntilUserSignupHasScheduledDeactivationTimeAround(t *testing.T) {
+	// given
+	expected := time.Now().Add(3 * 24 * time.Hour)
+	criterion := wait.UntilUserSignupHasScheduledDeactivationTimeAround(expected, time.Hour)
+	withScheduledDeactivation := func(scheduled *metav1.Time) *toolchainv1alpha1.UserSignup {
+		return &toolchainv1alpha1.UserSignup{
+			Status: toolchainv1alpha1.UserSignupStatus{
+				ScheduledDeactivationTimestamp: scheduled,
+			},
+		}
+	}
+
+	t.Run("match", func(t *testing.T) {
+		assert.True(t, criterion.Match(withScheduledDeactivation(&metav1.Time{Time: expected})))
+		assert.True(t, criterion.Match(withScheduledDeactivation(&metav1.Time{Time: expected.Add(-59 * time.Minute)})))
+		assert.True(t, criterion.Match(withScheduledDeactivation(&metav1.Time{Time: expected.Add(59 * time.Minute)})))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		// when
+		tooEarly := withScheduledDeactivation(&metav1.Time{Time: expected.Add(-2 * time.Hour)})
+		missing := withScheduledDeactivation(nil)
+
+		// then
+		assert.False(t, criterion.Match(tooEarly))
+		assert.Contains(t, criterion.Diff(tooEarly), "to be within 1h0m0s of")
+		assert.False(t, criterion.Match(missing))
+		assert.Contains(t, criterion.Diff(missing), "but it is nil")
+	})
+}
+
+func TestUntilMemberHasReadyStatus(t *testing.T) {
+	// given
+	withMember := func(clusterName string, conditions ...toolchainv1alpha1.Condition) *toolchainv1alpha1.ToolchainStatus {
+		return &toolchainv1alpha1.ToolchainStatus{
+			Status: toolchainv1alpha1.ToolchainStatusStatus{
+				Members: []toolchainv1alpha1.Member{
+					{
+						ClusterName: clusterName,
+						MemberStatus: toolchainv1alpha1.MemberStatusStatus{
+							Conditions: conditions,
+						},
+					},
+				},
+			},
+		}
+	}
+	notReady := withMember("member-cluster", toolchainv1alpha1.Condition{
+		Type:   toolchainv1alpha1.ConditionReady,
+		Status: corev1.ConditionFalse,
+		Reason: toolchainv1alpha1.ToolchainStatusMemberStatusNotFoundReason,
+	})
+
+	t.Run("match", func(t *testing.T) {
+		assert.True(t, wait.UntilMemberHasReadyStatus("member-cluster", corev1.ConditionFalse).Match(notReady))
+		assert.True(t, wait.UntilMemberHasReadyStatus("member-cluster", corev1.ConditionUnknown).Match(withMember("member-cluster")))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		// when
+		criterion := wait.UntilMemberHasReadyStatus("member-cluster", corev1.ConditionTrue)
+		unknownMember := wait.UntilMemberHasReadyStatus("other-cluster", corev1.ConditionFalse)
+
+		// then
+		assert.False(t, criterion.Match(notReady))
+		assert.Contains(t, criterion.Diff(notReady), "expected the ready condition of member cluster 'member-cluster' to have status 'True'")
+		assert.False(t, unknownMember.Match(notReady))
+		assert.Contains(t, unknownMember.Diff(notReady), "expected member cluster 'other-cluster' to be listed in the ToolchainStatus")
+	})
+}
@@ -34,7 +34,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -97,19 +96,7 @@ func (a *MemberAwaitility) WithRetryOptions(options ...RetryOption) *MemberAwait
 }
 
 // UserAccountWaitCriterion a struct to compare with a given UserAccount
-type UserAccountWaitCriterion struct {
-	Match func(*toolchainv1alpha1.UserAccount) bool
-	Diff  func(*toolchainv1alpha1.UserAccount) string
-}
-
-func matchUserAccountWaitCriterion(actual *toolchainv1alpha1.UserAccount, criteria ...UserAccountWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type UserAccountWaitCriterion = WaitCriterion[*toolchainv1alpha1.UserAccount]
 
 func (a *MemberAwaitility) printUserAccountWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.UserAccount, criteria ...UserAccountWaitCriterion) {
 	buf := &strings.Builder{}
@@ -119,16 +106,7 @@ func (a *MemberAwaitility) printUserAccountWaitCriterionDiffs(t *testing.T, actu
 	} else {
 		buf.WriteString("failed to find UserAccount with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) && c.Diff != nil {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	t.Log(buf.String())
 }
@@ -250,7 +228,7 @@ func UntilUserAccountIsCreatedAfter(timestamp metav1.Time) UserAccountWaitCriter
 // WaitForUserAccount waits until there is a UserAccount available with the given name, expected spec and the set of status conditions
 func (a *MemberAwaitility) WaitForUserAccount(t *testing.T, name string, criteria ...UserAccountWaitCriterion) (*toolchainv1alpha1.UserAccount, error) {
 	var userAccount *toolchainv1alpha1.UserAccount
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.UserAccount{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -259,7 +237,7 @@ func (a *MemberAwaitility) WaitForUserAccount(t *testing.T, name string, criteri
 			return false, err
 		}
 		userAccount = obj
-		return matchUserAccountWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -269,15 +247,12 @@ func (a *MemberAwaitility) WaitForUserAccount(t *testing.T, name string, criteri
 }
 
 // SpaceRequestWaitCriterion a struct to compare with a given SpaceRequest
-type SpaceRequestWaitCriterion struct {
-	Match func(request *toolchainv1alpha1.SpaceRequest) bool
-	Diff  func(request *toolchainv1alpha1.SpaceRequest) string
-}
+type SpaceRequestWaitCriterion = WaitCriterion[*toolchainv1alpha1.SpaceRequest]
 
 // WaitForSpaceRequest waits until there is a SpaceRequest available with the given name, namespace, spec and the set of status conditions
 func (a *MemberAwaitility) WaitForSpaceRequest(t *testing.T, namespacedName types.NamespacedName, criteria ...SpaceRequestWaitCriterion) (*toolchainv1alpha1.SpaceRequest, error) {
 	var spaceRequest *toolchainv1alpha1.SpaceRequest
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, namespacedName.String(), criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.SpaceRequest{}
 		if err := a.Client.Get(context.TODO(), namespacedName, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -286,7 +261,7 @@ func (a *MemberAwaitility) WaitForSpaceRequest(t *testing.T, namespacedName type
 			return false, err
 		}
 		spaceRequest = obj
-		return matchSpaceRequestWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -421,15 +396,6 @@ func UntilSpaceRequestHasConditions(expected ...toolchainv1alpha1.Condition) Spa
 	}
 }
 
-func matchSpaceRequestWaitCriterion(actual *toolchainv1alpha1.SpaceRequest, criteria ...SpaceRequestWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
-
 func (a *MemberAwaitility) printSpaceRequestWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.SpaceRequest, criteria ...SpaceRequestWaitCriterion) {
 	buf := &strings.Builder{}
 	if actual == nil {
@@ -438,30 +404,18 @@ func (a *MemberAwaitility) printSpaceRequestWaitCriterionDiffs(t *testing.T, act
 	} else {
 		buf.WriteString("failed to find SpaceRequest with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) && c.Diff != nil {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	t.Log(buf.String())
 }
 
 // SpaceBindingRequestWaitCriterion a struct to compare with a given SpaceBindingRequest
-type SpaceBindingRequestWaitCriterion struct {
-	Match func(request *toolchainv1alpha1.SpaceBindingRequest) bool
-	Diff  func(request *toolchainv1alpha1.SpaceBindingRequest) string
-}
+type SpaceBindingRequestWaitCriterion = WaitCriterion[*toolchainv1alpha1.SpaceBindingRequest]
 
 // WaitForSpaceBindingRequest waits until there is a SpaceBindingRequest available with the given name, namespace, spec and the set of status conditions
 func (a *MemberAwaitility) WaitForSpaceBindingRequest(t *testing.T, namespacedName types.NamespacedName, criteria ...SpaceBindingRequestWaitCriterion) (*toolchainv1alpha1.SpaceBindingRequest, error) {
 	var spaceBindingRequest *toolchainv1alpha1.SpaceBindingRequest
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, namespacedName.String(), criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.SpaceBindingRequest{}
 		if err := a.Client.Get(context.TODO(), namespacedName, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -470,7 +424,7 @@ func (a *MemberAwaitility) WaitForSpaceBindingRequest(t *testing.T, namespacedNa
 			return false, err
 		}
 		spaceBindingRequest = obj
-		return matchSpaceBindingRequestWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -518,15 +472,6 @@ func UntilSpaceBindingRequestHasSpecMUR(expected string) SpaceBindingRequestWait
 	}
 }
 
-func matchSpaceBindingRequestWaitCriterion(actual *toolchainv1alpha1.SpaceBindingRequest, criteria ...SpaceBindingRequestWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
-
 func (a *MemberAwaitility) printSpaceBindingRequestWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.SpaceBindingRequest, criteria ...SpaceBindingRequestWaitCriterion) {
 	buf := &strings.Builder{}
 	if actual == nil {
@@ -535,16 +480,7 @@ func (a *MemberAwaitility) printSpaceBindingRequestWaitCriterionDiffs(t *testing
 	} else {
 		buf.WriteString("failed to find SpaceBindingRequest with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) && c.Diff != nil {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	t.Log(buf.String())
 }
@@ -558,19 +494,7 @@ func (a *MemberAwaitility) ListSpaceBindingRequests(namespace string) ([]toolcha
 }
 
 // NSTemplateSetWaitCriterion a struct to compare with a given NSTemplateSet
-type NSTemplateSetWaitCriterion struct {
-	Match func(*toolchainv1alpha1.NSTemplateSet) bool
-	Diff  func(*toolchainv1alpha1.NSTemplateSet) string
-}
-
-func matchNSTemplateSetWaitCriterion(actual *toolchainv1alpha1.NSTemplateSet, criteria ...NSTemplateSetWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type NSTemplateSetWaitCriterion = WaitCriterion[*toolchainv1alpha1.NSTemplateSet]
 
 func (a *MemberAwaitility) printNSTemplateSetWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.NSTemplateSet, criteria ...NSTemplateSetWaitCriterion) {
 	buf := &strings.Builder{}
@@ -580,16 +504,7 @@ func (a *MemberAwaitility) printNSTemplateSetWaitCriterionDiffs(t *testing.T, ac
 	} else {
 		fmt.Fprintf(buf, "failed to find NSTemplateSet with matching criteria after %fs:\n", a.Timeout.Seconds())
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	t.Log(buf.String())
 }
@@ -726,7 +641,7 @@ func UntilNSTemplateSetHasProvisionedNamespaces(expected []toolchainv1alpha1.Spa
 func (a *MemberAwaitility) WaitForNSTmplSet(t *testing.T, name string, criteria ...NSTemplateSetWaitCriterion) (*toolchainv1alpha1.NSTemplateSet, error) {
 	t.Logf("waiting for NSTemplateSet '%s' to match criteria", name)
 	var nsTmplSet *toolchainv1alpha1.NSTemplateSet
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.NSTemplateSet{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -735,7 +650,7 @@ func (a *MemberAwaitility) WaitForNSTmplSet(t *testing.T, name string, criteria
 			return false, err
 		}
 		nsTmplSet = obj
-		return matchNSTemplateSetWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -747,7 +662,7 @@ func (a *MemberAwaitility) WaitForNSTmplSet(t *testing.T, name string, criteria
 // WaitUntilNSTemplateSetDeleted waits until the NSTemplateSet with the given name is deleted (ie, is not found)
 func (a *MemberAwaitility) WaitUntilNSTemplateSetDeleted(t *testing.T, name string) error {
 	t.Logf("waiting for until NSTemplateSet '%s' in namespace '%s' is deleted", name, a.Namespace)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		nsTmplSet := &toolchainv1alpha1.NSTemplateSet{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, nsTmplSet); err != nil {
 			if errors.IsNotFound(err) {
@@ -759,15 +674,9 @@ func (a *MemberAwaitility) WaitUntilNSTemplateSetDeleted(t *testing.T, name stri
 	})
 }
 
-type NamespaceWaitCriterion struct {
-	Match func(*corev1.Namespace) bool
-	Diff  func(*corev1.Namespace) string
-}
+type NamespaceWaitCriterion = WaitCriterion[*corev1.Namespace]
 
-type LabelWaitCriterion struct {
-	Match func(metav1.ObjectMeta) bool
-	Diff  func(metav1.ObjectMeta) string
-}
+type LabelWaitCriterion = WaitCriterion[metav1.ObjectMeta]
 
 // UntilNamespaceIsActive returns a `NamespaceWaitCriterion` which checks that the given
 // Namespace is in `Active` phase
@@ -813,15 +722,6 @@ func UntilHasLastAppliedSpaceRoles(expected []toolchainv1alpha1.NSTemplateSetSpa
 	}
 }
 
-func matchNamespaceWaitCriteria(actual *corev1.Namespace, criteria ...NamespaceWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
-
 // WaitForNamespace waits until a namespace with the given owner (username), type, revision and tier labels exists
 func (a *MemberAwaitility) WaitForNamespace(t *testing.T, owner, tmplRef, tierName string, criteria ...NamespaceWaitCriterion) (*corev1.Namespace, error) {
 	_, kind, err := TierAndType(tmplRef)
@@ -837,7 +737,7 @@ func (a *MemberAwaitility) WaitForNamespace(t *testing.T, owner, tmplRef, tierNa
 	}
 	t.Logf("waiting for namespace with custom criteria and labels %v", labels)
 	var ns *corev1.Namespace
-	err = wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err = pollFor(t, owner, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		nss := &corev1.NamespaceList{}
 		opts := client.MatchingLabels(labels)
 		if err := a.Client.List(context.TODO(), nss, opts); err != nil {
@@ -847,7 +747,7 @@ func (a *MemberAwaitility) WaitForNamespace(t *testing.T, owner, tmplRef, tierNa
 			return false, nil
 		}
 		ns = &nss.Items[0]
-		return matchNamespaceWaitCriteria(ns, criteria...), nil
+		return matchCriteria(ns, criteria...), nil
 	})
 	if err != nil {
 		t.Logf("failed to wait for namespace with labels: %v", labels)
@@ -871,7 +771,7 @@ func (a *MemberAwaitility) WaitForNamespace(t *testing.T, owner, tmplRef, tierNa
 // WaitForNamespaceWithName waits until a namespace with the given name
 func (a *MemberAwaitility) WaitForNamespaceWithName(t *testing.T, name string, criteria ...LabelWaitCriterion) (*corev1.Namespace, error) {
 	ns := &corev1.Namespace{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(wa context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(wa context.Context) (done bool, err error) {
 		obj := &corev1.Namespace{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -880,7 +780,7 @@ func (a *MemberAwaitility) WaitForNamespaceWithName(t *testing.T, name string, c
 			return false, err
 		}
 		ns = obj
-		return matchLabelWaitCriteria(ns.ObjectMeta, criteria...), nil
+		return matchCriteria(ns.ObjectMeta, criteria...), nil
 	})
 	if err != nil {
 		t.Log("failed to wait for namespace")
@@ -890,15 +790,6 @@ func (a *MemberAwaitility) WaitForNamespaceWithName(t *testing.T, name string, c
 	return ns, nil
 }
 
-func matchLabelWaitCriteria(actual metav1.ObjectMeta, criteria ...LabelWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
-
 func (a *MemberAwaitility) printNamespaceLabelCriterionDiffs(t *testing.T, actual *corev1.Namespace, criteria ...LabelWaitCriterion) {
 	buf := &strings.Builder{}
 	if actual == nil {
@@ -924,7 +815,7 @@ func (a *MemberAwaitility) printNamespaceLabelCriterionDiffs(t *testing.T, actua
 // WaitForNamespaceInTerminating waits until a namespace with the given name has a deletion timestamp and in Terminating Phase
 func (a *MemberAwaitility) WaitForNamespaceInTerminating(t *testing.T, nsName string) (*corev1.Namespace, error) {
 	ns := &corev1.Namespace{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &corev1.Namespace{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: nsName}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -968,7 +859,7 @@ func (a *MemberAwaitility) printRoleBindingWaitCriterionDiffs(t *testing.T, actu
 func (a *MemberAwaitility) WaitForRoleBinding(t *testing.T, namespace *corev1.Namespace, name string, criteria ...LabelWaitCriterion) (*rbacv1.RoleBinding, error) {
 	t.Logf("waiting for RoleBinding '%s' in namespace '%s'", name, namespace.Name)
 	roleBinding := &rbacv1.RoleBinding{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &rbacv1.RoleBinding{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -977,7 +868,7 @@ func (a *MemberAwaitility) WaitForRoleBinding(t *testing.T, namespace *corev1.Na
 			return false, err
 		}
 		roleBinding = obj
-		return matchLabelWaitCriteria(obj.ObjectMeta, criteria...), nil
+		return matchCriteria(obj.ObjectMeta, criteria...), nil
 	})
 	if err != nil {
 		t.Logf("failed to wait for rolebinding")
@@ -991,7 +882,7 @@ func (a *MemberAwaitility) WaitForRoleBinding(t *testing.T, namespace *corev1.Na
 // WaitUntilRoleBindingDeleted waits until a RoleBinding with the given name does not exist anymore in the given namespace
 func (a *MemberAwaitility) WaitUntilRoleBindingDeleted(t *testing.T, namespace *corev1.Namespace, name string) error {
 	t.Logf("waiting for RoleBinding '%s' in namespace '%s' to be deleted", name, namespace.Name)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		roleBinding := &rbacv1.RoleBinding{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, roleBinding); err != nil {
 			if errors.IsNotFound(err) {
@@ -1006,7 +897,7 @@ func (a *MemberAwaitility) WaitUntilRoleBindingDeleted(t *testing.T, namespace *
 func (a *MemberAwaitility) WaitForServiceAccount(t *testing.T, namespace string, name string, criteria ...LabelWaitCriterion) (*corev1.ServiceAccount, error) {
 	t.Logf("waiting for ServiceAccount '%s' in namespace '%s'", name, namespace)
 	serviceAccount := &corev1.ServiceAccount{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &corev1.ServiceAccount{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1015,7 +906,7 @@ func (a *MemberAwaitility) WaitForServiceAccount(t *testing.T, namespace string,
 			return false, err
 		}
 		serviceAccount = obj
-		return matchLabelWaitCriteria(obj.ObjectMeta, criteria...), nil
+		return matchCriteria(obj.ObjectMeta, criteria...), nil
 	})
 	if err != nil {
 		t.Logf("failed to wait for ServiceAccount '%s' in namespace '%s'.", name, namespace)
@@ -1028,7 +919,7 @@ func (a *MemberAwaitility) WaitForServiceAccount(t *testing.T, namespace string,
 func (a *MemberAwaitility) WaitForLimitRange(t *testing.T, namespace *corev1.Namespace, name string) (*corev1.LimitRange, error) {
 	t.Logf("waiting for LimitRange '%s' in namespace '%s'", name, namespace.Name)
 	lr := &corev1.LimitRange{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &corev1.LimitRange{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1053,7 +944,7 @@ func (a *MemberAwaitility) WaitForLimitRange(t *testing.T, namespace *corev1.Nam
 func (a *MemberAwaitility) WaitForNetworkPolicy(t *testing.T, namespace *corev1.Namespace, name string) (*netv1.NetworkPolicy, error) {
 	t.Logf("waiting for NetworkPolicy '%s' in namespace '%s'", name, namespace.Name)
 	np := &netv1.NetworkPolicy{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &netv1.NetworkPolicy{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1078,7 +969,7 @@ func (a *MemberAwaitility) WaitForNetworkPolicy(t *testing.T, namespace *corev1.
 func (a *MemberAwaitility) WaitForRole(t *testing.T, namespace *corev1.Namespace, name string, criteria ...LabelWaitCriterion) (*rbacv1.Role, error) {
 	t.Logf("waiting for Role '%s' in namespace '%s'", name, namespace.Name)
 	role := &rbacv1.Role{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &rbacv1.Role{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace.Name, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1087,7 +978,7 @@ func (a *MemberAwaitility) WaitForRole(t *testing.T, namespace *corev1.Namespace
 			return false, err
 		}
 		role = obj
-		return matchLabelWaitCriteria(obj.ObjectMeta, criteria...), nil
+		return matchCriteria(obj.ObjectMeta, criteria...), nil
 	})
 	if err != nil {
 		t.Logf("failed to wait for Role '%s' in namespace '%s'", name, namespace.Name)
@@ -1099,7 +990,7 @@ func (a *MemberAwaitility) WaitForRole(t *testing.T, namespace *corev1.Namespace
 // WaitUntilRoleDeleted waits until a Role with the given name does not exist anymore in the given namespace
 func (a *MemberAwaitility) WaitUntilRoleDeleted(t *testing.T, namespace *corev1.Namespace, name string) error {
 	t.Logf("waiting for Role '%s' in namespace '%s' to be deleted", name, namespace.Name)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		role := &rbacv1.Role{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, role); err != nil {
 			if errors.IsNotFound(err) {
@@ -1134,19 +1025,7 @@ func (a *MemberAwaitility) printRoleWaitCriterionDiffs(t *testing.T, actual *rba
 }
 
 // ClusterResourceQuotaWaitCriterion a struct to compare with a given ClusterResourceQuota
-type ClusterResourceQuotaWaitCriterion struct {
-	Match func(*quotav1.ClusterResourceQuota) bool
-	Diff  func(*quotav1.ClusterResourceQuota) string
-}
-
-func matchClusterResourceQuotaWaitCriteria(actual *quotav1.ClusterResourceQuota, criteria ...ClusterResourceQuotaWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type ClusterResourceQuotaWaitCriterion = WaitCriterion[*quotav1.ClusterResourceQuota]
 
 func (a *MemberAwaitility) printClusterResourceQuotaWaitCriterionDiffs(t *testing.T, actual *quotav1.ClusterResourceQuota, criteria ...ClusterResourceQuotaWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1156,16 +1035,7 @@ func (a *MemberAwaitility) printClusterResourceQuotaWaitCriterionDiffs(t *testin
 	} else {
 		buf.WriteString("failed to find ClusterResourceQuota with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	t.Log(buf.String())
 }
@@ -1174,7 +1044,7 @@ func (a *MemberAwaitility) printClusterResourceQuotaWaitCriterionDiffs(t *testin
 func (a *MemberAwaitility) WaitForClusterResourceQuota(t *testing.T, name string, criteria ...ClusterResourceQuotaWaitCriterion) (*quotav1.ClusterResourceQuota, error) {
 	t.Logf("waiting for ClusterResourceQuota '%s' to match criteria", name)
 	quota := &quotav1.ClusterResourceQuota{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &quotav1.ClusterResourceQuota{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1188,7 +1058,7 @@ func (a *MemberAwaitility) WaitForClusterResourceQuota(t *testing.T, name string
 			return false, err
 		}
 		quota = obj
-		return matchClusterResourceQuotaWaitCriteria(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -1198,19 +1068,7 @@ func (a *MemberAwaitility) WaitForClusterResourceQuota(t *testing.T, name string
 }
 
 // ResourceQuotaWaitCriterion a struct to compare with a given ResourceQuota
-type ResourceQuotaWaitCriterion struct {
-	Match func(*corev1.ResourceQuota) bool
-	Diff  func(*corev1.ResourceQuota) string
-}
-
-func matchResourceQuotaWaitCriteria(actual *corev1.ResourceQuota, criteria ...ResourceQuotaWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type ResourceQuotaWaitCriterion = WaitCriterion[*corev1.ResourceQuota]
 
 func (a *MemberAwaitility) printResourceQuotaWaitCriterionDiffs(t *testing.T, actual *corev1.ResourceQuota, criteria ...ResourceQuotaWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1220,16 +1078,7 @@ func (a *MemberAwaitility) printResourceQuotaWaitCriterionDiffs(t *testing.T, ac
 	} else {
 		buf.WriteString("failed to find ResourceQuota with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	t.Log(buf.String())
 }
@@ -1238,7 +1087,7 @@ func (a *MemberAwaitility) printResourceQuotaWaitCriterionDiffs(t *testing.T, ac
 func (a *MemberAwaitility) WaitForResourceQuota(t *testing.T, namespace, name string, criteria ...ResourceQuotaWaitCriterion) (*corev1.ResourceQuota, error) {
 	t.Logf("waiting for ResourceQuota '%s' in %s to match criteria", name, namespace)
 	quota := &corev1.ResourceQuota{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &corev1.ResourceQuota{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1247,7 +1096,7 @@ func (a *MemberAwaitility) WaitForResourceQuota(t *testing.T, namespace, name st
 			return false, err
 		}
 		quota = obj
-		return matchResourceQuotaWaitCriteria(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -1257,20 +1106,7 @@ func (a *MemberAwaitility) WaitForResourceQuota(t *testing.T, namespace, name st
 }
 
 // IdlerWaitCriterion a struct to compare with a given Idler
-type IdlerWaitCriterion struct {
-	Match func(*toolchainv1alpha1.Idler) bool
-	Diff  func(*toolchainv1alpha1.Idler) string
-}
-
-func matchIdlerWaitCriteria(actual *toolchainv1alpha1.Idler, criteria ...IdlerWaitCriterion) bool {
-	for _, c := range criteria {
-		// if at least one criteria does not match, keep waiting
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type IdlerWaitCriterion = WaitCriterion[*toolchainv1alpha1.Idler]
 
 func (a *MemberAwaitility) printIdlerWaitCriteriaDiffs(t *testing.T, actual *toolchainv1alpha1.Idler, criteria ...IdlerWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1281,17 +1117,7 @@ func (a *MemberAwaitility) printIdlerWaitCriteriaDiffs(t *testing.T, actual *too
 	} else {
 		buf.WriteString("failed to find Idler with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			// if at least one criteria does not match, keep waiting
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	t.Log(buf.String())
 }
@@ -1349,7 +1175,7 @@ func IdlerHasLabel(key, value string) IdlerWaitCriterion {
 func (a *MemberAwaitility) WaitForIdler(t *testing.T, name string, criteria ...IdlerWaitCriterion) (*toolchainv1alpha1.Idler, error) {
 	t.Logf("waiting for Idler '%s' to match criteria", name)
 	idler := &toolchainv1alpha1.Idler{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.Idler{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1358,7 +1184,7 @@ func (a *MemberAwaitility) WaitForIdler(t *testing.T, name string, criteria ...I
 			return false, err
 		}
 		idler = obj
-		return matchIdlerWaitCriteria(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -1372,7 +1198,7 @@ func (a *MemberAwaitility) WaitForIdler(t *testing.T, name string, criteria ...I
 // Returns the updated SpaceBindingRequest
 func (a *MemberAwaitility) UpdateSpaceBindingRequest(t *testing.T, spaceBindingRequestNamespacedName types.NamespacedName, modifySpaceBindingRequest func(s *toolchainv1alpha1.SpaceBindingRequest)) (*toolchainv1alpha1.SpaceBindingRequest, error) {
 	var sr *toolchainv1alpha1.SpaceBindingRequest
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		freshSpaceBindingRequest := &toolchainv1alpha1.SpaceBindingRequest{}
 		if err := a.Client.Get(context.TODO(), spaceBindingRequestNamespacedName, freshSpaceBindingRequest); err != nil {
 			return true, err
@@ -1391,7 +1217,7 @@ func (a *MemberAwaitility) UpdateSpaceBindingRequest(t *testing.T, spaceBindingR
 // WaitUntilSpaceBindingRequestDeleted waits until a SpaceBindingRequest with the given name does not exist anymore in the given namespace
 func (a *MemberAwaitility) WaitUntilSpaceBindingRequestDeleted(t *testing.T, spaceBindingRequest *toolchainv1alpha1.SpaceBindingRequest) error {
 	t.Logf("waiting for SpaceBindingRequest '%s' in namespace '%s' to be deleted", spaceBindingRequest.GetName(), spaceBindingRequest.GetNamespace())
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		sbr := &toolchainv1alpha1.SpaceBindingRequest{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: spaceBindingRequest.GetName(), Namespace: spaceBindingRequest.GetNamespace()}, sbr); err != nil {
 			if errors.IsNotFound(err) {
@@ -1406,7 +1232,7 @@ func (a *MemberAwaitility) WaitUntilSpaceBindingRequestDeleted(t *testing.T, spa
 // Create tries to create the object until success
 // Workaround for https://github.com/kubernetes/kubernetes/issues/67761
 func (a *MemberAwaitility) Create(t *testing.T, obj client.Object) error {
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		if err := a.Client.Create(context.TODO(), obj); err != nil {
 			t.Logf("trying to create %+v. Error: %s. Will try to create again.", obj, err.Error())
 			return false, nil
@@ -1416,19 +1242,7 @@ func (a *MemberAwaitility) Create(t *testing.T, obj client.Object) error {
 }
 
 // PodWaitCriterion a struct to compare with a given Pod
-type PodWaitCriterion struct {
-	Match func(*corev1.Pod) bool
-	Diff  func(*corev1.Pod) string
-}
-
-func matchPodWaitCriterion(actual *corev1.Pod, criteria ...PodWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type PodWaitCriterion = WaitCriterion[*corev1.Pod]
 
 func (a *MemberAwaitility) printPodWaitCriterionDiffs(t *testing.T, actual *corev1.Pod, ns string, criteria ...PodWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1450,7 +1264,7 @@ func (a *MemberAwaitility) printPodWaitCriterionDiffs(t *testing.T, actual *core
 func (a *MemberAwaitility) WaitForPod(t *testing.T, namespace, name string, criteria ...PodWaitCriterion) (*corev1.Pod, error) {
 	t.Logf("waiting for Pod '%s' in namespace '%s' with matching criteria", name, namespace)
 	var pod *corev1.Pod
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &corev1.Pod{}
 		if err = a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: namespace,
@@ -1464,7 +1278,7 @@ func (a *MemberAwaitility) WaitForPod(t *testing.T, namespace, name string, crit
 			return false, err
 		}
 		pod = obj
-		return matchPodWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	// no match found, print the diffs
 	if err != nil {
@@ -1477,7 +1291,7 @@ func (a *MemberAwaitility) WaitForPod(t *testing.T, namespace, name string, crit
 func (a *MemberAwaitility) WaitForConfigMap(t *testing.T, namespace, name string) (*corev1.ConfigMap, error) {
 	t.Logf("waiting for ConfigMap '%s' in namespace '%s'", name, namespace)
 	var cm *corev1.ConfigMap
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &corev1.ConfigMap{}
 		if err = a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: namespace,
@@ -1500,7 +1314,7 @@ func (a *MemberAwaitility) WaitForConfigMap(t *testing.T, namespace, name string
 func (a *MemberAwaitility) WaitForSecret(t *testing.T, name string) (*corev1.Secret, error) {
 	t.Logf("waiting for Secret '%s' in namespace '%s'", name, a.Namespace)
 	var cm *corev1.Secret
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &corev1.Secret{}
 		if err = a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: a.Namespace,
@@ -1523,7 +1337,7 @@ func (a *MemberAwaitility) WaitForSecret(t *testing.T, name string) (*corev1.Sec
 func (a *MemberAwaitility) WaitForAAP(t *testing.T, name, namespace string, aapRes dynamic.NamespaceableResourceInterface, expectedIdled bool) (*unstructured.Unstructured, error) {
 	t.Logf("waiting for AAP '%s' in namespace '%s'", name, a.Namespace)
 	var aap *unstructured.Unstructured
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (bool, error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (bool, error) {
 		var err error
 		aap, err = aapRes.Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
 		if err != nil {
@@ -1544,7 +1358,7 @@ func (a *MemberAwaitility) WaitForAAP(t *testing.T, name, namespace string, aapR
 // WaitUntilInferenceServiceDeleted waits for the InferenceService resource to be deleted (idled)
 func (a *MemberAwaitility) WaitUntilInferenceServiceDeleted(t *testing.T, name, namespace string, inferenceServiceRes dynamic.NamespaceableResourceInterface) error {
 	t.Logf("waiting for InferenceService '%s' to be deleted in namespace '%s'", name, namespace)
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (bool, error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (bool, error) {
 		_, err := inferenceServiceRes.Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			if errors.IsNotFound(err) {
@@ -1561,7 +1375,7 @@ func (a *MemberAwaitility) WaitUntilInferenceServiceDeleted(t *testing.T, name,
 func (a *MemberAwaitility) WaitForPods(t *testing.T, namespace string, n int, criteria ...PodWaitCriterion) ([]corev1.Pod, error) {
 	t.Logf("waiting for Pods in namespace '%s' with matching criteria", namespace)
 	pods := make([]corev1.Pod, 0, n)
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, namespace, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		pds := make([]corev1.Pod, 0, n)
 		foundPods := &corev1.PodList{}
 		if err := a.Client.List(context.TODO(), foundPods, client.InNamespace(namespace)); err != nil {
@@ -1569,7 +1383,7 @@ func (a *MemberAwaitility) WaitForPods(t *testing.T, namespace string, n int, cr
 		}
 	pods:
 		for _, p := range foundPods.Items {
-			if !matchPodWaitCriterion(&p, criteria...) { // nolint:gosec
+			if !matchCriteria(&p, criteria...) { // nolint:gosec
 				// skip of criteria do not match
 				continue pods
 			}
@@ -1588,7 +1402,7 @@ func (a *MemberAwaitility) WaitForPods(t *testing.T, namespace string, n int, cr
 // WaitUntilPodsDeleted waits until the pods are deleted from the given namespace
 func (a *MemberAwaitility) WaitUntilPodsDeleted(t *testing.T, namespace string, criteria ...PodWaitCriterion) error {
 	t.Logf("waiting until Pods with matching criteria in namespace '%s' are deleted", namespace)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return pollFor(t, namespace, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		foundPods := &corev1.PodList{}
 		if err := a.Client.List(context.TODO(), foundPods, &client.ListOptions{Namespace: namespace}); err != nil {
 			return false, err
@@ -1597,7 +1411,7 @@ func (a *MemberAwaitility) WaitUntilPodsDeleted(t *testing.T, namespace string,
 			return true, nil
 		}
 		for _, p := range foundPods.Items {
-			if !matchPodWaitCriterion(&p, criteria...) { // nolint:gosec
+			if !matchCriteria(&p, criteria...) { // nolint:gosec
 				// keep waiting
 				return false, nil
 			}
@@ -1609,7 +1423,7 @@ func (a *MemberAwaitility) WaitUntilPodsDeleted(t *testing.T, namespace string,
 // WaitUntilPodDeleted waits until the pod with the given name is deleted from the given namespace
 func (a *MemberAwaitility) WaitUntilPodDeleted(t *testing.T, namespace, name string) error {
 	t.Logf("waiting until Pod '%s' in namespace '%s' is deleted", name, namespace)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &corev1.Pod{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
 			if errors.IsNotFound(err) {
@@ -1696,7 +1510,7 @@ func checkPriorityClass(pod *corev1.Pod, name string, priority int) bool {
 func (a *MemberAwaitility) WaitUntilWebhookDeleted(t *testing.T) error {
 	t.Logf("waiting until webhook member-operator-webhook in namespace '%s' is deleted", a.Namespace)
 	deployment := &appsv1.Deployment{}
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		if err := a.Client.Get(context.TODO(), test.NamespacedName(a.Namespace, "member-operator-webhook"), deployment); err != nil {
 			if errors.IsNotFound(err) {
 				return true, nil
@@ -1710,7 +1524,7 @@ func (a *MemberAwaitility) WaitUntilWebhookDeleted(t *testing.T) error {
 // WaitUntilNamespaceDeleted waits until the namespace with the given name is deleted (ie, is not found)
 func (a *MemberAwaitility) WaitUntilNamespaceDeleted(t *testing.T, username, typeName string) error {
 	t.Logf("waiting until namespace for user '%s' and type '%s' is deleted", username, typeName)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		labels := map[string]string{
 			toolchainv1alpha1.SpaceLabelKey: username,
 			toolchainv1alpha1.TypeLabelKey:  typeName,
@@ -1730,7 +1544,7 @@ func (a *MemberAwaitility) WaitUntilNamespaceDeleted(t *testing.T, username, typ
 // WaitUntilSecretsDeleted waits until the secrets with the given labels are deleted (ie, is not found)
 func (a *MemberAwaitility) WaitUntilSecretsDeleted(t *testing.T, namespace string, labels client.MatchingLabels) error {
 	t.Logf("waiting until secrets with lables '%v' in namespace '%s' is deleted", labels, namespace)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		secretList := &corev1.SecretList{}
 		if err := a.Client.List(context.TODO(), secretList, labels); err != nil {
 			return false, err
@@ -1743,19 +1557,7 @@ func (a *MemberAwaitility) WaitUntilSecretsDeleted(t *testing.T, namespace strin
 }
 
 // UserWaitCriterion a struct to compare with a given User
-type UserWaitCriterion struct {
-	Match func(*userv1.User) bool
-	Diff  func(*userv1.User) string
-}
-
-func matchUserWaitCriterion(actual *userv1.User, criteria ...UserWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type UserWaitCriterion = WaitCriterion[*userv1.User]
 
 func (a *MemberAwaitility) printUserWaitCriterionDiffs(t *testing.T, actual *userv1.User, criteria ...UserWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1776,7 +1578,7 @@ func (a *MemberAwaitility) printUserWaitCriterionDiffs(t *testing.T, actual *use
 func (a *MemberAwaitility) WaitForUser(t *testing.T, name string, criteria ...UserWaitCriterion) (*userv1.User, error) {
 	t.Logf("waiting for User '%s'", name)
 	user := &userv1.User{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		user = &userv1.User{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, user); err != nil {
 			if errors.IsNotFound(err) {
@@ -1784,7 +1586,7 @@ func (a *MemberAwaitility) WaitForUser(t *testing.T, name string, criteria ...Us
 			}
 			return false, err
 		}
-		if !matchUserWaitCriterion(user, criteria...) {
+		if !matchCriteria(user, criteria...) {
 			return false, nil
 		}
 		if user.Name != "" && len(user.Identities) > 0 {
@@ -1824,25 +1626,13 @@ func UntilUserHasAnnotation(key, value string) UserWaitCriterion {
 }
 
 // IdentityWaitCriterion a struct to compare with a given Identity
-type IdentityWaitCriterion struct {
-	Match func(*userv1.Identity) bool
-	Diff  func(*userv1.Identity) string
-}
-
-func matchIdentityWaitCriterion(actual *userv1.Identity, criteria ...IdentityWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type IdentityWaitCriterion = WaitCriterion[*userv1.Identity]
 
 // WaitForIdentity waits until there is an Identity with the given name available
 func (a *MemberAwaitility) WaitForIdentity(t *testing.T, name string, criteria ...IdentityWaitCriterion) (*userv1.Identity, error) {
 	t.Logf("waiting for Identity '%s'", name)
 	identity := &userv1.Identity{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		identity = &userv1.Identity{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, identity); err != nil {
 			if errors.IsNotFound(err) {
@@ -1850,7 +1640,7 @@ func (a *MemberAwaitility) WaitForIdentity(t *testing.T, name string, criteria .
 			}
 			return false, err
 		}
-		if !matchIdentityWaitCriterion(identity, criteria...) {
+		if !matchCriteria(identity, criteria...) {
 			return false, nil
 		}
 		if identity.Name != "" && identity.User.Name != "" {
@@ -1886,7 +1676,7 @@ func UntilIdentityHasLabel(key, value string) IdentityWaitCriterion {
 // WaitUntilUserAccountDeleted waits until the UserAccount with the given name is not found
 func (a *MemberAwaitility) WaitUntilUserAccountDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until UserAccount '%s' in namespace '%s' is deleted", name, a.Namespace)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		ua := &toolchainv1alpha1.UserAccount{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: a.Namespace, Name: name}, ua); err != nil {
 			if errors.IsNotFound(err) {
@@ -1901,7 +1691,7 @@ func (a *MemberAwaitility) WaitUntilUserAccountDeleted(t *testing.T, name string
 // WaitUntilUserDeleted waits until the User with the given name is not found
 func (a *MemberAwaitility) WaitUntilUserDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until User is deleted '%s'", name)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		user := &userv1.User{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, user); err != nil {
 			if errors.IsNotFound(err) {
@@ -1919,7 +1709,7 @@ func (a *MemberAwaitility) WaitUntilUserDeleted(t *testing.T, name string) error
 // WaitUntilIdentityDeleted waits until the Identity with the given name is not found
 func (a *MemberAwaitility) WaitUntilIdentityDeleted(t *testing.T, name string) error {
 	t.Logf("waiting until Identity is deleted '%s'", name)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		identity := &userv1.Identity{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{Name: name}, identity); err != nil {
 			if errors.IsNotFound(err) {
@@ -1946,7 +1736,7 @@ func (a *MemberAwaitility) GetConsoleURL(t *testing.T) string {
 // WaitUntilClusterResourceQuotasDeleted waits until all ClusterResourceQuotas with the given owner label are deleted (ie, none is found)
 func (a *MemberAwaitility) WaitUntilClusterResourceQuotasDeleted(t *testing.T, username string) error {
 	t.Logf("waiting for deletion of ClusterResourceQuotas for user '%s'", username)
-	return wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	return poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		labels := map[string]string{
 			toolchainv1alpha1.SpaceLabelKey: username,
 		}
@@ -1963,19 +1753,7 @@ func (a *MemberAwaitility) WaitUntilClusterResourceQuotasDeleted(t *testing.T, u
 }
 
 // MemberStatusWaitCriterion a struct to compare with a given MemberStatus
-type MemberStatusWaitCriterion struct {
-	Match func(*toolchainv1alpha1.MemberStatus) bool
-	Diff  func(*toolchainv1alpha1.MemberStatus) string
-}
-
-func matchMemberStatusWaitCriterion(actual *toolchainv1alpha1.MemberStatus, criteria ...MemberStatusWaitCriterion) bool {
-	for _, c := range criteria {
-		if !c.Match(actual) {
-			return false
-		}
-	}
-	return true
-}
+type MemberStatusWaitCriterion = WaitCriterion[*toolchainv1alpha1.MemberStatus]
 
 func (a *MemberAwaitility) printMemberStatusWaitCriterionDiffs(t *testing.T, actual *toolchainv1alpha1.MemberStatus, criteria ...MemberStatusWaitCriterion) {
 	buf := &strings.Builder{}
@@ -1986,16 +1764,7 @@ func (a *MemberAwaitility) printMemberStatusWaitCriterionDiffs(t *testing.T, act
 	} else {
 		buf.WriteString("failed to find MemberStatus with matching criteria:\n")
 		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "actual:")
-		y, _ := StringifyObject(actual)
-		fmt.Fprintln(buf, string(y))
-		fmt.Fprintln(buf, "----")
-		fmt.Fprintln(buf, "diffs:")
-		for _, c := range criteria {
-			if !c.Match(actual) {
-				fmt.Fprintln(buf, c.Diff(actual))
-			}
-		}
+		writeCriteriaDiffs(buf, actual, criteria...)
 	}
 	t.Log(buf.String())
 }
@@ -2057,7 +1826,7 @@ func (a *MemberAwaitility) WaitForMemberStatus(t *testing.T, criteria ...MemberS
 	t.Logf("waiting for MemberStatus '%s' to match criteria", name)
 	// there should only be one member status with the name toolchain-member-status
 	var memberStatus *toolchainv1alpha1.MemberStatus
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, "", criteriaNames(criteria), a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		// retrieve the memberstatus from the member namespace
 		obj := &toolchainv1alpha1.MemberStatus{}
 		err = a.Client.Get(context.TODO(),
@@ -2073,7 +1842,7 @@ func (a *MemberAwaitility) WaitForMemberStatus(t *testing.T, criteria ...MemberS
 			return false, err
 		}
 		memberStatus = obj
-		return matchMemberStatusWaitCriterion(obj, criteria...), nil
+		return matchCriteria(obj, criteria...), nil
 	})
 	if err != nil {
 		a.printMemberStatusWaitCriterionDiffs(t, memberStatus, criteria...)
@@ -2110,7 +1879,7 @@ func (a *MemberAwaitility) WaitForMemberOperatorConfig(t *testing.T, hostAwait *
 	name := "config"
 	t.Logf("waiting for MemberOperatorConfig '%s'", name)
 	memberOperatorConfig := &toolchainv1alpha1.MemberOperatorConfig{}
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, 2*a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, 2*a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &toolchainv1alpha1.MemberOperatorConfig{}
 		// retrieve the MemberOperatorConfig from the member namespace
 		err = a.Client.Get(context.TODO(),
@@ -2171,7 +1940,7 @@ func (a *MemberAwaitility) waitForUsersPodPriorityClass(t *testing.T) {
 }
 
 func (a *MemberAwaitility) waitForResource(t *testing.T, namespace, name string, object client.Object) {
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		if err := a.Client.Get(context.TODO(), test.NamespacedName(namespace, name), object); err != nil {
 			if errors.IsNotFound(err) {
 				return false, nil
@@ -2500,7 +2269,7 @@ func (a *MemberAwaitility) WaitForExpectedNumberOfClusterResources(t *testing.T,
 
 func (a *MemberAwaitility) waitForExpectedNumberOfResources(expected int, list func() (int, error)) (int, error) {
 	var actual int
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := poll(nil, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		a, err := list()
 		if err != nil {
 			return false, err
@@ -2514,7 +2283,7 @@ func (a *MemberAwaitility) waitForExpectedNumberOfResources(expected int, list f
 func (a *MemberAwaitility) WaitForEnvironment(t *testing.T, namespace, name string, criteria ...LabelWaitCriterion) (*appstudiov1.Environment, error) {
 	t.Logf("waiting for Environment resource '%s' to exist in namespace '%s'", name, namespace)
 	var env *appstudiov1.Environment
-	err := wait.PollUntilContextTimeout(context.TODO(), a.RetryInterval, a.Timeout, true, func(ctx context.Context) (done bool, err error) {
+	err := pollFor(t, name, criteriaNames(criteria), a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
 		obj := &appstudiov1.Environment{}
 		if err := a.Client.Get(context.TODO(), types.NamespacedName{
 			Namespace: namespace,
@@ -2525,7 +2294,7 @@ func (a *MemberAwaitility) WaitForEnvironment(t *testing.T, namespace, name stri
 			return false, err
 		}
 		env = obj
-		return matchLabelWaitCriteria(obj.ObjectMeta, criteria...), nil
+		return matchCriteria(obj.ObjectMeta, criteria...), nil
 	})
 	if err != nil {
 		t.Logf("failed to wait for Environment")
@@ -0,0 +1,150 @@
+package wait
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/status"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubectl/pkg/util/podutils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The functions in this file check the readiness of the resources the awaitilities depend on, without any dependency
+// on `testing.T`, so that they can also be used outside of the tests (eg, by the `doctor` command)
+
+// NotReadyError the error returned when a resource does not exist or is not ready (yet)
+type NotReadyError struct {
+	Reason string
+}
+
+func (e *NotReadyError) Error() string {
+	return e.Reason
+}
+
+func notReady(format string, args ...interface{}) error {
+	return &NotReadyError{
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// IsNotReady returns `true` if the given error is a NotReadyError
+func IsNotReady(err error) bool {
+	e := &NotReadyError{}
+	return errors.As(err, &e)
+}
+
+// CheckDeploymentReady returns the deployment with the given name if it is ready together with the given number of replicas,
+// a NotReadyError if the deployment does not exist or is not ready, or any other error that occurred while retrieving the resources
+func CheckDeploymentReady(ctx context.Context, cl client.Client, namespace, name string, replicas int) (*appsv1.Deployment, error) {
+	obj := &appsv1.Deployment{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, notReady("deployment '%s' not found in namespace '%s'", name, namespace)
+		}
+		return nil, err
+	}
+	deploymentConditions := status.GetDeploymentStatusConditions(ctx, cl, name, namespace)
+	if err := status.ValidateComponentConditionReady(deploymentConditions...); err != nil {
+		return nil, notReady("deployment '%s' in namespace '%s' is not ready: %s", name, namespace, err.Error())
+	}
+	if int(obj.Status.AvailableReplicas) != replicas {
+		return nil, notReady("deployment '%s' in namespace '%s' has %d available replica(s) instead of %d", name, namespace, obj.Status.AvailableReplicas, replicas)
+	}
+	pods := &corev1.PodList{}
+	if err := cl.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels(obj.Spec.Selector.MatchLabels)); err != nil {
+		return nil, err
+	}
+	if len(pods.Items) != replicas {
+		return nil, notReady("deployment '%s' in namespace '%s' has %d pod(s) instead of %d", name, namespace, len(pods.Items), replicas)
+	}
+	for _, pod := range pods.Items { // nolint
+		if util.IsBeingDeleted(&pod) || !podutils.IsPodReady(&pod) {
+			return nil, notReady("pod '%s' of deployment '%s' in namespace '%s' is not ready", pod.Name, name, namespace)
+		}
+	}
+	return obj, nil
+}
+
+// CheckRouteAvailable returns the route with the given name if it has an Ingress with a host configured and if the endpoint
+// is reachable (with a `200 OK` status response), a NotReadyError if the route does not exist or is not available, or any
+// other error that occurred while retrieving the route or calling the endpoint.
+// The given bearer token is used to call the endpoint when the route is secured.
+func CheckRouteAvailable(ctx context.Context, cl client.Client, bearerToken, namespace, name, endpoint string) (routev1.Route, error) {
+	route := routev1.Route{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return route, notReady("route '%s' not found in namespace '%s'", name, namespace)
+		}
+		return route, err
+	}
+	// assume there's a single Ingress and that its host will not be empty when the route is ready
+	if len(route.Status.Ingress) == 0 || route.Status.Ingress[0].Host == "" {
+		return route, notReady("route '%s' in namespace '%s' has no ingress host", name, namespace)
+	}
+	// verify that the endpoint gives a `200 OK` response on a GET request
+	httpClient := http.Client{
+		Timeout: time.Duration(5 * time.Second), // because sometimes the network connection may be a bit slow
+	}
+	var request *http.Request
+	var err error
+	if route.Spec.TLS != nil {
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, // nolint:gosec
+			},
+		}
+		request, err = http.NewRequestWithContext(ctx, "GET", "https://"+route.Status.Ingress[0].Host+endpoint, nil)
+		if err != nil {
+			return route, err
+		}
+		request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", bearerToken))
+	} else {
+		request, err = http.NewRequestWithContext(ctx, "GET", "http://"+route.Status.Ingress[0].Host+endpoint, nil)
+		if err != nil {
+			return route, err
+		}
+	}
+	resp, err := httpClient.Do(request)
+	urlError := &url.Error{}
+	if errors.As(err, &urlError) && urlError.Timeout() {
+		// the endpoint is not available yet (pod is still re-starting)
+		return route, notReady("timeout while calling the endpoint '%s' of route '%s' in namespace '%s'", endpoint, name, namespace)
+	} else if err != nil {
+		return route, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return route, notReady("the endpoint '%s' of route '%s' in namespace '%s' returned status %d", endpoint, name, namespace, resp.StatusCode)
+	}
+	return route, nil
+}
+
+// FindToolchainCluster returns the ToolchainCluster in the given namespace which represents the operator running in the
+// given operator namespace, or `nil` if there is no such ToolchainCluster
+func FindToolchainCluster(ctx context.Context, cl client.Client, namespace, operatorNamespace string) (*toolchainv1alpha1.ToolchainCluster, error) {
+	clusters := &toolchainv1alpha1.ToolchainClusterList{}
+	if err := cl.List(ctx, clusters, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	// assume there is zero or 1 match only
+	for i := range clusters.Items {
+		if clusters.Items[i].Status.OperatorNamespace == operatorNamespace {
+			return &clusters.Items[i], nil
+		}
+	}
+	return nil, nil
+}
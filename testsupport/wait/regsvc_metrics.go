@@ -0,0 +1,174 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/metrics"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/kubectl/pkg/util/podutils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// registration service and proxy metric constants
+const (
+	// RegistrationServiceAPIRequestsMetric the number of requests handled by the registration service (labels: `code`, `method`)
+	RegistrationServiceAPIRequestsMetric = "sandbox_promhttp_client_api_requests_total"
+	// RegistrationServiceRequestDurationMetric the histogram of the durations of the requests handled by the registration service
+	RegistrationServiceRequestDurationMetric = "sandbox_promhttp_request_duration_seconds"
+
+	// ProxyAPIRequestTimeMetric the histogram of the time taken by the proxy to route the requests to a member cluster
+	// (labels: `status_code`, `route_to`)
+	ProxyAPIRequestTimeMetric = "sandbox_proxy_api_http_request_time"
+	// ProxyWorkspaceRequestTimeMetric the histogram of the time taken by the proxy to respond to the requests on the workspaces
+	// (labels: `status_code`, `kube_verb`)
+	ProxyWorkspaceRequestTimeMetric = "sandbox_proxy_workspace_http_request_time"
+)
+
+// RegistrationServiceMetricsAwaitility the Awaitility for the metrics exposed by the registration service
+type RegistrationServiceMetricsAwaitility struct {
+	*Awaitility
+}
+
+// ProxyMetricsAwaitility the Awaitility for the metrics exposed by the proxy (which runs in the registration service)
+type ProxyMetricsAwaitility struct {
+	*Awaitility
+}
+
+// RegistrationServiceMetrics returns an Awaitility for the metrics of the registration service, which are scraped
+// from each pod behind the `registration-service-metrics` service and summed
+func (a *HostAwaitility) RegistrationServiceMetrics(t *testing.T) *RegistrationServiceMetricsAwaitility {
+	return &RegistrationServiceMetricsAwaitility{
+		Awaitility: a.metricsAwaitility(t, "registration-service-metrics", "regsvc-metrics"),
+	}
+}
+
+// ProxyMetrics returns an Awaitility for the metrics of the proxy, which are scraped from each pod behind
+// the `proxy-metrics-service` service and summed
+func (a *HostAwaitility) ProxyMetrics(t *testing.T) *ProxyMetricsAwaitility {
+	return &ProxyMetricsAwaitility{
+		Awaitility: a.metricsAwaitility(t, "proxy-metrics-service", "proxy-metrics"),
+	}
+}
+
+// InitMetrics captures the baseline values of the registration service metrics, for all the label values
+// exposed at the time of the call
+func (a *RegistrationServiceMetricsAwaitility) InitMetrics(t *testing.T) {
+	a.captureMetricsBaselines(t, RegistrationServiceAPIRequestsMetric)
+	a.captureHistogramBaselines(t, RegistrationServiceRequestDurationMetric)
+}
+
+// InitMetrics captures the baseline values of the proxy metrics, for all the label values exposed at the time of the call
+func (a *ProxyMetricsAwaitility) InitMetrics(t *testing.T) {
+	a.captureHistogramBaselines(t, ProxyAPIRequestTimeMetric, ProxyWorkspaceRequestTimeMetric)
+}
+
+// metricsAwaitility returns a copy of this Awaitility which scrapes the `/metrics` endpoint of each (ready) pod selected by
+// the given service, via the proxy of the API server, and which sums their metrics.
+// Scraping a single endpoint (eg, via a route to the service) is not an option, since the requests are load-balanced
+// across the replicas and each replica only exposes the metrics of the requests that it handled.
+func (a *HostAwaitility) metricsAwaitility(t *testing.T, serviceName, targetPort string) *Awaitility {
+	t.Logf("setting up the scraping of the pods of service '%s' on port '%s'", serviceName, targetPort)
+	service, err := a.WaitForService(t, serviceName)
+	require.NoError(t, err)
+
+	result := a.Awaitility.copy()
+	result.MetricsURL = ""
+	result.metricsURLs = func(ctx context.Context) ([]string, error) {
+		return podMetricsURLs(ctx, a.Client, a.RestConfig.Host, service, targetPort)
+	}
+	// verify that the metrics can be scraped (the pods may be restarting)
+	err = poll(t, a.RetryInterval, a.Timeout, func(ctx context.Context) (done bool, err error) {
+		_, err = result.scrapeMetrics()
+		return err == nil, nil
+	})
+	require.NoError(t, err, "failed while waiting for the metrics of the pods of the '%s' service to be available", serviceName)
+	return result
+}
+
+// podMetricsURLs returns the URLs of the `/metrics` endpoints of all the ready pods selected by the given service,
+// via the proxy of the API server at the given host
+func podMetricsURLs(ctx context.Context, cl client.Client, apiServerHost string, service corev1.Service, targetPort string) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := cl.List(ctx, pods, client.InNamespace(service.Namespace), client.MatchingLabels(service.Spec.Selector)); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(apiServerHost, "://") {
+		apiServerHost = "https://" + apiServerHost
+	}
+	urls := make([]string, 0, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if util.IsBeingDeleted(pod) || !podutils.IsPodReady(pod) {
+			continue
+		}
+		port, found := containerPort(pod, targetPort)
+		if !found {
+			return nil, fmt.Errorf("no port named '%s' in pod '%s'", targetPort, pod.Name)
+		}
+		urls = append(urls, fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s:%d/proxy/metrics", strings.TrimSuffix(apiServerHost, "/"), pod.Namespace, pod.Name, port))
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no ready pod found for service '%s' in namespace '%s'", service.Name, service.Namespace)
+	}
+	return urls, nil
+}
+
+// containerPort returns the number of the container port with the given name in the given pod
+func containerPort(pod *corev1.Pod, name string) (int32, bool) {
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == name {
+				return p.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// captureMetricsBaselines captures the baseline values of all the samples of the given (counter or gauge)
+// metric families, using a single scrape
+func (a *Awaitility) captureMetricsBaselines(t *testing.T, families ...string) {
+	snapshot := a.TakeMetricsSnapshot(t)
+	if a.baselineValues == nil {
+		a.baselineValues = map[string]float64{}
+	}
+	for _, family := range families {
+		for _, sample := range snapshot.Select(metrics.Family(family)) {
+			a.baselineValues[a.baselineKey(t, family, labelsAndValues(sample.Labels)...)] = sample.Value
+		}
+	}
+	t.Logf("captured baselines:\n%s", spew.Sdump(a.baselineValues))
+}
+
+// captureHistogramBaselines captures the baseline values of the +Inf bucket of all the samples of the given
+// histogram families, using a single scrape
+func (a *Awaitility) captureHistogramBaselines(t *testing.T, families ...string) {
+	snapshot := a.TakeMetricsSnapshot(t)
+	if a.baselineHistogramValues == nil {
+		a.baselineHistogramValues = map[string]map[float64]uint64{}
+	}
+	for _, family := range families {
+		// the `_count` of a histogram is the value of its +Inf bucket
+		for _, sample := range snapshot.Select(metrics.Family(family + "_count")) {
+			a.baselineHistogramValues[a.baselineKey(t, family, labelsAndValues(sample.Labels)...)] = map[float64]uint64{
+				math.Inf(1): uint64(sample.Value),
+			}
+		}
+	}
+	t.Logf("captured histogram baselines:\n%s", spew.Sdump(a.baselineHistogramValues))
+}
+
+func labelsAndValues(labels map[string]string) []string {
+	result := make([]string, 0, 2*len(labels))
+	for name, value := range labels {
+		result = append(result, name, value)
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+package wait_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"k8s.io/client-go/rest"
+)
+
+func TestRegistrationServiceMetricsBaselines(t *testing.T) {
+	// given
+	var requests atomic.Int64
+	requests.Store(3)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `sandbox_promhttp_client_api_requests_total{code="200",method="get"} %[1]d
+# TYPE sandbox_promhttp_request_duration_seconds histogram
+sandbox_promhttp_request_duration_seconds_bucket{code="200",le="0.5"} %[1]d
+sandbox_promhttp_request_duration_seconds_bucket{code="200",le="+Inf"} %[1]d
+sandbox_promhttp_request_duration_seconds_sum{code="200"} 1
+sandbox_promhttp_request_duration_seconds_count{code="200"} %[1]d
+`, requests.Load())
+	}))
+	defer ts.Close()
+	a := &wait.RegistrationServiceMetricsAwaitility{
+		Awaitility: &wait.Awaitility{
+			RestConfig:    &rest.Config{},
+			MetricsURL:    ts.URL + "/metrics",
+			RetryInterval: 10 * time.Millisecond,
+			Timeout:       time.Second,
+		},
+	}
+
+	// when
+	a.InitMetrics(t)
+	requests.Add(2)
+
+	// then
+	a.WaitForMetricDelta(t, wait.RegistrationServiceAPIRequestsMetric, 2, "method", "get", "code", "200")
+	a.WaitForHistogramInfBucketDelta(t, wait.RegistrationServiceRequestDurationMetric, 2, "code", "200")
+
+	t.Run("or more", func(t *testing.T) {
+		// when
+		requests.Add(1)
+
+		// then
+		a.WaitForMetricDeltaOrMore(t, wait.RegistrationServiceAPIRequestsMetric, 2, "method", "get", "code", "200")
+		a.WaitForHistogramInfBucketDeltaOrMore(t, wait.RegistrationServiceRequestDurationMetric, 2, "code", "200")
+	})
+}
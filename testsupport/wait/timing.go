@@ -0,0 +1,259 @@
+package wait
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// WaitReportFileVar the env var with the path of the file in which the wait report is written at the end of the test suite.
+	// Since each test suite writes its own report, the name of the suite is appended to the name of the file, eg:
+	// `/tmp/wait-report.json` becomes `/tmp/wait-report-e2e.json`.
+	// If not set, then the report is written in the `$ARTIFACT_DIR` directory (if set)
+	WaitReportFileVar = "WAIT_REPORT_FILE"
+	// DefaultSlowestWaits the default number of slowest waits listed in the wait report
+	DefaultSlowestWaits = 50
+)
+
+// WaitOutcome the outcome of a single wait
+type WaitOutcome string
+
+const (
+	// WaitSucceeded the condition of the wait was met
+	WaitSucceeded WaitOutcome = "succeeded"
+	// WaitTimedOut the condition of the wait was not met before the timeout
+	WaitTimedOut WaitOutcome = "timed-out"
+	// WaitFailed the wait was aborted with an error
+	WaitFailed WaitOutcome = "failed"
+)
+
+// WaitRecord the timing record of a single wait
+type WaitRecord struct {
+	// Test the name of the test which performed the wait
+	Test string `json:"test"`
+	// Kind the name of the wait function, eg: `HostAwaitility.WaitForSpace`
+	Kind string `json:"kind"`
+	// Object the name of the object that was waited for (if any)
+	Object string `json:"object,omitempty"`
+	// Criteria the names of the criteria that the object was expected to match (if any)
+	Criteria []string `json:"criteria,omitempty"`
+	// Start the time when the wait started
+	Start time.Time `json:"start"`
+	// Duration how long the wait took
+	Duration time.Duration `json:"duration"`
+	// Outcome the outcome of the wait
+	Outcome WaitOutcome `json:"outcome"`
+}
+
+var waitRecords = struct {
+	sync.Mutex
+	records []WaitRecord
+}{}
+
+// WaitRecords returns the timing records of all the waits performed so far
+func WaitRecords() []WaitRecord {
+	waitRecords.Lock()
+	defer waitRecords.Unlock()
+	return append([]WaitRecord{}, waitRecords.records...)
+}
+
+// poll is a wrapper of `wait.PollUntilContextTimeout` which also records the timing of the wait in the given test.
+// The kind of wait is the name of the calling function.
+func poll(t *testing.T, interval, timeout time.Duration, condition wait.ConditionWithContextFunc) error {
+	return doPoll(t, callerName(), "", nil, interval, timeout, untilOutcome, condition)
+}
+
+// pollFor is similar to `poll`, but it also records the name of the object and the criteria that the object is expected to match
+func pollFor(t *testing.T, object string, criteria []string, interval, timeout time.Duration, condition wait.ConditionWithContextFunc) error {
+	return doPoll(t, callerName(), object, criteria, interval, timeout, untilOutcome, condition)
+}
+
+// pollWhile is similar to `pollFor`, but for the waits which verify that a condition keeps holding during the whole
+// duration: the wait succeeds when the duration elapsed (ie, when the poll is interrupted), and fails as soon as the
+// condition returns an error.
+func pollWhile(t *testing.T, object string, interval, duration time.Duration, condition wait.ConditionWithContextFunc) error {
+	return doPoll(t, callerName(), object, nil, interval, duration, whileOutcome, condition)
+}
+
+// untilOutcome returns the outcome of a wait until a condition is met, given the error returned by the poll
+func untilOutcome(err error) WaitOutcome {
+	switch {
+	case err == nil:
+		return WaitSucceeded
+	case wait.Interrupted(err):
+		return WaitTimedOut
+	default:
+		return WaitFailed
+	}
+}
+
+// whileOutcome returns the outcome of a wait while a condition holds, given the error returned by the poll
+func whileOutcome(err error) WaitOutcome {
+	if err == nil || wait.Interrupted(err) {
+		return WaitSucceeded
+	}
+	return WaitFailed
+}
+
+func doPoll(t *testing.T, kind, object string, criteria []string, interval, timeout time.Duration, outcome func(error) WaitOutcome, condition wait.ConditionWithContextFunc) error {
+	start := time.Now()
+	err := wait.PollUntilContextTimeout(context.TODO(), interval, timeout, true, condition)
+	record := WaitRecord{
+		Kind:     kind,
+		Object:   object,
+		Criteria: criteria,
+		Start:    start,
+		Duration: time.Since(start),
+		Outcome:  outcome(err),
+	}
+	if t != nil {
+		record.Test = t.Name()
+	}
+	waitRecords.Lock()
+	defer waitRecords.Unlock()
+	waitRecords.records = append(waitRecords.records, record)
+	return err
+}
+
+var genericParams = regexp.MustCompile(`\[.*\]`)
+
+// callerName returns the name of the function which called the function calling `callerName()`,
+// eg: `HostAwaitility.WaitForSpace`
+func callerName() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+	return shortFuncName(runtime.FuncForPC(pc).Name())
+}
+
+// shortFuncName strips the package path, the pointer receiver notation, the generic type parameters and
+// the anonymous function suffixes from the given fully qualified function name
+func shortFuncName(name string) string {
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimPrefix(name, "wait.")
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	name = genericParams.ReplaceAllString(name, "")
+	segments := strings.Split(name, ".")
+	for len(segments) > 1 && (strings.HasPrefix(segments[len(segments)-1], "func") || segments[len(segments)-1] == "1") {
+		segments = segments[:len(segments)-1]
+	}
+	return strings.Join(segments, ".")
+}
+
+// criteriaNames returns the names of the functions which created the given criteria, eg: `UntilSpaceHasTier`
+func criteriaNames[T any](criteria []WaitCriterion[T]) []string {
+	names := make([]string, 0, len(criteria))
+	for _, c := range criteria {
+		if c.Match == nil {
+			continue
+		}
+		names = append(names, shortFuncName(runtime.FuncForPC(reflect.ValueOf(c.Match).Pointer()).Name()))
+	}
+	return names
+}
+
+// TestWaitTime the total time spent waiting by a single test
+type TestWaitTime struct {
+	Test     string        `json:"test"`
+	Waits    int           `json:"waits"`
+	Duration time.Duration `json:"duration"`
+}
+
+// WaitReport the report of the waits performed during a test suite
+type WaitReport struct {
+	// TotalWaits the total number of waits
+	TotalWaits int `json:"totalWaits"`
+	// TotalDuration the total time spent waiting (by all tests)
+	TotalDuration time.Duration `json:"totalDuration"`
+	// Tests the total time spent waiting per test, sorted by decreasing duration
+	Tests []TestWaitTime `json:"tests"`
+	// SlowestWaits the slowest waits, sorted by decreasing duration
+	SlowestWaits []WaitRecord `json:"slowestWaits"`
+}
+
+// NewWaitReport returns the report of the given wait records, including the given number of slowest waits
+func NewWaitReport(records []WaitRecord, slowest int) WaitReport {
+	report := WaitReport{
+		TotalWaits: len(records),
+	}
+	perTest := map[string]*TestWaitTime{}
+	for _, r := range records {
+		report.TotalDuration += r.Duration
+		tw, found := perTest[r.Test]
+		if !found {
+			tw = &TestWaitTime{Test: r.Test}
+			perTest[r.Test] = tw
+		}
+		tw.Waits++
+		tw.Duration += r.Duration
+	}
+	for _, tw := range perTest {
+		report.Tests = append(report.Tests, *tw)
+	}
+	sort.Slice(report.Tests, func(i, j int) bool {
+		if report.Tests[i].Duration == report.Tests[j].Duration {
+			return report.Tests[i].Test < report.Tests[j].Test
+		}
+		return report.Tests[i].Duration > report.Tests[j].Duration
+	})
+
+	sorted := append([]WaitRecord{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Duration > sorted[j].Duration
+	})
+	if len(sorted) > slowest {
+		sorted = sorted[:slowest]
+	}
+	report.SlowestWaits = sorted
+	return report
+}
+
+// WriteWaitReport writes the report of all the waits performed so far in JSON format to the file configured
+// via the `WAIT_REPORT_FILE` env var (suffixed with the name of the suite, so that the reports of the test suites
+// do not overwrite each other), or to `$ARTIFACT_DIR/wait-report-<suite>.json`. If neither of the env vars
+// is set, then no report is written.
+//
+// It is meant to be called at the end of a test suite, eg:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		if err := wait.WriteWaitReport("e2e"); err != nil {
+//			fmt.Printf("failed to write the wait report: %s\n", err)
+//		}
+//		os.Exit(code)
+//	}
+func WriteWaitReport(suite string) error {
+	path := os.Getenv(WaitReportFileVar)
+	if path != "" {
+		ext := filepath.Ext(path)
+		path = strings.TrimSuffix(path, ext) + "-" + suite + ext
+	} else {
+		artifactDir := os.Getenv("ARTIFACT_DIR")
+		if artifactDir == "" {
+			return nil
+		}
+		path = filepath.Join(artifactDir, "wait-report-"+suite+".json")
+	}
+	report := NewWaitReport(WaitRecords(), DefaultSlowestWaits)
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
@@ -0,0 +1,133 @@
+package wait_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitRecords(t *testing.T) {
+	// given
+	a := newAwaitility(t, newSpace("host", "oddity-1", "base1ns"))
+
+	// when
+	_, err := wait.For(t, a, &toolchainv1alpha1.Space{}).WithNameThat("oddity-1")
+	require.NoError(t, err)
+	_, err = wait.For(t, a, &toolchainv1alpha1.Space{}).WithNameThat("unknown")
+	require.Error(t, err)
+
+	// then
+	var records []wait.WaitRecord
+	for _, r := range wait.WaitRecords() {
+		if r.Test == t.Name() {
+			records = append(records, r)
+		}
+	}
+	require.Len(t, records, 2)
+	assert.Equal(t, "Waiter.WithNameThat", records[0].Kind)
+	assert.Equal(t, "oddity-1", records[0].Object)
+	assert.Equal(t, wait.WaitSucceeded, records[0].Outcome)
+	assert.Equal(t, "unknown", records[1].Object)
+	assert.Equal(t, wait.WaitTimedOut, records[1].Outcome)
+	assert.GreaterOrEqual(t, records[1].Duration, a.Timeout)
+
+	t.Run("consistency checks", func(t *testing.T) {
+		// when
+		_, err := wait.For(t, a, &toolchainv1alpha1.Space{}).StaysThat("oddity-1", 50*time.Millisecond)
+		require.NoError(t, err)
+		err = wait.For(t, a, &toolchainv1alpha1.Space{}).StaysAbsent("unknown", 50*time.Millisecond)
+		require.NoError(t, err)
+		err = wait.For(t, a, &toolchainv1alpha1.Space{}).StaysAbsent("oddity-1", 50*time.Millisecond)
+		require.Error(t, err)
+
+		// then
+		var records []wait.WaitRecord
+		for _, r := range wait.WaitRecords() {
+			if r.Test == t.Name() {
+				records = append(records, r)
+			}
+		}
+		require.Len(t, records, 3)
+		assert.Equal(t, "Waiter.StaysThat", records[0].Kind)
+		assert.Equal(t, wait.WaitSucceeded, records[0].Outcome)
+		assert.Equal(t, "Waiter.StaysAbsent", records[1].Kind)
+		assert.Equal(t, wait.WaitSucceeded, records[1].Outcome)
+		assert.Equal(t, wait.WaitFailed, records[2].Outcome)
+	})
+}
+
+func TestNewWaitReport(t *testing.T) {
+	// given
+	records := []wait.WaitRecord{
+		{Test: "TestA", Kind: "HostAwaitility.WaitForSpace", Duration: 3 * time.Second},
+		{Test: "TestB", Kind: "HostAwaitility.WaitForUserSignup", Duration: 5 * time.Second},
+		{Test: "TestA", Kind: "MemberAwaitility.WaitForUserAccount", Duration: 4 * time.Second},
+		{Test: "TestB", Kind: "MemberAwaitility.WaitForNSTmplSet", Duration: 1 * time.Second},
+	}
+
+	// when
+	report := wait.NewWaitReport(records, 2)
+
+	// then
+	assert.Equal(t, 4, report.TotalWaits)
+	assert.Equal(t, 13*time.Second, report.TotalDuration)
+	assert.Equal(t, []wait.TestWaitTime{
+		{Test: "TestA", Waits: 2, Duration: 7 * time.Second},
+		{Test: "TestB", Waits: 2, Duration: 6 * time.Second},
+	}, report.Tests)
+	require.Len(t, report.SlowestWaits, 2)
+	assert.Equal(t, "HostAwaitility.WaitForUserSignup", report.SlowestWaits[0].Kind)
+	assert.Equal(t, "MemberAwaitility.WaitForUserAccount", report.SlowestWaits[1].Kind)
+}
+
+func TestWriteWaitReport(t *testing.T) {
+	t.Run("to the configured file", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		t.Setenv(wait.WaitReportFileVar, filepath.Join(dir, "report.json"))
+
+		// when
+		err := wait.WriteWaitReport("e2e")
+
+		// then
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(dir, "report-e2e.json"))
+		require.NoError(t, err)
+		report := wait.WaitReport{}
+		require.NoError(t, json.Unmarshal(content, &report))
+		assert.Equal(t, len(wait.WaitRecords()), report.TotalWaits)
+	})
+
+	t.Run("to the artifact dir", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		t.Setenv(wait.WaitReportFileVar, "")
+		t.Setenv("ARTIFACT_DIR", dir)
+
+		// when
+		err := wait.WriteWaitReport("e2e")
+
+		// then
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "wait-report-e2e.json"))
+	})
+
+	t.Run("not written", func(t *testing.T) {
+		// given
+		t.Setenv(wait.WaitReportFileVar, "")
+		t.Setenv("ARTIFACT_DIR", "")
+
+		// when
+		err := wait.WriteWaitReport("e2e")
+
+		// then
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,223 @@
+package workloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	openshiftappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TriggerIdling sets the given (short) timeout on the Idler, so that the workloads of its namespace are idled shortly after
+func TriggerIdling(t *testing.T, memberAwait *wait.MemberAwaitility, idlerName string, timeoutSeconds int32) *toolchainv1alpha1.Idler {
+	// The idler may be updating its status since it's already been idling the pods. So we need to keep trying to update.
+	idler, err := wait.For(t, memberAwait.Awaitility, &toolchainv1alpha1.Idler{}).
+		Update(idlerName, memberAwait.Namespace, func(i *toolchainv1alpha1.Idler) {
+			i.Spec.TimeoutSeconds = timeoutSeconds
+		})
+	require.NoError(t, err)
+	return idler
+}
+
+// VerifyIdled waits until all the pods of the workloads are deleted, and verifies that each workload was idled the way
+// the idler handles its kind:
+// - the Deployments, ReplicaSets, StatefulSets, DeploymentConfigs and ReplicationControllers are scaled down to 0,
+// - the standalone Pods, the DaemonSets and the Jobs are deleted,
+// - the Jobs of the CronJobs are deleted, but the CronJobs are kept,
+// - the VirtualMachines are stopped,
+// - the AnsibleAutomationPlatforms are marked as idled (and their Deployments are scaled down to 0),
+// - the InferenceServices are deleted.
+func (w *Workloads) VerifyIdled(t *testing.T) {
+	for _, p := range w.pods {
+		err := w.memberAwait.WaitUntilPodsDeleted(t, p.Namespace, wait.WithPodName(p.Name))
+		require.NoError(t, err)
+	}
+
+	for _, name := range w.created[Deployment] {
+		waitForScaledDown(t, w, &appsv1.Deployment{}, name, func(d *appsv1.Deployment) int32 { return *d.Spec.Replicas })
+	}
+	for _, name := range w.created[ReplicaSet] {
+		waitForScaledDown(t, w, &appsv1.ReplicaSet{}, name, func(rs *appsv1.ReplicaSet) int32 { return *rs.Spec.Replicas })
+	}
+	for _, name := range w.created[StatefulSet] {
+		waitForScaledDown(t, w, &appsv1.StatefulSet{}, name, func(ss *appsv1.StatefulSet) int32 { return *ss.Spec.Replicas })
+	}
+	for _, name := range w.created[DeploymentConfig] {
+		dc := waitForScaledDown(t, w, &openshiftappsv1.DeploymentConfig{}, name, func(dc *openshiftappsv1.DeploymentConfig) int32 { return dc.Spec.Replicas })
+		assert.False(t, dc.Spec.Paused, "the DeploymentConfig '%s' should have been unpaused by the idler", name)
+	}
+	for _, name := range w.created[ReplicationController] {
+		waitForScaledDown(t, w, &corev1.ReplicationController{}, name, func(rc *corev1.ReplicationController) int32 { return *rc.Spec.Replicas })
+	}
+
+	for _, name := range w.created[Pod] {
+		err := w.memberAwait.WaitUntilPodDeleted(t, w.namespace, name)
+		require.NoError(t, err)
+	}
+	for _, name := range w.created[DaemonSet] {
+		err := wait.For(t, w.memberAwait.Awaitility, &appsv1.DaemonSet{}).InNamespace(w.namespace).WithNameDeleted(name)
+		require.NoError(t, err)
+	}
+	for _, name := range w.created[Job] {
+		err := wait.For(t, w.memberAwait.Awaitility, &batchv1.Job{}).InNamespace(w.namespace).WithNameDeleted(name)
+		require.NoError(t, err)
+	}
+	for _, name := range w.created[CronJob] {
+		err := wait.For(t, w.memberAwait.Awaitility, &batchv1.Job{}).InNamespace(w.namespace).WithNameDeleted(name + "-manual")
+		require.NoError(t, err)
+		// the CronJob itself is not deleted, so that it can still be triggered later on
+		_, err = wait.For(t, w.memberAwait.Awaitility, &batchv1.CronJob{}).InNamespace(w.namespace).WithNameThat(name)
+		require.NoError(t, err)
+	}
+
+	for _, name := range w.created[VirtualMachine] {
+		w.waitForVirtualMachineStopped(t, name)
+	}
+	for _, name := range w.created[AAP] {
+		_, err := w.memberAwait.WaitForAAP(t, name, w.namespace, w.dynamic.Resource(AAPResource), true)
+		require.NoError(t, err)
+	}
+	for _, name := range w.created[KServe] {
+		err := w.memberAwait.WaitUntilInferenceServiceDeleted(t, name, w.namespace, w.dynamic.Resource(InferenceServiceResource))
+		require.NoError(t, err)
+	}
+}
+
+// VerifyNotRestarted verifies that the idled workloads are not restarted during the given duration, ie, that no pod of
+// the workloads is running again (the idler does not just kill the pods, which would then be recreated by their controllers)
+func (w *Workloads) VerifyNotRestarted(t *testing.T, duration time.Duration) {
+	t.Logf("verifying that the workloads in namespace '%s' are not restarted during %s", w.namespace, duration)
+	err := k8swait.PollUntilContextTimeout(context.TODO(), w.memberAwait.RetryInterval, duration, true, func(ctx context.Context) (bool, error) {
+		pods := &corev1.PodList{}
+		if err := w.memberAwait.Client.List(ctx, pods, client.InNamespace(w.namespace), client.MatchingLabels{IdlerLabelKey: IdlerLabelKey}); err != nil {
+			return false, err
+		}
+		for _, p := range pods.Items {
+			if p.DeletionTimestamp == nil && p.Status.Phase == corev1.PodRunning {
+				return false, fmt.Errorf("the pod '%s' is running again", p.Name)
+			}
+		}
+		return false, nil // keep checking until the end of the duration
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		require.NoError(t, err)
+	}
+}
+
+// VerifyNotIdled verifies that the workloads were not idled (eg, because their Idler has a long timeout): all their
+// pods are still running, and none of their containers was restarted since the workloads were created
+func (w *Workloads) VerifyNotIdled(t *testing.T) {
+	for _, expected := range w.pods {
+		pods, err := w.memberAwait.WaitForPods(t, w.namespace, 1, wait.WithPodName(expected.Name), wait.PodRunning())
+		require.NoError(t, err)
+		assert.Equal(t, expected.UID, pods[0].UID, "the pod '%s' was recreated", expected.Name)
+		assert.Equal(t, restartCount(expected), restartCount(pods[0]), "the containers of the pod '%s' were restarted", expected.Name)
+	}
+}
+
+// VerifyIdledOnRestarts verifies that the crash-looping Deployment with the given name (see CreateCrashLooping) is idled,
+// ie, scaled down to 0, once the kubelet restarted its pod more than RestartThreshold times, and thus long before the timeout
+// of the given Idler elapses: the Deployment is awaited for RestartsTimeout only, which must be well below the timeout of the
+// Idler, so that the test fails if the Deployment is only idled because of its timeout.
+func (w *Workloads) VerifyIdledOnRestarts(t *testing.T, name string, idler *toolchainv1alpha1.Idler) {
+	timeout := RestartsTimeout()
+	require.Less(t, 2*timeout, time.Duration(idler.Spec.TimeoutSeconds)*time.Second,
+		"the timeout of the Idler '%s' is too short to verify that the Deployment '%s' is idled because of its restarts", idler.Name, name)
+	t.Logf("waiting up to %s for the Deployment '%s' to be idled after more than %d restarts", timeout, name, RestartThreshold)
+	start := time.Now()
+	var restarts int32
+	var replicas int32
+	err := k8swait.PollUntilContextTimeout(context.TODO(), w.memberAwait.RetryInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		pods := &corev1.PodList{}
+		if err := w.memberAwait.Client.List(ctx, pods, client.InNamespace(w.namespace), client.MatchingLabels(selectorLabels(name))); err != nil {
+			return false, err
+		}
+		for _, p := range pods.Items {
+			restarts = max(restarts, restartCount(p))
+		}
+		deployment := &appsv1.Deployment{}
+		if err := w.memberAwait.Client.Get(ctx, client.ObjectKey{Namespace: w.namespace, Name: name}, deployment); err != nil {
+			return false, err
+		}
+		replicas = *deployment.Spec.Replicas
+		return replicas == 0, nil
+	})
+	require.NoError(t, err, "the Deployment '%s' was not idled within %s: it still has %d replicas and its pod was restarted %d times",
+		name, timeout, replicas, restarts)
+	t.Logf("the Deployment '%s' was idled after %s and %d restarts", name, time.Since(start), restarts)
+	// the last restart may have happened between two polls
+	assert.GreaterOrEqual(t, restarts, int32(RestartThreshold), "the Deployment '%s' was idled before its pod reached the restart threshold", name)
+	err = w.memberAwait.WaitUntilPodsDeleted(t, w.namespace, wait.WithPodLabel("app", name))
+	require.NoError(t, err)
+}
+
+// RestartsTimeout returns how long it takes for the kubelet to restart a crash-looping container more than RestartThreshold
+// times (with some margin), since it backs off the restarts exponentially, from 10 seconds up to 5 minutes
+func RestartsTimeout() time.Duration {
+	var timeout time.Duration
+	backoff := 10 * time.Second
+	for i := 0; i <= RestartThreshold; i++ {
+		timeout += backoff
+		backoff = min(2*backoff, 5*time.Minute)
+	}
+	return timeout + timeout/10
+}
+
+// waitForScaledDown waits until the workload of type T with the given name is scaled down to 0
+func waitForScaledDown[T client.Object](t *testing.T, w *Workloads, obj T, name string, replicas func(T) int32) T {
+	result, err := wait.For(t, w.memberAwait.Awaitility, obj).InNamespace(w.namespace).WithNameThat(name, wait.Criterion(wait.WaitCriterion[T]{
+		Match: func(actual T) bool {
+			return replicas(actual) == 0
+		},
+		Diff: func(actual T) string {
+			return fmt.Sprintf("expected '%s' to be scaled down to 0 but it has %d replicas", actual.GetName(), replicas(actual))
+		},
+	}))
+	require.NoError(t, err)
+	return result
+}
+
+// waitForVirtualMachineStopped waits until the VirtualMachine with the given name is stopped, ie, its `spec.running` field
+// is `false` or its `spec.runStrategy` is `Halted`
+func (w *Workloads) waitForVirtualMachineStopped(t *testing.T, name string) {
+	t.Logf("waiting for VirtualMachine '%s' in namespace '%s' to be stopped", name, w.namespace)
+	var vm *unstructured.Unstructured
+	err := k8swait.PollUntilContextTimeout(context.TODO(), w.memberAwait.RetryInterval, w.memberAwait.Timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		vm, err = w.dynamic.Resource(VirtualMachineResource).Namespace(w.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if running, found, _ := unstructured.NestedBool(vm.Object, "spec", "running"); found {
+			return !running, nil
+		}
+		runStrategy, _, _ := unstructured.NestedString(vm.Object, "spec", "runStrategy")
+		return runStrategy == "Halted", nil
+	})
+	require.NoError(t, err, "the VirtualMachine '%s' was not stopped: %v", name, vm)
+}
+
+func restartCount(pod corev1.Pod) int32 {
+	var count int32
+	for _, s := range pod.Status.ContainerStatuses {
+		count += s.RestartCount
+	}
+	return count
+}
@@ -0,0 +1,541 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-e2e/testsupport/wait"
+	openshiftappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Kind a kind of workload which is handled by the idler of the member operator
+type Kind string
+
+const (
+	Pod                   Kind = "Pod"
+	Deployment            Kind = "Deployment"
+	ReplicaSet            Kind = "ReplicaSet"
+	StatefulSet           Kind = "StatefulSet"
+	DaemonSet             Kind = "DaemonSet"
+	Job                   Kind = "Job"
+	CronJob               Kind = "CronJob"
+	DeploymentConfig      Kind = "DeploymentConfig"
+	ReplicationController Kind = "ReplicationController"
+	VirtualMachine        Kind = "VirtualMachine"
+	AAP                   Kind = "AnsibleAutomationPlatform"
+	KServe                Kind = "InferenceService"
+)
+
+// DefaultKinds returns the kinds of workloads which are created by default, ie, all the kinds which are handled by the
+// idler except the StatefulSets, the CronJobs and the VirtualMachines
+func DefaultKinds() []Kind {
+	return []Kind{Pod, Deployment, ReplicaSet, DaemonSet, Job, DeploymentConfig, ReplicationController, AAP, KServe}
+}
+
+// AllKinds returns all the kinds of workloads which are handled by the idler
+func AllKinds() []Kind {
+	return []Kind{Pod, Deployment, ReplicaSet, StatefulSet, DaemonSet, Job, CronJob, DeploymentConfig, ReplicationController, VirtualMachine, AAP, KServe}
+}
+
+var (
+	AAPResource              = schema.GroupVersionResource{Group: "aap.ansible.com", Version: "v1alpha1", Resource: "ansibleautomationplatforms"}
+	ServingRuntimeResource   = schema.GroupVersionResource{Group: "serving.kserve.io", Version: "v1alpha1", Resource: "servingruntimes"}
+	InferenceServiceResource = schema.GroupVersionResource{Group: "serving.kserve.io", Version: "v1beta1", Resource: "inferenceservices"}
+	VirtualMachineResource   = schema.GroupVersionResource{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"}
+)
+
+const (
+	// IdlerLabelKey the label which is set on all the pods of the workloads
+	IdlerLabelKey = "idler"
+	// StandalonePodName the name of the standalone pod, which has a priority class of its own
+	StandalonePodName = "idler-test-pod-1"
+	// RestartThreshold the number of restarts of the containers of a pod above which the idler of the member operator
+	// idles the pod (and its owner) right away, regardless of the timeout of the Idler
+	RestartThreshold = 50
+	// LongTestsVar the env var which, when set to `true`, enables the tests which take hours, such as the idling of a crash-looping
+	// workload because of its restarts (see VerifyIdledOnRestarts). The `-timeout` of `go test` must be raised accordingly.
+	LongTestsVar = "E2E_LONG_TESTS"
+)
+
+// Workloads a set of workloads (of different kinds) in a namespace, which can be idled by the member operator. For example:
+//
+//	toIdle := workloads.New(memberAwait, idler.Name).
+//		Kinds(workloads.AllKinds()...).
+//		Create(t, wait.WithSandboxPriorityClass())
+//	workloads.TriggerIdling(t, memberAwait, idler.Name, 5)
+//	toIdle.VerifyIdled(t)
+type Workloads struct {
+	memberAwait *wait.MemberAwaitility
+	dynamic     dynamic.Interface
+	namespace   string
+	kinds       []Kind
+	// created the names of the workloads which were created, by kind
+	created map[Kind][]string
+	pods    []corev1.Pod
+}
+
+// New returns a new set of workloads in the given namespace, with the default kinds of workloads (see DefaultKinds)
+func New(memberAwait *wait.MemberAwaitility, namespace string) *Workloads {
+	return &Workloads{
+		memberAwait: memberAwait,
+		namespace:   namespace,
+		kinds:       DefaultKinds(),
+		created:     map[Kind][]string{},
+	}
+}
+
+// Kinds specifies the kinds of workloads to create
+func (w *Workloads) Kinds(kinds ...Kind) *Workloads {
+	w.kinds = kinds
+	return w
+}
+
+// Namespace returns the namespace of the workloads
+func (w *Workloads) Namespace() string {
+	return w.namespace
+}
+
+// Pods returns the pods of the workloads, as they were running once all the workloads were created
+func (w *Workloads) Pods() []corev1.Pod {
+	return w.pods
+}
+
+// Created returns the names of the workloads of the given kind which were created
+func (w *Workloads) Created(kind Kind) []string {
+	return w.created[kind]
+}
+
+// Create creates the workloads and waits until all their pods are running (and match the given criteria).
+// The VirtualMachines are only created if KubeVirt is running in the cluster, since they have no pod otherwise.
+func (w *Workloads) Create(t *testing.T, additionalPodCriteria ...wait.PodWaitCriterion) *Workloads {
+	var err error
+	w.dynamic, err = dynamic.NewForConfig(w.memberAwait.RestConfig)
+	require.NoError(t, err)
+
+	n := 0 // total number of created pods
+	var pausedDC string
+	for _, kind := range w.kinds {
+		switch kind {
+		case Pod:
+			w.createStandalonePod(t, StandalonePodName)
+			n++
+		case Deployment:
+			d := w.createDeployment(t, "idler-test-deployment", "idler-deployment", 3, nil)
+			n += int(*d.Spec.Replicas)
+		case ReplicaSet:
+			rs := w.createReplicaSet(t)
+			n += int(*rs.Spec.Replicas)
+		case StatefulSet:
+			ss := w.createStatefulSet(t)
+			n += int(*ss.Spec.Replicas)
+		case DaemonSet:
+			w.createDaemonSet(t)
+			nodes := &corev1.NodeList{}
+			err := w.memberAwait.Client.List(context.TODO(), nodes, client.MatchingLabels(map[string]string{"node-role.kubernetes.io/worker": ""}))
+			require.NoError(t, err)
+			n += len(nodes.Items) // DaemonSet creates N pods where N is the number of worker nodes in the cluster
+		case Job:
+			w.createJob(t, "idler-test-job", nil)
+			n++
+		case CronJob:
+			w.createCronJob(t)
+			n++
+		case DeploymentConfig:
+			dc := w.createDeploymentConfig(t, "idler-test-dc")
+			n += int(dc.Spec.Replicas)
+			// create another DeploymentConfig that will be paused to test that the idler will unpause it when scaling it down
+			dcPaused := w.createDeploymentConfig(t, "idler-test-dc-paused")
+			n += int(dcPaused.Spec.Replicas)
+			pausedDC = dcPaused.Name
+		case ReplicationController:
+			rc := w.createReplicationController(t)
+			n += int(*rc.Spec.Replicas)
+		case VirtualMachine:
+			if !kubeVirtRunning(t, w.memberAwait) {
+				t.Log("KubeVirt is not running in the cluster, skipping the VirtualMachine workload")
+				continue
+			}
+			w.createVirtualMachine(t, "idler-test-vm")
+			n++
+		case AAP:
+			d := w.createAAP(t, "test-idler-aap")
+			n += int(*d.Spec.Replicas)
+		case KServe:
+			d := w.createKServeWorkloads(t, "test-idler-kserve")
+			n += int(*d.Spec.Replicas)
+		default:
+			require.Failf(t, "unsupported kind of workload", "'%s'", kind)
+		}
+	}
+
+	w.pods, err = w.memberAwait.WaitForPods(t, w.namespace, n, append(additionalPodCriteria, wait.PodRunning(),
+		wait.WithPodLabel(IdlerLabelKey, IdlerLabelKey))...)
+	require.NoError(t, err)
+
+	if pausedDC != "" {
+		// pause the DeploymentConfig now that its pods are running
+		_, err := wait.For(t, w.memberAwait.Awaitility, &openshiftappsv1.DeploymentConfig{}).
+			Update(pausedDC, w.namespace, func(dc *openshiftappsv1.DeploymentConfig) {
+				dc.Spec.Paused = true
+			})
+		require.NoError(t, err)
+	}
+	return w
+}
+
+// CreateStandalonePod creates another standalone pod (with the given name) in the namespace of the workloads
+func (w *Workloads) CreateStandalonePod(t *testing.T, name string) *corev1.Pod {
+	return w.createStandalonePod(t, name)
+}
+
+// CreateCrashLooping creates a Deployment (with the given name) in the namespace of the workloads, whose single pod keeps
+// crashing, and waits until the container of the pod was restarted at least once. The Deployment is idled along with the
+// other workloads (see VerifyIdled), or on its own once it was restarted too many times (see VerifyIdledOnRestarts).
+func (w *Workloads) CreateCrashLooping(t *testing.T, name string) *appsv1.Deployment {
+	replicas := int32(1)
+	template := podTemplateSpec(name)
+	template.Spec.Containers[0].Command = []string{"sh", "-c", "exit 1"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.namespace},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels(name)},
+			Replicas: &replicas,
+			Template: template,
+		},
+	}
+	err := w.memberAwait.Create(t, deployment)
+	require.NoError(t, err)
+	w.created[Deployment] = append(w.created[Deployment], name)
+
+	_, err = w.memberAwait.WaitForPods(t, w.namespace, 1, wait.WithPodLabel("app", name), wait.PodWaitCriterion{
+		Match: func(actual *corev1.Pod) bool {
+			return restartCount(*actual) > 0
+		},
+		Diff: func(actual *corev1.Pod) string {
+			return fmt.Sprintf("expected the containers of the pod '%s' to be restarted", actual.Name)
+		},
+	})
+	require.NoError(t, err)
+	return deployment
+}
+
+func (w *Workloads) createStandalonePod(t *testing.T, name string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: w.namespace,
+			Name:      name,
+			Labels:    map[string]string{IdlerLabelKey: IdlerLabelKey},
+		},
+		Spec: podSpec(),
+	}
+	pod.Spec.PriorityClassName = "system-cluster-critical"
+	err := w.memberAwait.Create(t, pod)
+	require.NoError(t, err)
+	w.created[Pod] = append(w.created[Pod], name)
+	return pod
+}
+
+func (w *Workloads) createDeployment(t *testing.T, name, app string, replicas int32, owner metav1.Object) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.namespace},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels(app)},
+			Replicas: &replicas,
+			Template: podTemplateSpec(app),
+		},
+	}
+	if owner != nil {
+		err := controllerutil.SetOwnerReference(owner, deployment, scheme.Scheme)
+		require.NoError(t, err)
+	} else {
+		w.created[Deployment] = append(w.created[Deployment], name)
+	}
+	err := w.memberAwait.Create(t, deployment)
+	require.NoError(t, err)
+	return deployment
+}
+
+func (w *Workloads) createReplicaSet(t *testing.T) *appsv1.ReplicaSet {
+	// Standalone ReplicaSet
+	replicas := int32(2)
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-replicaset", Namespace: w.namespace},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels("idler-rs")},
+			Replicas: &replicas,
+			Template: podTemplateSpec("idler-rs"),
+		},
+	}
+	err := w.memberAwait.Create(t, rs)
+	require.NoError(t, err)
+	w.created[ReplicaSet] = append(w.created[ReplicaSet], rs.Name)
+	return rs
+}
+
+func (w *Workloads) createStatefulSet(t *testing.T) *appsv1.StatefulSet {
+	replicas := int32(2)
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-statefulset", Namespace: w.namespace},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels("idler-ss")},
+			Replicas: &replicas,
+			Template: podTemplateSpec("idler-ss"),
+			// start the pods in parallel, so that they are all running sooner
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+		},
+	}
+	err := w.memberAwait.Create(t, ss)
+	require.NoError(t, err)
+	w.created[StatefulSet] = append(w.created[StatefulSet], ss.Name)
+	return ss
+}
+
+func (w *Workloads) createDaemonSet(t *testing.T) *appsv1.DaemonSet {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-daemonset", Namespace: w.namespace},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selectorLabels("idler-ds")},
+			Template: podTemplateSpec("idler-ds"),
+		},
+	}
+	err := w.memberAwait.Create(t, ds)
+	require.NoError(t, err)
+	w.created[DaemonSet] = append(w.created[DaemonSet], ds.Name)
+	return ds
+}
+
+func (w *Workloads) createJob(t *testing.T, name string, owner metav1.Object) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.namespace},
+		Spec: batchv1.JobSpec{
+			Template: podTemplateSpec(""),
+		},
+	}
+	job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	if owner != nil {
+		err := controllerutil.SetOwnerReference(owner, job, scheme.Scheme)
+		require.NoError(t, err)
+	} else {
+		w.created[Job] = append(w.created[Job], name)
+	}
+	err := w.memberAwait.Create(t, job)
+	require.NoError(t, err)
+	return job
+}
+
+// createCronJob creates a CronJob which is not scheduled before long, and a Job which was triggered from it (as with
+// `oc create job --from=cronjob/...`), so that the CronJob has a running pod right away
+func (w *Workloads) createCronJob(t *testing.T) *batchv1.CronJob {
+	template := podTemplateSpec("")
+	template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-cronjob", Namespace: w.namespace},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "@yearly",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{Template: template},
+			},
+		},
+	}
+	err := w.memberAwait.Create(t, cronJob)
+	require.NoError(t, err)
+	w.created[CronJob] = append(w.created[CronJob], cronJob.Name)
+	w.createJob(t, "idler-test-cronjob-manual", cronJob)
+	return cronJob
+}
+
+func (w *Workloads) createDeploymentConfig(t *testing.T, name string) *openshiftappsv1.DeploymentConfig {
+	spec := podTemplateSpec("idler-dc")
+	dc := &openshiftappsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.namespace},
+		Spec: openshiftappsv1.DeploymentConfigSpec{
+			Selector: selectorLabels("idler-dc"),
+			Replicas: 2,
+			Template: &spec,
+		},
+	}
+	err := w.memberAwait.Create(t, dc)
+	require.NoError(t, err)
+	w.created[DeploymentConfig] = append(w.created[DeploymentConfig], name)
+	return dc
+}
+
+func (w *Workloads) createReplicationController(t *testing.T) *corev1.ReplicationController {
+	// Standalone ReplicationController
+	spec := podTemplateSpec("idler-rc")
+	replicas := int32(2)
+	rc := &corev1.ReplicationController{
+		ObjectMeta: metav1.ObjectMeta{Name: "idler-test-rc", Namespace: w.namespace},
+		Spec: corev1.ReplicationControllerSpec{
+			Selector: selectorLabels("idler-rc"),
+			Replicas: &replicas,
+			Template: &spec,
+		},
+	}
+	err := w.memberAwait.Create(t, rc)
+	require.NoError(t, err)
+	w.created[ReplicationController] = append(w.created[ReplicationController], rc.Name)
+	return rc
+}
+
+// createVirtualMachine creates a small VirtualMachine which is started right away (its pod is the `virt-launcher` pod
+// which runs the VirtualMachineInstance, and which has the labels of the VirtualMachineInstance template)
+func (w *Workloads) createVirtualMachine(t *testing.T, name string) *unstructured.Unstructured {
+	vm := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "kubevirt.io/v1",
+			"kind":       "VirtualMachine",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}{
+				"runStrategy": "Always",
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{
+							IdlerLabelKey: IdlerLabelKey,
+							"app":         name,
+						},
+					},
+					"spec": map[string]interface{}{
+						"domain": map[string]interface{}{
+							"devices": map[string]interface{}{
+								"disks": []interface{}{
+									map[string]interface{}{
+										"name": "containerdisk",
+										"disk": map[string]interface{}{"bus": "virtio"},
+									},
+								},
+							},
+							"resources": map[string]interface{}{
+								"requests": map[string]interface{}{"memory": "128Mi"},
+							},
+						},
+						"volumes": []interface{}{
+							map[string]interface{}{
+								"name": "containerdisk",
+								"containerDisk": map[string]interface{}{
+									"image": "quay.io/kubevirt/cirros-container-disk-demo:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	created, err := w.dynamic.Resource(VirtualMachineResource).Namespace(w.namespace).Create(context.TODO(), vm, metav1.CreateOptions{})
+	require.NoError(t, err)
+	w.created[VirtualMachine] = append(w.created[VirtualMachine], name)
+	return created
+}
+
+// createAAP creates an instance of ansibleautomationplatforms.aap.ansible.com with one deployment owned by this instance
+// returns the underlying deployment
+func (w *Workloads) createAAP(t *testing.T, name string) *appsv1.Deployment {
+	aap := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "aap.ansible.com/v1alpha1",
+			"kind":       "AnsibleAutomationPlatform",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}{
+				"idle_aap": false,
+			},
+		},
+	}
+	createdAAP, err := w.dynamic.Resource(AAPResource).Namespace(w.namespace).Create(context.TODO(), aap, metav1.CreateOptions{})
+	require.NoError(t, err)
+	w.created[AAP] = append(w.created[AAP], name)
+
+	// Create a Deployment with two replicas and the AAP instance as the owner
+	return w.createDeployment(t, name, name, 2, createdAAP)
+}
+
+// createKServeWorkloads creates ServingRuntime and InferenceService instances with one deployment owned by ServingRuntime
+// returns the underlying deployment
+func (w *Workloads) createKServeWorkloads(t *testing.T, name string) *appsv1.Deployment {
+	// Create a ServingRuntime instance
+	servingRuntime := &unstructured.Unstructured{}
+	servingRuntime.SetAPIVersion("serving.kserve.io/v1alpha1")
+	servingRuntime.SetKind("ServingRuntime")
+	servingRuntime.SetName(name)
+	createdServingRuntime, err := w.dynamic.Resource(ServingRuntimeResource).Namespace(w.namespace).Create(context.TODO(), servingRuntime, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Create an InferenceService instance
+	inferenceService := &unstructured.Unstructured{}
+	inferenceService.SetAPIVersion("serving.kserve.io/v1beta1")
+	inferenceService.SetKind("InferenceService")
+	inferenceService.SetName(name)
+	_, err = w.dynamic.Resource(InferenceServiceResource).Namespace(w.namespace).Create(context.TODO(), inferenceService, metav1.CreateOptions{})
+	require.NoError(t, err)
+	w.created[KServe] = append(w.created[KServe], name)
+
+	// Create a Deployment with two replicas and the ServingRuntime instance as the owner.
+	// The InferenceService doesn't own anything directly.
+	return w.createDeployment(t, name, name, 2, createdServingRuntime)
+}
+
+// kubeVirtRunning returns `true` if the KubeVirt controller is running in the cluster, ie, if the VirtualMachines are actually started
+func kubeVirtRunning(t *testing.T, memberAwait *wait.MemberAwaitility) bool {
+	deployments := &appsv1.DeploymentList{}
+	err := memberAwait.Client.List(context.TODO(), deployments, client.MatchingLabels{"kubevirt.io": "virt-controller"})
+	require.NoError(t, err)
+	for _, d := range deployments.Items {
+		if d.Status.ReadyReplicas > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func podSpec() corev1.PodSpec {
+	zero := int64(0)
+	return corev1.PodSpec{
+		TerminationGracePeriodSeconds: &zero,
+		Containers: []corev1.Container{{
+			Name:    "sleep",
+			Image:   "quay.io/prometheus/busybox:latest",
+			Command: []string{"sleep", "36000"}, // 10 hours
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					"cpu":    resource.MustParse("1m"),
+					"memory": resource.MustParse("8Mi"),
+				},
+				Limits: corev1.ResourceList{
+					"cpu":    resource.MustParse("50m"),
+					"memory": resource.MustParse("80Mi"),
+				},
+			},
+		}},
+	}
+}
+
+func podTemplateSpec(app string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+			IdlerLabelKey: IdlerLabelKey,
+			"app":         app,
+		}},
+		Spec: podSpec(),
+	}
+}
+
+func selectorLabels(app string) map[string]string {
+	return map[string]string{"app": app}
+}